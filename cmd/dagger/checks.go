@@ -1,8 +1,12 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/juju/ansiterm/tabwriter"
@@ -19,11 +23,23 @@ import (
 )
 
 var (
-	checksListMode bool
+	checksListMode     bool
+	checksReportFile   string
+	checksReportFormat string
+	checksFailFast     bool
+	checksRetries      int
+	checksSince        string
+	checksExplain      bool
 )
 
 func init() {
 	checksCmd.Flags().BoolVarP(&checksListMode, "list", "l", false, "List available checks")
+	checksCmd.Flags().StringVar(&checksReportFile, "report-file", "", "Write a report of the check results to this file")
+	checksCmd.Flags().BoolVar(&checksFailFast, "fail-fast", false, "Cancel the remaining checks as soon as one fails")
+	checksCmd.Flags().IntVar(&checksRetries, "retries", 0, "Number of times a failed check is retried")
+	checksCmd.Flags().StringVar(&checksReportFormat, "report-format", "", "Format of the report file: junit, json, sarif or markdown (default: inferred from the file extension)")
+	checksCmd.Flags().StringVar(&checksSince, "since", "", "Only run the checks affected by the changes since this git ref")
+	checksCmd.Flags().BoolVar(&checksExplain, "explain", false, "Explain why each check is selected or skipped by --since")
 }
 
 var checksCmd = &cobra.Command{
@@ -34,9 +50,12 @@ var checksCmd = &cobra.Command{
 	Long: `Check the state of your project by running tests, linters, etc.
 
 Examples:
-  dagger check                    # Run all checks
-  dagger check -l                 # List all available checks
-  dagger check go:lint            # Run the go:lint check and any subchecks
+  dagger check                          # Run all checks
+  dagger check -l                       # List all available checks
+  dagger check go:lint                  # Run the go:lint check and any subchecks
+  dagger check --report-file=checks.xml # Write a JUnit report of the results
+  dagger check --fail-fast --retries=2  # Retry failed checks, stop at the first failure
+  dagger check --since=origin/main      # Only run the checks affected by the changes
 `,
 	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,11 +76,32 @@ Examples:
 				} else {
 					checks = mod.Checks()
 				}
+				if checksExplain && checksSince == "" {
+					return fmt.Errorf("--explain requires --since")
+				}
+				if checksSince != "" {
+					changed, err := changedPathsSince(ctx, dag, checksSince)
+					if err != nil {
+						return err
+					}
+					checks = checks.AffectedByPaths(changed)
+				}
+				if checksExplain {
+					if err := explainChecks(ctx, checks, cmd); err != nil {
+						return err
+					}
+				}
 				if checksListMode {
 					return listChecks(ctx, checks, cmd)
-				} else {
-					return runChecks(ctx, checks, cmd)
 				}
+				var reportFormat dagger.CheckReportFormat
+				if checksReportFile != "" {
+					reportFormat, err = checkReportFormat(checksReportFile, checksReportFormat)
+					if err != nil {
+						return err
+					}
+				}
+				return runChecks(ctx, checks, cmd, reportFormat)
 			},
 		)
 	},
@@ -77,6 +117,97 @@ func loadModule(ctx context.Context, dag *dagger.Client) (*dagger.Module, error)
 	return dag.ModuleSource(modRef).AsModule().Sync(ctx)
 }
 
+// changedPathsSince returns the paths of the context directory of the module
+// changed since the given git ref, including uncommitted and untracked
+// changes. They are computed with the git CLI on the host, so that nothing
+// needs to be uploaded to the engine.
+func changedPathsSince(ctx context.Context, dag *dagger.Client, ref string) ([]string, error) {
+	modRef, _ := getExplicitModuleSourceRef()
+	if modRef == "" {
+		modRef = moduleURLDefault
+	}
+	ctx, span := Tracer().Start(ctx, "compute changes since "+ref)
+	defer span.End()
+	contextDirPath, err := dag.ModuleSource(modRef).LocalContextDirectoryPath(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("--since requires a local module: %w", err)
+	}
+	gitPaths := func(args ...string) ([]string, error) {
+		cmd := exec.CommandContext(ctx, "git", append([]string{"-C", contextDirPath}, args...)...)
+		out, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return nil, fmt.Errorf("git %s: %w: %s", args[0], err, bytes.TrimSpace(exitErr.Stderr))
+			}
+			return nil, fmt.Errorf("git %s: %w", args[0], err)
+		}
+		return strings.FieldsFunc(string(out), func(r rune) bool { return r == 0 }), nil
+	}
+	// paths are relative to the current directory, i.e. the context directory
+	changed, err := gitPaths("diff", "--name-only", "--no-renames", "--relative", "-z", ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := gitPaths("ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, err
+	}
+	return append(changed, untracked...), nil
+}
+
+type selectionInfo struct {
+	Name    string
+	Skipped bool
+	Reason  string
+}
+
+// printSelection prints why each check or generator is selected or skipped.
+func printSelection(cmd *cobra.Command, infos []selectionInfo) error {
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', tabwriter.DiscardEmptyColumns)
+	fmt.Fprintf(tw, "%s\t%s\t%s\n",
+		termenv.String("Name").Bold(),
+		termenv.String("Selected").Bold(),
+		termenv.String("Reason").Bold(),
+	)
+	for _, info := range infos {
+		selected := "yes"
+		if info.Skipped {
+			selected = "no"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", info.Name, selected, info.Reason)
+	}
+	return tw.Flush()
+}
+
+// 'dagger check --since=ref --explain'
+func explainChecks(ctx context.Context, checkgroup *dagger.CheckGroup, cmd *cobra.Command) error {
+	ctx, span := Tracer().Start(ctx, "explain check selection")
+	defer span.End()
+
+	checks, err := checkgroup.List(ctx)
+	if err != nil {
+		return err
+	}
+	infos := make([]selectionInfo, 0, len(checks))
+	for _, check := range checks {
+		name, err := check.Name(ctx)
+		if err != nil {
+			return err
+		}
+		skipped, err := check.Skipped(ctx)
+		if err != nil {
+			return err
+		}
+		reason, err := check.SelectionReason(ctx)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, selectionInfo{Name: cliName(name), Skipped: skipped, Reason: reason})
+	}
+	return printSelection(cmd, infos)
+}
+
 func loadCheckGroupInfo(ctx context.Context, checkgroup *dagger.CheckGroup) (*CheckGroupInfo, error) {
 	ctx, span := Tracer().Start(ctx, "fetch check information")
 	defer span.End()
@@ -138,8 +269,39 @@ func listChecks(ctx context.Context, checkgroup *dagger.CheckGroup, cmd *cobra.C
 	return tw.Flush()
 }
 
+// checkReportFormat returns the report format for the --report-file and
+// --report-format flags.
+func checkReportFormat(file, format string) (dagger.CheckReportFormat, error) {
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".xml":
+			return dagger.CheckReportFormatJunit, nil
+		case ".json":
+			return dagger.CheckReportFormatJson, nil
+		case ".sarif":
+			return dagger.CheckReportFormatSarif, nil
+		case ".md", ".markdown":
+			return dagger.CheckReportFormatMarkdown, nil
+		default:
+			return "", fmt.Errorf("cannot infer report format from %q, set --report-format", file)
+		}
+	}
+	switch strings.ToLower(format) {
+	case "junit":
+		return dagger.CheckReportFormatJunit, nil
+	case "json":
+		return dagger.CheckReportFormatJson, nil
+	case "sarif":
+		return dagger.CheckReportFormatSarif, nil
+	case "markdown", "md":
+		return dagger.CheckReportFormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unknown report format %q", format)
+	}
+}
+
 // 'dagger checks' (runs by default)
-func runChecks(ctx context.Context, checkgroup *dagger.CheckGroup, _ *cobra.Command) error {
+func runChecks(ctx context.Context, checkgroup *dagger.CheckGroup, _ *cobra.Command, reportFormat dagger.CheckReportFormat) error {
 	ctx, zoomSpan := Tracer().Start(ctx, "checks", telemetry.Passthrough())
 	defer zoomSpan.End()
 	Frontend.SetPrimary(dagui.SpanID{SpanID: zoomSpan.SpanContext().SpanID()})
@@ -147,17 +309,46 @@ func runChecks(ctx context.Context, checkgroup *dagger.CheckGroup, _ *cobra.Comm
 	// We don't actually use the API for rendering results
 	// Instead, we rely on telemetry
 	// FIXME: this feels a little weird. Can we move the relevant telemetry collection in the API?
-	checks, err := checkgroup.Run().List(ctx)
+	results := checkgroup.Run(dagger.CheckGroupRunOpts{
+		FailFast: checksFailFast,
+		Retries:  checksRetries,
+		// the failures are needed in the report
+		NoFail: checksReportFile != "",
+	})
+	checks, err := results.List(ctx)
 	if err != nil {
 		return err
 	}
+	if checksReportFile != "" {
+		_, err := results.Report(dagger.CheckGroupReportOpts{Format: reportFormat}).Export(ctx, checksReportFile)
+		if err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+	}
 	var failed int
 	for _, check := range checks {
+		// checks skipped by --since or canceled by --fail-fast are neither
+		// passed nor failed
+		completed, err := check.Completed(ctx)
+		if err != nil {
+			return err
+		}
+		if !completed {
+			continue
+		}
 		passed, err := check.Passed(ctx)
 		if err != nil {
 			return err
 		}
-		if !passed {
+		if passed {
+			continue
+		}
+		// failures of quarantined checks are reported, but don't fail the run
+		quarantined, err := check.Quarantined(ctx)
+		if err != nil {
+			return err
+		}
+		if !quarantined {
 			failed++
 		}
 	}
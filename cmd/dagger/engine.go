@@ -2,10 +2,15 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"dagger.io/dagger/telemetry"
+	"github.com/adrg/xdg"
 	"github.com/dagger/dagger/dagql/dagui"
 	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/client"
@@ -36,12 +41,19 @@ var (
 
 	// RunnerImageLoader holds the image store for the client.
 	RunnerImageLoader string
+
+	// pinnedRunnerHostPath holds the runner host pinned by "dagger engine
+	// upgrade", followed by the version of the CLI that pinned it.
+	pinnedRunnerHostPath = filepath.Join(xdg.ConfigHome, "dagger", "runner-host")
 )
 
 func init() {
 	if v, ok := os.LookupEnv(RunnerHostEnv); ok {
 		RunnerHost = v
 	}
+	if RunnerHost == "" {
+		RunnerHost = pinnedRunnerHost()
+	}
 	if RunnerHost == "" {
 		RunnerHost = defaultRunnerHost()
 	}
@@ -62,6 +74,28 @@ func defaultRunnerHost() string {
 	return fmt.Sprintf("image://%s:%s", engine.EngineImageRepo, tag)
 }
 
+// pinnedRunnerHost returns the runner host pinned by "dagger engine upgrade".
+// The pin is ignored once the CLI is upgraded, so the CLI doesn't keep using
+// an engine older than itself.
+func pinnedRunnerHost() string {
+	dt, err := os.ReadFile(pinnedRunnerHostPath)
+	if err != nil {
+		return ""
+	}
+	runnerHost, cliVersion, _ := strings.Cut(strings.TrimSpace(string(dt)), "\n")
+	if strings.TrimSpace(cliVersion) != engine.Tag {
+		return ""
+	}
+	return strings.TrimSpace(runnerHost)
+}
+
+func pinRunnerHost(runnerHost string) error {
+	if err := os.MkdirAll(filepath.Dir(pinnedRunnerHostPath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(pinnedRunnerHostPath, []byte(runnerHost+"\n"+engine.Tag+"\n"), 0o644)
+}
+
 type runClientCallback func(context.Context, *client.Client) error
 
 func withEngine(
@@ -120,6 +154,12 @@ func withEngine(
 
 		params.WithTerminal = withTerminal
 
+		if !skipHistory {
+			params.Command = traceName()
+		} else if recordFilePath != "" {
+			return cleanup.Run, errors.New("--record is not supported by this command, which is kept out of the history")
+		}
+
 		params.Interactive = interactive
 		params.InteractiveCommand = interactiveCommandParsed
 
@@ -139,6 +179,12 @@ func withEngine(
 			return cleanup.Run, err
 		}
 		cleanup.Add("close dagger session", sess.Close)
+		if recordFilePath != "" {
+			// runs before the session is closed, once the command is done
+			cleanup.Add("record trace", func() error {
+				return recordRun(context.WithoutCancel(ctx), sess)
+			})
+		}
 
 		Frontend.SetClient(sess.Dagger())
 
@@ -146,6 +192,40 @@ func withEngine(
 	})
 }
 
+// recordRun writes the telemetry of the current run, as persisted by the
+// engine for its history, to the --record file.
+func recordRun(ctx context.Context, sess *client.Client) (rerr error) {
+	body, err := sess.HistoryTelemetry(ctx, sess.ID)
+	if err != nil {
+		return fmt.Errorf("record trace: %w", err)
+	}
+	defer body.Close()
+	f, err := os.Create(recordFilePath)
+	if err != nil {
+		return fmt.Errorf("record trace: %w", err)
+	}
+	defer func() {
+		rerr = errors.Join(rerr, f.Close())
+	}()
+	if _, err := io.Copy(f, body); err != nil {
+		return fmt.Errorf("record trace: %w", err)
+	}
+	return nil
+}
+
+// traceName returns the full command string, which names the root span and
+// the run in the engine's history.
+//
+// If you pass credentials in plaintext, yes, they will be leaked; don't do
+// that, since they will also be leaked in various other places (like the
+// process tree). Use Secret arguments instead.
+func traceName() string {
+	if name := os.Getenv(TraceNameEnv); name != "" {
+		return name
+	}
+	return spanName(os.Args)
+}
+
 func initEngineTelemetry(ctx context.Context) (context.Context, func(error)) {
 	// Setup telemetry config
 	telemetryCfg := telemetry.Config{
@@ -163,16 +243,7 @@ func initEngineTelemetry(ctx context.Context) (context.Context, func(error)) {
 	}
 	ctx = telemetry.Init(ctx, telemetryCfg)
 
-	// Set the full command string as the name of the root span.
-	//
-	// If you pass credentials in plaintext, yes, they will be leaked; don't do
-	// that, since they will also be leaked in various other places (like the
-	// process tree). Use Secret arguments instead.
-	name := spanName(os.Args)
-	if os.Getenv(TraceNameEnv) != "" {
-		name = os.Getenv(TraceNameEnv)
-	}
-	ctx, span := Tracer().Start(ctx, name)
+	ctx, span := Tracer().Start(ctx, traceName())
 
 	// Set up global slog to log to the primary span output.
 	slog.SetDefault(slog.SpanLogger(ctx, InstrumentationLibrary))
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/ansiterm/tabwriter"
+	"github.com/spf13/cobra"
+	"golang.org/x/mod/semver"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/client"
+	"github.com/dagger/dagger/engine/client/drivers"
+)
+
+var (
+	engineStatusJSON  bool
+	engineStatusCache bool
+	engineLogsFollow  bool
+	engineStopAll     bool
+	engineStopOld     bool
+)
+
+func init() {
+	engineStatusCmd.Flags().BoolVar(&engineStatusJSON, "json", false, "Output the engines in JSON format")
+	engineStatusCmd.Flags().BoolVar(&engineStatusCache, "cache", false, "Show the cache usage of the current engine, starting it if needed")
+
+	engineLogsCmd.Flags().BoolVarP(&engineLogsFollow, "follow", "f", false, "Follow the logs")
+
+	engineStopCmd.Flags().BoolVar(&engineStopAll, "all", false, "Stop all the engines")
+	engineStopCmd.Flags().BoolVar(&engineStopOld, "old", false, "Stop the engines other than the current one, like engines of previous versions")
+	engineStopCmd.MarkFlagsMutuallyExclusive("all", "old")
+
+	engineCmd.AddCommand(engineStartCmd)
+	engineCmd.AddCommand(engineStatusCmd)
+	engineCmd.AddCommand(engineLogsCmd)
+	engineCmd.AddCommand(engineStopCmd)
+	engineCmd.AddCommand(engineUpgradeCmd)
+}
+
+var engineCmd = &cobra.Command{
+	Use:   "engine",
+	Short: "Manage the Dagger Engines provisioned by the CLI",
+	Long: `Manage the Dagger Engines provisioned by the CLI.
+
+The current engine is the one the CLI connects to, as configured with the
+` + RunnerHostEnv + ` environment variable or "dagger engine upgrade".`,
+}
+
+var engineStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start the current engine, provisioning it if needed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withEngine(cmd.Context(), client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
+			version, err := engineClient.Dagger().Version(ctx)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Engine %s running at %s\n", version, RunnerHost)
+			return nil
+		})
+	},
+}
+
+var engineStatusCmd = &cobra.Command{
+	Use:     "status [options]",
+	Aliases: []string{"ls"},
+	Short:   "List the engines provisioned by the CLI",
+	Example: "dagger engine status --cache",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		engines, err := listEngines(cmd)
+		if err != nil {
+			return err
+		}
+
+		var cache *engineCacheUsage
+		if engineStatusCache {
+			err := withEngine(cmd.Context(), client.Params{}, func(ctx context.Context, engineClient *client.Client) error {
+				entries := engineClient.Dagger().Engine().LocalCache().EntrySet()
+				count, err := entries.EntryCount(ctx)
+				if err != nil {
+					return err
+				}
+				size, err := entries.DiskSpaceBytes(ctx)
+				if err != nil {
+					return err
+				}
+				cache = &engineCacheUsage{Entries: count, DiskSpaceBytes: size}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get cache usage: %w", err)
+			}
+		}
+
+		w := cmd.OutOrStdout()
+		if engineStatusJSON {
+			enc := json.NewEncoder(w)
+			enc.SetIndent("", "  ")
+			return enc.Encode(struct {
+				RunnerHost string            `json:"runnerHost"`
+				Engines    []drivers.Engine  `json:"engines"`
+				Cache      *engineCacheUsage `json:"cache,omitempty"`
+			}{RunnerHost, engines, cache})
+		}
+
+		fmt.Fprintf(w, "Runner host: %s\n", RunnerHost)
+		if cache != nil {
+			fmt.Fprintf(w, "Cache: %d entries, %s\n", cache.Entries, humanize.IBytes(uint64(cache.DiskSpaceBytes)))
+		}
+		if len(engines) == 0 {
+			fmt.Fprintln(w, "No engines provisioned.")
+			return nil
+		}
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', tabwriter.DiscardEmptyColumns)
+		fmt.Fprintf(tw, "DRIVER\tNAME\tVERSION\tSTATUS\n")
+		for _, e := range engines {
+			status := "stopped"
+			if e.Running {
+				status = "running"
+			}
+			if e.Current {
+				status += " (current)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", e.Driver, e.Name, e.Version, status)
+		}
+		return tw.Flush()
+	},
+}
+
+type engineCacheUsage struct {
+	Entries        int `json:"entries"`
+	DiskSpaceBytes int `json:"diskSpaceBytes"`
+}
+
+var engineLogsCmd = &cobra.Command{
+	Use:     "logs [options] [engine]",
+	Short:   "Print the logs of an engine",
+	Long:    "Print the logs of an engine, by name or version. Defaults to the current engine.",
+	Example: "dagger engine logs --follow",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		engines, err := listEngines(cmd)
+		if err != nil {
+			return err
+		}
+		var selected []drivers.Engine
+		if len(args) > 0 {
+			selected = selectEngines(engines, args)
+		} else {
+			selected = currentEngines(engines)
+		}
+		switch len(selected) {
+		case 0:
+			return errors.New("no such engine, see dagger engine status")
+		case 1:
+		default:
+			return errors.New("multiple engines match, use their name")
+		}
+
+		err = drivers.EngineLogs(ctx, selected[0], engineLogsFollow, cmd.OutOrStdout())
+		if engineLogsFollow && ctx.Err() != nil {
+			// following ends when interrupted
+			return nil
+		}
+		return err
+	},
+}
+
+var engineStopCmd = &cobra.Command{
+	Use:   "stop [options] [engine...]",
+	Short: "Stop and remove engines",
+	Long: `Stop and remove engines, by name or version. Defaults to the current engine.
+
+Engines are provisioned again when needed. Use --old to garbage collect the
+engines of other versions.`,
+	Example: "dagger engine stop --old",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if len(args) > 0 && (engineStopAll || engineStopOld) {
+			return errors.New("engines can't be named with --all or --old")
+		}
+		engines, err := listEngines(cmd)
+		if err != nil {
+			return err
+		}
+
+		var selected []drivers.Engine
+		switch {
+		case len(args) > 0:
+			selected = selectEngines(engines, args)
+			if len(selected) == 0 {
+				return errors.New("no such engine, see dagger engine status")
+			}
+		case engineStopAll:
+			selected = engines
+		case engineStopOld:
+			for _, e := range engines {
+				if !e.Current {
+					selected = append(selected, e)
+				}
+			}
+		default:
+			selected = currentEngines(engines)
+		}
+
+		var errs error
+		for _, e := range selected {
+			if err := drivers.StopEngine(ctx, e); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("failed to stop %s: %w", e.Name, err))
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Stopped %s (%s)\n", e.Name, e.Driver)
+		}
+		return errs
+	},
+}
+
+var engineUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [version]",
+	Short: "Upgrade the engine to the version of the CLI, or pin it to another version",
+	Long: `Upgrade the engine to the version of the CLI, or pin it to another version.
+
+A pinned version is used by all later commands, until the next upgrade, or
+until the CLI itself is upgraded. The "latest" version is the latest release. The engines of other versions are
+removed.`,
+	Example: `dagger engine upgrade
+dagger engine upgrade v0.19.0
+dagger engine upgrade latest`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		if v, ok := os.LookupEnv(RunnerHostEnv); ok {
+			return fmt.Errorf("the engine is set by %s=%s, and can't be upgraded", RunnerHostEnv, v)
+		}
+
+		version := engine.Tag
+		if len(args) > 0 {
+			version = args[0]
+		}
+		switch {
+		case version == "latest":
+			var err error
+			version, err = latestVersion(ctx)
+			if err != nil {
+				return err
+			}
+		case version == "":
+			return errors.New("the version of the CLI is unknown, pass a version")
+		case !strings.HasPrefix(version, "v") && semver.IsValid("v"+version):
+			version = "v" + version
+		}
+
+		runnerHost, err := runnerHostForVersion(RunnerHost, version)
+		if err != nil {
+			return err
+		}
+		err = withEngine(ctx, client.Params{RunnerHost: runnerHost}, func(ctx context.Context, engineClient *client.Client) error {
+			_, err := engineClient.Dagger().Version(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to start engine %s: %w", version, err)
+		}
+
+		// only pin versions other than the default
+		if runnerHost == defaultRunnerHost() {
+			if err := os.Remove(pinnedRunnerHostPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		} else {
+			if err := pinRunnerHost(runnerHost); err != nil {
+				return err
+			}
+		}
+		RunnerHost = runnerHost
+
+		fmt.Fprintf(cmd.OutOrStdout(), "Engine %s running at %s\n", version, runnerHost)
+		return nil
+	},
+}
+
+// runnerHostForVersion returns the runner host provisioning another version
+// of the engine with the same driver.
+func runnerHostForVersion(runnerHost, version string) (string, error) {
+	u, err := url.Parse(runnerHost)
+	if err != nil {
+		return "", fmt.Errorf("parse runner host: %w", err)
+	}
+	if os.Getenv(GPUSupportEnv) != "" {
+		version += "-gpu"
+	}
+	imageRef := engine.EngineImageRepo + ":" + version
+
+	switch {
+	case u.Scheme == "process":
+		query := u.Query()
+		query.Set("image", imageRef)
+		return u.Scheme + "://?" + query.Encode(), nil
+	case u.Scheme == "image" || strings.HasPrefix(u.Scheme, "image+") || u.Scheme == "docker-image":
+		host := u.Scheme + "://" + imageRef
+		if u.RawQuery != "" {
+			host += "?" + u.RawQuery
+		}
+		return host, nil
+	default:
+		return "", fmt.Errorf("the engine at %s is not provisioned by the CLI, and can't be upgraded", runnerHost)
+	}
+}
+
+func listEngines(cmd *cobra.Command) ([]drivers.Engine, error) {
+	engines, err := drivers.ListEngines(cmd.Context(), RunnerHost)
+	if err != nil {
+		if len(engines) == 0 {
+			return nil, err
+		}
+		// still manage the engines of other drivers
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
+	}
+	return engines, nil
+}
+
+// selectEngines returns the engines matching any of the given names or
+// versions.
+func selectEngines(engines []drivers.Engine, names []string) []drivers.Engine {
+	var selected []drivers.Engine
+	for _, e := range engines {
+		for _, name := range names {
+			if e.Name == name || (e.Version != "" && e.Version == name) {
+				selected = append(selected, e)
+				break
+			}
+		}
+	}
+	return selected
+}
+
+func currentEngines(engines []drivers.Engine) []drivers.Engine {
+	var current []drivers.Engine
+	for _, e := range engines {
+		if e.Current {
+			current = append(current, e)
+		}
+	}
+	return current
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/client/drivers"
+)
+
+func TestRunnerHostForVersion(t *testing.T) {
+	for _, tc := range []struct {
+		runnerHost string
+		want       string
+	}{
+		{
+			runnerHost: "image://registry.dagger.io/engine:v0.18.0",
+			want:       "image://registry.dagger.io/engine:v0.19.0",
+		},
+		{
+			runnerHost: "image+podman://registry.dagger.io/engine:v0.18.0?gpu=true",
+			want:       "image+podman://registry.dagger.io/engine:v0.19.0?gpu=true",
+		},
+		{
+			runnerHost: "process:///usr/local/bin/dagger-engine?state=/tmp/dagger",
+			want:       "process://?image=registry.dagger.io%2Fengine%3Av0.19.0&state=%2Ftmp%2Fdagger",
+		},
+	} {
+		t.Run(tc.runnerHost, func(t *testing.T) {
+			t.Setenv(GPUSupportEnv, "")
+			got, err := runnerHostForVersion(tc.runnerHost, "v0.19.0")
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := runnerHostForVersion("tcp://localhost:1234", "v0.19.0")
+	require.ErrorContains(t, err, "not provisioned by the CLI")
+}
+
+func TestPinnedRunnerHost(t *testing.T) {
+	origPath, origTag := pinnedRunnerHostPath, engine.Tag
+	t.Cleanup(func() {
+		pinnedRunnerHostPath, engine.Tag = origPath, origTag
+	})
+	pinnedRunnerHostPath = filepath.Join(t.TempDir(), "runner-host")
+	engine.Tag = "v0.18.0"
+
+	require.Empty(t, pinnedRunnerHost())
+
+	require.NoError(t, pinRunnerHost("image://registry.dagger.io/engine:v0.19.0"))
+	require.Equal(t, "image://registry.dagger.io/engine:v0.19.0", pinnedRunnerHost())
+
+	// the pin is ignored once the CLI is upgraded
+	engine.Tag = "v0.18.1"
+	require.Empty(t, pinnedRunnerHost())
+
+	// and so are pins without the version of the CLI
+	require.NoError(t, os.WriteFile(pinnedRunnerHostPath, []byte("image://registry.dagger.io/engine:v0.19.0\n"), 0o644))
+	require.Empty(t, pinnedRunnerHost())
+}
+
+func TestSelectEngines(t *testing.T) {
+	engines := []drivers.Engine{
+		{Driver: "image+docker", Name: "dagger-engine-v0.18.0", Version: "v0.18.0"},
+		{Driver: "image+docker", Name: "dagger-engine-v0.19.0", Version: "v0.19.0", Current: true},
+		{Driver: "process", Name: "/home/user/.cache/dagger/engine"},
+	}
+	require.Equal(t, engines[:1], selectEngines(engines, []string{"v0.18.0"}))
+	require.Equal(t, engines[1:], selectEngines(engines, []string{"dagger-engine-v0.19.0", "/home/user/.cache/dagger/engine"}))
+	require.Empty(t, selectEngines(engines, []string{""}))
+	require.Equal(t, engines[1:2], currentEngines(engines))
+}
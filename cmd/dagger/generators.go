@@ -18,10 +18,14 @@ import (
 
 var (
 	generateListMode bool
+	generateSince    string
+	generateExplain  bool
 )
 
 func init() {
 	generateCmd.Flags().BoolVarP(&generateListMode, "list", "l", false, "List available generators")
+	generateCmd.Flags().StringVar(&generateSince, "since", "", "Only run the generators affected by the changes since this git ref")
+	generateCmd.Flags().BoolVar(&generateExplain, "explain", false, "Explain why each generator is selected or skipped by --since")
 }
 
 var generateCmd = &cobra.Command{
@@ -34,6 +38,7 @@ Examples:
   dagger generate                            # Generate all assets
   dagger generate -l                         # List all available generators
   dagger generate go:bin                     # Generate by selecting the generator function
+  dagger generate --since=origin/main        # Only run the generators affected by the changes
 `,
 	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -54,6 +59,21 @@ Examples:
 				} else {
 					generators = mod.Generators()
 				}
+				if generateExplain && generateSince == "" {
+					return fmt.Errorf("--explain requires --since")
+				}
+				if generateSince != "" {
+					changed, err := changedPathsSince(ctx, dag, generateSince)
+					if err != nil {
+						return err
+					}
+					generators = generators.AffectedByPaths(changed)
+				}
+				if generateExplain {
+					if err := explainGenerators(ctx, generators, cmd); err != nil {
+						return err
+					}
+				}
 				if generateListMode {
 					return listGenerators(ctx, generators, cmd)
 				} else {
@@ -64,6 +84,34 @@ Examples:
 	},
 }
 
+// 'dagger generate --since=ref --explain'
+func explainGenerators(ctx context.Context, generatorGroup *dagger.GeneratorGroup, cmd *cobra.Command) error {
+	ctx, span := Tracer().Start(ctx, "explain generator selection")
+	defer span.End()
+
+	generators, err := generatorGroup.List(ctx)
+	if err != nil {
+		return err
+	}
+	infos := make([]selectionInfo, 0, len(generators))
+	for _, generator := range generators {
+		name, err := generator.Name(ctx)
+		if err != nil {
+			return err
+		}
+		skipped, err := generator.Skipped(ctx)
+		if err != nil {
+			return err
+		}
+		reason, err := generator.SelectionReason(ctx)
+		if err != nil {
+			return err
+		}
+		infos = append(infos, selectionInfo{Name: cliName(name), Skipped: skipped, Reason: reason})
+	}
+	return printSelection(cmd, infos)
+}
+
 func loadGeneratorGroupInfo(ctx context.Context, generatorGroup *dagger.GeneratorGroup) (*GeneratorGroupInfo, error) {
 	ctx, span := Tracer().Start(ctx, "fetch generator information")
 	defer span.End()
@@ -0,0 +1,366 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/ansiterm/tabwriter"
+	"github.com/spf13/cobra"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dagger/dagger/dagql/call/callpbv1"
+	"github.com/dagger/dagger/dagql/dagui"
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/client"
+	enginetel "github.com/dagger/dagger/engine/telemetry"
+)
+
+var (
+	historyLimit int
+	historyJSON  bool
+
+	// skipHistory keeps the current command out of the history.
+	skipHistory bool
+)
+
+// historyIDLength is the length of the run IDs that are displayed; any
+// unambiguous prefix of a run ID can be used to refer to it.
+const historyIDLength = 12
+
+func init() {
+	historyCmd.Flags().IntVarP(&historyLimit, "limit", "n", 20, "Maximum number of runs to list, or 0 for all of them")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "Output the runs in JSON format")
+
+	historyCmd.AddCommand(historyShowCmd)
+	historyCmd.AddCommand(historyDiffCmd)
+}
+
+var historyCmd = &cobra.Command{
+	Use:   "history [options]",
+	Short: "Browse the past runs of commands",
+	Long: `Browse the past runs of commands from this host, as recorded by the engine.
+
+The engine keeps the telemetry of each run for an hour by default, within 2GB
+of disk space. To keep it longer, configure the "history" key of the engine
+configuration.`,
+	Example: `dagger history
+dagger history show 3c5jkq2bsn0w
+dagger history diff 3c5jkq2bsn0w 8xv1m6t0qzrd`,
+	Annotations: map[string]string{
+		"experimental": "true",
+	},
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withHistory(cmd.Context(), func(ctx context.Context, engineClient *client.Client) error {
+			runs, err := engineClient.History(ctx, historyLimit)
+			if err != nil {
+				return err
+			}
+			w := cmd.OutOrStdout()
+			if historyJSON {
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				return enc.Encode(runs)
+			}
+			if len(runs) == 0 {
+				fmt.Fprintln(w, "No runs found.")
+				return nil
+			}
+			tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', tabwriter.DiscardEmptyColumns)
+			fmt.Fprintln(tw, "ID\tSTARTED\tDURATION\tSTATUS\tCACHED\tCOMMAND")
+			for _, run := range runs {
+				status := "ok"
+				if run.Failed {
+					status = "failed"
+				}
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+					shortRunID(run.ClientID),
+					humanize.Time(run.Start),
+					dagui.FormatDuration(run.End.Sub(run.Start)),
+					status,
+					cacheHitRatio(run),
+					run.Command,
+				)
+			}
+			return tw.Flush()
+		})
+	},
+}
+
+var historyShowCmd = &cobra.Command{
+	Use:   "show <run>",
+	Short: "Reopen a past run in the TUI",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// keep the replayed run on screen, like "dagger trace"
+		opts.NoExit = true
+		return withHistory(cmd.Context(), func(ctx context.Context, engineClient *client.Client) error {
+			run, err := resolveRun(ctx, engineClient, args[0])
+			if err != nil {
+				return err
+			}
+			body, err := engineClient.HistoryTelemetry(ctx, run.ClientID)
+			if err != nil {
+				return err
+			}
+			defer body.Close()
+
+			return replayRun(ctx, body)
+		})
+	},
+}
+
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <run> <other run>",
+	Short: "Show which calls of a run stopped hitting the cache compared to a previous run, and why",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withHistory(cmd.Context(), func(ctx context.Context, engineClient *client.Client) error {
+			before, err := loadRun(ctx, engineClient, args[0])
+			if err != nil {
+				return err
+			}
+			after, err := loadRun(ctx, engineClient, args[1])
+			if err != nil {
+				return err
+			}
+			misses := cacheMisses(before, after)
+
+			w := cmd.OutOrStdout()
+			if len(misses) == 0 {
+				fmt.Fprintln(w, "No calls stopped hitting the cache.")
+				return nil
+			}
+			fmt.Fprintf(w, "%d calls stopped hitting the cache:\n\n", len(misses))
+			tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+			for _, miss := range misses {
+				fmt.Fprintf(tw, "%s\t%s\n", miss.Name, miss.Reason)
+			}
+			return tw.Flush()
+		})
+	},
+}
+
+// withHistory runs a callback with the engine, keeping the current command
+// out of the history so browsing it doesn't fill it.
+func withHistory(ctx context.Context, fn runClientCallback) error {
+	skipHistory = true
+	return withEngine(ctx, client.Params{}, fn)
+}
+
+func shortRunID(id string) string {
+	if len(id) > historyIDLength {
+		return id[:historyIDLength]
+	}
+	return id
+}
+
+func cacheHitRatio(run *engine.HistoryRun) string {
+	if run.Calls == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%% (%d/%d)", run.CachedCalls*100/run.Calls, run.CachedCalls, run.Calls)
+}
+
+// replayRun replays the telemetry of a run, as served from the history or
+// recorded with --record, through the frontend.
+func replayRun(ctx context.Context, r io.Reader) error {
+	var primarySet bool
+	return enginetel.ReplayFile(ctx, r,
+		Frontend.SpanExporter(),
+		Frontend.LogExporter(),
+		Frontend.MetricExporter(),
+		func(spans []sdktrace.ReadOnlySpan) {
+			if primarySet || len(spans) == 0 {
+				return
+			}
+			// The span of the command itself is only known to the CLI, so
+			// the run is beneath the parent of its first span.
+			primary := spans[0].Parent().SpanID()
+			if !primary.IsValid() {
+				primary = spans[0].SpanContext().SpanID()
+			}
+			Frontend.SetPrimary(dagui.SpanID{SpanID: primary})
+			primarySet = true
+		})
+}
+
+// resolveRun finds the run with the given ID or ID prefix.
+func resolveRun(ctx context.Context, engineClient *client.Client, id string) (*engine.HistoryRun, error) {
+	runs, err := engineClient.History(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	var found *engine.HistoryRun
+	for _, run := range runs {
+		if !strings.HasPrefix(run.ClientID, id) {
+			continue
+		}
+		if run.ClientID == id {
+			return run, nil
+		}
+		if found != nil {
+			return nil, fmt.Errorf("ambiguous run ID %q", id)
+		}
+		found = run
+	}
+	if found == nil {
+		return nil, fmt.Errorf("run %q not found", id)
+	}
+	return found, nil
+}
+
+// loadRun replays the telemetry of a run into a new DB.
+func loadRun(ctx context.Context, engineClient *client.Client, id string) (*dagui.DB, error) {
+	run, err := resolveRun(ctx, engineClient, id)
+	if err != nil {
+		return nil, err
+	}
+	body, err := engineClient.HistoryTelemetry(ctx, run.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	db := dagui.NewDB()
+	if err := enginetel.ReplayFile(ctx, body, db, db.LogExporter(), db.MetricExporter(), nil); err != nil {
+		return nil, fmt.Errorf("load run %s: %w", shortRunID(run.ClientID), err)
+	}
+	return db, nil
+}
+
+// cacheMiss is a call of a run that didn't hit the cache, although the same
+// call of the previous run did.
+type cacheMiss struct {
+	Name   string
+	Reason string
+}
+
+// runCall is a distinct call made by a run.
+type runCall struct {
+	Name   string
+	Path   string
+	Digest string
+	Call   *callpbv1.Call
+	Cached bool
+}
+
+// cacheMisses returns the calls of a run that didn't hit the cache, among the
+// calls that hit the cache in the previous run.
+//
+// The calls of both runs are matched by their path of fields from the root of
+// the API (e.g. container.from.withExec), in order, so that a call is matched
+// even if its arguments changed.
+func cacheMisses(before, after *dagui.DB) []cacheMiss {
+	beforeCalls := map[string][]*runCall{}
+	for _, call := range runCalls(before) {
+		beforeCalls[call.Path] = append(beforeCalls[call.Path], call)
+	}
+	var misses []cacheMiss
+	seen := map[string]int{}
+	for _, call := range runCalls(after) {
+		n := seen[call.Path]
+		seen[call.Path]++
+		if call.Cached || n >= len(beforeCalls[call.Path]) {
+			continue
+		}
+		beforeCall := beforeCalls[call.Path][n]
+		if !beforeCall.Cached {
+			// missed the cache both times; not a regression
+			continue
+		}
+		misses = append(misses, cacheMiss{
+			Name:   call.Name,
+			Reason: missReason(after, beforeCall, call),
+		})
+	}
+	return misses
+}
+
+// runCalls returns the distinct calls of a run, in order.
+func runCalls(db *dagui.DB) []*runCall {
+	var calls []*runCall
+	byDigest := map[string]*runCall{}
+	paths := map[string]string{}
+	for _, span := range db.Spans.Order {
+		if span.CallDigest == "" {
+			continue
+		}
+		if call, ok := byDigest[span.CallDigest]; ok {
+			// the same call can be made many times; it hits the cache if any
+			// of them do
+			call.Cached = call.Cached || span.Cached
+			continue
+		}
+		pbCall := span.Call()
+		if pbCall == nil {
+			continue
+		}
+		call := &runCall{
+			Name:   span.Name,
+			Path:   callPath(db, span.CallDigest, paths),
+			Digest: span.CallDigest,
+			Call:   pbCall,
+			Cached: span.Cached,
+		}
+		byDigest[span.CallDigest] = call
+		calls = append(calls, call)
+	}
+	return calls
+}
+
+// callPath returns the fields selected from the root of the API to make a
+// call, ignoring their arguments.
+func callPath(db *dagui.DB, dig string, paths map[string]string) string {
+	if path, ok := paths[dig]; ok {
+		return path
+	}
+	call := db.Call(dig)
+	if call == nil {
+		return "?"
+	}
+	path := call.Field
+	if call.ReceiverDigest != "" {
+		path = callPath(db, call.ReceiverDigest, paths) + "." + path
+	}
+	paths[dig] = path
+	return path
+}
+
+// missReason explains why a call didn't hit the cache, given the call of the
+// previous run it's matched with.
+func missReason(db *dagui.DB, prev, call *runCall) string {
+	if prev.Digest == call.Digest {
+		return "same call as before, but its result wasn't cached anymore"
+	}
+	var changed []string
+	prevArgs := map[string]*callpbv1.Literal{}
+	for _, arg := range prev.Call.Args {
+		prevArgs[arg.Name] = arg.Value
+	}
+	for _, arg := range call.Call.Args {
+		if prevArg, ok := prevArgs[arg.Name]; !ok || !proto.Equal(prevArg, arg.Value) {
+			changed = append(changed, arg.Name)
+		}
+		delete(prevArgs, arg.Name)
+	}
+	for name := range prevArgs {
+		changed = append(changed, name)
+	}
+	if len(changed) > 0 {
+		slices.Sort(changed)
+		return "arguments changed: " + strings.Join(changed, ", ")
+	}
+	if prev.Call.ReceiverDigest != call.Call.ReceiverDigest {
+		if receiver := db.Call(call.Call.ReceiverDigest); receiver != nil {
+			return "receiver changed: " + receiver.Field
+		}
+		return "receiver changed"
+	}
+	return "call changed"
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dagger.io/dagger/telemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dagger/dagger/dagql/call/callpbv1"
+	"github.com/dagger/dagger/dagql/dagui"
+)
+
+type testCall struct {
+	name     string
+	digest   string
+	receiver string
+	field    string
+	args     []*callpbv1.Argument
+	cached   bool
+}
+
+func testRun(t *testing.T, calls ...testCall) *dagui.DB {
+	db := dagui.NewDB()
+	var spans []sdktrace.ReadOnlySpan
+	for i, call := range calls {
+		payload, err := (&callpbv1.Call{
+			ReceiverDigest: call.receiver,
+			Field:          call.field,
+			Args:           call.args,
+		}).Encode()
+		require.NoError(t, err)
+		spans = append(spans, tracetest.SpanStub{
+			Name: call.name,
+			SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+				TraceID: trace.TraceID{1},
+				SpanID:  trace.SpanID{byte(i + 1)},
+			}),
+			StartTime: time.Unix(int64(i), 0),
+			EndTime:   time.Unix(int64(i)+1, 0),
+			Attributes: []attribute.KeyValue{
+				attribute.String(telemetry.DagDigestAttr, call.digest),
+				attribute.String(telemetry.DagCallAttr, payload),
+				attribute.Bool(telemetry.CachedAttr, call.cached),
+			},
+		}.Snapshot())
+	}
+	require.NoError(t, db.ExportSpans(context.Background(), spans))
+	return db
+}
+
+func stringArg(name, value string) *callpbv1.Argument {
+	return &callpbv1.Argument{
+		Name:  name,
+		Value: &callpbv1.Literal{Value: &callpbv1.Literal_String_{String_: value}},
+	}
+}
+
+func TestCacheMisses(t *testing.T) {
+	before := testRun(t,
+		testCall{name: "Query.container", digest: "container", field: "container"},
+		testCall{name: "Container.from", digest: "from", receiver: "container", field: "from",
+			args: []*callpbv1.Argument{stringArg("address", "alpine")}},
+		testCall{name: "Container.withExec", digest: "exec-a", receiver: "from", field: "withExec",
+			args: []*callpbv1.Argument{stringArg("cmd", "echo a")}, cached: true},
+		testCall{name: "Container.stdout", digest: "stdout-a", receiver: "exec-a", field: "stdout", cached: true},
+		testCall{name: "Container.withWorkdir", digest: "workdir", receiver: "from", field: "withWorkdir",
+			args: []*callpbv1.Argument{stringArg("path", "/src")}, cached: true},
+		testCall{name: "Container.withEnvVariable", digest: "env-a", receiver: "from", field: "withEnvVariable",
+			args: []*callpbv1.Argument{stringArg("value", "a")}},
+	)
+	after := testRun(t,
+		testCall{name: "Query.container", digest: "container", field: "container", cached: true},
+		testCall{name: "Container.from", digest: "from", receiver: "container", field: "from",
+			args: []*callpbv1.Argument{stringArg("address", "alpine")}, cached: true},
+		testCall{name: "Container.withExec", digest: "exec-b", receiver: "from", field: "withExec",
+			args: []*callpbv1.Argument{stringArg("cmd", "echo b")}},
+		testCall{name: "Container.stdout", digest: "stdout-b", receiver: "exec-b", field: "stdout"},
+		testCall{name: "Container.withWorkdir", digest: "workdir", receiver: "from", field: "withWorkdir",
+			args: []*callpbv1.Argument{stringArg("path", "/src")}},
+		// calls that didn't hit the cache before didn't stop hitting it
+		testCall{name: "Container.withEnvVariable", digest: "env-b", receiver: "from", field: "withEnvVariable",
+			args: []*callpbv1.Argument{stringArg("value", "b")}},
+		// neither did new calls
+		testCall{name: "Container.withUser", digest: "user", receiver: "from", field: "withUser",
+			args: []*callpbv1.Argument{stringArg("name", "root")}},
+	)
+
+	require.Equal(t, []cacheMiss{
+		{Name: "Container.withExec", Reason: "arguments changed: cmd"},
+		{Name: "Container.stdout", Reason: "receiver changed: withExec"},
+		{Name: "Container.withWorkdir", Reason: "same call as before, but its result wasn't cached anymore"},
+	}, cacheMisses(before, after))
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/google/shlex"
 	"github.com/mattn/go-isatty"
+	"github.com/moby/sys/reexec"
 	"github.com/muesli/reflow/indent"
 	"github.com/muesli/reflow/wordwrap"
 	"github.com/muesli/termenv"
@@ -71,6 +72,8 @@ var (
 	_, useCloudEngine        = os.LookupEnv("DAGGER_CLOUD_ENGINE")
 	enableScaleOut           bool
 
+	recordFilePath string
+
 	dotOutputFilePath string
 	dotFocusField     string
 	dotShowInternal   bool
@@ -139,6 +142,7 @@ func init() {
 		queryCmd,
 		runCmd,
 		traceCmd,
+		historyCmd,
 		configCmd,
 		checksCmd,
 		generateCmd,
@@ -156,6 +160,7 @@ func init() {
 		newGenCmd(),
 		shellCmd,
 		clientCmd,
+		engineCmd,
 		mcpCmd,
 	)
 
@@ -338,6 +343,7 @@ func installGlobalFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&web, "web", "w", false, "Open trace URL in a web browser")
 	flags.BoolVarP(&noExit, "no-exit", "E", false, "Leave the TUI running after completion")
 	flags.BoolVarP(&autoApply, "auto-apply", "y", false, "Automatically apply changes when a changeset is returned")
+	flags.StringVar(&recordFilePath, "record", "", "Record the trace, logs and metrics to an OTLP JSON lines file, to view with 'dagger trace --file'")
 
 	flags.StringVar(&dotOutputFilePath, "dot-output", "", "If set, write the calls made during execution to a dot file at the given path before exiting")
 	flags.StringVar(&dotFocusField, "dot-focus-field", "", "In dot output, filter out vertices that aren't this field or descendents of this field")
@@ -416,6 +422,12 @@ const InstrumentationLibrary = "dagger.io/cli"
 var opts dagui.FrontendOpts
 
 func main() {
+	// the process engine driver sets up the engine in a re-execution of the
+	// CLI
+	if reexec.Init() {
+		return
+	}
+
 	parseGlobalFlags()
 	opts.Verbosity += dagui.ShowCompletedVerbosity // keep progress by default
 	opts.Verbosity += verbose                      // raise verbosity with -v
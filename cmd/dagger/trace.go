@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"dagger.io/dagger/telemetry"
 	"github.com/dagger/dagger/dagql/dagui"
@@ -13,27 +14,41 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var traceOrgFlag string
+var (
+	traceOrgFlag  string
+	traceFileFlag string
+)
 
 var traceCmd = &cobra.Command{
 	Use:    "trace [trace ID]",
 	Hidden: true,
-	Args:   cobra.ExactArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if traceFileFlag != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	Annotations: map[string]string{
 		"experimental": "true",
 	},
 	Aliases: []string{"t"},
-	Short:   "View a Dagger trace from Dagger Cloud.",
+	Short:   "View a Dagger trace from Dagger Cloud, or recorded with --record.",
 	GroupID: cloudGroup.ID,
-	Example: `dagger trace 2f123ba77bf7bd2d4db2f70ed20613e8`,
-	RunE:    Trace,
+	Example: `dagger trace 2f123ba77bf7bd2d4db2f70ed20613e8
+dagger trace --file trace.otlp.jsonl`,
+	RunE: Trace,
 }
 
 func init() {
 	traceCmd.Flags().StringVar(&traceOrgFlag, "org", "", "Dagger Cloud org name (defaults to current org)")
+	traceCmd.Flags().StringVar(&traceFileFlag, "file", "", "View a trace recorded with --record instead of one from Dagger Cloud")
 }
 
 func Trace(cmd *cobra.Command, args []string) error {
+	if traceFileFlag != "" {
+		return traceFile(cmd.Context(), traceFileFlag)
+	}
+
 	traceID := args[0]
 
 	return Frontend.Run(cmd.Context(), dagui.FrontendOpts{
@@ -116,3 +131,24 @@ func resolveOrgID(ctx context.Context, client *cloud.Client, cloudAuth *auth.Clo
 
 	return "", fmt.Errorf("no org specified; use --org or run 'dagger login' to set a default org")
 }
+
+// traceFile replays a trace recorded with --record through the frontend.
+func traceFile(ctx context.Context, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return Frontend.Run(ctx, dagui.FrontendOpts{
+		Verbosity: dagui.ShowCompletedVerbosity,
+		NoExit:    true,
+	}, func(ctx context.Context) (cleanups.CleanupF, error) {
+		noop := func() error { return nil }
+
+		if err := replayRun(ctx, f); err != nil {
+			return noop, fmt.Errorf("replay %s: %w", path, err)
+		}
+		return noop, nil
+	})
+}
@@ -2,18 +2,47 @@ package core
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dagger/dagger/dagql"
 	"github.com/dagger/dagger/util/parallel"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
+// checkLogExcerptLines is the maximum number of output lines kept in a failed
+// check's log excerpt.
+const checkLogExcerptLines = 20
+
 // Check represents a validation check with its result
 type Check struct {
 	Node      *ModTreeNode `json:"node"`
 	Completed bool         `field:"true" doc:"Whether the check completed"`
 	Passed    bool         `field:"true" doc:"Whether the check passed"`
+
+	DurationMillis int64  `field:"true" name:"durationMs" doc:"How long the check took to run, in milliseconds"`
+	ErrorMessage   string `field:"true" doc:"The error the check failed with, if any"`
+	LogExcerpt     string `field:"true" doc:"The last lines of output of the failed command, if the check failed running one"`
+	TraceID        string `field:"true" name:"traceID" doc:"The ID of the trace the check ran in"`
+	SpanID         string `field:"true" name:"spanID" doc:"The ID of the span of the check"`
+	Attempts       int    `field:"true" doc:"The number of times the check was run, including retries"`
+
+	Quarantined bool `field:"true" doc:"Whether the check is quarantined: its failures are reported, but don't fail the group"`
+
+	Skipped         bool   `field:"true" doc:"Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy"`
+	SelectionReason string `field:"true" doc:"Why the check was selected or skipped by affectedBy"`
+}
+
+// CheckRunOpts are the policies applied when running checks.
+type CheckRunOpts struct {
+	// Cancel the remaining checks as soon as one fails
+	FailFast bool
+	// Number of times a failed check is retried
+	Retries int
+	// Record failed checks in the results instead of failing the run
+	NoFail bool
 }
 
 type CheckGroup struct {
@@ -27,12 +56,15 @@ func NewCheckGroup(ctx context.Context, mod *Module, include []string) (*CheckGr
 		return nil, err
 	}
 
-	var exclude []string
+	var exclude, quarantine []string
 	if mod.Toolchains != nil {
 		for _, entry := range mod.Toolchains.Entries() {
 			for _, ignorePattern := range entry.IgnoreChecks {
 				exclude = append(exclude, entry.FieldName+":"+ignorePattern)
 			}
+			for _, quarantinePattern := range entry.QuarantineChecks {
+				quarantine = append(quarantine, entry.FieldName+":"+quarantinePattern)
+			}
 		}
 	}
 	checkNodes, err := rootNode.RollupChecks(ctx, include, exclude)
@@ -42,7 +74,14 @@ func NewCheckGroup(ctx context.Context, mod *Module, include []string) (*CheckGr
 	checks := make([]*Check, 0, len(checkNodes))
 
 	for _, checkNode := range checkNodes {
-		checks = append(checks, &Check{Node: checkNode})
+		check := &Check{Node: checkNode}
+		if len(quarantine) > 0 {
+			check.Quarantined, err = checkNode.Match(ctx, quarantine)
+			if err != nil {
+				return nil, err
+			}
+		}
+		checks = append(checks, check)
 	}
 	return &CheckGroup{
 		Node:   rootNode,
@@ -62,18 +101,27 @@ func (r *CheckGroup) List() []*Check {
 }
 
 // Run all the checks in the group
-func (r *CheckGroup) Run(ctx context.Context) (*CheckGroup, error) {
+func (r *CheckGroup) Run(ctx context.Context, opts CheckRunOpts) (*CheckGroup, error) {
 	r = r.Clone()
 
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
 	jobs := parallel.New().WithContextualTracer(true)
 	for _, check := range r.Checks {
-		// Reset output fields, in case we're re-running
-		check.Completed = false
-		check.Passed = false
 		jobs = jobs.WithJob(check.Name(), func(ctx context.Context) error {
-			err := check.Node.RunCheck(ctx, nil, nil)
-			check.Completed = true
-			check.Passed = (err == nil)
+			err := check.run(ctx, opts.Retries)
+			if !check.Failed() {
+				return nil
+			}
+			if opts.FailFast {
+				cancel(fmt.Errorf("canceled after check %s failed", check.Name()))
+			}
+			if opts.NoFail {
+				// the failure is recorded in the check's result, like with
+				// Check.Run
+				return nil
+			}
 			return err
 		})
 	}
@@ -83,17 +131,52 @@ func (r *CheckGroup) Run(ctx context.Context) (*CheckGroup, error) {
 	return r, nil
 }
 
-func (r *CheckGroup) Report(ctx context.Context) (*File, error) {
-	headers := []string{"check", "description", "success"}
-	rows := [][]string{}
+// AffectedBy marks the checks whose inputs aren't affected by the changes as
+// skipped.
+func (r *CheckGroup) AffectedBy(ctx context.Context, changes *Changeset) (*CheckGroup, error) {
+	changed, err := changes.changedPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compute changed paths: %w", err)
+	}
+	return r.AffectedByPaths(changed)
+}
+
+// AffectedByPaths marks the checks whose inputs aren't affected by the
+// changed paths, relative to the context directory, as skipped.
+func (r *CheckGroup) AffectedByPaths(changed []string) (*CheckGroup, error) {
+	r = r.Clone()
 	for _, check := range r.Checks {
-		rows = append(rows, []string{
-			check.Name(),
-			check.Description(),
-			check.ResultEmoji(),
-		})
+		affected, reason, err := check.Node.AffectedBy(changed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", check.Name(), err)
+		}
+		check.Skipped = !affected
+		check.SelectionReason = reason
+	}
+	return r, nil
+}
+
+func (r *CheckGroup) Report(ctx context.Context, format CheckReportFormat) (*File, error) {
+	var name, contents string
+	var err error
+	switch format {
+	case CheckReportFormatMarkdown, "":
+		name, contents = "checks.md", r.markdownReport()
+	case CheckReportFormatJUnit:
+		name = "checks.xml"
+		contents, err = r.junitReport()
+	case CheckReportFormatJSON:
+		name = "checks.json"
+		contents, err = r.jsonReport()
+	case CheckReportFormatSARIF:
+		name = "checks.sarif"
+		contents, err = r.sarifReport()
+	default:
+		return nil, fmt.Errorf("unknown report format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("generate %s report: %w", format, err)
 	}
-	contents := markdownTable(headers, rows...)
 
 	srv, err := CurrentDagqlServer(ctx)
 	if err != nil {
@@ -105,7 +188,7 @@ func (r *CheckGroup) Report(ctx context.Context) (*File, error) {
 		dagql.Selector{
 			Field: "file",
 			Args: []dagql.NamedInput{
-				{Name: "name", Value: dagql.String("checks.md")},
+				{Name: "name", Value: dagql.String(name)},
 				{Name: "contents", Value: dagql.String(contents)},
 			},
 		},
@@ -116,19 +199,6 @@ func (r *CheckGroup) Report(ctx context.Context) (*File, error) {
 	return file, nil
 }
 
-func markdownTable(headers []string, rows ...[]string) string {
-	var sb strings.Builder
-	sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
-	for range headers {
-		sb.WriteString("| -- ")
-	}
-	sb.WriteString("|\n")
-	for _, row := range rows {
-		sb.WriteString("|" + strings.Join(row, " | ") + " |\n")
-	}
-	return sb.String()
-}
-
 func (r *CheckGroup) Clone() *CheckGroup {
 	cp := *r
 	cp.Node = cp.Node.Clone()
@@ -163,6 +233,9 @@ func (c *Check) ResultEmoji() string {
 		if c.Passed {
 			return "🟢"
 		}
+		if c.Quarantined {
+			return "🟡"
+		}
 		return "🔴"
 	}
 	return ""
@@ -178,11 +251,103 @@ func (c *Check) Clone() *Check {
 	return &cp
 }
 
-func (c *Check) Run(ctx context.Context) (*Check, error) {
+func (c *Check) Run(ctx context.Context, retries int) (*Check, error) {
 	c = c.Clone()
-
-	err := c.Node.RunCheck(ctx, nil, nil)
-	c.Completed = true
-	c.Passed = (err == nil)
+	// a failed check is recorded in its result
+	_ = c.run(ctx, retries)
 	return c, nil
 }
+
+// Failed reports whether the check failed in a way that fails its group,
+// i.e. it didn't pass and isn't quarantined.
+func (c *Check) Failed() bool {
+	return c.Completed && !c.Passed && !c.Quarantined
+}
+
+// run runs the check, retrying it up to the given number of times until it
+// passes, and records its result. It returns the error of the last attempt.
+func (c *Check) run(ctx context.Context, retries int) error {
+	// Reset output fields, in case we're re-running
+	c.reset()
+	if c.Skipped {
+		return nil
+	}
+
+	start := time.Now()
+	defer func() {
+		c.DurationMillis = time.Since(start).Milliseconds()
+	}()
+	canceled := func() error {
+		// canceled before the check could complete, e.g. by fail-fast: it's
+		// reported as skipped, not failed
+		c.Completed = false
+		c.Passed = false
+		c.ErrorMessage = context.Cause(ctx).Error()
+		c.LogExcerpt = ""
+		return context.Cause(ctx)
+	}
+	var lastErr error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if ctx.Err() != nil {
+			return canceled()
+		}
+		c.Attempts++
+		spanCtx, err := c.Node.RunCheck(ctx, nil, nil)
+		if spanCtx.IsValid() {
+			c.TraceID = spanCtx.TraceID().String()
+			c.SpanID = spanCtx.SpanID().String()
+		}
+		if err != nil && ctx.Err() != nil {
+			// the check was interrupted while running
+			return canceled()
+		}
+		c.Completed = true
+		c.Passed = (err == nil)
+		if err == nil {
+			c.ErrorMessage = ""
+			c.LogExcerpt = ""
+			return nil
+		}
+		c.ErrorMessage = err.Error()
+		c.LogExcerpt = checkLogExcerpt(err)
+		lastErr = err
+	}
+	return lastErr
+}
+
+func (c *Check) reset() {
+	c.Completed = false
+	c.Passed = false
+	c.DurationMillis = 0
+	c.ErrorMessage = ""
+	c.LogExcerpt = ""
+	c.TraceID = ""
+	c.SpanID = ""
+	c.Attempts = 0
+}
+
+// checkLogExcerpt returns the last lines of output of the command that
+// caused a check to fail, preferring stderr over stdout.
+func checkLogExcerpt(err error) string {
+	var extErr dagql.ExtendedError
+	if !errors.As(err, &extErr) {
+		return ""
+	}
+	ext := extErr.Extensions()
+	for _, key := range []string{"stderr", "stdout"} {
+		out, ok := ext[key].(string)
+		if !ok {
+			continue
+		}
+		out = strings.TrimSpace(out)
+		if out == "" {
+			continue
+		}
+		lines := strings.Split(out, "\n")
+		if len(lines) > checkLogExcerptLines {
+			lines = lines[len(lines)-checkLogExcerptLines:]
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ""
+}
@@ -0,0 +1,426 @@
+package core
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+type CheckReportFormat string
+
+var CheckReportFormatEnum = dagql.NewEnum[CheckReportFormat]()
+
+var (
+	CheckReportFormatMarkdown = CheckReportFormatEnum.Register("MARKDOWN",
+		`A markdown table, for humans`,
+	)
+	CheckReportFormatJUnit = CheckReportFormatEnum.Register("JUNIT",
+		`JUnit XML, as consumed by most CI systems`,
+	)
+	CheckReportFormatJSON = CheckReportFormatEnum.Register("JSON",
+		`The check results as JSON`,
+	)
+	CheckReportFormatSARIF = CheckReportFormatEnum.Register("SARIF",
+		`SARIF 2.1.0, as consumed by code scanning tools`,
+	)
+)
+
+func (format CheckReportFormat) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "CheckReportFormat",
+		NonNull:   true,
+	}
+}
+
+func (format CheckReportFormat) TypeDescription() string {
+	return "The format of a check report"
+}
+
+func (format CheckReportFormat) Decoder() dagql.InputDecoder {
+	return CheckReportFormatEnum
+}
+
+func (format CheckReportFormat) ToLiteral() call.Literal {
+	return CheckReportFormatEnum.Literal(format)
+}
+
+// Status returns a short description of the check's result.
+func (c *Check) Status() string {
+	switch {
+	case !c.Completed:
+		return "skipped"
+	case c.Passed:
+		return "passed"
+	case c.Quarantined:
+		return "quarantined"
+	default:
+		return "failed"
+	}
+}
+
+func (c *Check) duration() time.Duration {
+	return time.Duration(c.DurationMillis) * time.Millisecond
+}
+
+func (r *CheckGroup) markdownReport() string {
+	headers := []string{"check", "description", "success", "duration"}
+	rows := [][]string{}
+	var failures []*Check
+	for _, check := range r.Checks {
+		var duration string
+		if check.Completed {
+			duration = check.duration().String()
+		}
+		rows = append(rows, []string{
+			check.Name(),
+			check.Description(),
+			check.ResultEmoji(),
+			duration,
+		})
+		if check.Completed && !check.Passed {
+			failures = append(failures, check)
+		}
+	}
+	contents := markdownTable(headers, rows...)
+	for _, check := range failures {
+		title := check.Name()
+		if check.Quarantined {
+			title += " (quarantined)"
+		}
+		contents += fmt.Sprintf("\n### %s\n\n%s\n", title, check.ErrorMessage)
+		if check.LogExcerpt != "" {
+			contents += "\n```\n" + check.LogExcerpt + "\n```\n"
+		}
+	}
+	return contents
+}
+
+func markdownTable(headers []string, rows ...[]string) string {
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(headers, " | ") + " |\n")
+	for range headers {
+		sb.WriteString("| -- ")
+	}
+	sb.WriteString("|\n")
+	for _, row := range rows {
+		sb.WriteString("|" + strings.Join(row, " | ") + " |\n")
+	}
+	return sb.String()
+}
+
+type checkReportSummary struct {
+	Total       int `json:"total"`
+	Passed      int `json:"passed"`
+	Failed      int `json:"failed"`
+	Quarantined int `json:"quarantined"`
+	Skipped     int `json:"skipped"`
+}
+
+func (r *CheckGroup) summary() checkReportSummary {
+	var sum checkReportSummary
+	for _, check := range r.Checks {
+		sum.Total++
+		switch check.Status() {
+		case "passed":
+			sum.Passed++
+		case "failed":
+			sum.Failed++
+		case "quarantined":
+			sum.Quarantined++
+		default:
+			sum.Skipped++
+		}
+	}
+	return sum
+}
+
+func (r *CheckGroup) durationMillis() int64 {
+	var total int64
+	for _, check := range r.Checks {
+		total += check.DurationMillis
+	}
+	return total
+}
+
+type jsonCheckReport struct {
+	Checks  []jsonCheckResult  `json:"checks"`
+	Summary checkReportSummary `json:"summary"`
+}
+
+type jsonCheckResult struct {
+	Name        string   `json:"name"`
+	Path        []string `json:"path"`
+	Description string   `json:"description,omitempty"`
+	Status      string   `json:"status"`
+	Completed   bool     `json:"completed"`
+	Passed      bool     `json:"passed"`
+	Quarantined bool     `json:"quarantined"`
+	Attempts    int      `json:"attempts"`
+	DurationMs  int64    `json:"durationMs"`
+	Error       string   `json:"error,omitempty"`
+	LogExcerpt  string   `json:"logExcerpt,omitempty"`
+	TraceID     string   `json:"traceID,omitempty"`
+	SpanID      string   `json:"spanID,omitempty"`
+	Selection   string   `json:"selectionReason,omitempty"`
+}
+
+func (r *CheckGroup) jsonReport() (string, error) {
+	report := jsonCheckReport{
+		Checks:  make([]jsonCheckResult, 0, len(r.Checks)),
+		Summary: r.summary(),
+	}
+	for _, check := range r.Checks {
+		report.Checks = append(report.Checks, jsonCheckResult{
+			Name:        check.Name(),
+			Path:        check.Path(),
+			Description: check.Description(),
+			Status:      check.Status(),
+			Completed:   check.Completed,
+			Passed:      check.Passed,
+			Quarantined: check.Quarantined,
+			Attempts:    check.Attempts,
+			DurationMs:  check.DurationMillis,
+			Error:       check.ErrorMessage,
+			LogExcerpt:  check.LogExcerpt,
+			TraceID:     check.TraceID,
+			SpanID:      check.SpanID,
+			Selection:   check.SelectionReason,
+		})
+	}
+	bs, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bs) + "\n", nil
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Failure    *junitFailure    `xml:"failure,omitempty"`
+	Skipped    *junitSkipped    `xml:"skipped,omitempty"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+	Content string `xml:",chardata"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+func junitSeconds(millis int64) string {
+	return fmt.Sprintf("%.3f", float64(millis)/1000)
+}
+
+func (r *CheckGroup) junitReport() (string, error) {
+	suiteName := r.Node.Module.Name()
+	sum := r.summary()
+	suite := junitTestSuite{
+		Name:     suiteName,
+		Tests:    sum.Total,
+		Failures: sum.Failed,
+		Skipped:  sum.Skipped + sum.Quarantined,
+		Time:     junitSeconds(r.durationMillis()),
+	}
+	for _, check := range r.Checks {
+		className := suiteName
+		if mod := check.OriginalModule(); mod != nil {
+			className = mod.Name()
+		}
+		tc := junitTestCase{
+			Name:      check.Name(),
+			ClassName: className,
+			Time:      junitSeconds(check.DurationMillis),
+		}
+		switch check.Status() {
+		case "skipped":
+			msg := check.ErrorMessage
+			if check.Skipped {
+				msg = check.SelectionReason
+			}
+			tc.Skipped = &junitSkipped{Message: msg}
+		case "quarantined":
+			// report quarantined failures without failing the suite
+			tc.Skipped = &junitSkipped{
+				Message: "quarantined: " + check.ErrorMessage,
+				Content: check.LogExcerpt,
+			}
+		case "failed":
+			tc.Failure = &junitFailure{
+				Message: check.ErrorMessage,
+				Content: check.LogExcerpt,
+			}
+		}
+		props := []junitProperty{
+			{Name: "attempts", Value: fmt.Sprint(check.Attempts)},
+		}
+		if check.TraceID != "" {
+			props = append(props,
+				junitProperty{Name: "traceID", Value: check.TraceID},
+				junitProperty{Name: "spanID", Value: check.SpanID},
+			)
+		}
+		tc.Properties = &junitProperties{Properties: props}
+		suite.TestCases = append(suite.TestCases, tc)
+	}
+	report := junitTestSuites{
+		Name:     "dagger check",
+		Tests:    sum.Total,
+		Failures: sum.Failed,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+	bs, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(bs) + "\n", nil
+}
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+	FullDescription  *sarifMessage `json:"fullDescription,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string         `json:"ruleId"`
+	RuleIndex  int            `json:"ruleIndex"`
+	Kind       string         `json:"kind"`
+	Level      string         `json:"level"`
+	Message    sarifMessage   `json:"message"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+func (r *CheckGroup) sarifReport() (string, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "dagger",
+			InformationURI: "https://dagger.io",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	for i, check := range r.Checks {
+		rule := sarifRule{ID: check.Name()}
+		if desc := check.Description(); desc != "" {
+			short, _, _ := strings.Cut(desc, "\n")
+			rule.ShortDescription = &sarifMessage{Text: short}
+			rule.FullDescription = &sarifMessage{Text: desc}
+		}
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rule)
+
+		res := sarifResult{
+			RuleID:    check.Name(),
+			RuleIndex: i,
+			Level:     "none",
+			Properties: map[string]any{
+				"durationMs":  check.DurationMillis,
+				"attempts":    check.Attempts,
+				"quarantined": check.Quarantined,
+			},
+		}
+		switch check.Status() {
+		case "passed":
+			res.Kind = "pass"
+			res.Message.Text = "Check passed"
+		case "failed", "quarantined":
+			res.Kind = "fail"
+			res.Level = "error"
+			if check.Quarantined {
+				res.Level = "warning"
+			}
+			res.Message.Text = check.ErrorMessage
+			if check.LogExcerpt != "" {
+				res.Message.Text += "\n\n" + check.LogExcerpt
+			}
+		default:
+			res.Kind = "notApplicable"
+			res.Message.Text = "Check did not run"
+			if check.Skipped {
+				res.Message.Text += ": " + check.SelectionReason
+			}
+		}
+		if check.TraceID != "" {
+			res.Properties["traceID"] = check.TraceID
+			res.Properties["spanID"] = check.SpanID
+		}
+		run.Results = append(run.Results, res)
+	}
+	bs, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(bs) + "\n", nil
+}
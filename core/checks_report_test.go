@@ -0,0 +1,170 @@
+package core
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testCheckGroup() *CheckGroup {
+	mod := &Module{NameField: "my-mod"}
+	root := &ModTreeNode{Module: mod, OriginalModule: mod}
+	node := func(name, desc string) *ModTreeNode {
+		return &ModTreeNode{
+			Parent:         root,
+			Name:           name,
+			Description:    desc,
+			Module:         mod,
+			OriginalModule: mod,
+			IsCheck:        true,
+		}
+	}
+	return &CheckGroup{
+		Node: root,
+		Checks: []*Check{
+			{
+				Node:           node("lint", "Lint the code"),
+				Completed:      true,
+				Passed:         true,
+				Attempts:       1,
+				DurationMillis: 1500,
+				TraceID:        "0af7651916cd43dd8448eb211c80319c",
+				SpanID:         "b7ad6b7169203331",
+			},
+			{
+				Node:           node("test", "Run the tests\n\nAll of them."),
+				Completed:      true,
+				Attempts:       1,
+				DurationMillis: 250,
+				ErrorMessage:   "process \"go test\" did not complete successfully: exit code: 1",
+				LogExcerpt:     "--- FAIL: TestFoo\nFAIL",
+			},
+			{
+				Node: node("slow", ""),
+			},
+			{
+				Node:           node("flaky", "A flaky check"),
+				Completed:      true,
+				Quarantined:    true,
+				Attempts:       3,
+				DurationMillis: 100,
+				ErrorMessage:   "timed out",
+			},
+		},
+	}
+}
+
+func TestCheckLogExcerpt(t *testing.T) {
+	lines := make([]string, 0, 30)
+	for i := range 30 {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	stderr, err := json.Marshal(strings.Join(lines, "\n") + "\n")
+	require.NoError(t, err)
+	checkErr := NewError("failed").
+		WithValue("stderr", JSON(stderr)).
+		WithValue("stdout", JSON(`"ignored"`))
+	require.Equal(t, strings.Join(lines[10:], "\n"), checkLogExcerpt(checkErr))
+
+	checkErr = NewError("failed").WithValue("stdout", JSON(`"only stdout"`))
+	require.Equal(t, "only stdout", checkLogExcerpt(checkErr))
+
+	require.Empty(t, checkLogExcerpt(NewError("no output")))
+}
+
+func TestCheckReportJSON(t *testing.T) {
+	contents, err := testCheckGroup().jsonReport()
+	require.NoError(t, err)
+
+	var report jsonCheckReport
+	require.NoError(t, json.Unmarshal([]byte(contents), &report))
+	require.Equal(t, checkReportSummary{Total: 4, Passed: 1, Failed: 1, Quarantined: 1, Skipped: 1}, report.Summary)
+	require.Len(t, report.Checks, 4)
+
+	require.Equal(t, "lint", report.Checks[0].Name)
+	require.Equal(t, "passed", report.Checks[0].Status)
+	require.Equal(t, int64(1500), report.Checks[0].DurationMs)
+	require.Equal(t, "b7ad6b7169203331", report.Checks[0].SpanID)
+
+	require.Equal(t, "failed", report.Checks[1].Status)
+	require.Contains(t, report.Checks[1].Error, "exit code: 1")
+	require.Equal(t, "--- FAIL: TestFoo\nFAIL", report.Checks[1].LogExcerpt)
+
+	require.Equal(t, "skipped", report.Checks[2].Status)
+
+	require.Equal(t, "quarantined", report.Checks[3].Status)
+	require.True(t, report.Checks[3].Quarantined)
+	require.Equal(t, 3, report.Checks[3].Attempts)
+}
+
+func TestCheckReportJUnit(t *testing.T) {
+	contents, err := testCheckGroup().junitReport()
+	require.NoError(t, err)
+	require.Contains(t, contents, xml.Header)
+
+	var report junitTestSuites
+	require.NoError(t, xml.Unmarshal([]byte(contents), &report))
+	require.Equal(t, 4, report.Tests)
+	require.Equal(t, 1, report.Failures)
+	require.Equal(t, 2, report.Skipped)
+	require.Equal(t, "1.850", report.Time)
+	require.Len(t, report.Suites, 1)
+
+	cases := report.Suites[0].TestCases
+	require.Len(t, cases, 4)
+	require.Equal(t, "lint", cases[0].Name)
+	require.Equal(t, "my-mod", cases[0].ClassName)
+	require.Equal(t, "1.500", cases[0].Time)
+	require.Nil(t, cases[0].Failure)
+	require.NotNil(t, cases[0].Properties)
+
+	require.NotNil(t, cases[1].Failure)
+	require.Contains(t, cases[1].Failure.Message, "exit code: 1")
+	require.Equal(t, "--- FAIL: TestFoo\nFAIL", cases[1].Failure.Content)
+
+	require.NotNil(t, cases[2].Skipped)
+
+	require.Nil(t, cases[3].Failure)
+	require.NotNil(t, cases[3].Skipped)
+	require.Equal(t, "quarantined: timed out", cases[3].Skipped.Message)
+}
+
+func TestCheckReportSARIF(t *testing.T) {
+	contents, err := testCheckGroup().sarifReport()
+	require.NoError(t, err)
+
+	var report sarifLog
+	require.NoError(t, json.Unmarshal([]byte(contents), &report))
+	require.Equal(t, "2.1.0", report.Version)
+	require.Len(t, report.Runs, 1)
+
+	run := report.Runs[0]
+	require.Len(t, run.Tool.Driver.Rules, 4)
+	require.Equal(t, "Run the tests", run.Tool.Driver.Rules[1].ShortDescription.Text)
+	require.Nil(t, run.Tool.Driver.Rules[2].ShortDescription)
+
+	require.Len(t, run.Results, 4)
+	require.Equal(t, "pass", run.Results[0].Kind)
+	require.Equal(t, "none", run.Results[0].Level)
+	require.Equal(t, "fail", run.Results[1].Kind)
+	require.Equal(t, "error", run.Results[1].Level)
+	require.Contains(t, run.Results[1].Message.Text, "--- FAIL: TestFoo")
+	require.Equal(t, "notApplicable", run.Results[2].Kind)
+	require.Equal(t, "fail", run.Results[3].Kind)
+	require.Equal(t, "warning", run.Results[3].Level)
+}
+
+func TestCheckReportMarkdown(t *testing.T) {
+	contents := testCheckGroup().markdownReport()
+	require.Contains(t, contents, "| check | description | success | duration |\n")
+	require.Contains(t, contents, "|lint | Lint the code | 🟢 | 1.5s |\n")
+	require.Contains(t, contents, "|slow |  |  |  |\n")
+	require.Contains(t, contents, "### test\n")
+	require.Contains(t, contents, "|flaky | A flaky check | 🟡 | 100ms |\n")
+	require.Contains(t, contents, "### flaky (quarantined)\n")
+	require.Contains(t, contents, "```\n--- FAIL: TestFoo\nFAIL\n```\n")
+}
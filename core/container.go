@@ -69,6 +69,9 @@ type Container struct {
 	// List of GPU devices that will be exposed to the container
 	EnabledGPUs []string
 
+	// cgroup resource limits enforced on commands run in the container
+	ResourceLimits buildkit.ResourceLimits
+
 	// Mount points configured for the container.
 	Mounts ContainerMounts
 
@@ -93,6 +96,13 @@ type Container struct {
 	// Ports to expose from the container.
 	Ports []Port
 
+	// Readiness check run when the container is started as a service.
+	Healthcheck *Healthcheck
+
+	// Healthcheck of the image config, as set by the HEALTHCHECK Dockerfile
+	// instruction.
+	ImageHealthcheck *Healthcheck
+
 	// Services to start before running the container.
 	Services ServiceBindings
 
@@ -624,6 +634,9 @@ func (container *Container) FromCanonicalRefUpdateConfig(
 	container.Config = mergeImageConfig(container.Config, imgSpec.Config)
 	container.ImageRef = refStr
 	container.Platform = Platform(platforms.Normalize(imgSpec.Platform))
+	if err := container.setImageHealthcheck(cfgBytes); err != nil {
+		return nil, err
+	}
 
 	return container, nil
 }
@@ -805,6 +818,9 @@ func (container *Container) Build(
 		}
 
 		container.Config = mergeImageConfig(container.Config, imgSpec.Config)
+		if err := container.setImageHealthcheck(cfgBytes); err != nil {
+			return nil, err
+		}
 	}
 
 	return container, nil
@@ -1708,6 +1724,42 @@ func (container *Container) WithGPU(ctx context.Context, gpuOpts ContainerGPUOpt
 	return container, nil
 }
 
+func (container *Container) WithCPULimit(cpus float64) (*Container, error) {
+	if cpus < 0 {
+		return nil, fmt.Errorf("CPU limit must not be negative, got %v", cpus)
+	}
+	container = container.Clone()
+	container.ResourceLimits.CPUs = cpus
+	return container, nil
+}
+
+func (container *Container) WithMemoryLimit(bytes int64) (*Container, error) {
+	if bytes < 0 {
+		return nil, fmt.Errorf("memory limit must not be negative, got %d", bytes)
+	}
+	container = container.Clone()
+	container.ResourceLimits.MemoryBytes = bytes
+	return container, nil
+}
+
+func (container *Container) WithPidsLimit(limit int64) (*Container, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("pids limit must not be negative, got %d", limit)
+	}
+	container = container.Clone()
+	container.ResourceLimits.Pids = limit
+	return container, nil
+}
+
+func (container *Container) WithIOWeight(weight int) (*Container, error) {
+	if weight != 0 && (weight < 10 || weight > 1000) {
+		return nil, fmt.Errorf("IO weight must be between 10 and 1000, got %d", weight)
+	}
+	container = container.Clone()
+	container.ResourceLimits.IOWeight = uint16(weight)
+	return container, nil
+}
+
 func (container *Container) Evaluate(ctx context.Context) (*buildkit.Result, error) {
 	if container == nil {
 		return nil, nil
@@ -1875,9 +1927,10 @@ func (container *Container) Publish(
 	platformVariants []*Container,
 	forcedCompression ImageLayerCompression,
 	mediaTypes ImageMediaTypes,
+	attestations ImageAttestations,
 ) (string, error) {
 	variants := filterEmptyContainers(append([]*Container{container}, platformVariants...))
-	inputByPlatform, err := getVariantRefs(ctx, variants)
+	inputByPlatform, err := getVariantRefs(ctx, variants, attestations)
 	if err != nil {
 		return "", err
 	}
@@ -1942,7 +1995,7 @@ func (container *Container) AsTarball(
 	}
 
 	variants := filterEmptyContainers(append([]*Container{container}, platformVariants...))
-	inputByPlatform, err := getVariantRefs(ctx, variants)
+	inputByPlatform, err := getVariantRefs(ctx, variants, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1993,6 +2046,7 @@ type ExportOpts struct {
 	MediaTypes        ImageMediaTypes
 	Tar               bool
 	LeaseID           string
+	Attestations      ImageAttestations
 }
 
 func useOCIMediaTypes(mediaTypes ImageMediaTypes) bool {
@@ -2021,7 +2075,7 @@ func filterEmptyContainers(containers []*Container) []*Container {
 	return l
 }
 
-func getVariantRefs(ctx context.Context, variants []*Container) (map[string]buildkit.ContainerExport, error) {
+func getVariantRefs(ctx context.Context, variants []*Container, attestations ImageAttestations) (map[string]buildkit.ContainerExport, error) {
 	inputByPlatform := map[string]buildkit.ContainerExport{}
 	var eg errgroup.Group
 	var mu sync.Mutex
@@ -2049,9 +2103,10 @@ func getVariantRefs(ctx context.Context, variants []*Container) (map[string]buil
 			defer mu.Unlock()
 
 			inputByPlatform[platformString] = buildkit.ContainerExport{
-				Ref:         fsRef,
-				Config:      variant.Config,
-				Annotations: variant.Annotations,
+				Ref:          fsRef,
+				Config:       variant.Config,
+				Annotations:  variant.Annotations,
+				Attestations: attestations.export(platformString),
 			}
 			return nil
 		})
@@ -2078,7 +2133,7 @@ func (container *Container) Export(ctx context.Context, opts ExportOpts) (*specs
 	}
 
 	variants := filterEmptyContainers(append([]*Container{container}, opts.PlatformVariants...))
-	inputByPlatform, err := getVariantRefs(ctx, variants)
+	inputByPlatform, err := getVariantRefs(ctx, variants, opts.Attestations)
 	if err != nil {
 		return nil, err
 	}
@@ -2216,6 +2271,9 @@ func (container *Container) FromInternal(
 		return nil, fmt.Errorf("load image config: %w", err)
 	}
 	container.Config = imgSpec.Config
+	if err := container.setImageHealthcheck(configBlob); err != nil {
+		return nil, err
+	}
 
 	return container, nil
 }
@@ -2267,6 +2325,34 @@ func (container *Container) WithoutExposedPort(port int, protocol NetworkProtoco
 	return container, nil
 }
 
+func (container *Container) WithHealthcheck(hc *Healthcheck) (*Container, error) {
+	if hc.LogPattern != "" {
+		if _, err := newLogPatternWriter(hc.LogPattern); err != nil {
+			return nil, err
+		}
+	}
+
+	container = container.Clone()
+	container.Healthcheck = hc
+	return container, nil
+}
+
+func (container *Container) WithImageHealthcheck() (*Container, error) {
+	if container.ImageHealthcheck == nil {
+		return nil, fmt.Errorf("image has no healthcheck")
+	}
+
+	container = container.Clone()
+	container.Healthcheck = container.ImageHealthcheck
+	return container, nil
+}
+
+func (container *Container) WithoutHealthcheck() *Container {
+	container = container.Clone()
+	container.Healthcheck = nil
+	return container
+}
+
 func (container *Container) WithServiceBinding(ctx context.Context, svc dagql.ObjectResult[*Service], alias string) (*Container, error) {
 	container = container.Clone()
 
@@ -2620,6 +2706,66 @@ func (proto ImageMediaTypes) ToLiteral() call.Literal {
 	return ImageMediaTypesEnum.Literal(proto)
 }
 
+type NetworkModes string
+
+var NetworkModesEnum = dagql.NewEnum[NetworkModes]()
+
+var (
+	NetworkUnrestricted = NetworkModesEnum.Register("UNRESTRICTED",
+		`Full network access`,
+	)
+	NetworkOffline = NetworkModesEnum.Register("OFFLINE",
+		`No network access, except for the loopback interface`,
+	)
+	NetworkServicesOnly = NetworkModesEnum.Register("SERVICES_ONLY",
+		`Access to bound services and DNS only`,
+	)
+	NetworkAllowList = NetworkModesEnum.Register("ALLOW_LIST",
+		`Access to bound services, DNS, and an allow-list of hostnames, IPs and CIDRs`,
+	)
+)
+
+func (mode NetworkModes) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "NetworkMode",
+		NonNull:   true,
+	}
+}
+
+func (mode NetworkModes) TypeDescription() string {
+	return "Network access granted to an execution"
+}
+
+func (mode NetworkModes) Decoder() dagql.InputDecoder {
+	return NetworkModesEnum
+}
+
+func (mode NetworkModes) ToLiteral() call.Literal {
+	return NetworkModesEnum.Literal(mode)
+}
+
+// NetworkPolicy returns the executor policy for the mode and allow-list.
+func (mode NetworkModes) NetworkPolicy(allow []string) (buildkit.NetworkPolicy, error) {
+	var policy buildkit.NetworkPolicy
+	switch mode {
+	case NetworkUnrestricted, "":
+		policy.Mode = buildkit.NetworkModeUnrestricted
+	case NetworkOffline:
+		policy.Mode = buildkit.NetworkModeOffline
+	case NetworkServicesOnly:
+		policy.Mode = buildkit.NetworkModeServicesOnly
+	case NetworkAllowList:
+		policy.Mode = buildkit.NetworkModeAllowList
+	default:
+		return policy, fmt.Errorf("unknown network mode %q", mode)
+	}
+	policy.Allow = allow
+	if err := policy.Validate(); err != nil {
+		return policy, err
+	}
+	return policy, nil
+}
+
 type ReturnTypes string
 
 var ReturnTypesEnum = dagql.NewEnum[ReturnTypes]()
@@ -0,0 +1,435 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/distribution/reference"
+	intoto "github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/common"
+	slsa1 "github.com/in-toto/in-toto-golang/in_toto/slsa_provenance/v1"
+	packageurl "github.com/package-url/packageurl-go"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/buildkit"
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	"github.com/dagger/dagger/internal/buildkit/util/purl"
+	"github.com/dagger/dagger/util/sbom"
+)
+
+const (
+	// provenanceBuilderID identifies the Dagger engine as the builder of
+	// SLSA provenance.
+	provenanceBuilderID = "https://dagger.io/engine"
+	// provenanceBuildType identifies provenance derived from the chain of
+	// calls that produced a container.
+	provenanceBuildType = "https://dagger.io/provenance/call/v1"
+)
+
+// ContainerAttestation is an in-toto attestation of a container image.
+type ContainerAttestation struct {
+	PredicateType string `field:"true" doc:"The in-toto predicate type of the attestation."`
+	Predicate     JSON   `field:"true" doc:"The predicate of the attestation, as a JSON document."`
+}
+
+func (*ContainerAttestation) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "ContainerAttestation",
+		NonNull:   true,
+	}
+}
+
+func (*ContainerAttestation) TypeDescription() string {
+	return "An in-toto attestation of a container image, such as an SBOM or SLSA provenance."
+}
+
+type SBOMFormat string
+
+var SBOMFormats = dagql.NewEnum[SBOMFormat]()
+
+var (
+	SBOMFormatSPDX = SBOMFormats.Register("SPDX",
+		`SPDX 2.3 JSON document`,
+	)
+	SBOMFormatCycloneDX = SBOMFormats.Register("CYCLONEDX",
+		`CycloneDX 1.5 JSON document`,
+	)
+)
+
+func (format SBOMFormat) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "SBOMFormat",
+		NonNull:   true,
+	}
+}
+
+func (format SBOMFormat) TypeDescription() string {
+	return "Format of a software bill of materials."
+}
+
+func (format SBOMFormat) Decoder() dagql.InputDecoder {
+	return SBOMFormats
+}
+
+func (format SBOMFormat) ToLiteral() call.Literal {
+	return SBOMFormats.Literal(format)
+}
+
+// ImageAttestationOpts selects the attestations of a container image.
+type ImageAttestationOpts struct {
+	// The name of the image, used as the name of the SBOM document
+	Name string
+	// The format of the SBOM, if any
+	SBOM SBOMFormat
+	// Whether to include SLSA provenance
+	Provenance bool
+}
+
+// ImageAttestations are the attestations of the platform variants of an
+// image, by platform.
+type ImageAttestations map[string][]*ContainerAttestation
+
+func (atts ImageAttestations) export(platform string) []buildkit.ImageAttestation {
+	var out []buildkit.ImageAttestation
+	for _, att := range atts[platform] {
+		out = append(out, buildkit.ImageAttestation{
+			PredicateType: att.PredicateType,
+			Predicate:     []byte(att.Predicate),
+		})
+	}
+	return out
+}
+
+// NewImageAttestations generates the attestations of each platform variant
+// of an image.
+func NewImageAttestations(
+	ctx context.Context,
+	variants []dagql.ObjectResult[*Container],
+	opts ImageAttestationOpts,
+) (ImageAttestations, error) {
+	if opts.SBOM == "" && !opts.Provenance {
+		return nil, nil
+	}
+	atts := ImageAttestations{}
+	for _, variant := range variants {
+		if variant.Self().FS == nil || variant.Self().FS.Self() == nil {
+			continue
+		}
+		platformAtts, err := variant.Self().Attestations(ctx, variant.ID(), opts)
+		if err != nil {
+			return nil, err
+		}
+		atts[variant.Self().Platform.Format()] = platformAtts
+	}
+	return atts, nil
+}
+
+// Attestations generates the attestations of the container, whose ID is
+// id.
+func (container *Container) Attestations(
+	ctx context.Context,
+	id *call.ID,
+	opts ImageAttestationOpts,
+) ([]*ContainerAttestation, error) {
+	var atts []*ContainerAttestation
+	if opts.SBOM != "" {
+		name := opts.Name
+		if name == "" {
+			name = id.Digest().String()
+		}
+		att, err := container.sbomAttestation(ctx, name, opts.SBOM)
+		if err != nil {
+			return nil, fmt.Errorf("generate SBOM: %w", err)
+		}
+		atts = append(atts, att)
+	}
+	if opts.Provenance {
+		att, err := provenanceAttestation(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("generate provenance: %w", err)
+		}
+		atts = append(atts, att)
+	}
+	return atts, nil
+}
+
+func (container *Container) sbomAttestation(ctx context.Context, name string, format SBOMFormat) (*ContainerAttestation, error) {
+	var ref bkcache.ImmutableRef
+	if container.FS != nil && container.FS.Self() != nil {
+		var err error
+		ref, err = getRefOrEvaluate(ctx, container.FS.Self())
+		if err != nil {
+			return nil, err
+		}
+	}
+	bkSessionGroup, ok := buildkit.CurrentBuildkitSessionGroup(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no buildkit session group found")
+	}
+
+	var pkgs []sbom.Package
+	err := MountRef(ctx, ref, bkSessionGroup, func(root string, _ *mount.Mount) (err error) {
+		pkgs, err = sbom.Scan(root)
+		return err
+	}, mountRefAsReadOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	created := time.Now().UTC()
+	switch format {
+	case SBOMFormatSPDX:
+		doc, err := sbom.SPDX(name, pkgs, created)
+		if err != nil {
+			return nil, err
+		}
+		return &ContainerAttestation{
+			PredicateType: intoto.PredicateSPDX,
+			Predicate:     JSON(doc),
+		}, nil
+	case SBOMFormatCycloneDX:
+		doc, err := sbom.CycloneDX(name, pkgs, created)
+		if err != nil {
+			return nil, err
+		}
+		return &ContainerAttestation{
+			PredicateType: intoto.PredicateCycloneDX,
+			Predicate:     JSON(doc),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown SBOM format %q", format)
+	}
+}
+
+// provenanceStep is a call of the chain that produced a container.
+type provenanceStep struct {
+	Digest   string         `json:"digest"`
+	Receiver string         `json:"receiver,omitempty"`
+	Field    string         `json:"field"`
+	Type     string         `json:"type"`
+	Args     map[string]any `json:"args,omitempty"`
+}
+
+// provenanceAttestation generates SLSA provenance from the call ID of a
+// container: every call it depends on is a step of the build, and the images
+// and git commits it was built from are its resolved dependencies.
+//
+// Sensitive arguments, such as secret plaintexts, are omitted.
+func provenanceAttestation(ctx context.Context, id *call.ID) (*ContainerAttestation, error) {
+	p := &provenanceBuilder{
+		visited: map[string]bool{},
+		deps:    map[string]bool{},
+	}
+	if err := p.visit(ctx, id); err != nil {
+		return nil, err
+	}
+
+	predicate := slsa1.ProvenancePredicate{
+		BuildDefinition: slsa1.ProvenanceBuildDefinition{
+			BuildType: provenanceBuildType,
+			ExternalParameters: map[string]any{
+				"call":  id.Digest().String(),
+				"steps": p.steps,
+			},
+			ResolvedDependencies: p.resolved,
+		},
+		RunDetails: slsa1.ProvenanceRunDetails{
+			Builder: slsa1.Builder{
+				ID: provenanceBuilderID,
+				Version: map[string]string{
+					"dagger": engine.Version,
+				},
+			},
+			BuildMetadata: slsa1.BuildMetadata{
+				InvocationID: id.Digest().String(),
+			},
+		},
+	}
+	data, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+	return &ContainerAttestation{
+		PredicateType: slsa1.PredicateSLSAProvenance,
+		Predicate:     JSON(data),
+	}, nil
+}
+
+type provenanceBuilder struct {
+	steps    []provenanceStep
+	resolved []slsa1.ResourceDescriptor
+	visited  map[string]bool
+	deps     map[string]bool
+}
+
+// visit records the steps of the call and its inputs, inputs first.
+func (p *provenanceBuilder) visit(ctx context.Context, id *call.ID) error {
+	dgst := id.Digest().String()
+	if p.visited[dgst] {
+		return nil
+	}
+	p.visited[dgst] = true
+
+	step := provenanceStep{
+		Digest: dgst,
+		Field:  id.Field(),
+		Type:   id.Type().NamedType(),
+	}
+	if receiver := id.Receiver(); receiver != nil {
+		if err := p.visit(ctx, receiver); err != nil {
+			return err
+		}
+		step.Receiver = receiver.Digest().String()
+	}
+	for _, arg := range id.Args() {
+		if arg.IsSensitive() {
+			continue
+		}
+		value, err := p.literal(ctx, arg.Value())
+		if err != nil {
+			return err
+		}
+		if step.Args == nil {
+			step.Args = map[string]any{}
+		}
+		step.Args[arg.Name()] = value
+	}
+	p.steps = append(p.steps, step)
+	return p.resolve(ctx, id)
+}
+
+// literal returns the JSON value of an argument, visiting the calls it
+// refers to.
+func (p *provenanceBuilder) literal(ctx context.Context, lit call.Literal) (any, error) {
+	switch lit := lit.(type) {
+	case *call.LiteralID:
+		if err := p.visit(ctx, lit.Value()); err != nil {
+			return nil, err
+		}
+		return map[string]string{"id": lit.Value().Digest().String()}, nil
+	case *call.LiteralList:
+		values := []any{}
+		for _, value := range lit.Values() {
+			v, err := p.literal(ctx, value)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, nil
+	case *call.LiteralObject:
+		values := map[string]any{}
+		for _, arg := range lit.Args() {
+			if arg.IsSensitive() {
+				continue
+			}
+			v, err := p.literal(ctx, arg.Value())
+			if err != nil {
+				return nil, err
+			}
+			values[arg.Name()] = v
+		}
+		return values, nil
+	default:
+		value := lit.ToInput()
+		if s, ok := value.(string); ok {
+			// URLs like the one of git(url:) can embed credentials
+			return redactURL(s), nil
+		}
+		return value, nil
+	}
+}
+
+// redactURL removes the user info, like credentials, from a URL. Other
+// strings are returned as is.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil || u.Host == "" {
+		return s
+	}
+	u.User = nil
+	return u.String()
+}
+
+// resolve records the external artifacts a call depends on.
+func (p *provenanceBuilder) resolve(ctx context.Context, id *call.ID) error {
+	switch id.Type().NamedType() {
+	case "Container":
+		if id.Field() != "from" {
+			return nil
+		}
+		arg := id.Arg("address")
+		if arg == nil {
+			return nil
+		}
+		address, ok := arg.Value().ToInput().(string)
+		if !ok {
+			return nil
+		}
+		named, err := reference.ParseNormalizedNamed(address)
+		if err != nil {
+			return nil //nolint:nilerr // not an image address, nothing to resolve
+		}
+		canonical, ok := named.(reference.Canonical)
+		if !ok {
+			// only images resolved to a digest are recorded
+			return nil
+		}
+		// the digest is recorded in the digest set, not the URI
+		var image reference.Named = reference.TrimNamed(canonical)
+		if tagged, ok := canonical.(reference.Tagged); ok {
+			image, err = reference.WithTag(image, tagged.Tag())
+			if err != nil {
+				return err
+			}
+		}
+		uri, err := purl.RefToPURL(packageurl.TypeDocker, image.String(), nil)
+		if err != nil {
+			return err
+		}
+		p.addDependency(uri, common.DigestSet{
+			canonical.Digest().Algorithm().String(): canonical.Digest().Encoded(),
+		})
+	case "GitRef":
+		srv, err := CurrentDagqlServer(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := srv.Load(ctx, id)
+		if err != nil {
+			return err
+		}
+		ref, ok := res.Unwrap().(*GitRef)
+		if !ok || ref.Ref == nil || ref.Ref.SHA == "" {
+			return nil
+		}
+		repo := ref.Repo.Self()
+		if repo == nil || !repo.URL.Valid {
+			return nil
+		}
+		uri := "git+" + redactURL(repo.URL.Value.String())
+		if ref.Ref.Name != "" && ref.Ref.Name != ref.Ref.SHA {
+			uri += "@" + ref.Ref.Name
+		}
+		p.addDependency(uri, common.DigestSet{"gitCommit": ref.Ref.SHA})
+	}
+	return nil
+}
+
+func (p *provenanceBuilder) addDependency(uri string, digest common.DigestSet) {
+	if p.deps[uri] {
+		return
+	}
+	p.deps[uri] = true
+	p.resolved = append(p.resolved, slsa1.ResourceDescriptor{
+		URI:    uri,
+		Digest: digest,
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 
@@ -73,6 +74,33 @@ type ContainerExecOpts struct {
 	// Skip the init process injected into containers by default so that the
 	// user's process is PID 1
 	NoInit bool `default:"false"`
+
+	// Maximum duration the command may run before it is terminated (e.g. "5m").
+	// A timed out command always fails, regardless of Expect.
+	Timeout string `default:""`
+
+	// Network access granted to the command
+	NetworkMode NetworkModes `default:"UNRESTRICTED"`
+
+	// Hostnames, IPs and CIDRs the command may reach with the ALLOW_LIST
+	// network mode
+	NetworkAllow []string `default:"[]"`
+}
+
+// ParseExecTimeout parses a user-provided exec timeout duration. An empty
+// string means no timeout.
+func ParseExecTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timeout duration %q: %w", timeout, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("timeout duration must be positive, got %q", timeout)
+	}
+	return d, nil
 }
 
 func (container *Container) execMeta(ctx context.Context, opts ContainerExecOpts, parent *buildkit.ExecutionMetadata) (*buildkit.ExecutionMetadata, error) {
@@ -108,9 +136,29 @@ func (container *Container) execMeta(ctx context.Context, opts ContainerExecOpts
 	execMD.RedirectStderrPath = opts.RedirectStderr
 	execMD.SystemEnvNames = container.SystemEnvNames
 	execMD.EnabledGPUs = container.EnabledGPUs
+	execMD.ResourceLimits = container.ResourceLimits
 	if opts.NoInit {
 		execMD.NoInit = true
 	}
+	if opts.Timeout != "" {
+		execMD.Timeout, err = ParseExecTimeout(opts.Timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	execMD.NetworkPolicy, err = opts.NetworkMode.NetworkPolicy(opts.NetworkAllow)
+	if err != nil {
+		return nil, err
+	}
+	if !execMD.NetworkPolicy.IsZero() {
+		if opts.InsecureRootCapabilities {
+			return nil, fmt.Errorf("network mode %s cannot be enforced with insecure root capabilities", opts.NetworkMode)
+		}
+		if execMD.NetworkPolicy.Mode == buildkit.NetworkModeOffline && len(container.Services) > 0 {
+			return nil, fmt.Errorf("network mode %s cannot be used with service bindings", opts.NetworkMode)
+		}
+	}
 
 	var callerModID *call.ID
 	if execMD.EncodedModuleID != "" {
@@ -146,6 +194,9 @@ func (container *Container) execMeta(ctx context.Context, opts ContainerExecOpts
 		for _, alias := range bnd.Aliases {
 			execMD.HostAliases[bnd.Hostname] = append(execMD.HostAliases[bnd.Hostname], alias)
 		}
+		if !execMD.NetworkPolicy.IsZero() {
+			execMD.NetworkPolicy.Services = append(execMD.NetworkPolicy.Services, bnd.Hostname)
+		}
 	}
 
 	for i, secret := range container.Secrets {
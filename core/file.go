@@ -31,6 +31,7 @@ import (
 	"dagger.io/dagger/telemetry"
 	"github.com/dagger/dagger/dagql"
 	"github.com/dagger/dagger/engine/buildkit"
+	"github.com/dagger/dagger/util/gitutil"
 )
 
 // File is a content-addressed file.
@@ -272,6 +273,152 @@ func (file *File) Search(ctx context.Context, opts SearchOpts, verbose bool) ([]
 	return results, nil
 }
 
+// Blame returns the lines of the file with the commit that last changed
+// them, from the git repository the file is checked out in.
+func (file *File) Blame(ctx context.Context) ([]*GitBlameLine, error) {
+	ref, err := getRefOrEvaluate(ctx, file)
+	if err != nil {
+		return nil, err
+	}
+	if ref == nil {
+		return nil, fmt.Errorf("file %s is not in a git repository", file.File)
+	}
+
+	opt, ok := buildkit.CurrentOpOpts(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no buildkit opts in context")
+	}
+
+	ctx = trace.ContextWithSpanContext(ctx, opt.CauseCtx)
+
+	bkSessionGroup, ok := buildkit.CurrentBuildkitSessionGroup(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no buildkit session group in context")
+	}
+
+	var lines []*GitBlameLine
+	err = MountRef(ctx, ref, bkSessionGroup, func(root string, _ *mount.Mount) error {
+		var err error
+		lines, err = blameInMount(ctx, root, file.File)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// blameInMount blames the file at filePath in the mount at root, in the git
+// repository it is checked out in.
+func blameInMount(ctx context.Context, root, filePath string) ([]*GitBlameLine, error) {
+	// look for the repository in the parents of the file, without
+	// leaving the mount like git would
+	repoPath := filepath.Dir(filepath.Clean("/" + filePath))
+	for {
+		dir, err := containerdfs.RootPath(root, repoPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Lstat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		if repoPath == "/" {
+			return nil, fmt.Errorf("file %s is not in a git repository", filePath)
+		}
+		repoPath = filepath.Dir(repoPath)
+	}
+	repoDir, err := containerdfs.RootPath(root, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	relPath, err := filepath.Rel(repoPath, filepath.Clean("/"+filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	gitDir, err := blameGitDir(root, repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The repository comes from the caller, so don't let its config run
+	// commands on the engine: git only runs the fsmonitor hook, textconv
+	// and clean filters to blame a file, or fetches to complete a partial
+	// clone, so disable or reject them. The file of revisions to ignore
+	// could be any file of the engine, so it's ignored too.
+	git := gitutil.NewGitCLI(
+		gitutil.WithDir(repoDir),
+		gitutil.WithWorkTree(repoDir),
+		gitutil.WithGitDir(gitDir),
+		gitutil.WithNoLazyFetch(),
+		gitutil.WithConfig(map[string]string{
+			"core.fsmonitor": "false",
+			"protocol.allow": "never",
+		}),
+	)
+	out, err := git.New(gitutil.WithIgnoreError()).Run(ctx,
+		"config", "--get-regexp", `^(extensions\.partialclone|remote\..*\.promisor)$`)
+	if err != nil {
+		return nil, fmt.Errorf("git config failed: %w", err)
+	}
+	if len(bytes.TrimSpace(out)) > 0 {
+		return nil, fmt.Errorf("file %s is in a partial clone, which blame doesn't support", filePath)
+	}
+	out, err = git.Run(ctx, "check-attr", "filter", "--", relPath)
+	if err != nil {
+		return nil, fmt.Errorf("git check-attr failed: %w", err)
+	}
+	if _, filter, _ := strings.Cut(strings.TrimSpace(string(out)), ": filter: "); filter != "unspecified" && filter != "unset" {
+		return nil, fmt.Errorf("file %s has a %q filter, which blame doesn't support", filePath, filter)
+	}
+	out, err = git.Run(ctx, "blame", "--no-textconv", "--no-ignore-revs-file", "--line-porcelain", "--", relPath)
+	if err != nil {
+		return nil, fmt.Errorf("git blame failed: %w", err)
+	}
+	return parseGitBlame(string(out))
+}
+
+// blameGitDir returns the path of the git directory of the repository at
+// repoPath in the mount. Git resolves the paths set in a repository on the
+// engine's filesystem, so the ones that could lead outside of the mount are
+// rejected.
+func blameGitDir(root, repoPath string) (string, error) {
+	dotGit, err := containerdfs.RootPath(root, filepath.Join(repoPath, ".git"))
+	if err != nil {
+		return "", err
+	}
+	gitDir := dotGit
+	fi, err := os.Stat(dotGit)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		// a "gitdir: <path>" file, like in submodules and worktrees
+		dt, err := os.ReadFile(dotGit)
+		if err != nil {
+			return "", err
+		}
+		target, ok := strings.CutPrefix(strings.TrimSpace(string(dt)), "gitdir: ")
+		if !ok {
+			return "", fmt.Errorf("invalid .git file in %s", repoPath)
+		}
+		rel := filepath.Join(strings.TrimPrefix(repoPath, "/"), target)
+		if filepath.IsAbs(target) || !filepath.IsLocal(rel) {
+			return "", fmt.Errorf("git directory %q of %s is outside of the directory", target, repoPath)
+		}
+		gitDir, err = containerdfs.RootPath(root, rel)
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, name := range []string{"commondir", "objects/info/alternates"} {
+		if _, err := os.Lstat(filepath.Join(gitDir, name)); err == nil {
+			return "", fmt.Errorf("git directory of %s has a %s file, which blame doesn't support", repoPath, name)
+		}
+	}
+	return gitDir, nil
+}
+
 func (file *File) WithReplaced(ctx context.Context, searchStr, replacementStr string, firstFrom *int, all bool) (*File, error) {
 	opt, ok := buildkit.CurrentOpOpts(ctx)
 	if !ok {
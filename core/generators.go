@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dagger/dagger/util/parallel"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -12,6 +13,9 @@ type Generator struct {
 	Node      *ModTreeNode `json:"node"`
 	Completed bool         `field:"true" doc:"Whether the generator complete"`
 	Changes   *Changeset   `field:"true" doc:"The generated changeset"`
+
+	Skipped         bool   `field:"true" doc:"Whether the generator is skipped because none of its inputs are affected by the changes passed to affectedBy"`
+	SelectionReason string `field:"true" doc:"Why the generator was selected or skipped by affectedBy"`
 }
 
 func (*Generator) Type() *ast.Type {
@@ -45,6 +49,9 @@ func (g *Generator) Clone() *Generator {
 
 func (g *Generator) Run(ctx context.Context) (*Generator, error) {
 	g = g.Clone()
+	if g.Skipped {
+		return g, nil
+	}
 
 	cs, _ := g.Node.RunGenerator(ctx, nil, nil) // ignore error as already sent to the trace if needed
 	g.Completed = true
@@ -108,6 +115,9 @@ func (gg *GeneratorGroup) Run(ctx context.Context) (*GeneratorGroup, error) {
 		// Reset output fields, in case we're re-running
 		generator.Completed = false
 		generator.Changes = nil
+		if generator.Skipped {
+			continue
+		}
 		jobs = jobs.WithJob(generator.Name(), func(ctx context.Context) error {
 			cs, err := generator.Node.RunGenerator(ctx, nil, nil)
 			generator.Completed = true
@@ -121,6 +131,31 @@ func (gg *GeneratorGroup) Run(ctx context.Context) (*GeneratorGroup, error) {
 	return gg, nil
 }
 
+// AffectedBy marks the generators whose inputs aren't affected by the changes
+// as skipped.
+func (gg *GeneratorGroup) AffectedBy(ctx context.Context, changes *Changeset) (*GeneratorGroup, error) {
+	changed, err := changes.changedPaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("compute changed paths: %w", err)
+	}
+	return gg.AffectedByPaths(changed)
+}
+
+// AffectedByPaths marks the generators whose inputs aren't affected by the
+// changed paths, relative to the context directory, as skipped.
+func (gg *GeneratorGroup) AffectedByPaths(changed []string) (*GeneratorGroup, error) {
+	gg = gg.Clone()
+	for _, generator := range gg.Generators {
+		affected, reason, err := generator.Node.AffectedBy(changed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", generator.Name(), err)
+		}
+		generator.Skipped = !affected
+		generator.SelectionReason = reason
+	}
+	return gg, nil
+}
+
 func (gg *GeneratorGroup) IsEmpty(ctx context.Context) (bool, error) {
 	for _, g := range gg.Generators {
 		if g.Changes != nil {
@@ -135,15 +170,18 @@ func (gg *GeneratorGroup) IsEmpty(ctx context.Context) (bool, error) {
 }
 
 func (gg *GeneratorGroup) Changes(ctx context.Context, conflictStrategy WithChangesetsMergeConflict) (*Changeset, error) {
-	switch len(gg.Generators) {
+	cs := make([]*Changeset, 0, len(gg.Generators))
+	for _, g := range gg.Generators {
+		// skipped generators have no changes
+		if g.Changes != nil {
+			cs = append(cs, g.Changes)
+		}
+	}
+	switch len(cs) {
 	case 0:
 		return NewEmptyChangeset(ctx)
 	case 1:
-		return gg.Generators[0].Changes, nil
-	}
-	cs := make([]*Changeset, 0, len(gg.Generators))
-	for _, g := range gg.Generators {
-		cs = append(cs, g.Changes)
+		return cs[0], nil
 	}
 	return cs[0].WithChangesets(ctx, cs[1:], conflictStrategy)
 }
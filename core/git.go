@@ -45,14 +45,32 @@ type GitRef struct {
 	Repo    dagql.ObjectResult[*GitRepository]
 	Backend GitRefBackend
 	Ref     *gitutil.Ref
+
+	// Signer is the identity of the signer of the ref, once verified.
+	Signer string
 }
 
 type GitRefBackend interface {
-	Tree(ctx context.Context, srv *dagql.Server, discard bool, depth int) (checkout *Directory, err error)
+	Tree(ctx context.Context, srv *dagql.Server, opts GitTreeOptions) (checkout *Directory, err error)
 
 	mount(ctx context.Context, depth int, fn func(*gitutil.GitCLI) error) error
 }
 
+// GitTreeOptions configures the checkout of a git ref.
+type GitTreeOptions struct {
+	DiscardGitDir bool
+	Depth         int
+
+	// Sparse are the directories to check out, in git's cone mode: the files
+	// at the root of the repository and next to the parents of these
+	// directories are checked out too. With wildcards or negations, they're
+	// non-cone patterns instead, matched like in .gitignore files. Everything
+	// is checked out if empty.
+	Sparse []string
+	// LFS downloads the Git LFS objects of the checked out files.
+	LFS bool
+}
+
 func NewGitRepository(ctx context.Context, backend GitRepositoryBackend) (*GitRepository, error) {
 	repo := &GitRepository{
 		Backend: backend,
@@ -93,21 +111,23 @@ func (*GitRef) TypeDescription() string {
 	return "A git ref (tag, branch, or commit)."
 }
 
-func (ref *GitRef) Tree(ctx context.Context, srv *dagql.Server, discardGitDir bool, depth int) (*Directory, error) {
-	return ref.Backend.Tree(ctx, srv, ref.Repo.Self().DiscardGitDir || discardGitDir, depth)
+func (ref *GitRef) Tree(ctx context.Context, srv *dagql.Server, opts GitTreeOptions) (*Directory, error) {
+	opts.DiscardGitDir = ref.Repo.Self().DiscardGitDir || opts.DiscardGitDir
+	return ref.Backend.Tree(ctx, srv, opts)
 }
 
 // doGitCheckout performs a git checkout using the given git helper.
 //
-// The provided git dir should *always* be empty.
+// The provided git dir should *always* be empty. If filter is set, cloneURL
+// is a partial clone, missing the objects that aren't checked out.
 func doGitCheckout(
 	ctx context.Context,
 	checkoutGit *gitutil.GitCLI,
 	remoteURL string,
 	cloneURL string,
 	ref *gitutil.Ref,
-	depth int,
-	discardGitDir bool,
+	filter string,
+	opts GitTreeOptions,
 ) error {
 	checkoutDirGit, err := checkoutGit.GitDir(ctx)
 	if err != nil {
@@ -119,16 +139,37 @@ func doGitCheckout(
 		return err
 	}
 
+	if len(opts.Sparse) > 0 {
+		mode := "--cone"
+		if !gitSparseCone(opts.Sparse) {
+			mode = "--no-cone"
+		}
+		args := append([]string{"sparse-checkout", "set", mode, "--"}, opts.Sparse...)
+		if _, err := checkoutGit.Run(ctx, args...); err != nil {
+			return fmt.Errorf("failed to set sparse checkout: %w", err)
+		}
+	}
+
 	tmpref := "refs/dagger.tmp/" + identity.NewID()
 
 	// TODO: maybe this should use --no-tags by default, but that's a breaking change :(
 	// also, we currently don't do any special work to ensure that the fetched
 	// tags are consistent with the GitRepository.Remote (oops)
 	args := []string{"fetch", "-u"}
-	if depth > 0 {
-		args = append(args, fmt.Sprintf("--depth=%d", depth))
+	if opts.Depth > 0 {
+		args = append(args, fmt.Sprintf("--depth=%d", opts.Depth))
+	}
+	if filter != "" {
+		// partial clones need a named remote to lazily fetch the missing
+		// objects of the checkout from
+		_, err = checkoutGit.Run(ctx, "remote", "add", "origin", cloneURL)
+		if err != nil {
+			return fmt.Errorf("failed to add clone remote: %w", err)
+		}
+		args = append(args, "--filter="+filter, "origin")
+	} else {
+		args = append(args, cloneURL)
 	}
-	args = append(args, cloneURL)
 	args = append(args, ref.SHA+":"+tmpref)
 	_, err = checkoutGit.Run(ctx, args...)
 	if err != nil {
@@ -154,7 +195,11 @@ func doGitCheckout(
 		}
 	}
 	if remoteURL != "" {
-		_, err = checkoutGit.Run(ctx, "remote", "add", "origin", remoteURL)
+		remoteCmd := "add"
+		if filter != "" {
+			remoteCmd = "set-url"
+		}
+		_, err = checkoutGit.Run(ctx, "remote", remoteCmd, "origin", remoteURL)
 		if err != nil {
 			return fmt.Errorf("failed to set remote origin to %s: %w", remoteURL, err)
 		}
@@ -175,19 +220,38 @@ func doGitCheckout(
 	// TODO: this feels completely out-of-sync from how we do the rest
 	// of the clone - caching will not be as great here
 	subArgs := []string{"submodule", "update", "--init", "--recursive", "--depth=1"}
-	if _, err := checkoutGit.Run(ctx, subArgs...); err != nil {
-		if errors.Is(err, gitutil.ErrShallowNotSupported) {
-			subArgs = slices.DeleteFunc(subArgs, func(s string) bool {
-				return strings.HasPrefix(s, "--depth")
-			})
-			_, err = checkoutGit.Run(ctx, subArgs...)
-		}
+	updateSubmodules := true
+	if len(opts.Sparse) > 0 {
+		// submodules outside of the sparse checkout aren't checked out
+		paths, err := sparseSubmodules(ctx, checkoutGit, opts.Sparse)
 		if err != nil {
-			return fmt.Errorf("failed to update submodules: %w", err)
+			return err
+		}
+		subArgs = append(subArgs, "--")
+		subArgs = append(subArgs, paths...)
+		updateSubmodules = len(paths) > 0
+	}
+	if updateSubmodules {
+		if _, err := checkoutGit.Run(ctx, subArgs...); err != nil {
+			if errors.Is(err, gitutil.ErrShallowNotSupported) {
+				subArgs = slices.DeleteFunc(subArgs, func(s string) bool {
+					return strings.HasPrefix(s, "--depth")
+				})
+				_, err = checkoutGit.Run(ctx, subArgs...)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to update submodules: %w", err)
+			}
+		}
+	}
+
+	if opts.LFS {
+		if err := pullGitLFS(ctx, checkoutGit, remoteURL, cloneURL, ref, opts.Sparse); err != nil {
+			return err
 		}
 	}
 
-	if discardGitDir {
+	if opts.DiscardGitDir {
 		if err := os.RemoveAll(checkoutDirGit); err != nil && !errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("failed to remove .git: %w", err)
 		}
@@ -280,7 +344,8 @@ func refJoin(ctx context.Context, refs []*GitRef) (_ *gitutil.GitCLI, _ []string
 				if _, err := git.Run(egCtx, "remote", "add", remoteName, remoteURL); err != nil {
 					return fmt.Errorf("failed to add remote %s: %w", remoteName, err)
 				}
-				if _, err := git.Run(egCtx, "fetch", "--no-tags", remoteName, ref.Ref.SHA); err != nil {
+				// only commits are needed, which also works for partial clones
+				if _, err := git.Run(egCtx, "fetch", "--no-tags", "--filter=blob:none", remoteName, ref.Ref.SHA); err != nil {
 					return fmt.Errorf("failed to fetch ref %d: %w", i+1, err)
 				}
 				return nil
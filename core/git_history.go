@@ -0,0 +1,200 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+type GitCommit struct {
+	Commit      string   `field:"true" doc:"The commit id."`
+	Parents     []string `field:"true" doc:"The ids of the parents of the commit."`
+	AuthorName  string   `field:"true" doc:"The name of the author of the commit."`
+	AuthorEmail string   `field:"true" doc:"The email of the author of the commit."`
+	Date        string   `field:"true" doc:"The date the commit was authored, in RFC 3339 format."`
+	Message     string   `field:"true" doc:"The full message of the commit."`
+	Files       []string `field:"true" doc:"The paths of the files changed by the commit, compared to its first parent."`
+}
+
+func (*GitCommit) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "GitCommit",
+		NonNull:   true,
+	}
+}
+
+func (*GitCommit) TypeDescription() string {
+	return "A commit in the history of a git ref."
+}
+
+type GitBlameLine struct {
+	Line        int    `field:"true" doc:"The line number, starting at 1."`
+	Content     string `field:"true" doc:"The content of the line."`
+	Commit      string `field:"true" doc:"The id of the commit that last changed the line, or all zeroes if it isn't committed yet."`
+	AuthorName  string `field:"true" doc:"The name of the author of the commit."`
+	AuthorEmail string `field:"true" doc:"The email of the author of the commit."`
+	Date        string `field:"true" doc:"The date the commit was authored, in RFC 3339 format."`
+}
+
+func (*GitBlameLine) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "GitBlameLine",
+		NonNull:   true,
+	}
+}
+
+func (*GitBlameLine) TypeDescription() string {
+	return "A line of a file, with the commit that last changed it."
+}
+
+// GitLogOptions filters the history returned by GitRef.Log.
+type GitLogOptions struct {
+	// Paths only keeps the commits changing these paths.
+	Paths []string
+	// Limit is the maximum number of commits, or 0 for no limit.
+	Limit int
+	// Since only keeps the commits more recent than a date, in any format
+	// git understands (e.g., "2024-01-31" or "2 weeks ago").
+	Since string
+}
+
+// gitLogFormat separates commits with RS and their fields with NUL, which
+// can't be part of any field. With -z, the changed files follow, separated
+// by NUL too.
+const gitLogFormat = "%x1e%H%x00%P%x00%an%x00%ae%x00%aI%x00%B%x00"
+
+// Log returns the history of the ref, most recent commits first.
+func (ref *GitRef) Log(ctx context.Context, opts GitLogOptions) ([]*GitCommit, error) {
+	args := []string{
+		"log", "-z", "--name-only", "--no-renames", "--diff-merges=first-parent",
+		"--format=" + gitLogFormat,
+	}
+	if opts.Limit > 0 {
+		args = append(args, fmt.Sprintf("--max-count=%d", opts.Limit))
+	}
+	if opts.Since != "" {
+		args = append(args, "--since="+opts.Since)
+	}
+	if len(opts.Paths) > 0 {
+		// merges are diffed with their first parent, so they'd all be kept
+		// without simplification
+		args = append(args, "--simplify-merges")
+	}
+	args = append(args, ref.Ref.SHA, "--")
+	args = append(args, opts.Paths...)
+
+	var commits []*GitCommit
+	err := ref.Backend.mount(ctx, 0, func(git *gitutil.GitCLI) error {
+		out, err := git.Run(ctx, args...)
+		if err != nil {
+			return fmt.Errorf("git log failed: %w", err)
+		}
+		commits, err = parseGitLog(string(out))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+func parseGitLog(output string) ([]*GitCommit, error) {
+	commits := []*GitCommit{}
+	for record := range strings.SplitSeq(output, "\x1e") {
+		if record == "" {
+			continue
+		}
+		fields := strings.Split(record, "\x00")
+		if len(fields) < 6 {
+			return nil, fmt.Errorf("unexpected git log output %q", record)
+		}
+		commit := &GitCommit{
+			Commit:      fields[0],
+			Parents:     strings.Fields(fields[1]),
+			AuthorName:  fields[2],
+			AuthorEmail: fields[3],
+			Date:        fields[4],
+			Message:     strings.TrimRight(fields[5], "\n"),
+			Files:       []string{},
+		}
+		for _, file := range fields[6:] {
+			// the files are separated from the format by a newline
+			file = strings.TrimPrefix(file, "\n")
+			if file != "" {
+				commit.Files = append(commit.Files, file)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// parseGitBlame parses the output of git blame --line-porcelain.
+func parseGitBlame(output string) ([]*GitBlameLine, error) {
+	lines := []*GitBlameLine{}
+	var line *GitBlameLine
+	var authorTime int64
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		if line == nil {
+			// <commit> <original line> <final line> [<lines in group>]
+			fields := strings.Fields(text)
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("unexpected git blame output %q", text)
+			}
+			n, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("unexpected git blame output %q: %w", text, err)
+			}
+			line = &GitBlameLine{Commit: fields[0], Line: n}
+			continue
+		}
+		if content, ok := strings.CutPrefix(text, "\t"); ok {
+			line.Content = content
+			lines = append(lines, line)
+			line = nil
+			continue
+		}
+		key, value, _ := strings.Cut(text, " ")
+		switch key {
+		case "author":
+			line.AuthorName = value
+		case "author-mail":
+			line.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			authorTime, _ = strconv.ParseInt(value, 10, 64)
+		case "author-tz":
+			line.Date = gitBlameDate(authorTime, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// gitBlameDate formats a unix time and a timezone like "+0130" in RFC 3339.
+func gitBlameDate(unix int64, tz string) string {
+	t := time.Unix(unix, 0).UTC()
+	if len(tz) == 5 {
+		hours, herr := strconv.Atoi(tz[1:3])
+		minutes, merr := strconv.Atoi(tz[3:5])
+		if herr == nil && merr == nil {
+			offset := hours*3600 + minutes*60
+			if tz[0] == '-' {
+				offset = -offset
+			}
+			t = t.In(time.FixedZone(tz, offset))
+		}
+	}
+	return t.Format(time.RFC3339)
+}
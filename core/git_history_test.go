@@ -0,0 +1,235 @@
+package core
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+func TestGitLog(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	repo := t.TempDir()
+	git := gitutil.NewGitCLI(gitutil.WithDir(repo))
+	run := func(args ...string) string {
+		out, err := git.Run(ctx, append([]string{
+			"-c", "user.name=Alice", "-c", "user.email=alice@example.com",
+		}, args...)...)
+		require.NoError(t, err)
+		return string(out)
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(repo, name), []byte(content), 0o600))
+	}
+
+	run("-c", "init.defaultBranch=main", "init")
+	write("README.md", "hello\n")
+	run("add", ".")
+	run("commit", "-m", "first\n\nwith a body")
+	first := run("rev-parse", "HEAD")[:40]
+	run("checkout", "-b", "feature")
+	write("feature file", "feature\n")
+	run("add", ".")
+	run("commit", "-m", "feature")
+	feature := run("rev-parse", "HEAD")[:40]
+	run("checkout", "main")
+	write("README.md", "hello world\n")
+	run("add", ".")
+	run("commit", "-m", "readme")
+	readme := run("rev-parse", "HEAD")[:40]
+	run("merge", "--no-edit", "feature")
+	merge := run("rev-parse", "HEAD")[:40]
+
+	ref := &GitRef{Backend: testGitRefBackend{git: git}, Ref: &gitutil.Ref{Name: "refs/heads/main", SHA: merge}}
+
+	commits, err := ref.Log(ctx, GitLogOptions{})
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+	require.Equal(t, merge, commits[0].Commit)
+	require.Equal(t, []string{readme, feature}, commits[0].Parents)
+	require.Equal(t, []string{"feature file"}, commits[0].Files)
+	require.Equal(t, first, commits[3].Commit)
+	require.Empty(t, commits[3].Parents)
+	require.Equal(t, "Alice", commits[3].AuthorName)
+	require.Equal(t, "alice@example.com", commits[3].AuthorEmail)
+	require.Equal(t, "first\n\nwith a body", commits[3].Message)
+	require.Equal(t, []string{"README.md"}, commits[3].Files)
+	require.NotEmpty(t, commits[3].Date)
+
+	commits, err = ref.Log(ctx, GitLogOptions{Paths: []string{"README.md"}})
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, readme, commits[0].Commit)
+	require.Equal(t, first, commits[1].Commit)
+
+	commits, err = ref.Log(ctx, GitLogOptions{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+
+	commits, err = ref.Log(ctx, GitLogOptions{Since: "1 hour ago"})
+	require.NoError(t, err)
+	require.Len(t, commits, 4)
+
+	commits, err = ref.Log(ctx, GitLogOptions{Since: "2037-01-01"})
+	require.NoError(t, err)
+	require.Empty(t, commits)
+}
+
+func TestParseGitBlame(t *testing.T) {
+	lines, err := parseGitBlame(`0123456789abcdef0123456789abcdef01234567 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1735689600
+author-tz +0130
+committer Alice
+committer-mail <alice@example.com>
+committer-time 1735689600
+committer-tz +0130
+summary first
+boundary
+filename README.md
+	hello
+0123456789abcdef0123456789abcdef01234567 2 2
+author Alice
+author-mail <alice@example.com>
+author-time 1735689600
+author-tz +0130
+committer Alice
+committer-mail <alice@example.com>
+committer-time 1735689600
+committer-tz +0130
+summary first
+boundary
+filename README.md
+	author fake
+0000000000000000000000000000000000000000 3 3 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+author-time 1735693200
+author-tz -0500
+committer Not Committed Yet
+committer-mail <not.committed.yet>
+committer-time 1735693200
+committer-tz -0500
+summary Version of README.md from README.md
+previous 0123456789abcdef0123456789abcdef01234567 README.md
+filename README.md
+	
+`)
+	require.NoError(t, err)
+	require.Equal(t, []*GitBlameLine{
+		{
+			Line:        1,
+			Content:     "hello",
+			Commit:      "0123456789abcdef0123456789abcdef01234567",
+			AuthorName:  "Alice",
+			AuthorEmail: "alice@example.com",
+			Date:        "2025-01-01T01:30:00+01:30",
+		},
+		{
+			Line:        2,
+			Content:     "author fake",
+			Commit:      "0123456789abcdef0123456789abcdef01234567",
+			AuthorName:  "Alice",
+			AuthorEmail: "alice@example.com",
+			Date:        "2025-01-01T01:30:00+01:30",
+		},
+		{
+			Line:        3,
+			Content:     "",
+			Commit:      "0000000000000000000000000000000000000000",
+			AuthorName:  "Not Committed Yet",
+			AuthorEmail: "not.committed.yet",
+			Date:        "2024-12-31T20:00:00-05:00",
+		},
+	}, lines)
+}
+
+func TestBlameGitDir(t *testing.T) {
+	root := t.TempDir()
+	write := func(name, content string) {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "repo/.git/modules/sub"), 0o755))
+	write("repo/sub/.git", "gitdir: ../.git/modules/sub\n")
+	write("abs/.git", "gitdir: /var/lib/repo/.git\n")
+	write("escape/.git", "gitdir: ../../../repo/.git\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "alternates/.git/objects/info"), 0o755))
+	write("alternates/.git/objects/info/alternates", "/var/lib/repo/.git/objects\n")
+
+	gitDir, err := blameGitDir(root, "/repo")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "repo/.git"), gitDir)
+
+	gitDir, err = blameGitDir(root, "/repo/sub")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(root, "repo/.git/modules/sub"), gitDir)
+
+	_, err = blameGitDir(root, "/abs")
+	require.ErrorContains(t, err, "outside of the directory")
+
+	_, err = blameGitDir(root, "/escape")
+	require.ErrorContains(t, err, "outside of the directory")
+
+	_, err = blameGitDir(root, "/alternates")
+	require.ErrorContains(t, err, "alternates")
+}
+
+func TestBlameInMount(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	root := t.TempDir()
+	repo := filepath.Join(root, "repo")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "docs"), 0o755))
+	git := gitutil.NewGitCLI(gitutil.WithDir(repo))
+	run := func(args ...string) {
+		_, err := git.Run(ctx, append([]string{
+			"-c", "user.name=Alice", "-c", "user.email=alice@example.com",
+		}, args...)...)
+		require.NoError(t, err)
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "docs/index.md"), []byte("hello\n"), 0o600))
+	run("init")
+	run("add", ".")
+	run("commit", "-m", "first")
+
+	lines, err := blameInMount(ctx, root, "repo/docs/index.md")
+	require.NoError(t, err)
+	require.Len(t, lines, 1)
+	require.Equal(t, "hello", lines[0].Content)
+	require.Equal(t, "Alice", lines[0].AuthorName)
+
+	// files of the engine aren't read as revisions to ignore
+	run("config", "blame.ignoreRevsFile", "/etc/hostname")
+	_, err = blameInMount(ctx, root, "repo/docs/index.md")
+	require.NoError(t, err)
+
+	// objects missing from a partial clone would be fetched by running the
+	// commands configured for its promisor remote
+	run("config", "remote.origin.url", "ext::sh -c touch% /tmp/pwned")
+	run("config", "remote.origin.promisor", "true")
+	run("config", "extensions.partialClone", "origin")
+	_, err = blameInMount(ctx, root, "repo/docs/index.md")
+	require.ErrorContains(t, err, "partial clone")
+	run("config", "--unset", "extensions.partialClone")
+	run("config", "--unset", "remote.origin.promisor")
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ".gitattributes"), []byte("*.md filter=evil\n"), 0o600))
+	_, err = blameInMount(ctx, root, "repo/docs/index.md")
+	require.ErrorContains(t, err, `"evil" filter`)
+
+	_, err = blameInMount(ctx, root, "index.md")
+	require.ErrorContains(t, err, "not in a git repository")
+}
@@ -236,7 +236,7 @@ func (ref *LocalGitRef) mount(ctx context.Context, depth int, fn func(*gitutil.G
 	return ref.repo.mount(ctx, depth, []GitRefBackend{ref}, fn)
 }
 
-func (ref *LocalGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGitDir bool, depth int) (_ *Directory, rerr error) {
+func (ref *LocalGitRef) Tree(ctx context.Context, srv *dagql.Server, opts GitTreeOptions) (_ *Directory, rerr error) {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return nil, err
@@ -261,7 +261,7 @@ func (ref *LocalGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGitD
 		}
 	}()
 
-	err = ref.mount(ctx, depth, func(git *gitutil.GitCLI) error {
+	err = ref.mount(ctx, opts.Depth, func(git *gitutil.GitCLI) error {
 		gitURL, err := git.URL(ctx)
 		if err != nil {
 			return fmt.Errorf("could not find git url: %w", err)
@@ -277,7 +277,7 @@ func (ref *LocalGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGitD
 				gitutil.WithWorkTree(checkoutDir),
 				gitutil.WithGitDir(checkoutDirGit),
 			)
-			return doGitCheckout(ctx, checkoutGit, "", gitURL, ref.Ref, depth, discardGitDir)
+			return doGitCheckout(ctx, checkoutGit, "", gitURL, ref.Ref, "", opts)
 		})
 	})
 	if err != nil {
@@ -50,6 +50,10 @@ type RemoteGitRepository struct {
 	AuthUsername string
 	AuthToken    dagql.ObjectResult[*Secret]
 	AuthHeader   dagql.ObjectResult[*Secret]
+
+	// Filter is the partial clone filter to fetch the repository with, like
+	// "blob:none". The objects of a checkout are fetched when needed.
+	Filter string
 }
 
 var _ GitRepositoryBackend = (*RemoteGitRepository)(nil)
@@ -131,6 +135,15 @@ func (repo *RemoteGitRepository) Get(ctx context.Context, target *gitutil.Ref) (
 	}, nil
 }
 
+// sharedKey identifies the bare repository shared by the refs of the
+// remote. Partial clones are kept apart, since they miss objects.
+func (repo *RemoteGitRepository) sharedKey() string {
+	if repo.Filter == "" {
+		return repo.URL.Remote()
+	}
+	return repo.URL.Remote() + "#filter=" + repo.Filter
+}
+
 func (repo *RemoteGitRepository) remoteCacheKey(ctx context.Context) (string, error) {
 	clientMetadata, err := engine.ClientMetadataFromContext(ctx)
 	if err != nil {
@@ -331,7 +344,15 @@ func (repo *RemoteGitRepository) mount(ctx context.Context, depth int, refs []Gi
 				}
 			}
 
-			// TODO: should set doFetch if a tag in ls-remote has been updated?
+			// fetch tags missing or updated since the commit was fetched, to
+			// get their tag objects
+			if !doFetch && strings.HasPrefix(ref.Name, "refs/tags/") {
+				res, err := git.New(gitutil.WithIgnoreError()).Run(ctx, "rev-parse", "--verify", "--quiet", ref.Name+"^{commit}")
+				if err != nil {
+					return fmt.Errorf("failed to rev-parse: %w", err)
+				}
+				doFetch = strings.TrimSpace(string(res)) != ref.SHA
+			}
 
 			if doFetch {
 				fetchRefs = append(fetchRefs, ref)
@@ -382,6 +403,9 @@ func (repo *RemoteGitRepository) fetch(ctx context.Context, git *gitutil.GitCLI,
 	} else {
 		args = append(args, "--depth="+fmt.Sprint(depth))
 	}
+	if repo.Filter != "" {
+		args = append(args, "--filter="+repo.Filter)
+	}
 	args = append(args, "origin")
 	args = append(args, refSpecs...)
 
@@ -412,18 +436,61 @@ func (repo *RemoteGitRepository) fetch(ctx context.Context, git *gitutil.GitCLI,
 	return nil
 }
 
+// fetchCheckoutObjects fetches the objects missing from a partial clone to
+// check out a commit.
+func (repo *RemoteGitRepository) fetchCheckoutObjects(ctx context.Context, git *gitutil.GitCLI, sha string, sparse []string) error {
+	missing, err := missingCheckoutObjects(ctx, git, sha, sparse)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return err
+	}
+	svcs, err := query.Services(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get services: %w", err)
+	}
+	detach, _, err := svcs.StartBindings(ctx, repo.Services)
+	if err != nil {
+		return err
+	}
+	defer detach()
+
+	// same as the lazy fetches of git, batched to keep command lines short
+	for oids := range slices.Chunk(missing, 1000) {
+		args := []string{
+			"fetch",
+			"--no-tags",
+			"--no-write-fetch-head",
+			"--recurse-submodules=no",
+			"--filter=" + repo.Filter,
+			"origin",
+		}
+		if _, err := git.Run(ctx, append(args, oids...)...); err != nil {
+			return fmt.Errorf("failed to fetch objects from remote %s: %w", repo.URL.Remote(), err)
+		}
+	}
+	return nil
+}
+
 func (repo *RemoteGitRepository) initRemote(ctx context.Context, g bksession.Group, fn func(string) error) (retErr error) {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return err
 	}
+	sharedKey := repo.sharedKey()
 	locker := query.Locker()
-	locker.Lock(indexGitRemote + repo.URL.Remote())
-	defer locker.Unlock(indexGitRemote + repo.URL.Remote())
+	locker.Lock(indexGitRemote + sharedKey)
+	defer locker.Unlock(indexGitRemote + sharedKey)
 
 	cache := query.BuildkitCache()
 
-	sis, err := searchGitRemote(ctx, cache, repo.URL.Remote())
+	sis, err := searchGitRemote(ctx, cache, sharedKey)
 	if err != nil {
 		return fmt.Errorf("failed to search metadata for %s: %w", repo.URL.Remote(), err)
 	}
@@ -446,7 +513,7 @@ func (repo *RemoteGitRepository) initRemote(ctx context.Context, g bksession.Gro
 	if remoteRef == nil {
 		remoteRef, err = cache.New(ctx, nil, g,
 			bkcache.CachePolicyRetain,
-			bkcache.WithDescription(fmt.Sprintf("shared git repo for %s", repo.URL.Remote())))
+			bkcache.WithDescription(fmt.Sprintf("shared git repo for %s", sharedKey)))
 		if err != nil {
 			return fmt.Errorf("failed to create new mutable for %s: %w", repo.URL.Remote(), err)
 		}
@@ -491,9 +558,18 @@ func (repo *RemoteGitRepository) initRemote(ctx context.Context, g bksession.Gro
 			return fmt.Errorf("failed add origin repo at %s: %w", dir, err)
 		}
 
+		if repo.Filter != "" {
+			// checkouts are partial clones of this repo too
+			for _, key := range []string{"uploadpack.allowFilter", "uploadpack.allowAnySHA1InWant"} {
+				if _, err := git.Run(ctx, "config", key, "true"); err != nil {
+					return fmt.Errorf("failed to configure repo at %s: %w", dir, err)
+				}
+			}
+		}
+
 		// save new remote metadata
 		md := cacheRefMetadata{remoteRef}
-		if err := md.setGitRemote(repo.URL.Remote()); err != nil {
+		if err := md.setGitRemote(sharedKey); err != nil {
 			return err
 		}
 	}
@@ -501,7 +577,7 @@ func (repo *RemoteGitRepository) initRemote(ctx context.Context, g bksession.Gro
 	return fn(dir)
 }
 
-func (ref *RemoteGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGitDir bool, depth int) (_ *Directory, rerr error) {
+func (ref *RemoteGitRef) Tree(ctx context.Context, srv *dagql.Server, opts GitTreeOptions) (_ *Directory, rerr error) {
 	cacheKey := dagql.CurrentID(ctx).Digest().Encoded()
 
 	query, err := CurrentQuery(ctx)
@@ -510,6 +586,22 @@ func (ref *RemoteGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGit
 	}
 	cache := query.BuildkitCache()
 
+	if len(opts.Sparse) > 0 && opts.DiscardGitDir {
+		// sparse checkouts are cached by the entries they check out, so
+		// that changes to other directories don't invalidate them
+		err := ref.mount(ctx, opts.Depth, func(git *gitutil.GitCLI) error {
+			entries, err := sparseTreeEntries(ctx, git, ref.SHA, opts.Sparse)
+			if err != nil {
+				return err
+			}
+			cacheKey = sparseCacheKey(entries, opts.LFS)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	locker := query.Locker()
 	locker.Lock(indexGitSnapshot + cacheKey)
 	defer locker.Unlock(indexGitSnapshot + cacheKey)
@@ -538,11 +630,28 @@ func (ref *RemoteGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGit
 	if !ok {
 		return nil, fmt.Errorf("no buildkit session group in context")
 	}
-	err = ref.mount(ctx, depth, func(git *gitutil.GitCLI) error {
+	if opts.LFS {
+		// LFS objects are downloaded from the remote by the checkout
+		svcs, err := query.Services(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get services: %w", err)
+		}
+		detach, _, err := svcs.StartBindings(ctx, ref.repo.Services)
+		if err != nil {
+			return nil, err
+		}
+		defer detach()
+	}
+	err = ref.mount(ctx, opts.Depth, func(git *gitutil.GitCLI) error {
 		gitURL, err := git.URL(ctx)
 		if err != nil {
 			return fmt.Errorf("could not find git dir: %w", err)
 		}
+		if ref.repo.Filter != "" {
+			if err := ref.repo.fetchCheckoutObjects(ctx, git, ref.SHA, opts.Sparse); err != nil {
+				return err
+			}
+		}
 
 		checkoutRef, err = cache.New(ctx, nil, bkSessionGroup,
 			bkcache.CachePolicyRetain,
@@ -559,7 +668,7 @@ func (ref *RemoteGitRef) Tree(ctx context.Context, srv *dagql.Server, discardGit
 			}
 			checkoutGit := git.New(gitutil.WithWorkTree(checkoutDir), gitutil.WithGitDir(checkoutDirGit))
 
-			return doGitCheckout(ctx, checkoutGit, ref.repo.URL.Remote(), gitURL, ref.Ref, depth, discardGitDir)
+			return doGitCheckout(ctx, checkoutGit, ref.repo.URL.Remote(), gitURL, ref.Ref, ref.repo.Filter, opts)
 		})
 		if err != nil {
 			return fmt.Errorf("failed to checkout %s in %s: %w", ref.Name, ref.repo.URL.Remote(), err)
@@ -0,0 +1,338 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	doublestar "github.com/bmatcuk/doublestar/v4"
+
+	"github.com/dagger/dagger/util/gitutil"
+	"github.com/dagger/dagger/util/hashutil"
+)
+
+// NormalizeGitSparse validates the patterns of a sparse checkout.
+//
+// Directories are checked out in cone mode, and returned sorted, without the
+// directories nested in others. Patterns with wildcards or negations select
+// non-cone mode instead, where all patterns are matched like in .gitignore
+// files, and are returned as is.
+func NormalizeGitSparse(patterns []string) ([]string, error) {
+	if !gitSparseCone(patterns) {
+		for _, pattern := range patterns {
+			if strings.TrimSpace(strings.TrimPrefix(pattern, "!")) == "" {
+				return nil, fmt.Errorf("invalid sparse pattern %q", pattern)
+			}
+		}
+		return slices.Clone(patterns), nil
+	}
+	normalized := make([]string, 0, len(patterns))
+	for _, dir := range patterns {
+		clean := path.Clean("/" + dir)[1:]
+		if clean == "" {
+			return nil, fmt.Errorf("sparse directory %q selects the whole repository", dir)
+		}
+		normalized = append(normalized, clean)
+	}
+	slices.Sort(normalized)
+	normalized = slices.Compact(normalized)
+	return slices.DeleteFunc(normalized, func(dir string) bool {
+		return slices.ContainsFunc(normalized, func(other string) bool {
+			return strings.HasPrefix(dir, other+"/")
+		})
+	}), nil
+}
+
+// gitSparseCone returns whether the patterns of a sparse checkout are
+// directories, checked out in cone mode.
+func gitSparseCone(patterns []string) bool {
+	return !slices.ContainsFunc(patterns, func(pattern string) bool {
+		return strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "*?[\\")
+	})
+}
+
+// gitSparsePattern is a non-cone sparse checkout pattern, matched like in
+// .gitignore files.
+type gitSparsePattern struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+func parseGitSparsePatterns(patterns []string) []gitSparsePattern {
+	parsed := make([]gitSparsePattern, 0, len(patterns))
+	for _, pattern := range patterns {
+		var p gitSparsePattern
+		pattern, p.negate = strings.CutPrefix(pattern, "!")
+		pattern, p.dirOnly = strings.CutSuffix(pattern, "/")
+		// patterns with a slash are relative to the root, others match names
+		p.anchored = strings.Contains(pattern, "/")
+		p.pattern = strings.TrimPrefix(pattern, "/")
+		parsed = append(parsed, p)
+	}
+	return parsed
+}
+
+func (p gitSparsePattern) match(name string, isDir bool) bool {
+	if p.dirOnly && !isDir {
+		return false
+	}
+	if !p.anchored {
+		name = path.Base(name)
+	}
+	ok, _ := doublestar.Match(p.pattern, name)
+	return ok
+}
+
+// inSparsePatterns returns whether a file is checked out by non-cone sparse
+// patterns. Like git, the last pattern matching the file decides, or else the
+// last one matching its closest parent directory.
+func inSparsePatterns(p string, patterns []gitSparsePattern) bool {
+	isDir := false
+	for ; p != "."; p, isDir = path.Dir(p), true {
+		for _, pattern := range slices.Backward(patterns) {
+			if pattern.match(p, isDir) {
+				return !pattern.negate
+			}
+		}
+	}
+	return false
+}
+
+// sparseParents returns the parents of the directories of a sparse
+// checkout, whose files are checked out too.
+func sparseParents(dirs []string) []string {
+	var parents []string
+	for _, dir := range dirs {
+		for parent := path.Dir(dir); parent != "."; parent = path.Dir(parent) {
+			parents = append(parents, parent)
+		}
+	}
+	slices.Sort(parents)
+	return slices.Compact(parents)
+}
+
+// inSparseCheckout returns whether a file is checked out by a sparse
+// checkout.
+func inSparseCheckout(p string, sparse []string) bool {
+	if !gitSparseCone(sparse) {
+		return inSparsePatterns(p, parseGitSparsePatterns(sparse))
+	}
+	return inSparseCone(p, sparse)
+}
+
+// inSparseCone returns whether a path is checked out by a sparse checkout
+// of the given directories.
+func inSparseCone(p string, dirs []string) bool {
+	parent := path.Dir(p)
+	if parent == "." {
+		return true
+	}
+	for _, dir := range dirs {
+		if p == dir || strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+		if dir == parent || strings.HasPrefix(dir, parent+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+type gitTreeEntry struct {
+	Mode string
+	Type string
+	OID  string
+	Path string
+}
+
+// sparseTreeEntries lists the entries of a commit selected by a sparse
+// checkout. In cone mode, these are the files of the root and parent
+// directories, and the trees of the checked out directories; otherwise, the
+// matching files.
+func sparseTreeEntries(ctx context.Context, git *gitutil.GitCLI, sha string, sparse []string) ([]gitTreeEntry, error) {
+	if !gitSparseCone(sparse) {
+		entries, err := lsTree(ctx, git, "-r", sha)
+		if err != nil {
+			return nil, err
+		}
+		patterns := parseGitSparsePatterns(sparse)
+		return slices.DeleteFunc(entries, func(entry gitTreeEntry) bool {
+			return !inSparsePatterns(entry.Path, patterns)
+		}), nil
+	}
+
+	var entries []gitTreeEntry
+	for _, parent := range append([]string{""}, sparseParents(sparse)...) {
+		args := []string{sha}
+		if parent != "" {
+			args = append(args, "--", parent+"/")
+		}
+		parentEntries, err := lsTree(ctx, git, args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range parentEntries {
+			if entry.Type == "tree" && !slices.Contains(sparse, entry.Path) {
+				// parents are listed on their own, others aren't checked out
+				continue
+			}
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// lsTree lists the entries of a tree, with their full paths.
+func lsTree(ctx context.Context, git *gitutil.GitCLI, args ...string) ([]gitTreeEntry, error) {
+	out, err := git.Run(ctx, append([]string{"ls-tree", "-z", "--full-tree"}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tree: %w", err)
+	}
+	var entries []gitTreeEntry
+	for line := range strings.SplitSeq(string(out), "\x00") {
+		if line == "" {
+			continue
+		}
+		meta, p, ok := strings.Cut(line, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 3 {
+			return nil, fmt.Errorf("unexpected ls-tree output %q", line)
+		}
+		entries = append(entries, gitTreeEntry{Mode: fields[0], Type: fields[1], OID: fields[2], Path: p})
+	}
+	return entries, nil
+}
+
+// sparseCacheKey returns the cache key of a sparse checkout without .git
+// directory, which only depends on the checked out entries.
+func sparseCacheKey(entries []gitTreeEntry, lfs bool) string {
+	inputs := []string{"sparse", "lfs=" + strconv.FormatBool(lfs)}
+	for _, entry := range entries {
+		inputs = append(inputs, entry.Mode, entry.Type, entry.OID, entry.Path)
+	}
+	return hashutil.HashStrings(inputs...).Encoded()
+}
+
+// sparseSubmodules returns the paths of the submodules checked out by a
+// sparse checkout.
+func sparseSubmodules(ctx context.Context, git *gitutil.GitCLI, sparse []string) ([]string, error) {
+	out, err := git.Run(ctx, "ls-files", "-z", "--stage")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+	var paths []string
+	for line := range strings.SplitSeq(string(out), "\x00") {
+		meta, p, ok := strings.Cut(line, "\t")
+		if !ok || !strings.HasPrefix(meta, "160000 ") {
+			continue
+		}
+		if inSparseCheckout(p, sparse) {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// missingCheckoutObjects lists the objects to fetch into a partial clone to
+// check out a commit. The files of a sparse checkout outside of its checked
+// out trees are always listed, since rev-list can only tell which objects are
+// missing from the trees it walks.
+func missingCheckoutObjects(ctx context.Context, git *gitutil.GitCLI, sha string, sparse []string) ([]string, error) {
+	args := []string{"rev-list", "--objects", "--missing=print"}
+	var missing []string
+	if len(sparse) == 0 {
+		args = append(args, "--no-walk", sha)
+	} else {
+		entries, err := sparseTreeEntries(ctx, git, sha, sparse)
+		if err != nil {
+			return nil, err
+		}
+		trees := 0
+		for _, entry := range entries {
+			switch entry.Type {
+			case "tree":
+				args = append(args, entry.OID)
+				trees++
+			case "blob":
+				missing = append(missing, entry.OID)
+			}
+		}
+		if trees == 0 {
+			return missing, nil
+		}
+	}
+	out, err := git.Run(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list missing objects: %w", err)
+	}
+	for line := range strings.SplitSeq(string(out), "\n") {
+		if oid, ok := strings.CutPrefix(line, "?"); ok {
+			missing = append(missing, oid)
+		}
+	}
+	return missing, nil
+}
+
+// pullGitLFS downloads the Git LFS objects of a checkout.
+func pullGitLFS(ctx context.Context, git *gitutil.GitCLI, remoteURL, cloneURL string, ref *gitutil.Ref, sparse []string) error {
+	if _, err := git.Run(ctx, "lfs", "install", "--local"); err != nil {
+		return fmt.Errorf("failed to install git lfs: %w", err)
+	}
+
+	var args []string
+	if remoteURL == "" {
+		// local repositories serve their own LFS objects
+		args = append(args, "-c", "lfs.url="+cloneURL)
+	}
+	args = append(args, "lfs", "pull")
+	if len(sparse) > 0 {
+		entries, err := sparseTreeEntries(ctx, git, ref.SHA, sparse)
+		if err != nil {
+			return err
+		}
+		var includes []string
+		for _, entry := range entries {
+			switch entry.Type {
+			case "tree":
+				includes = append(includes, lfsIncludePattern(entry.Path)+"/**")
+			case "blob":
+				includes = append(includes, lfsIncludePattern(entry.Path))
+			}
+		}
+		// batched to keep command lines short
+		for includes := range slices.Chunk(includes, 1000) {
+			includeArgs := append(slices.Clip(args), "--include="+strings.Join(includes, ","))
+			if _, err := git.Run(ctx, includeArgs...); err != nil {
+				return fmt.Errorf("failed to pull git lfs objects: %w", err)
+			}
+		}
+		return nil
+	}
+	if _, err := git.Run(ctx, args...); err != nil {
+		return fmt.Errorf("failed to pull git lfs objects: %w", err)
+	}
+	return nil
+}
+
+// lfsIncludePattern escapes a path to only match itself in the include list
+// of git lfs. The list is split on commas, which can't be escaped, so they
+// match any character instead.
+func lfsIncludePattern(p string) string {
+	var b strings.Builder
+	for _, r := range p {
+		switch r {
+		case '\\', '*', '?', '[', ']':
+			b.WriteByte('\\')
+		case ',':
+			b.WriteByte('?')
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,193 @@
+package core
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+func TestNormalizeGitSparse(t *testing.T) {
+	dirs, err := NormalizeGitSparse([]string{"services/api/", "/libs", "services/api/internal", "libs", "./docs"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs", "libs", "services/api"}, dirs)
+
+	_, err = NormalizeGitSparse([]string{"."})
+	require.ErrorContains(t, err, "whole repository")
+
+	// non-cone patterns are kept as is
+	patterns, err := NormalizeGitSparse([]string{"/services/*/", "!services/web/", "*.md"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/services/*/", "!services/web/", "*.md"}, patterns)
+	_, err = NormalizeGitSparse([]string{"*.md", "!"})
+	require.ErrorContains(t, err, "invalid sparse pattern")
+}
+
+func TestInSparseCheckout(t *testing.T) {
+	patterns := []string{"/services/*/", "!/services/web/", "*.md"}
+	for p, expected := range map[string]bool{
+		"README.md":             true,
+		"docs/index.md":         true,
+		"services/go.work":      false,
+		"services/api/main.go":  true,
+		"services/web/main.go":  false,
+		"services/web/index.md": true,
+		"libs/a/b.go":           false,
+	} {
+		require.Equal(t, expected, inSparseCheckout(p, patterns), p)
+	}
+	// a file matching a pattern isn't excluded by a pattern of its parent
+	require.True(t, inSparseCheckout("services/web/main.go", []string{"*.go", "!services/web/"}))
+}
+
+func TestLFSIncludePattern(t *testing.T) {
+	require.Equal(t, "docs/a.bin", lfsIncludePattern("docs/a.bin"))
+	require.Equal(t, `a?b/\*\?\[x\]\\.bin`, lfsIncludePattern(`a,b/*?[x]\.bin`))
+}
+
+func TestInSparseCone(t *testing.T) {
+	dirs := []string{"libs", "services/api"}
+	for p, expected := range map[string]bool{
+		"README.md":               true,
+		"libs/a/b.go":             true,
+		"services/go.work":        true,
+		"services/api/main.go":    true,
+		"services/web/main.go":    false,
+		"services/api2/main.go":   false,
+		"docs/index.md":           false,
+		"services/api/sub/mod.go": true,
+	} {
+		require.Equal(t, expected, inSparseCone(p, dirs), p)
+	}
+	require.Equal(t, []string{"a", "a/b"}, sparseParents([]string{"a/b/c", "a/d"}))
+}
+
+func TestSparseCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	origin := filepath.Join(tmp, "origin")
+	write := func(p, content string) {
+		p = filepath.Join(origin, p)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	originGit := gitutil.NewGitCLI(gitutil.WithDir(origin))
+	commit := func() string {
+		_, err := originGit.Run(ctx, "add", ".")
+		require.NoError(t, err)
+		_, err = originGit.Run(ctx, "-c", "user.name=test", "-c", "user.email=test@dagger.io", "commit", "-m", "commit")
+		require.NoError(t, err)
+		out, err := originGit.Run(ctx, "rev-parse", "HEAD")
+		require.NoError(t, err)
+		return string(out[:40])
+	}
+	require.NoError(t, os.MkdirAll(origin, 0o755))
+	_, err := originGit.Run(ctx, "-c", "init.defaultBranch=main", "init")
+	require.NoError(t, err)
+	_, err = originGit.Run(ctx, "config", "uploadpack.allowFilter", "true")
+	require.NoError(t, err)
+	write("README.md", "readme")
+	write("services/go.work", "work")
+	write("services/api/main.go", "api")
+	write("services/web/main.go", "web")
+	first := commit()
+	write("services/web/main.go", "web v2")
+	second := commit()
+
+	bare := filepath.Join(tmp, "bare")
+	bareGit := gitutil.NewGitCLI(gitutil.WithGitDir(bare))
+	for _, args := range [][]string{
+		{"init", "--bare", bare},
+		{"config", "uploadpack.allowFilter", "true"},
+		{"config", "uploadpack.allowAnySHA1InWant", "true"},
+		{"remote", "add", "origin", "file://" + origin},
+		{"fetch", "--filter=blob:none", "origin", second},
+	} {
+		_, err := bareGit.Run(ctx, args...)
+		require.NoError(t, err)
+	}
+
+	sparse := []string{"services/api"}
+	entries, err := sparseTreeEntries(ctx, bareGit, second, sparse)
+	require.NoError(t, err)
+	var paths []string
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	require.Equal(t, []string{"README.md", "services/api", "services/go.work"}, paths)
+
+	// changes outside of the sparse checkout don't change its cache key
+	firstEntries, err := sparseTreeEntries(ctx, bareGit, first, sparse)
+	require.NoError(t, err)
+	require.Equal(t, sparseCacheKey(firstEntries, false), sparseCacheKey(entries, false))
+	require.NotEqual(t, sparseCacheKey(entries, true), sparseCacheKey(entries, false))
+
+	// only the checked out files are missing from the partial clone
+	missing, err := missingCheckoutObjects(ctx, bareGit, second, sparse)
+	require.NoError(t, err)
+	require.Len(t, missing, 3)
+	_, err = bareGit.Run(ctx, append([]string{"fetch", "--filter=blob:none", "origin"}, missing...)...)
+	require.NoError(t, err)
+
+	checkout := filepath.Join(tmp, "checkout")
+	require.NoError(t, os.MkdirAll(checkout, 0o755))
+	checkoutGit := gitutil.NewGitCLI(gitutil.WithWorkTree(checkout), gitutil.WithGitDir(filepath.Join(checkout, ".git")))
+	err = doGitCheckout(ctx, checkoutGit, "file://"+origin, "file://"+bare, &gitutil.Ref{SHA: second}, "blob:none", GitTreeOptions{
+		Sparse:        sparse,
+		DiscardGitDir: true,
+	})
+	require.NoError(t, err)
+
+	var files []string
+	err = filepath.WalkDir(checkout, func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(checkout, p)
+			files = append(files, rel)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"README.md", "services/go.work", "services/api/main.go"}, files)
+
+	// non-cone patterns only check out the matching files
+	sparse = []string{"*.go", "!/services/web/*"}
+	entries, err = sparseTreeEntries(ctx, bareGit, second, sparse)
+	require.NoError(t, err)
+	paths = nil
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	require.Equal(t, []string{"services/api/main.go"}, paths)
+	firstEntries, err = sparseTreeEntries(ctx, bareGit, first, sparse)
+	require.NoError(t, err)
+	require.Equal(t, sparseCacheKey(firstEntries, false), sparseCacheKey(entries, false))
+
+	checkout = filepath.Join(tmp, "checkout-no-cone")
+	require.NoError(t, os.MkdirAll(checkout, 0o755))
+	checkoutGit = gitutil.NewGitCLI(gitutil.WithWorkTree(checkout), gitutil.WithGitDir(filepath.Join(checkout, ".git")))
+	err = doGitCheckout(ctx, checkoutGit, "file://"+origin, "file://"+bare, &gitutil.Ref{SHA: second}, "blob:none", GitTreeOptions{
+		Sparse:        sparse,
+		DiscardGitDir: true,
+	})
+	require.NoError(t, err)
+
+	files = nil
+	err = filepath.WalkDir(checkout, func(p string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			rel, _ := filepath.Rel(checkout, p)
+			files = append(files, rel)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"services/api/main.go"}, files)
+}
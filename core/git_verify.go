@@ -0,0 +1,198 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+// GitSignatureError is returned when a git ref isn't signed by one of the
+// trusted signers passed to GitRef.verify.
+type GitSignatureError struct {
+	// The verified ref, and the commit it points to
+	Ref    string
+	Commit string
+	// Why the verification failed
+	Reason string
+}
+
+func (e *GitSignatureError) Error() string {
+	return fmt.Sprintf("git signature verification failed for %s: %s", e.Ref, e.Reason)
+}
+
+func (e *GitSignatureError) Extensions() map[string]any {
+	return map[string]any{
+		"_type":  "GIT_SIGNATURE_INVALID",
+		"ref":    e.Ref,
+		"commit": e.Commit,
+		"reason": e.Reason,
+	}
+}
+
+// GitVerifyOptions are the trusted signers to verify a git ref with.
+type GitVerifyOptions struct {
+	// AllowedSigners is an SSH allowed signers file, see ssh-keygen(1).
+	AllowedSigners []byte
+	// Keys are armored GPG public keys, or SSH public keys trusted for the
+	// principal in their comment.
+	Keys [][]byte
+}
+
+// Verify verifies the signature of the ref, and returns the identity of its
+// signer. Annotated tags are verified with the signature of the tag, other
+// refs with the signature of their commit.
+func (ref *GitRef) Verify(ctx context.Context, opts GitVerifyOptions) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "dagger-git-verify")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	allowedSigners := bytes.Clone(opts.AllowedSigners)
+	var gpgKeys [][]byte
+	for _, key := range opts.Keys {
+		if bytes.Contains(key, []byte("BEGIN PGP PUBLIC KEY BLOCK")) {
+			gpgKeys = append(gpgKeys, key)
+			continue
+		}
+		signer, err := sshAllowedSigner(key)
+		if err != nil {
+			return "", err
+		}
+		if len(allowedSigners) > 0 && !bytes.HasSuffix(allowedSigners, []byte("\n")) {
+			allowedSigners = append(allowedSigners, '\n')
+		}
+		allowedSigners = append(allowedSigners, signer...)
+	}
+	if len(allowedSigners) == 0 && len(gpgKeys) == 0 {
+		return "", errors.New("no allowed signers or keys to verify with")
+	}
+
+	allowedSignersPath := filepath.Join(tmpDir, "allowed_signers")
+	if err := os.WriteFile(allowedSignersPath, allowedSigners, 0o600); err != nil {
+		return "", err
+	}
+	gpgHome := filepath.Join(tmpDir, "gnupg")
+	if err := os.Mkdir(gpgHome, 0o700); err != nil {
+		return "", err
+	}
+	for _, key := range gpgKeys {
+		cmd := exec.CommandContext(ctx, "gpg", "--batch", "--homedir", gpgHome, "--import")
+		cmd.Stdin = bytes.NewReader(key)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return "", fmt.Errorf("failed to import gpg key: %w: %s", err, out)
+		}
+	}
+
+	var signer string
+	err = ref.Backend.mount(ctx, 1, func(git *gitutil.GitCLI) error {
+		var output bytes.Buffer
+		git = git.New(
+			gitutil.WithConfig(map[string]string{
+				"gpg.ssh.allowedSignersFile": allowedSignersPath,
+			}),
+			gitutil.WithGPGHome(gpgHome),
+			gitutil.WithStreams(func(context.Context) (io.WriteCloser, io.WriteCloser, func()) {
+				return nopWriteCloser{io.Discard}, nopWriteCloser{&output}, func() {}
+			}),
+		)
+
+		args := []string{"verify-commit", "--raw", ref.Ref.SHA}
+		if strings.HasPrefix(ref.Ref.Name, "refs/tags/") {
+			out, err := git.Run(ctx, "cat-file", "-t", ref.Ref.Name)
+			if err != nil {
+				return fmt.Errorf("failed to get tag %s: %w", ref.Ref.Name, err)
+			}
+			if strings.TrimSpace(string(out)) == "tag" {
+				args = []string{"verify-tag", "--raw", ref.Ref.Name}
+			}
+		}
+
+		_, verifyErr := git.Run(ctx, args...)
+		var reason string
+		signer, reason = parseGitSignatureOutput(output.String())
+		if verifyErr != nil || signer == "" {
+			if reason == "" {
+				reason = "no signature"
+			}
+			return &GitSignatureError{
+				Ref:    cmp.Or(ref.Ref.Name, ref.Ref.SHA),
+				Commit: ref.Ref.SHA,
+				Reason: reason,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return signer, nil
+}
+
+// sshAllowedSigner returns the line of an allowed signers file trusting an
+// SSH public key, for the principal in its comment or any principal.
+func sshAllowedSigner(key []byte) ([]byte, error) {
+	fields := strings.Fields(string(key))
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ssh-") && !strings.HasPrefix(fields[0], "ecdsa-") && !strings.HasPrefix(fields[0], "sk-") {
+		return nil, errors.New("key is neither an armored GPG public key nor an SSH public key")
+	}
+	principal := "*"
+	if len(fields) > 2 {
+		principal = fields[2]
+	}
+	return []byte(principal + " " + fields[0] + " " + fields[1] + "\n"), nil
+}
+
+// parseGitSignatureOutput parses the raw output of git verify-commit and
+// verify-tag, returning the signer of a good signature, or why it isn't.
+func parseGitSignatureOutput(output string) (signer string, reason string) {
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if status, ok := strings.CutPrefix(line, "[GNUPG:] "); ok {
+			keyword, args, _ := strings.Cut(status, " ")
+			switch keyword {
+			case "GOODSIG":
+				// GOODSIG <long keyid> <user id>
+				_, uid, _ := strings.Cut(args, " ")
+				signer = uid
+			case "BADSIG":
+				return "", "bad signature"
+			case "EXPSIG":
+				return "", "expired signature"
+			case "EXPKEYSIG":
+				return "", "signed by an expired key"
+			case "REVKEYSIG":
+				return "", "signed by a revoked key"
+			case "NO_PUBKEY":
+				return "", "signed by an untrusted key " + args
+			case "ERRSIG":
+				reason = "signature could not be checked"
+			}
+			continue
+		}
+		// SSH: Good "git" signature for <principal> with <type> key <fingerprint>
+		if rest, ok := strings.CutPrefix(line, `Good "git" signature for `); ok {
+			principal, _, _ := strings.Cut(rest, " with ")
+			signer = principal
+			continue
+		}
+		if line != "" && reason == "" && signer == "" {
+			reason = line
+		}
+	}
+	if signer != "" {
+		reason = ""
+	}
+	return signer, reason
+}
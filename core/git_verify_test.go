@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+func TestParseGitSignatureOutput(t *testing.T) {
+	signer, reason := parseGitSignatureOutput(`Good "git" signature for alice@example.com with ED25519 key SHA256:abc`)
+	require.Equal(t, "alice@example.com", signer)
+	require.Empty(t, reason)
+
+	signer, reason = parseGitSignatureOutput(`[GNUPG:] NEWSIG
+[GNUPG:] KEY_CONSIDERED 0123456789ABCDEF0123456789ABCDEF01234567 0
+[GNUPG:] SIG_ID abc 2025-01-01 1735689600
+[GNUPG:] GOODSIG 0123456789ABCDEF Bob <bob@example.com>
+[GNUPG:] VALIDSIG 0123456789ABCDEF0123456789ABCDEF01234567 2025-01-01 1735689600 0 4 0 22 10 00 0123456789ABCDEF0123456789ABCDEF01234567
+[GNUPG:] TRUST_UNDEFINED 0 pgp`)
+	require.Equal(t, "Bob <bob@example.com>", signer)
+	require.Empty(t, reason)
+
+	signer, reason = parseGitSignatureOutput(`[GNUPG:] NEWSIG
+[GNUPG:] ERRSIG 0123456789ABCDEF 22 10 00 1735689600 9 -
+[GNUPG:] NO_PUBKEY 0123456789ABCDEF`)
+	require.Empty(t, signer)
+	require.Equal(t, "signed by an untrusted key 0123456789ABCDEF", reason)
+
+	signer, reason = parseGitSignatureOutput("No principal matched.")
+	require.Empty(t, signer)
+	require.Equal(t, "No principal matched.", reason)
+}
+
+type testGitRefBackend struct {
+	git *gitutil.GitCLI
+}
+
+func (backend testGitRefBackend) Tree(context.Context, *dagql.Server, GitTreeOptions) (*Directory, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (backend testGitRefBackend) mount(_ context.Context, _ int, fn func(*gitutil.GitCLI) error) error {
+	return fn(backend.git)
+}
+
+func TestGitVerify(t *testing.T) {
+	for _, bin := range []string{"git", "ssh-keygen"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	keygen := func(name, comment string) string {
+		key := filepath.Join(tmp, name)
+		out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", comment, "-f", key).CombinedOutput()
+		require.NoError(t, err, string(out))
+		return key
+	}
+	alice := keygen("alice", "alice@example.com")
+	mallory := keygen("mallory", "mallory@example.com")
+	readKey := func(key string) []byte {
+		pub, err := os.ReadFile(key + ".pub")
+		require.NoError(t, err)
+		return pub
+	}
+
+	repo := filepath.Join(tmp, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o755))
+	git := gitutil.NewGitCLI(gitutil.WithDir(repo))
+	run := func(args ...string) string {
+		out, err := git.Run(ctx, append([]string{
+			"-c", "user.name=test", "-c", "user.email=test@dagger.io", "-c", "gpg.format=ssh",
+		}, args...)...)
+		require.NoError(t, err)
+		return string(out)
+	}
+	run("-c", "init.defaultBranch=main", "init")
+	run("commit", "--allow-empty", "-m", "unsigned")
+	unsigned := run("rev-parse", "HEAD")[:40]
+	run("-c", "user.signingkey="+alice, "commit", "--allow-empty", "-S", "-m", "signed by alice")
+	signed := run("rev-parse", "HEAD")[:40]
+	run("-c", "user.signingkey="+mallory, "tag", "-s", "-m", "v1", "v1")
+
+	ref := func(name, sha string) *GitRef {
+		return &GitRef{Backend: testGitRefBackend{git: git}, Ref: &gitutil.Ref{Name: name, SHA: sha}}
+	}
+	trustAlice := GitVerifyOptions{Keys: [][]byte{readKey(alice)}}
+
+	signer, err := ref("refs/heads/main", signed).Verify(ctx, trustAlice)
+	require.NoError(t, err)
+	require.Equal(t, "alice@example.com", signer)
+
+	signer, err = ref("", signed).Verify(ctx, GitVerifyOptions{
+		AllowedSigners: []byte("maintainers " + string(readKey(alice))),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "maintainers", signer)
+
+	_, err = ref("", unsigned).Verify(ctx, trustAlice)
+	var sigErr *GitSignatureError
+	require.ErrorAs(t, err, &sigErr)
+	require.Equal(t, unsigned, sigErr.Commit)
+	require.Equal(t, "no signature", sigErr.Reason)
+
+	// the tag is verified, not the commit signed by a trusted key
+	_, err = ref("refs/tags/v1", signed).Verify(ctx, trustAlice)
+	require.ErrorAs(t, err, &sigErr)
+	require.Equal(t, "refs/tags/v1", sigErr.Ref)
+
+	signer, err = ref("refs/tags/v1", signed).Verify(ctx, GitVerifyOptions{Keys: [][]byte{readKey(mallory)}})
+	require.NoError(t, err)
+	require.Equal(t, "mallory@example.com", signer)
+
+	_, err = ref("", signed).Verify(ctx, GitVerifyOptions{Keys: [][]byte{[]byte("not a key")}})
+	require.ErrorContains(t, err, "neither an armored GPG public key nor an SSH public key")
+}
+
+func TestGitVerifyGPG(t *testing.T) {
+	for _, bin := range []string{"git", "gpg"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not installed", bin)
+		}
+	}
+	ctx := context.Background()
+	tmp := t.TempDir()
+
+	gpgHome := filepath.Join(tmp, "gnupg")
+	require.NoError(t, os.Mkdir(gpgHome, 0o700))
+	gpg := func(args ...string) []byte {
+		out, err := exec.Command("gpg", append([]string{"--batch", "--homedir", gpgHome}, args...)...).Output()
+		require.NoError(t, err)
+		return out
+	}
+	gpg("--passphrase", "", "--quick-gen-key", "Bob <bob@example.com>", "ed25519", "sign", "never")
+	pubKey := gpg("--armor", "--export", "bob@example.com")
+
+	repo := filepath.Join(tmp, "repo")
+	require.NoError(t, os.MkdirAll(repo, 0o755))
+	git := gitutil.NewGitCLI(gitutil.WithDir(repo), gitutil.WithGPGHome(gpgHome))
+	for _, args := range [][]string{
+		{"-c", "init.defaultBranch=main", "init"},
+		{"-c", "user.name=Bob", "-c", "user.email=bob@example.com", "commit", "--allow-empty", "-S", "-m", "signed by bob"},
+	} {
+		_, err := git.Run(ctx, args...)
+		require.NoError(t, err)
+	}
+	out, err := git.Run(ctx, "rev-parse", "HEAD")
+	require.NoError(t, err)
+
+	ref := &GitRef{
+		Backend: testGitRefBackend{git: gitutil.NewGitCLI(gitutil.WithDir(repo))},
+		Ref:     &gitutil.Ref{Name: "refs/heads/main", SHA: string(out[:40])},
+	}
+	signer, err := ref.Verify(ctx, GitVerifyOptions{Keys: [][]byte{pubKey}})
+	require.NoError(t, err)
+	require.Equal(t, "Bob <bob@example.com>", signer)
+
+	// keys from the signer's keyring aren't trusted
+	_, err = ref.Verify(ctx, GitVerifyOptions{AllowedSigners: []byte("* ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOmFfmqNmdAEhLv0aJMcB5IJ0hKHTcr9yfAHoSDkWDnn\n")})
+	var sigErr *GitSignatureError
+	require.ErrorAs(t, err, &sigErr)
+}
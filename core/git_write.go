@@ -0,0 +1,273 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/dagger/dagger/engine/buildkit"
+	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
+	bkclient "github.com/dagger/dagger/internal/buildkit/client"
+	"github.com/dagger/dagger/util/gitutil"
+)
+
+// GitCommitOptions configures a commit created by GitRepository.WithCommit.
+type GitCommitOptions struct {
+	Message string
+	// Author is the author and committer of the commit, as "Name <email>".
+	Author string
+}
+
+// WithCommit commits changes on top of the HEAD of the repository. It
+// returns a bare repository with only the new commit and its parent, whose
+// HEAD is the new commit: pushing it requires the remote to have the parent.
+func (repo *GitRepository) WithCommit(ctx context.Context, changes *Changeset, opts GitCommitOptions) (_ *Directory, rerr error) {
+	author, err := mail.ParseAddress(opts.Author)
+	if err != nil || author.Name == "" {
+		return nil, fmt.Errorf("invalid author %q, expected \"Name <email>\"", opts.Author)
+	}
+	if strings.TrimSpace(opts.Message) == "" {
+		return nil, errors.New("commit message is required")
+	}
+
+	paths, err := changes.ComputePaths(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute changes: %w", err)
+	}
+
+	// an empty repository gets a root commit on its default branch
+	branch := "refs/heads/main"
+	var parent *gitutil.Ref
+	if head, err := repo.Remote.Lookup("HEAD"); err == nil {
+		parent = head
+		branch = head.Name
+	}
+
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bkSessionGroup, ok := buildkit.CurrentBuildkitSessionGroup(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no buildkit session group in context")
+	}
+	bkref, err := query.BuildkitCache().New(ctx, nil, bkSessionGroup,
+		bkcache.CachePolicyRetain,
+		bkcache.WithRecordType(bkclient.UsageRecordTypeRegular),
+		bkcache.WithDescription(fmt.Sprintf("git commit (%s)", branch)))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if rerr != nil && bkref != nil {
+			bkref.Release(context.WithoutCancel(ctx))
+		}
+	}()
+
+	tmpDir, err := os.MkdirTemp("", "dagger-git-commit")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = MountRef(ctx, bkref, bkSessionGroup, func(gitDir string, _ *mount.Mount) error {
+		git := gitutil.NewGitCLI(
+			gitutil.WithGitDir(gitDir),
+			gitutil.WithIndexFile(filepath.Join(tmpDir, "index")),
+			gitutil.WithConfig(map[string]string{
+				"user.name":  author.Name,
+				"user.email": author.Address,
+			}),
+		)
+		if _, err := git.Run(ctx, "init", "--bare", gitDir); err != nil {
+			return fmt.Errorf("failed to init repository: %w", err)
+		}
+
+		commitArgs := []string{"commit-tree", "-m", opts.Message}
+		if parent != nil {
+			err := repo.fetchCommit(ctx, git, parent)
+			if err != nil {
+				return err
+			}
+			if _, err := git.Run(ctx, "read-tree", parent.SHA); err != nil {
+				return fmt.Errorf("failed to read tree: %w", err)
+			}
+			commitArgs = append(commitArgs, "-p", parent.SHA)
+		}
+
+		err := changes.withMountedDirs(ctx, func(_, afterDir string) error {
+			git := git.New(gitutil.WithWorkTree(afterDir))
+			if changed := slices.Concat(paths.Added, paths.Modified); len(changed) > 0 {
+				pathspec, err := writeGitPathspec(tmpDir, "changed", changed)
+				if err != nil {
+					return err
+				}
+				if _, err := git.Run(ctx, "add", "--force", "--all", "--pathspec-from-file="+pathspec, "--pathspec-file-nul"); err != nil {
+					return fmt.Errorf("failed to add changes: %w", err)
+				}
+			}
+			if len(paths.Removed) > 0 {
+				pathspec, err := writeGitPathspec(tmpDir, "removed", paths.Removed)
+				if err != nil {
+					return err
+				}
+				if _, err := git.Run(ctx, "rm", "--quiet", "--cached", "-r", "--ignore-unmatch", "--pathspec-from-file="+pathspec, "--pathspec-file-nul"); err != nil {
+					return fmt.Errorf("failed to remove changes: %w", err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		tree, err := git.Run(ctx, "write-tree")
+		if err != nil {
+			return fmt.Errorf("failed to write tree: %w", err)
+		}
+		commitArgs = append(commitArgs, strings.TrimSpace(string(tree)))
+		commit, err := git.Run(ctx, commitArgs...)
+		if err != nil {
+			return fmt.Errorf("failed to commit: %w", err)
+		}
+		sha := strings.TrimSpace(string(commit))
+
+		if gitutil.IsCommitSHA(branch) {
+			// keep a detached HEAD detached
+			_, err = git.Run(ctx, "update-ref", "--no-deref", "HEAD", sha)
+		} else {
+			_, err = git.Run(ctx, "update-ref", branch, sha)
+			if err == nil {
+				_, err = git.Run(ctx, "symbolic-ref", "HEAD", branch)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", branch, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dir := NewDirectory(nil, "/", query.Platform(), nil)
+	snap, err := bkref.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bkref = nil
+	dir.Result = snap
+	return dir, nil
+}
+
+// fetchCommit fetches a commit of the repository, without its history.
+func (repo *GitRepository) fetchCommit(ctx context.Context, git *gitutil.GitCLI, ref *gitutil.Ref) error {
+	backend, err := repo.Backend.Get(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return backend.mount(ctx, 1, func(src *gitutil.GitCLI) error {
+		srcURL, err := src.URL(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := git.Run(ctx, "fetch", "--no-tags", "--depth=1", srcURL, ref.SHA); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", ref.SHA, err)
+		}
+		return nil
+	})
+}
+
+func writeGitPathspec(dir, name string, paths []string) (string, error) {
+	pathspec := filepath.Join(dir, name)
+	if err := os.WriteFile(pathspec, []byte(strings.Join(paths, "\x00")), 0o600); err != nil {
+		return "", err
+	}
+	return pathspec, nil
+}
+
+// GitPushOptions configures GitRef.Push.
+type GitPushOptions struct {
+	// Ref is the ref to update on the remote, like "refs/heads/fix" or
+	// "fix" for a branch. Defaults to the name of the pushed ref.
+	Ref string
+	// Force updates the remote ref even if it isn't an ancestor.
+	Force bool
+}
+
+// Push pushes the commit of the ref to a remote, authenticated like the
+// remote repository, and returns the updated remote ref.
+func (ref *GitRef) Push(ctx context.Context, remote *RemoteGitRepository, opts GitPushOptions) (string, error) {
+	dst := opts.Ref
+	if dst == "" {
+		if !strings.HasPrefix(ref.Ref.Name, "refs/") {
+			return "", fmt.Errorf("a ref to push %s to is required", ref.Ref.SHA)
+		}
+		dst = ref.Ref.Name
+	}
+	if !strings.HasPrefix(dst, "refs/") {
+		dst = "refs/heads/" + dst
+	}
+
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// push from a temporary repository, since the one of the ref may only be
+	// mounted for the engine
+	tmpDir, err := os.MkdirTemp("", "dagger-git-push")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	git := gitutil.NewGitCLI(gitutil.WithGitDir(tmpDir))
+	if _, err := git.Run(ctx, "init", "--bare", tmpDir); err != nil {
+		return "", fmt.Errorf("failed to init temp repo: %w", err)
+	}
+	err = ref.Backend.mount(ctx, 0, func(src *gitutil.GitCLI) error {
+		srcURL, err := src.URL(ctx)
+		if err != nil {
+			return err
+		}
+		if _, err := git.Run(ctx, "fetch", "--no-tags", srcURL, ref.Ref.SHA); err != nil {
+			return fmt.Errorf("failed to fetch %s: %w", ref.Ref.SHA, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	remoteGit, cleanup, err := remote.setup(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	svcs, err := query.Services(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get services: %w", err)
+	}
+	detach, _, err := svcs.StartBindings(ctx, remote.Services)
+	if err != nil {
+		return "", err
+	}
+	defer detach()
+
+	args := []string{"push"}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	args = append(args, remote.URL.String(), ref.Ref.SHA+":"+dst)
+	if _, err := remoteGit.New(gitutil.WithGitDir(tmpDir)).Run(ctx, args...); err != nil {
+		return "", fmt.Errorf("failed to push to %s: %w", remote.URL.Remote(), err)
+	}
+	return dst, nil
+}
@@ -1,13 +1,23 @@
 package core
 
 import (
+	"bytes"
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
 
 	"dagger.io/dagger/telemetry"
 	"github.com/dagger/dagger/engine/buildkit"
@@ -86,3 +96,295 @@ func (d *portHealthChecker) Check(ctx context.Context) (rerr error) {
 
 	return nil
 }
+
+// Healthcheck is a readiness check of a container started as a service. The
+// service is not considered started until the check passes.
+//
+// Exactly one of Args, HTTPPort and LogPattern is set.
+type Healthcheck struct {
+	// Command run in the service container, ready when it exits zero.
+	Args []string
+
+	// Port to send an HTTP GET request to, ready when it responds with
+	// HTTPStatus.
+	HTTPPort   int
+	HTTPPath   string
+	HTTPStatus int
+
+	// Regular expression matched against the lines of the service stdout,
+	// ready once a line matches.
+	LogPattern string
+
+	// Time between checks.
+	Interval time.Duration
+	// Time after which a single check fails.
+	Timeout time.Duration
+	// Initialization time during which failed checks are not counted.
+	StartPeriod time.Duration
+	// Time between checks until the service is healthy, e.g. shorter than
+	// Interval to start quickly. Defaults to Interval.
+	StartInterval time.Duration
+	// Number of consecutive failed checks after which the service fails to
+	// start.
+	Retries int
+}
+
+// Defaults of the HEALTHCHECK Dockerfile instruction.
+const (
+	defaultImageHealthcheckInterval      = 30 * time.Second
+	defaultImageHealthcheckTimeout       = 30 * time.Second
+	defaultImageHealthcheckStartInterval = 5 * time.Second
+	defaultImageHealthcheckRetries       = 3
+)
+
+// imageHealthcheck returns the healthcheck of an image config, as set by the
+// HEALTHCHECK Dockerfile instruction, or nil if it has none.
+func imageHealthcheck(cfgBytes []byte) (*Healthcheck, error) {
+	var img dockerspec.DockerOCIImage
+	if err := json.Unmarshal(cfgBytes, &img); err != nil {
+		return nil, err
+	}
+	cfg := img.Config.Healthcheck
+	if cfg == nil || len(cfg.Test) == 0 {
+		return nil, nil
+	}
+
+	hc := &Healthcheck{
+		Interval:      cmp.Or(cfg.Interval, defaultImageHealthcheckInterval),
+		Timeout:       cmp.Or(cfg.Timeout, defaultImageHealthcheckTimeout),
+		StartPeriod:   cfg.StartPeriod,
+		StartInterval: cmp.Or(cfg.StartInterval, defaultImageHealthcheckStartInterval),
+		Retries:       cmp.Or(cfg.Retries, defaultImageHealthcheckRetries),
+	}
+	switch cfg.Test[0] {
+	case "NONE":
+		return nil, nil
+	case "CMD":
+		hc.Args = cfg.Test[1:]
+	case "CMD-SHELL":
+		hc.Args = []string{"/bin/sh", "-c", strings.Join(cfg.Test[1:], " ")}
+	default:
+		return nil, fmt.Errorf("unsupported image healthcheck test %q", cfg.Test[0])
+	}
+	if len(hc.Args) == 0 {
+		return nil, fmt.Errorf("image healthcheck has an empty command")
+	}
+	return hc, nil
+}
+
+// setImageHealthcheck records the healthcheck of the image config of the
+// container, which is only used once opted into with WithImageHealthcheck.
+func (container *Container) setImageHealthcheck(cfgBytes []byte) error {
+	hc, err := imageHealthcheck(cfgBytes)
+	if err != nil {
+		return err
+	}
+	container.ImageHealthcheck = hc
+	return nil
+}
+
+func (hc *Healthcheck) String() string {
+	switch {
+	case hc.HTTPPort != 0:
+		return fmt.Sprintf("GET :%d%s", hc.HTTPPort, hc.HTTPPath)
+	case hc.LogPattern != "":
+		return fmt.Sprintf("stdout =~ /%s/", hc.LogPattern)
+	default:
+		return strings.Join(hc.Args, " ")
+	}
+}
+
+// serviceHealthChecker runs the healthcheck of a service until it passes or
+// fails too many times in a row.
+type serviceHealthChecker struct {
+	hc   *Healthcheck
+	bk   *buildkit.Client
+	ns   buildkit.Namespaced
+	host string
+
+	// exec runs a command in the service container
+	exec func(ctx context.Context, args []string, stdout, stderr io.Writer) error
+	// logs matches the service stdout against the log pattern
+	logs *logPatternWriter
+}
+
+func (d *serviceHealthChecker) Check(ctx context.Context) (rerr error) {
+	// always show health checks
+	ctx, span := Tracer(ctx).Start(ctx, "healthcheck "+d.hc.String())
+	defer telemetry.EndWithCause(span, &rerr)
+
+	slog := slog.SpanLogger(ctx, InstrumentationLibrary).With("host", d.host)
+
+	start := time.Now()
+	var failures int
+	for {
+		checkCtx, cancel := context.WithTimeoutCause(ctx, d.hc.Timeout,
+			fmt.Errorf("healthcheck timed out after %s", d.hc.Timeout))
+		err := d.check(checkCtx)
+		cancel()
+		if err == nil {
+			slog.Info("service is healthy", "elapsed", time.Since(start))
+			return nil
+		}
+		if ctx.Err() != nil {
+			return context.Cause(ctx)
+		}
+
+		// the service is checked until it's healthy, so all retries are at the
+		// start interval
+		interval := cmp.Or(d.hc.StartInterval, d.hc.Interval)
+		if time.Since(start) < d.hc.StartPeriod {
+			// failures during the start period don't count
+			slog.Warn("service not ready", "error", err, "elapsed", time.Since(start))
+		} else {
+			failures++
+			slog.Warn("service not ready", "error", err, "failures", failures)
+			if failures >= d.hc.Retries {
+				return fmt.Errorf("healthcheck failed %d times: %w", failures, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(interval):
+		}
+	}
+}
+
+func (d *serviceHealthChecker) check(ctx context.Context) error {
+	switch {
+	case d.hc.HTTPPort != 0:
+		return d.checkHTTP(ctx)
+	case d.hc.LogPattern != "":
+		if !d.logs.Matched() {
+			return fmt.Errorf("no line of stdout matches %q", d.hc.LogPattern)
+		}
+		return nil
+	default:
+		stdout := new(strings.Builder)
+		stderr := new(strings.Builder)
+		if err := d.exec(ctx, d.hc.Args, stdout, stderr); err != nil {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			if out := strings.TrimSpace(stderr.String() + stdout.String()); out != "" {
+				return fmt.Errorf("%w: %s", err, out)
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+func (d *serviceHealthChecker) checkHTTP(ctx context.Context) error {
+	addr := net.JoinHostPort(d.host, strconv.Itoa(d.hc.HTTPPort))
+	dialer := net.Dialer{}
+	// dial in the network namespace, then speak HTTP over the connection
+	conn, err := buildkit.RunInNetNS(ctx, d.bk, d.ns, func() (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", addr)
+	})
+	if err != nil {
+		return err
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(context.Context, string, string) (net.Conn, error) {
+				return conn, nil
+			},
+			DisableKeepAlives: true,
+		},
+		// a redirect would need another connection
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	defer client.CloseIdleConnections()
+
+	u := url.URL{Scheme: "http", Host: addr, Path: d.hc.HTTPPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != d.hc.HTTPStatus {
+		return fmt.Errorf("GET %s: expected status %d, got %d", u.Path, d.hc.HTTPStatus, resp.StatusCode)
+	}
+	return nil
+}
+
+// maxLogPatternLine is the most bytes of a line kept to match it against a log
+// pattern; only the end of longer lines is matched.
+const maxLogPatternLine = 64 * 1024
+
+// logPatternWriter matches the lines written to it against a pattern, until
+// one matches.
+type logPatternWriter struct {
+	re *regexp.Regexp
+
+	mu      sync.Mutex
+	line    []byte
+	matched bool
+}
+
+func newLogPatternWriter(pattern string) (*logPatternWriter, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log pattern: %w", err)
+	}
+	return &logPatternWriter{re: re}, nil
+}
+
+func (w *logPatternWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.matched {
+		return len(p), nil
+	}
+	w.line = append(w.line, p...)
+	for {
+		i := bytes.IndexByte(w.line, '\n')
+		if i < 0 {
+			break
+		}
+		if w.re.Match(w.line[:i]) {
+			w.matched = true
+			w.line = nil
+			return len(p), nil
+		}
+		w.line = w.line[i+1:]
+	}
+	if len(w.line) > maxLogPatternLine {
+		// copy so that the dropped start of the line can be freed
+		w.line = append([]byte(nil), w.line[len(w.line)-maxLogPatternLine:]...)
+	}
+	return len(p), nil
+}
+
+func (w *logPatternWriter) Close() error {
+	return nil
+}
+
+// Matched returns whether a line matched, including an unterminated last
+// line.
+func (w *logPatternWriter) Matched() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.matched && len(w.line) > 0 && w.re.Match(w.line) {
+		w.matched = true
+		w.line = nil
+	}
+	return w.matched
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
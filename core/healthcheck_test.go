@@ -0,0 +1,209 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageHealthcheck(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config string
+		expect *Healthcheck
+	}{
+		{
+			name:   "none set",
+			config: `{"config":{}}`,
+		},
+		{
+			name:   "disabled",
+			config: `{"config":{"Healthcheck":{"Test":["NONE"]}}}`,
+		},
+		{
+			name:   "cmd with defaults",
+			config: `{"config":{"Healthcheck":{"Test":["CMD","pg_isready","-U","postgres"]}}}`,
+			expect: &Healthcheck{
+				Args:          []string{"pg_isready", "-U", "postgres"},
+				Interval:      30 * time.Second,
+				Timeout:       30 * time.Second,
+				StartInterval: 5 * time.Second,
+				Retries:       3,
+			},
+		},
+		{
+			name: "shell",
+			config: `{"config":{"Healthcheck":{
+				"Test":["CMD-SHELL","curl -f http://localhost/ || exit 1"],
+				"Interval":2000000000,"Timeout":1000000000,"StartPeriod":10000000000,"Retries":5
+			}}}`,
+			expect: &Healthcheck{
+				Args:          []string{"/bin/sh", "-c", "curl -f http://localhost/ || exit 1"},
+				Interval:      2 * time.Second,
+				Timeout:       time.Second,
+				StartPeriod:   10 * time.Second,
+				StartInterval: 5 * time.Second,
+				Retries:       5,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			hc, err := imageHealthcheck([]byte(tc.config))
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, hc)
+		})
+	}
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := imageHealthcheck([]byte(`{"config":{"Healthcheck":{"Test":["BOGUS"]}}}`))
+		require.ErrorContains(t, err, "unsupported image healthcheck test")
+	})
+}
+
+func TestLogPatternWriter(t *testing.T) {
+	w, err := newLogPatternWriter(`listening on .*:(\d+)$`)
+	require.NoError(t, err)
+
+	_, err = io.WriteString(w, "starting\nlistening on 0.0.0.0:")
+	require.NoError(t, err)
+	require.False(t, w.Matched())
+
+	// lines are matched once terminated, or when checked
+	_, err = io.WriteString(w, "80\nserving\n")
+	require.NoError(t, err)
+	require.True(t, w.Matched())
+
+	w, err = newLogPatternWriter(`ready$`)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "not yet\nready")
+	require.NoError(t, err)
+	require.True(t, w.Matched())
+
+	w, err = newLogPatternWriter(`^ready$`)
+	require.NoError(t, err)
+	_, err = io.WriteString(w, "not ready\nrea")
+	require.NoError(t, err)
+	require.False(t, w.Matched())
+	_, err = io.WriteString(w, "dy")
+	require.NoError(t, err)
+	require.True(t, w.Matched())
+
+	// the buffered line is dropped once matched
+	require.Nil(t, w.line)
+	_, err = io.WriteString(w, "more output")
+	require.NoError(t, err)
+	require.Nil(t, w.line)
+
+	// output without newlines only keeps the end of the line
+	w, err = newLogPatternWriter(`ready$`)
+	require.NoError(t, err)
+	chunk := strings.Repeat("x", 4096)
+	for range 2 * maxLogPatternLine / len(chunk) {
+		_, err = io.WriteString(w, chunk)
+		require.NoError(t, err)
+	}
+	require.Len(t, w.line, maxLogPatternLine)
+	require.False(t, w.Matched())
+	_, err = io.WriteString(w, " ready")
+	require.NoError(t, err)
+	require.True(t, w.Matched())
+
+	_, err = newLogPatternWriter(`(`)
+	require.ErrorContains(t, err, "invalid log pattern")
+}
+
+func TestServiceHealthChecker(t *testing.T) {
+	ctx := context.Background()
+
+	newChecker := func(hc *Healthcheck, results ...error) (*serviceHealthChecker, *int) {
+		var calls int
+		return &serviceHealthChecker{
+			hc: hc,
+			exec: func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+				calls++
+				if len(results) == 0 {
+					return nil
+				}
+				err := results[0]
+				results = results[1:]
+				if err != nil {
+					io.WriteString(stderr, "not yet")
+				}
+				return err
+			},
+		}, &calls
+	}
+
+	t.Run("retries", func(t *testing.T) {
+		fail := errors.New("exit code: 1")
+		checker, calls := newChecker(&Healthcheck{
+			Args:     []string{"true"},
+			Interval: time.Millisecond,
+			Timeout:  time.Second,
+			Retries:  3,
+		}, fail, fail, nil)
+		require.NoError(t, checker.Check(ctx))
+		require.Equal(t, 3, *calls)
+
+		checker, calls = newChecker(&Healthcheck{
+			Args:     []string{"true"},
+			Interval: time.Millisecond,
+			Timeout:  time.Second,
+			Retries:  3,
+		}, fail, fail, fail, nil)
+		err := checker.Check(ctx)
+		require.ErrorContains(t, err, "healthcheck failed 3 times: exit code: 1: not yet")
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("start period", func(t *testing.T) {
+		fail := errors.New("exit code: 1")
+		checker, calls := newChecker(&Healthcheck{
+			Args:          []string{"true"},
+			Interval:      time.Millisecond,
+			Timeout:       time.Second,
+			StartPeriod:   time.Minute,
+			StartInterval: time.Millisecond,
+			Retries:       1,
+		}, fail, fail, fail, fail, nil)
+		require.NoError(t, checker.Check(ctx))
+		require.Equal(t, 5, *calls)
+	})
+
+	t.Run("start interval", func(t *testing.T) {
+		fail := errors.New("exit code: 1")
+		// retries until healthy are at the start interval, not the interval
+		checker, calls := newChecker(&Healthcheck{
+			Args:          []string{"true"},
+			Interval:      time.Hour,
+			Timeout:       time.Second,
+			StartInterval: time.Millisecond,
+			Retries:       3,
+		}, fail, fail, nil)
+		require.NoError(t, checker.Check(ctx))
+		require.Equal(t, 3, *calls)
+	})
+
+	t.Run("log pattern", func(t *testing.T) {
+		logs, err := newLogPatternWriter(`^ready$`)
+		require.NoError(t, err)
+		checker := &serviceHealthChecker{
+			hc: &Healthcheck{
+				LogPattern: `^ready$`,
+				Interval:   time.Millisecond,
+				Timeout:    time.Second,
+				Retries:    2,
+			},
+			logs: logs,
+		}
+		require.ErrorContains(t, checker.Check(ctx), `no line of stdout matches "^ready$"`)
+
+		io.WriteString(logs, "ready\n")
+		require.NoError(t, checker.Check(ctx))
+	})
+}
@@ -0,0 +1,490 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Image signatures are compatible with cosign key-based signatures: a
+// "simple signing" payload naming the image digest, signed with the key and
+// attached to the image both as an OCI referrer and with the cosign tag
+// scheme, which cosign looks up by default.
+const (
+	// The artifact type of signatures attached to an image as referrers
+	cosignSignatureArtifactType = "application/vnd.dev.cosign.artifact.sig.v1+json"
+	// The media type of signed payloads
+	cosignSimpleSigningMediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	// The annotation of a payload layer holding its base64 encoded signature
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	// The type of the signed payloads
+	cosignSignatureType = "cosign container image signature"
+
+	// The maximum size of a signed payload
+	maxSignaturePayloadSize = 1 << 20
+)
+
+type cosignPayload struct {
+	Critical cosignCritical `json:"critical"`
+	Optional map[string]any `json:"optional"`
+}
+
+type cosignCritical struct {
+	Identity struct {
+		DockerReference string `json:"docker-reference"`
+	} `json:"identity"`
+	Image struct {
+		DockerManifestDigest string `json:"docker-manifest-digest"`
+	} `json:"image"`
+	Type string `json:"type"`
+}
+
+// ParseImageSigningKey parses a PEM encoded private key to sign images with,
+// either a password-encrypted cosign key or an unencrypted PKCS#8, EC or
+// PKCS#1 key.
+func ParseImageSigningKey(data []byte, password []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("signing key is not PEM encoded")
+	}
+	var key any
+	var err error
+	switch block.Type {
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		der, derr := decryptCosignKey(block.Bytes, password)
+		if derr != nil {
+			return nil, derr
+		}
+		key, err = x509.ParsePKCS8PrivateKey(der)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse signing key: %w", err)
+	}
+	switch key := key.(type) {
+	case *ecdsa.PrivateKey:
+		return key, nil
+	case *rsa.PrivateKey:
+		return key, nil
+	case ed25519.PrivateKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing key %T", key)
+	}
+}
+
+// ParseImageVerifyingKey parses a PEM encoded public key to verify image
+// signatures with.
+func ParseImageVerifyingKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("public key is not PEM encoded")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported public key type %q", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key %T", key)
+	}
+}
+
+// encryptedCosignKey is the format of password-encrypted cosign keys.
+type encryptedCosignKey struct {
+	KDF struct {
+		Name   string `json:"name"`
+		Params struct {
+			N int `json:"N"`
+			R int `json:"r"`
+			P int `json:"p"`
+		} `json:"params"`
+		Salt []byte `json:"salt"`
+	} `json:"kdf"`
+	Cipher struct {
+		Name  string `json:"name"`
+		Nonce []byte `json:"nonce"`
+	} `json:"cipher"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+func decryptCosignKey(data []byte, password []byte) ([]byte, error) {
+	var enc encryptedCosignKey
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return nil, fmt.Errorf("decode encrypted signing key: %w", err)
+	}
+	if enc.KDF.Name != "scrypt" {
+		return nil, fmt.Errorf("unsupported signing key KDF %q", enc.KDF.Name)
+	}
+	if enc.Cipher.Name != "nacl/secretbox" {
+		return nil, fmt.Errorf("unsupported signing key cipher %q", enc.Cipher.Name)
+	}
+	if len(enc.Cipher.Nonce) != 24 {
+		return nil, errors.New("invalid signing key nonce")
+	}
+	derived, err := scrypt.Key(password, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	if err != nil {
+		return nil, fmt.Errorf("derive signing key password: %w", err)
+	}
+	var key [32]byte
+	copy(key[:], derived)
+	var nonce [24]byte
+	copy(nonce[:], enc.Cipher.Nonce)
+	der, ok := secretbox.Open(nil, enc.Ciphertext, &nonce, &key)
+	if !ok {
+		return nil, errors.New("decrypt signing key: invalid password")
+	}
+	return der, nil
+}
+
+func signImagePayload(signer crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := signer.(ed25519.PrivateKey); ok {
+		return signer.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	sum := sha256.Sum256(payload)
+	return signer.Sign(rand.Reader, sum[:], crypto.SHA256)
+}
+
+func verifyImagePayload(key crypto.PublicKey, payload, sig []byte) error {
+	sum := sha256.Sum256(payload)
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, sum[:], sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, payload, sig) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key %T", key)
+	}
+}
+
+// SignImageRef signs the image at ref, an address with digest, using the
+// registry credentials of the client.
+func SignImageRef(ctx context.Context, ref string, signer crypto.Signer) error {
+	dgst, opts, err := imageDigestRemote(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return signImage(dgst, signer, opts...)
+}
+
+// VerifyImageRef verifies the signature of the image at ref, an address with
+// digest, using the registry credentials of the client.
+func VerifyImageRef(ctx context.Context, ref string, key crypto.PublicKey) error {
+	dgst, opts, err := imageDigestRemote(ctx, ref)
+	if err != nil {
+		return err
+	}
+	return verifyImage(dgst, key, opts...)
+}
+
+func imageDigestRemote(ctx context.Context, ref string) (name.Digest, []remote.Option, error) {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return name.Digest{}, nil, err
+	}
+	bk, err := query.Buildkit(ctx)
+	if err != nil {
+		return name.Digest{}, nil, fmt.Errorf("failed to get buildkit client: %w", err)
+	}
+	parsed, opts, err := bk.RegistryRemote(ctx, ref)
+	if err != nil {
+		return name.Digest{}, nil, err
+	}
+	dgst, ok := parsed.(name.Digest)
+	if !ok {
+		return name.Digest{}, nil, fmt.Errorf("image address %q has no digest", ref)
+	}
+	return dgst, opts, nil
+}
+
+// signImage signs the image manifest referenced by dgst, and attaches the
+// signature to it as an OCI referrer and with the cosign tag scheme.
+func signImage(dgst name.Digest, signer crypto.Signer, opts ...remote.Option) error {
+	subject, err := remote.Head(dgst, opts...)
+	if err != nil {
+		return fmt.Errorf("get %s: %w", dgst, err)
+	}
+
+	var payload cosignPayload
+	payload.Critical.Identity.DockerReference = dgst.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = dgst.DigestStr()
+	payload.Critical.Type = cosignSignatureType
+	payloadData, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	sig, err := signImagePayload(signer, payloadData)
+	if err != nil {
+		return fmt.Errorf("sign %s: %w", dgst, err)
+	}
+
+	payloadLayer := static.NewLayer(payloadData, cosignSimpleSigningMediaType)
+	configLayer := static.NewLayer([]byte("{}"), cosignSignatureArtifactType)
+	for _, layer := range []v1.Layer{payloadLayer, configLayer} {
+		if err := remote.WriteLayer(dgst.Context(), layer, opts...); err != nil {
+			return fmt.Errorf("push signature: %w", err)
+		}
+	}
+	payloadDesc, err := layerDescriptor(payloadLayer)
+	if err != nil {
+		return err
+	}
+	payloadDesc.Annotations = map[string]string{
+		cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+	}
+	configDesc, err := layerDescriptor(configLayer)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := json.Marshal(v1.Manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		Config:        configDesc,
+		Layers:        []v1.Descriptor{payloadDesc},
+		Subject: &v1.Descriptor{
+			MediaType: subject.MediaType,
+			Size:      subject.Size,
+			Digest:    subject.Digest,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	manifestDigest, _, err := v1.SHA256(bytes.NewReader(manifest))
+	if err != nil {
+		return err
+	}
+	if err := remote.Put(dgst.Context().Digest(manifestDigest.String()), rawManifest{manifest, types.OCIManifestSchema1}, opts...); err != nil {
+		return fmt.Errorf("push signature: %w", err)
+	}
+
+	// add the signature to the ones pushed to the tag, like cosign does
+	tag := signatureTag(dgst)
+	sigImg, err := remote.Image(tag, opts...)
+	switch {
+	case isNotFound(err):
+		sigImg = mutate.ConfigMediaType(mutate.MediaType(empty.Image, types.OCIManifestSchema1), types.OCIConfigJSON)
+	case err != nil:
+		return fmt.Errorf("get signatures of %s: %w", dgst, err)
+	}
+	sigImg, err = mutate.Append(sigImg, mutate.Addendum{
+		Layer:       payloadLayer,
+		Annotations: payloadDesc.Annotations,
+	})
+	if err != nil {
+		return err
+	}
+	if err := remote.Write(tag, sigImg, opts...); err != nil {
+		return fmt.Errorf("push signature: %w", err)
+	}
+	return nil
+}
+
+// signatureTag returns the tag cosign pushes the signatures of an image to,
+// i.e. "sha256-<hex>.sig".
+func signatureTag(dgst name.Digest) name.Tag {
+	return dgst.Context().Tag(strings.Replace(dgst.DigestStr(), ":", "-", 1) + ".sig")
+}
+
+func isNotFound(err error) bool {
+	var terr *transport.Error
+	return errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound
+}
+
+// verifyImage checks that the image manifest referenced by dgst has a
+// signature verified by the public key, attached either as an OCI referrer or
+// with the cosign tag scheme.
+func verifyImage(dgst name.Digest, key crypto.PublicKey, opts ...remote.Option) error {
+	sigs, err := imageSignatures(dgst, opts...)
+	if err != nil {
+		return fmt.Errorf("get signatures of %s: %w", dgst, err)
+	}
+	if len(sigs) == 0 {
+		return fmt.Errorf("image %s is not signed", dgst)
+	}
+	var errs []error
+	for _, sig := range sigs {
+		err := sig.verify(key, dgst.DigestStr())
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("no signature of image %s is verified by the public key: %w", dgst, errors.Join(errs...))
+}
+
+type imageSignature struct {
+	payload   []byte
+	signature string
+}
+
+func (sig imageSignature) verify(key crypto.PublicKey, dgst string) error {
+	rawSig, err := base64.StdEncoding.DecodeString(sig.signature)
+	if err != nil {
+		return fmt.Errorf("decode signature: %w", err)
+	}
+	if err := verifyImagePayload(key, sig.payload, rawSig); err != nil {
+		return err
+	}
+	var payload cosignPayload
+	if err := json.Unmarshal(sig.payload, &payload); err != nil {
+		return fmt.Errorf("decode signed payload: %w", err)
+	}
+	if payload.Critical.Type != cosignSignatureType {
+		return fmt.Errorf("unsupported signed payload type %q", payload.Critical.Type)
+	}
+	if payload.Critical.Image.DockerManifestDigest != dgst {
+		return fmt.Errorf("signed digest %s does not match", payload.Critical.Image.DockerManifestDigest)
+	}
+	return nil
+}
+
+// imageSignatures returns the signatures of an image, attached as referrers
+// or pushed to the "sha256-<hex>.sig" tag by cosign.
+func imageSignatures(dgst name.Digest, opts ...remote.Option) ([]imageSignature, error) {
+	var sigs []imageSignature
+
+	referrers, err := remote.Referrers(dgst, opts...)
+	if err != nil {
+		return nil, err
+	}
+	index, err := referrers.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	for _, desc := range index.Manifests {
+		if desc.ArtifactType != cosignSignatureArtifactType {
+			continue
+		}
+		img, err := remote.Image(dgst.Context().Digest(desc.Digest.String()), opts...)
+		if err != nil {
+			return nil, err
+		}
+		imgSigs, err := signaturesOfImage(img)
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, imgSigs...)
+	}
+
+	img, err := remote.Image(signatureTag(dgst), opts...)
+	if err != nil {
+		if isNotFound(err) {
+			return sigs, nil
+		}
+		return nil, err
+	}
+	imgSigs, err := signaturesOfImage(img)
+	if err != nil {
+		return nil, err
+	}
+	return append(sigs, imgSigs...), nil
+}
+
+func signaturesOfImage(img v1.Image) ([]imageSignature, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	var sigs []imageSignature
+	for _, desc := range manifest.Layers {
+		sig, ok := desc.Annotations[cosignSignatureAnnotation]
+		if !ok || desc.MediaType != cosignSimpleSigningMediaType {
+			continue
+		}
+		if desc.Size > maxSignaturePayloadSize {
+			return nil, fmt.Errorf("signed payload %s too large: %d bytes", desc.Digest, desc.Size)
+		}
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		payload, err := io.ReadAll(io.LimitReader(rc, maxSignaturePayloadSize))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, imageSignature{payload: payload, signature: sig})
+	}
+	return sigs, nil
+}
+
+func layerDescriptor(layer v1.Layer) (v1.Descriptor, error) {
+	mediaType, err := layer.MediaType()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	dgst, err := layer.Digest()
+	if err != nil {
+		return v1.Descriptor{}, err
+	}
+	return v1.Descriptor{MediaType: mediaType, Size: size, Digest: dgst}, nil
+}
+
+// rawManifest is a manifest pushed as-is.
+type rawManifest struct {
+	data      []byte
+	mediaType types.MediaType
+}
+
+func (m rawManifest) RawManifest() ([]byte, error) {
+	return m.data, nil
+}
+
+func (m rawManifest) MediaType() (types.MediaType, error) {
+	return m.mediaType, nil
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+func TestParseImageSigningKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	require.NoError(t, err)
+
+	t.Run("pkcs8", func(t *testing.T) {
+		signer, err := ParseImageSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil)
+		require.NoError(t, err)
+		require.True(t, ecKey.Equal(signer))
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		keyPEM := encryptCosignKey(t, der, []byte("hunter2"))
+		signer, err := ParseImageSigningKey(keyPEM, []byte("hunter2"))
+		require.NoError(t, err)
+		require.True(t, ecKey.Equal(signer))
+
+		_, err = ParseImageSigningKey(keyPEM, []byte("wrong"))
+		require.ErrorContains(t, err, "invalid password")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseImageSigningKey([]byte("not a key"), nil)
+		require.ErrorContains(t, err, "not PEM encoded")
+
+		_, err = ParseImageSigningKey(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), nil)
+		require.ErrorContains(t, err, "unsupported signing key type")
+	})
+}
+
+func TestImageSignature(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name      string
+		referrers bool
+	}{
+		{"referrers API", true},
+		{"referrers tag schema", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0)), registry.WithReferrersSupport(tc.referrers)))
+			defer srv.Close()
+			u, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			for _, key := range []crypto.Signer{ecKey, rsaKey, edKey} {
+				dgst := pushRandomImage(t, u.Host+"/test/signed")
+
+				err := verifyImage(dgst, key.Public())
+				require.ErrorContains(t, err, "is not signed")
+
+				require.NoError(t, signImage(dgst, key))
+				require.NoError(t, verifyImage(dgst, parsePublicKey(t, key.Public())))
+
+				err = verifyImage(dgst, otherKey.Public())
+				require.ErrorContains(t, err, "is verified by the public key")
+
+				// the signature does not apply to other images
+				other := pushRandomImage(t, u.Host+"/test/signed")
+				err = verifyImage(other, key.Public())
+				require.ErrorContains(t, err, "is not signed")
+			}
+		})
+	}
+}
+
+func TestImageSignatureTagScheme(t *testing.T) {
+	srv := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	defer srv.Close()
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	dgst := pushRandomImage(t, u.Host+"/test/cosign")
+
+	// sign the way cosign does by default, pushing to the .sig tag
+	var payload cosignPayload
+	payload.Critical.Identity.DockerReference = dgst.Context().Name()
+	payload.Critical.Image.DockerManifestDigest = dgst.DigestStr()
+	payload.Critical.Type = cosignSignatureType
+	payloadData, err := json.Marshal(payload)
+	require.NoError(t, err)
+	sig, err := signImagePayload(key, payloadData)
+	require.NoError(t, err)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: static.NewLayer(payloadData, cosignSimpleSigningMediaType),
+		Annotations: map[string]string{
+			cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig),
+		},
+	})
+	require.NoError(t, err)
+	tag := dgst.Context().Tag(strings.Replace(dgst.DigestStr(), ":", "-", 1) + ".sig")
+	require.NoError(t, remote.Write(tag, img))
+
+	require.NoError(t, verifyImage(dgst, key.Public()))
+
+	// signing adds to the signatures cosign pushed to the tag
+	require.NoError(t, signImage(dgst, key))
+	img, err = remote.Image(tag)
+	require.NoError(t, err)
+	sigs, err := signaturesOfImage(img)
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+	for _, sig := range sigs {
+		require.NoError(t, sig.verify(key.Public(), dgst.DigestStr()))
+	}
+}
+
+func pushRandomImage(t *testing.T, repo string) name.Digest {
+	t.Helper()
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	img = mutate.MediaType(img, types.OCIManifestSchema1)
+	ref, err := name.NewTag(repo + ":latest")
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+	dgst, err := img.Digest()
+	require.NoError(t, err)
+	return ref.Context().Digest(dgst.String())
+}
+
+func parsePublicKey(t *testing.T, pub crypto.PublicKey) crypto.PublicKey {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	require.NoError(t, err)
+	key, err := ParseImageVerifyingKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	return key
+}
+
+// encryptCosignKey encrypts a key the way "cosign generate-key-pair" does.
+func encryptCosignKey(t *testing.T, der, password []byte) []byte {
+	t.Helper()
+	var enc encryptedCosignKey
+	enc.KDF.Name = "scrypt"
+	enc.KDF.Params.N = 1 << 10
+	enc.KDF.Params.R = 8
+	enc.KDF.Params.P = 1
+	enc.KDF.Salt = make([]byte, 32)
+	_, err := rand.Read(enc.KDF.Salt)
+	require.NoError(t, err)
+	derived, err := scrypt.Key(password, enc.KDF.Salt, enc.KDF.Params.N, enc.KDF.Params.R, enc.KDF.Params.P, 32)
+	require.NoError(t, err)
+	var key [32]byte
+	copy(key[:], derived)
+	var nonce [24]byte
+	_, err = rand.Read(nonce[:])
+	require.NoError(t, err)
+	enc.Cipher.Name = "nacl/secretbox"
+	enc.Cipher.Nonce = nonce[:]
+	enc.Ciphertext = secretbox.Seal(nil, der, &nonce, &key)
+	data, err := json.Marshal(enc)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: data})
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"path/filepath"
 	"testing"
 
@@ -191,3 +192,172 @@ func (ChecksSuite) TestChecksAsToolchain(ctx context.Context, t *testctx.T) {
 		})
 	}
 }
+
+func (ChecksSuite) TestChecksReportFile(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+	modGen, err := checksTestEnv(t, c)
+	require.NoError(t, err)
+	modGen = modGen.WithWorkdir("hello-with-checks")
+
+	t.Run("json", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerExecFail("check", "--report-file=report.json", "passing-check", "failing-check")).
+			File("report.json").
+			Contents(ctx)
+		require.NoError(t, err)
+
+		var report struct {
+			Checks []struct {
+				Name       string
+				Status     string
+				DurationMs int64
+				Error      string
+				TraceID    string
+				SpanID     string
+			}
+			Summary struct {
+				Total, Passed, Failed int
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		require.Equal(t, 2, report.Summary.Total)
+		require.Equal(t, 1, report.Summary.Passed)
+		require.Equal(t, 1, report.Summary.Failed)
+		for _, check := range report.Checks {
+			require.NotEmpty(t, check.TraceID)
+			require.NotEmpty(t, check.SpanID)
+			switch check.Name {
+			case "passingCheck":
+				require.Equal(t, "passed", check.Status)
+			case "failingCheck":
+				require.Equal(t, "failed", check.Status)
+				require.Contains(t, check.Error, "exit code: 1")
+			}
+		}
+	})
+
+	t.Run("junit", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerExecFail("check", "--report-file=report.xml", "failing-check")).
+			File("report.xml").
+			Contents(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, `<testsuites name="dagger check" tests="1" failures="1"`)
+		require.Contains(t, out, `<failure message=`)
+	})
+
+	t.Run("sarif", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerExec("check", "--report-file=out.txt", "--report-format=sarif", "passing-check")).
+			File("out.txt").
+			Contents(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, `"version": "2.1.0"`)
+		require.Contains(t, out, `"kind": "pass"`)
+	})
+}
+
+func (ChecksSuite) TestChecksRunPolicies(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+	modGen, err := checksTestEnv(t, c)
+	require.NoError(t, err)
+	modGen = modGen.WithWorkdir("hello-with-checks")
+
+	t.Run("retries", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			With(daggerExecFail("check", "--retries=2", "--report-file=report.json", "passing-check", "failing-check")).
+			File("report.json").
+			Contents(ctx)
+		require.NoError(t, err)
+
+		var report struct {
+			Checks []struct {
+				Name     string
+				Status   string
+				Attempts int
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		require.Len(t, report.Checks, 2)
+		for _, check := range report.Checks {
+			switch check.Name {
+			case "passingCheck":
+				require.Equal(t, "passed", check.Status)
+				require.Equal(t, 1, check.Attempts)
+			case "failingCheck":
+				require.Equal(t, "failed", check.Status)
+				require.Equal(t, 3, check.Attempts)
+			}
+		}
+	})
+
+	t.Run("fail fast", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			WithWorkdir("../fail-fast-checks").
+			With(daggerExecFail("check", "--fail-fast", "--report-file=report.json")).
+			File("report.json").
+			Contents(ctx)
+		require.NoError(t, err)
+
+		var report struct {
+			Checks []struct {
+				Name   string
+				Status string
+				Error  string
+			}
+			Summary struct {
+				Failed, Skipped int
+			}
+		}
+		require.NoError(t, json.Unmarshal([]byte(out), &report))
+		require.Len(t, report.Checks, 2)
+		require.Equal(t, 1, report.Summary.Failed)
+		require.Equal(t, 1, report.Summary.Skipped)
+		for _, check := range report.Checks {
+			switch check.Name {
+			case "failingCheck":
+				require.Equal(t, "failed", check.Status)
+			case "slowCheck":
+				// the sibling check is canceled, not failed
+				require.Equal(t, "skipped", check.Status)
+				require.Contains(t, check.Error, "canceled after check failingCheck failed")
+			}
+		}
+	})
+}
+
+func (ChecksSuite) TestChecksSince(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+	modGen, err := checksTestEnv(t, c)
+	require.NoError(t, err)
+	modGen = modGen.
+		WithExec([]string{"git", "add", "."}).
+		WithExec([]string{"git", "-c", "user.name=test", "-c", "user.email=test@dagger.io", "commit", "-m", "init"}).
+		WithWorkdir("hello-with-checks")
+
+	t.Run("unrelated changes", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			WithNewFile("/work/modules/app/notes.txt", "hello").
+			With(daggerExec("check", "--since=HEAD", "--explain", "--report-file=report.json")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Regexp(t, `failing-check\s+no\s+no changes in `, out)
+		require.Regexp(t, `passing-check\s+no\s+no changes in `, out)
+	})
+
+	t.Run("module changes", func(ctx context.Context, t *testctx.T) {
+		out, err := modGen.
+			WithExec([]string{"sh", "-c", "echo '// changed' >> main.go"}).
+			With(daggerExec("check", "--since=HEAD", "--explain", "passing-check")).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Regexp(t, `passing-check\s+yes\s+modules/hello-with-checks/main.go changed`, out)
+	})
+
+	t.Run("explain requires since", func(ctx context.Context, t *testctx.T) {
+		_, err := modGen.
+			With(daggerExec("check", "--explain")).
+			Sync(ctx)
+		requireErrOut(t, err, "--explain requires --since")
+	})
+}
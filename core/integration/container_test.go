@@ -3,12 +3,16 @@ package core
 import (
 	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/md5"
 	"crypto/rand"
+	"crypto/x509"
 	_ "embed"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"net"
 	"net/http"
@@ -2648,6 +2652,147 @@ func (ContainerSuite) TestPublish(ctx context.Context, t *testctx.T) {
 	require.Equal(t, "im-a-default-arg\n", output)
 }
 
+func (ContainerSuite) TestPublishSign(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	newKeyPair := func() (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		privDER, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		pubDER, err := x509.MarshalPKIXPublicKey(key.Public())
+		require.NoError(t, err)
+		return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})),
+			string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}))
+	}
+	privKey, pubKey := newKeyPair()
+	_, otherPubKey := newKeyPair()
+
+	signedRef, err := c.Container().From(alpineImage).
+		WithEnvVariable("SIGNED", identity.NewID()).
+		Publish(ctx, registryRef("container-publish-sign"), dagger.ContainerPublishOpts{
+			Sign: c.SetSecret("cosign-key", privKey),
+		})
+	require.NoError(t, err)
+
+	unsignedRef, err := c.Container().From(alpineImage).
+		WithEnvVariable("UNSIGNED", identity.NewID()).
+		Publish(ctx, registryRef("container-publish-sign"))
+	require.NoError(t, err)
+
+	t.Run("verified", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Container().From(signedRef, dagger.ContainerFromOpts{Verify: pubKey}).Sync(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("verified by tag", func(ctx context.Context, t *testctx.T) {
+		tag, _, _ := strings.Cut(signedRef, "@")
+		_, err := c.Container().From(tag, dagger.ContainerFromOpts{Verify: pubKey}).Sync(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong key", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Container().From(signedRef, dagger.ContainerFromOpts{Verify: otherPubKey}).Sync(ctx)
+		requireErrOut(t, err, "is verified by the public key")
+	})
+
+	t.Run("unsigned", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Container().From(unsignedRef, dagger.ContainerFromOpts{Verify: pubKey}).Sync(ctx)
+		requireErrOut(t, err, "is not signed")
+	})
+}
+
+func (ContainerSuite) TestAttestations(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	ctr := c.Container().From(alpineImage).
+		WithExec([]string{"apk", "add", "jq"})
+
+	t.Run("sbom", func(ctx context.Context, t *testctx.T) {
+		for _, format := range []dagger.SBOMFormat{dagger.SBOMFormatSpdx, dagger.SBOMFormatCyclonedx} {
+			atts, err := ctr.Attestations(ctx, dagger.ContainerAttestationsOpts{Sbom: format})
+			require.NoError(t, err)
+			require.Len(t, atts, 1)
+			predicate, err := atts[0].Predicate(ctx)
+			require.NoError(t, err)
+			require.Contains(t, string(predicate), "pkg:apk/alpine/musl@")
+			require.Contains(t, string(predicate), "pkg:apk/alpine/jq@")
+		}
+	})
+
+	t.Run("provenance", func(ctx context.Context, t *testctx.T) {
+		atts, err := ctr.WithSecretVariable("TOKEN", c.SetSecret("token", "hunter2")).
+			Attestations(ctx, dagger.ContainerAttestationsOpts{Provenance: true})
+		require.NoError(t, err)
+		require.Len(t, atts, 1)
+		predicateType, err := atts[0].PredicateType(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "https://slsa.dev/provenance/v1", predicateType)
+		predicate, err := atts[0].Predicate(ctx)
+		require.NoError(t, err)
+		require.Contains(t, string(predicate), `"field":"withExec"`)
+		require.Contains(t, string(predicate), `"uri":"pkg:docker/alpine@`)
+		require.NotContains(t, string(predicate), "hunter2")
+	})
+
+	t.Run("publish", func(ctx context.Context, t *testctx.T) {
+		pushedRef, err := ctr.Publish(ctx, registryRef("container-attestations"), dagger.ContainerPublishOpts{
+			Sbom:       dagger.SBOMFormatSpdx,
+			Provenance: true,
+		})
+		require.NoError(t, err)
+
+		parsedRef, err := name.ParseReference(pushedRef, name.Insecure)
+		require.NoError(t, err)
+		imgDesc, err := remote.Get(parsedRef, remote.WithTransport(http.DefaultTransport))
+		require.NoError(t, err)
+		idx, err := imgDesc.ImageIndex()
+		require.NoError(t, err)
+		idxManifest, err := idx.IndexManifest()
+		require.NoError(t, err)
+		require.Len(t, idxManifest.Manifests, 2)
+
+		attDesc := idxManifest.Manifests[1]
+		require.Equal(t, "attestation-manifest", attDesc.Annotations["vnd.docker.reference.type"])
+		require.Equal(t, idxManifest.Manifests[0].Digest.String(), attDesc.Annotations["vnd.docker.reference.digest"])
+		attImg, err := idx.Image(attDesc.Digest)
+		require.NoError(t, err)
+		attManifest, err := attImg.Manifest()
+		require.NoError(t, err)
+		var predicateTypes []string
+		for _, layer := range attManifest.Layers {
+			predicateTypes = append(predicateTypes, layer.Annotations["in-toto.io/predicate-type"])
+		}
+		require.ElementsMatch(t, []string{"https://spdx.dev/Document", "https://slsa.dev/provenance/v1"}, predicateTypes)
+	})
+
+	t.Run("export", func(ctx context.Context, t *testctx.T) {
+		imageTar := filepath.Join(t.TempDir(), "image.tar")
+		_, err := ctr.Export(ctx, imageTar, dagger.ContainerExportOpts{
+			Sbom: dagger.SBOMFormatCyclonedx,
+		})
+		require.NoError(t, err)
+
+		idxDt := readTarFile(t, imageTar, "index.json")
+		idx := ocispecs.Index{}
+		require.NoError(t, json.Unmarshal(idxDt, &idx))
+		require.Len(t, idx.Manifests, 1)
+
+		// the image is an index of its manifest and attestation manifest
+		innerDt := readTarFile(t, imageTar, "blobs/sha256/"+idx.Manifests[0].Digest.Encoded())
+		inner := ocispecs.Index{}
+		require.NoError(t, json.Unmarshal(innerDt, &inner))
+		require.Len(t, inner.Manifests, 2)
+		require.Equal(t, "attestation-manifest", inner.Manifests[1].Annotations["vnd.docker.reference.type"])
+
+		attDt := readTarFile(t, imageTar, "blobs/sha256/"+inner.Manifests[1].Digest.Encoded())
+		att := ocispecs.Manifest{}
+		require.NoError(t, json.Unmarshal(attDt, &att))
+		require.Len(t, att.Layers, 1)
+		require.Equal(t, "https://cyclonedx.org/bom", att.Layers[0].Annotations["in-toto.io/predicate-type"])
+	})
+}
+
 func (ContainerSuite) TestAnnotations(ctx context.Context, t *testctx.T) {
 	build := func(c *dagger.Client, platform dagger.Platform) *dagger.Container {
 		return c.Container(dagger.ContainerOpts{Platform: platform}).
@@ -4324,6 +4469,387 @@ func (ContainerSuite) TestExecExpect(ctx context.Context, t *testctx.T) {
 	})
 }
 
+func (ContainerSuite) TestExecTimeout(ctx context.Context, t *testctx.T) {
+	t.Run("terminates long running exec", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						Sync string
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["sleep", "300"], timeout: "2s") {
+						sync
+					}
+				}
+			}
+		}`, nil)
+
+		var exErr *dagger.ExecError
+		require.ErrorAs(t, err, &exErr)
+		require.True(t, exErr.TimedOut)
+		require.Contains(t, exErr.Error(), "process timed out after 2s")
+	})
+
+	t.Run("fails even if SIGTERM is handled", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						ExitCode int
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["sh", "-c", "trap 'exit 0' TERM; sleep 300 & wait"], timeout: "2s", expect: ANY) {
+						exitCode
+					}
+				}
+			}
+		}`, nil)
+
+		var exErr *dagger.ExecError
+		require.ErrorAs(t, err, &exErr)
+		require.True(t, exErr.TimedOut)
+	})
+
+	t.Run("fails regardless of expect", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						ExitCode int
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["sleep", "300"], timeout: "2s", expect: FAILURE) {
+						exitCode
+					}
+				}
+			}
+		}`, nil)
+
+		var exErr *dagger.ExecError
+		require.ErrorAs(t, err, &exErr)
+		require.True(t, exErr.TimedOut)
+	})
+
+	t.Run("keeps the output", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						Sync string
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["sh", "-c", "echo started; sleep 300"], timeout: "2s") {
+						sync
+					}
+				}
+			}
+		}`, nil)
+
+		// callers expecting the timeout match the error
+		var exErr *dagger.ExecError
+		require.ErrorAs(t, err, &exErr)
+		require.True(t, exErr.TimedOut)
+		require.Equal(t, "started", exErr.Stdout)
+	})
+
+	t.Run("completes within timeout", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		res, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						Stdout string
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["echo", "hello"], timeout: "1m") {
+						stdout
+					}
+				}
+			}
+		}`, nil)
+		require.NoError(t, err)
+		require.Equal(t, "hello\n", res.Container.From.WithExec.Stdout)
+	})
+
+	t.Run("invalid timeout", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithExec struct {
+						Sync string
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withExec(args: ["true"], timeout: "soon") {
+						sync
+					}
+				}
+			}
+		}`, nil)
+		require.ErrorContains(t, err, `failed to parse timeout duration "soon"`)
+	})
+}
+
+func (ContainerSuite) TestResourceLimits(ctx context.Context, t *testctx.T) {
+	t.Run("cgroup limits are applied", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		res, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithCPULimit struct {
+						WithMemoryLimit struct {
+							WithPidsLimit struct {
+								WithExec struct {
+									Stdout string
+								}
+							}
+						}
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withCPULimit(cpus: 1.5) {
+						withMemoryLimit(limit: "256MiB") {
+							withPidsLimit(limit: 64) {
+								withExec(args: ["cat", "/sys/fs/cgroup/cpu.max", "/sys/fs/cgroup/memory.max", "/sys/fs/cgroup/pids.max"]) {
+									stdout
+								}
+							}
+						}
+					}
+				}
+			}
+		}`, nil)
+		require.NoError(t, err)
+		require.Equal(t, "150000 100000\n268435456\n64\n",
+			res.Container.From.WithCPULimit.WithMemoryLimit.WithPidsLimit.WithExec.Stdout)
+	})
+
+	t.Run("OOM kill is reported", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithMemoryLimit struct {
+						WithExec struct {
+							Sync string
+						}
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withMemoryLimit(limit: "32MiB") {
+						withExec(args: ["sh", "-c", "x=$(head -c 268435456 /dev/zero | tr '\\0' a); echo ${#x}"]) {
+							sync
+						}
+					}
+				}
+			}
+		}`, nil)
+
+		var exErr *dagger.ExecError
+		require.ErrorAs(t, err, &exErr)
+		require.True(t, exErr.OOMKilled)
+		require.Contains(t, exErr.Error(), "exceeding memory limit")
+	})
+
+	t.Run("invalid IO weight", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := testutil.QueryWithClient[struct {
+			Container struct {
+				From struct {
+					WithIOWeight struct {
+						ID string
+					}
+				}
+			}
+		}](c, t,
+			`{
+			container {
+				from(address: "`+alpineImage+`") {
+					withIOWeight(weight: 5000) {
+						id
+					}
+				}
+			}
+		}`, nil)
+		require.ErrorContains(t, err, "IO weight must be between 10 and 1000")
+	})
+}
+
+func (ContainerSuite) TestExecNetworkMode(ctx context.Context, t *testctx.T) {
+	type execResponse struct {
+		Container struct {
+			WithExec struct {
+				Stdout string
+			}
+		} `json:"loadContainerFromID"`
+	}
+
+	execNetworked := func(ctx context.Context, t *testctx.T, c *dagger.Client, ctr *dagger.Container, script string, networkArgs string) (string, error) {
+		cid, err := ctr.ID(ctx)
+		require.NoError(t, err)
+
+		res, err := testutil.QueryWithClient[execResponse](c, t, `query Test($id: ContainerID!, $script: String!) {
+			loadContainerFromID(id: $id) {
+				withExec(args: ["sh", "-c", $script], `+networkArgs+`) {
+					stdout
+				}
+			}
+		}`, &testutil.QueryOptions{
+			Variables: map[string]any{
+				"id":     cid,
+				"script": script,
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		return res.Container.WithExec.Stdout, nil
+	}
+
+	probe := func(url string) string {
+		return "wget -T 5 -q -O /dev/null " + url + " && echo reachable || echo blocked"
+	}
+
+	t.Run("offline", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		ctr := c.Container().From(alpineImage).
+			WithEnvVariable("CACHEBUSTER", identity.NewID())
+
+		out, err := execNetworked(ctx, t, c, ctr, probe("https://dagger.io"), "networkMode: OFFLINE")
+		require.NoError(t, err)
+		require.Equal(t, "blocked\n", out)
+	})
+
+	t.Run("offline with service bindings", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		srv, _ := httpService(ctx, t, c, "Hello, world!")
+		ctr := c.Container().From(alpineImage).
+			WithServiceBinding("www", srv)
+
+		_, err := execNetworked(ctx, t, c, ctr, "true", "networkMode: OFFLINE")
+		require.ErrorContains(t, err, "cannot be used with service bindings")
+	})
+
+	t.Run("services only", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		srv, _ := httpService(ctx, t, c, "Hello, world!")
+		ctr := c.Container().From(alpineImage).
+			WithServiceBinding("www", srv).
+			WithEnvVariable("CACHEBUSTER", identity.NewID())
+
+		out, err := execNetworked(ctx, t, c, ctr,
+			probe("http://www")+"; "+probe("https://dagger.io"),
+			"networkMode: SERVICES_ONLY")
+		require.NoError(t, err)
+		require.Equal(t, "reachable\nblocked\n", out)
+	})
+
+	t.Run("services only blocks unbound services", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		bound, _ := httpService(ctx, t, c, "Hello, bound!")
+		unbound, unboundURL := httpService(ctx, t, c, "Hello, unbound!")
+		_, err := unbound.Start(ctx)
+		require.NoError(t, err)
+
+		ctr := c.Container().From(alpineImage).
+			WithServiceBinding("www", bound).
+			WithEnvVariable("CACHEBUSTER", identity.NewID())
+
+		out, err := execNetworked(ctx, t, c, ctr,
+			probe("http://www")+"; "+probe(unboundURL),
+			"networkMode: SERVICES_ONLY")
+		require.NoError(t, err)
+		require.Equal(t, "reachable\nblocked\n", out)
+	})
+
+	t.Run("allow list", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		srv, _ := httpService(ctx, t, c, "Hello, world!")
+		ctr := c.Container().From(alpineImage).
+			WithServiceBinding("www", srv).
+			WithEnvVariable("CACHEBUSTER", identity.NewID())
+
+		out, err := execNetworked(ctx, t, c, ctr,
+			probe("http://www")+"; "+probe("https://dagger.io")+"; "+probe("https://github.com"),
+			`networkMode: ALLOW_LIST, networkAllow: ["dagger.io"]`)
+		require.NoError(t, err)
+		require.Equal(t, "reachable\nreachable\nblocked\n", out)
+	})
+
+	t.Run("allow list requires allow list mode", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := execNetworked(ctx, t, c, c.Container().From(alpineImage), "true",
+			`networkMode: SERVICES_ONLY, networkAllow: ["dagger.io"]`)
+		require.ErrorContains(t, err, "requires the allow list network mode")
+	})
+
+	t.Run("invalid allow list entry", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+
+		_, err := execNetworked(ctx, t, c, c.Container().From(alpineImage), "true",
+			`networkMode: ALLOW_LIST, networkAllow: ["10.0.0.0/99"]`)
+		require.ErrorContains(t, err, "invalid allowed CIDR")
+	})
+}
+
 func (ContainerSuite) TestEnvExpand(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
@@ -434,6 +434,327 @@ func (GitSuite) TestGitDepth(ctx context.Context, t *testctx.T) {
 	require.Contains(t, last, "Move prototype 69-dagger-archon to top-level")
 }
 
+func (GitSuite) TestGitSparse(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	t.Run("partial clone", func(ctx context.Context, t *testctx.T) {
+		dir := c.Git("https://github.com/dagger/dagger", dagger.GitOpts{Filter: "blob:none"}).
+			Branch("main").
+			Tree(dagger.GitRefTreeOpts{Sparse: []string{"sdk/go"}, DiscardGitDir: true})
+		ent, err := dir.Entries(ctx)
+		require.NoError(t, err)
+		require.Contains(t, ent, "go.mod")
+		require.Contains(t, ent, "sdk/")
+		require.NotContains(t, ent, "core/")
+		require.NotContains(t, ent, ".git/")
+
+		ent, err = dir.Entries(ctx, dagger.DirectoryEntriesOpts{Path: "sdk"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"go/"}, ent)
+
+		_, err = dir.File("sdk/go/go.mod").Contents(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("full checkout of partial clone", func(ctx context.Context, t *testctx.T) {
+		dir := c.Git("https://github.com/dagger/dagger", dagger.GitOpts{Filter: "blob:none"}).
+			Branch("main").
+			Tree()
+		ent, err := dir.Entries(ctx)
+		require.NoError(t, err)
+		require.Contains(t, ent, "core/")
+		require.Contains(t, ent, ".git/")
+	})
+
+	t.Run("unrelated changes", func(ctx context.Context, t *testctx.T) {
+		svc, url := gitService(ctx, t, c, c.Directory().
+			WithNewFile("README.md", "Hello "+identity.NewID()).
+			WithNewFile("api/main.go", "package main").
+			WithNewFile("web/index.html", "<html>"))
+		svc, err := svc.Start(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() {
+			_, err := svc.Stop(ctx)
+			require.NoError(t, err)
+		})
+
+		ctr := c.Container().
+			From(alpineImage).
+			WithExec([]string{"apk", "add", "git"}).
+			With(gitUserConfig).
+			WithWorkdir("/src").
+			WithExec([]string{"git", "clone", url, "."})
+		sparseDigest := func(ctx context.Context, ctr *dagger.Container) string {
+			commit, err := ctr.WithExec([]string{"git", "rev-parse", "HEAD"}).Stdout(ctx)
+			require.NoError(t, err)
+			dir := c.Git(url).Commit(strings.TrimSpace(commit)).
+				Tree(dagger.GitRefTreeOpts{Sparse: []string{"api"}, DiscardGitDir: true})
+			ent, err := dir.Entries(ctx)
+			require.NoError(t, err)
+			require.ElementsMatch(t, []string{"README.md", "api/"}, ent)
+			dgst, err := dir.Digest(ctx)
+			require.NoError(t, err)
+			return dgst
+		}
+		before := sparseDigest(ctx, ctr)
+
+		ctr = ctr.WithExec([]string{"sh", "-c", `echo "<body>" >> web/index.html && git commit -am web && git push origin main`})
+		require.Equal(t, before, sparseDigest(ctx, ctr))
+
+		ctr = ctr.WithExec([]string{"sh", "-c", `echo "func main() {}" >> api/main.go && git commit -am api && git push origin main`})
+		require.NotEqual(t, before, sparseDigest(ctx, ctr))
+	})
+
+	t.Run("non-cone patterns", func(ctx context.Context, t *testctx.T) {
+		dir := c.Git("https://github.com/dagger/dagger", dagger.GitOpts{Filter: "blob:none"}).
+			Branch("main").
+			Tree(dagger.GitRefTreeOpts{Sparse: []string{"/sdk/go/*.go", "!/sdk/go/*_test.go"}, DiscardGitDir: true})
+		ent, err := dir.Entries(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"sdk/"}, ent)
+
+		ent, err = dir.Entries(ctx, dagger.DirectoryEntriesOpts{Path: "sdk/go"})
+		require.NoError(t, err)
+		require.Contains(t, ent, "client.go")
+		require.NotContains(t, ent, "go.mod")
+		for _, name := range ent {
+			require.NotContains(t, name, "_test.go")
+		}
+	})
+
+	t.Run("invalid", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Git("https://github.com/dagger/dagger").
+			Branch("main").
+			Tree(dagger.GitRefTreeOpts{Sparse: []string{"*.go", "!"}}).
+			Sync(ctx)
+		requireErrOut(t, err, "invalid sparse pattern")
+
+		_, err = c.Git("https://github.com/dagger/dagger", dagger.GitOpts{Filter: "tree:0"}).
+			Head().
+			Tree().
+			Sync(ctx)
+		requireErrOut(t, err, "unsupported filter")
+	})
+}
+
+func (GitSuite) TestGitVerify(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	ctr := c.Container().
+		From(alpineImage).
+		WithExec([]string{"apk", "add", "git", "openssh-keygen"}).
+		With(gitUserConfig).
+		WithExec([]string{"ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "alice@example.com", "-f", "/root/alice"}).
+		WithExec([]string{"ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "mallory@example.com", "-f", "/root/mallory"}).
+		WithWorkdir("/src").
+		WithExec([]string{"git", "init"}).
+		WithExec([]string{"git", "commit", "--allow-empty", "-m", "unsigned " + identity.NewID()}).
+		WithExec([]string{"git", "tag", "-a", "-m", "unsigned", "unsigned"}).
+		WithExec([]string{"git", "-c", "gpg.format=ssh", "-c", "user.signingkey=/root/alice", "tag", "-s", "-m", "v1.0.0", "v1.0.0"}).
+		WithExec([]string{"git", "-c", "gpg.format=ssh", "-c", "user.signingkey=/root/mallory", "tag", "-s", "-m", "v1.0.1", "v1.0.1"})
+	repo := ctr.Directory("/src").AsGit()
+	aliceKey, err := ctr.File("/root/alice.pub").Contents(ctx)
+	require.NoError(t, err)
+	alice := c.SetSecret("alice-key", aliceKey)
+
+	t.Run("trusted key", func(ctx context.Context, t *testctx.T) {
+		signer, err := repo.Tag("v1.0.0").
+			Verify(dagger.GitRefVerifyOpts{Keys: []*dagger.Secret{alice}}).
+			Signer(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "alice@example.com", signer)
+	})
+
+	t.Run("allowed signers", func(ctx context.Context, t *testctx.T) {
+		allowedSigners := c.Directory().
+			WithNewFile("allowed_signers", "maintainers "+aliceKey).
+			File("allowed_signers")
+		signer, err := repo.Tag("v1.0.0").
+			Verify(dagger.GitRefVerifyOpts{AllowedSigners: allowedSigners}).
+			Signer(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "maintainers", signer)
+	})
+
+	t.Run("untrusted key", func(ctx context.Context, t *testctx.T) {
+		_, err := repo.Tag("v1.0.1").
+			Verify(dagger.GitRefVerifyOpts{Keys: []*dagger.Secret{alice}}).
+			Commit(ctx)
+		requireErrOut(t, err, "git signature verification failed for refs/tags/v1.0.1")
+	})
+
+	t.Run("unsigned", func(ctx context.Context, t *testctx.T) {
+		_, err := repo.Tag("unsigned").
+			Verify(dagger.GitRefVerifyOpts{Keys: []*dagger.Secret{alice}}).
+			Commit(ctx)
+		requireErrOut(t, err, "no signature")
+	})
+
+	t.Run("not verified", func(ctx context.Context, t *testctx.T) {
+		_, err := repo.Tag("v1.0.0").Signer(ctx)
+		requireErrOut(t, err, "git ref is not verified")
+	})
+}
+
+func (GitSuite) TestGitWithCommitPush(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	content := identity.NewID()
+	svc, url := gitService(ctx, t, c, c.Directory().
+		WithNewFile("README.md", "Hello "+content).
+		WithNewFile("LICENSE", "MIT"))
+
+	svc, err := svc.Start(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, err := svc.Stop(ctx)
+		require.NoError(t, err)
+	})
+
+	repo := c.Git(url)
+	before := repo.Head().Tree()
+	changes := before.
+		WithNewFile("README.md", "Fixed "+content).
+		WithNewFile("fix/NOTES.md", "notes").
+		WithoutFile("LICENSE").
+		Changes(before)
+
+	committed := repo.WithCommit(changes, "Fix the readme", "Fix Bot <bot@example.com>")
+
+	t.Run("commit", func(ctx context.Context, t *testctx.T) {
+		tree := committed.Head().Tree()
+		readme, err := tree.File("README.md").Contents(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "Fixed "+content, readme)
+		entries, err := tree.Entries(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"README.md", "fix/"}, entries)
+
+		parent, err := repo.Head().Commit(ctx)
+		require.NoError(t, err)
+		out, err := c.Container().
+			From(alpineImage).
+			WithExec([]string{"apk", "add", "git"}).
+			WithMountedDirectory("/src", committed.Head().Tree(dagger.GitRefTreeOpts{Depth: 2})).
+			WithWorkdir("/src").
+			WithExec([]string{"git", "log", "--format=%an <%ae>|%s|%P", "-1"}).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "Fix Bot <bot@example.com>|Fix the readme|"+parent, strings.TrimSpace(out))
+	})
+
+	t.Run("push", func(ctx context.Context, t *testctx.T) {
+		ref, err := committed.Head().Push(ctx, url, dagger.GitRefPushOpts{Ref: "fix-readme"})
+		require.NoError(t, err)
+		require.Equal(t, "refs/heads/fix-readme", ref)
+
+		readme, err := c.Git(url).Branch("fix-readme").Tree().File("README.md").Contents(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "Fixed "+content, readme)
+
+		// the default branch is untouched
+		readme, err = c.Git(url).Branch("main").Tree().File("README.md").Contents(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "Hello "+content, readme)
+	})
+
+	t.Run("invalid author", func(ctx context.Context, t *testctx.T) {
+		_, err := repo.WithCommit(changes, "Fix the readme", "bot@example.com").Head().Commit(ctx)
+		requireErrOut(t, err, "invalid author")
+	})
+}
+
+func (GitSuite) TestGitHistory(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	ctr := c.Container().
+		From(alpineImage).
+		WithExec([]string{"apk", "add", "git"}).
+		With(gitUserConfig).
+		WithWorkdir("/src").
+		WithExec([]string{"git", "init"}).
+		WithNewFile("README.md", "hello\n").
+		WithNewFile("LICENSE", "MIT\n").
+		WithExec([]string{"sh", "-c", `git add . && git commit -m "first ` + identity.NewID() + `" && git tag v1`}).
+		WithNewFile("README.md", "hello\nworld\n").
+		WithExec([]string{"sh", "-c", `git rm -q LICENSE && git add . && git commit -m "second"`}).
+		WithNewFile("docs/index.md", "docs\n").
+		WithExec([]string{"sh", "-c", `git add . && git commit -m "docs"`})
+	repo := ctr.Directory("/src").AsGit()
+
+	t.Run("log", func(ctx context.Context, t *testctx.T) {
+		commits, err := repo.Head().Log(ctx)
+		require.NoError(t, err)
+		require.Len(t, commits, 3)
+
+		message, err := commits[1].Message(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "second", message)
+		files, err := commits[1].Files(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"LICENSE", "README.md"}, files)
+		parents, err := commits[1].Parents(ctx)
+		require.NoError(t, err)
+		first, err := commits[2].Commit(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{first}, parents)
+
+		commits, err = repo.Head().Log(ctx, dagger.GitRefLogOpts{Paths: []string{"docs"}})
+		require.NoError(t, err)
+		require.Len(t, commits, 1)
+
+		commits, err = repo.Head().Log(ctx, dagger.GitRefLogOpts{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, commits, 2)
+	})
+
+	t.Run("diff", func(ctx context.Context, t *testctx.T) {
+		changes := repo.Tag("v1").Diff(repo.Head())
+		added, err := changes.AddedPaths(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"docs/", "docs/index.md"}, added)
+		modified, err := changes.ModifiedPaths(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"README.md"}, modified)
+		removed, err := changes.RemovedPaths(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"LICENSE"}, removed)
+	})
+
+	t.Run("blame", func(ctx context.Context, t *testctx.T) {
+		lines, err := repo.Head().Tree(dagger.GitRefTreeOpts{Depth: 10}).File("README.md").Blame(ctx)
+		require.NoError(t, err)
+		require.Len(t, lines, 2)
+
+		commits, err := repo.Head().Log(ctx)
+		require.NoError(t, err)
+		for i, commit := range []dagger.GitCommit{commits[2], commits[1]} {
+			expected, err := commit.Commit(ctx)
+			require.NoError(t, err)
+			actual, err := lines[i].Commit(ctx)
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+		}
+		content, err := lines[1].Content(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "world", content)
+	})
+
+	t.Run("blame outside of a repository", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Directory().WithNewFile("README.md", "hello").File("README.md").Blame(ctx)
+		requireErrOut(t, err, "not in a git repository")
+	})
+
+	t.Run("blame with a git directory outside of the directory", func(ctx context.Context, t *testctx.T) {
+		_, err := c.Directory().
+			WithNewFile(".git", "gitdir: /var/lib/dagger\n").
+			WithNewFile("README.md", "hello").
+			File("README.md").
+			Blame(ctx)
+		requireErrOut(t, err, "outside of the directory")
+	})
+}
+
 func (GitSuite) TestSSHAuthSock(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
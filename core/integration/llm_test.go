@@ -166,6 +166,140 @@ func (LLMSuite) TestAPILimit(ctx context.Context, t *testctx.T) {
 	requireErrOut(t, err, "reached API call limit: 1")
 }
 
+func (LLMSuite) TestBudget(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	// reuse the recording of TestAPILimit, whose replies use over 8000 tokens each
+	replayData, err := os.ReadFile("llmtest/api-limit.golden")
+	require.NoError(t, err)
+	model := "replay/" + base64.StdEncoding.EncodeToString(replayData)
+
+	_, err = daggerCliBase(t, c).
+		With(daggerShell(fmt.Sprintf(`llm --model="%s" | with-budget --max-tokens=10000 | with-env $(.core | env | with-container-input "alpine" alpine "an alpine linux container") | with-prompt "tell me the value of PATH" | loop | with-prompt "now tell me the value of TERM" | historyJSON`, model))).
+		Stdout(ctx)
+	requireErrOut(t, err, "LLM budget exceeded")
+	requireErrOut(t, err, "over maximum 10000")
+
+	// the replayed model has no price, so its cost can't be limited
+	_, err = daggerCliBase(t, c).
+		With(daggerShell(fmt.Sprintf(`llm --model="%s" | with-budget --max-cost=0.5 | with-prompt "tell me the value of PATH" | loop | last-reply`, model))).
+		Stdout(ctx)
+	requireErrOut(t, err, "cannot enforce the maximum cost of 0.5 USD")
+}
+
+func (LLMSuite) TestCassette(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	// record the replay of TestAPILimit's recording, as if it were a model
+	replayData, err := os.ReadFile("llmtest/api-limit.golden")
+	require.NoError(t, err)
+	model := "replay/" + base64.StdEncoding.EncodeToString(replayData)
+	conversation := func(prompt string) string {
+		return fmt.Sprintf(`with-env $(.core | env | with-container-input "alpine" alpine "an alpine linux container") | with-prompt %q | loop`, prompt)
+	}
+
+	cassette, err := daggerCliBase(t, c).
+		With(daggerShell(fmt.Sprintf(`llm --model="%s" | with-cassette --mode=RECORD | %s | cassette | contents`, model, conversation("tell me the value of PATH")))).
+		Stdout(ctx)
+	require.NoError(t, err)
+	require.Contains(t, cassette, `"interactions"`)
+	require.Contains(t, cassette, "Container_withExec")
+
+	t.Run("replay", func(ctx context.Context, t *testctx.T) {
+		out, err := daggerCliBase(t, c).
+			WithNewFile("/cassette.json", cassette).
+			With(daggerShell(fmt.Sprintf(`llm | with-cassette --file=/cassette.json | %s | last-reply`, conversation("tell me the value of PATH")))).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Contains(t, out, "/usr/local/sbin")
+	})
+
+	t.Run("divergence", func(ctx context.Context, t *testctx.T) {
+		_, err := daggerCliBase(t, c).
+			WithNewFile("/cassette.json", cassette).
+			With(daggerShell(fmt.Sprintf(`llm | with-cassette --file=/cassette.json | %s | last-reply`, conversation("tell me the value of HOME")))).
+			Stdout(ctx)
+		requireErrOut(t, err, "cassette divergence")
+	})
+}
+
+func (LLMSuite) TestStream(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	replayData, err := os.ReadFile("llmtest/api-limit.golden")
+	require.NoError(t, err)
+	model := "replay/" + base64.StdEncoding.EncodeToString(replayData)
+
+	llm := c.LLM(dagger.LLMOpts{Model: model}).
+		WithEnv(c.Env().WithContainerInput("alpine", c.Container().From("alpine"), "an alpine linux container")).
+		WithPrompt("tell me the value of PATH")
+
+	// read the events as they are emitted, until there are no more
+	var events []dagger.LLMStreamEvent
+	var calls int
+	for {
+		next, err := llm.Stream(ctx, dagger.LLMStreamOpts{After: len(events)})
+		require.NoError(t, err)
+		if len(next) == 0 {
+			break
+		}
+		events = append(events, next...)
+		calls++
+	}
+	// the tool call happens between model replies, so the events can't all be
+	// returned at once
+	require.Greater(t, calls, 1)
+
+	var kinds []dagger.LLMStreamEventKind
+	var text strings.Builder
+	var toolCalls []string
+	for _, event := range events {
+		kind, err := event.Kind(ctx)
+		require.NoError(t, err)
+		kinds = append(kinds, kind)
+		switch kind {
+		case dagger.LLMStreamEventKindTextDelta:
+			delta, err := event.Text(ctx)
+			require.NoError(t, err)
+			text.WriteString(delta)
+		case dagger.LLMStreamEventKindToolCallStart:
+			name, err := event.ToolName(ctx)
+			require.NoError(t, err)
+			toolCalls = append(toolCalls, name)
+		case dagger.LLMStreamEventKindTokenUsage:
+			total, err := event.TokenUsage().TotalTokens(ctx)
+			require.NoError(t, err)
+			require.Positive(t, total)
+		}
+	}
+	require.Contains(t, kinds, dagger.LLMStreamEventKindToolCallFinish)
+	require.Contains(t, kinds, dagger.LLMStreamEventKindTokenUsage)
+	require.Contains(t, toolCalls, "Container_withExec")
+	require.Contains(t, text.String(), "/usr/local/sbin")
+}
+
+func (LLMSuite) TestSessionBudget(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	// reuse the recording of TestAPILimit, whose first prompt uses 8827 tokens
+	replayData, err := os.ReadFile("llmtest/api-limit.golden")
+	require.NoError(t, err)
+	model := "replay/" + base64.StdEncoding.EncodeToString(replayData)
+
+	// each LLM is within the session budget, but not both of them; their max
+	// API calls differ so that the second one isn't cached
+	agent := func(maxAPICalls int) string {
+		return fmt.Sprintf(`llm --model="%s" --max-api-calls=%d | with-env $(.core | env | with-container-input "alpine" alpine "an alpine linux container") | with-prompt "tell me the value of PATH" | loop | last-reply`, model, maxAPICalls)
+	}
+	_, err = daggerCliBase(t, c).
+		WithNewFile("/llm.json", `{"sessionBudget": {"maxTokens": 10000}}`).
+		WithEnvVariable("DAGGER_LLM_CONFIG", "/llm.json").
+		With(daggerShell(agent(10) + "\n" + agent(11))).
+		Stdout(ctx)
+	requireErrOut(t, err, "LLM session budget exceeded")
+	requireErrOut(t, err, "over maximum 10000")
+}
+
 func (LLMSuite) TestAllowLLM(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
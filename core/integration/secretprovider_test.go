@@ -491,6 +491,323 @@ func (SecretProvider) TestAWS(ctx context.Context, t *testctx.T) {
 	require.Equal(t, out1, out2)
 }
 
+// secretManagerEmulatorSrc is a minimal emulator of the REST APIs of Google
+// Cloud Secret Manager and Azure Key Vault, serving the secrets of $SECRETS.
+const secretManagerEmulatorSrc = `package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+)
+
+func main() {
+	var secrets map[string]string
+	if err := json.Unmarshal([]byte(os.Getenv("SECRETS")), &secrets); err != nil {
+		panic(err)
+	}
+	notFound := func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]any{
+			"error": map[string]any{"code": "SecretNotFound", "message": "not found"},
+		})
+	}
+	http.HandleFunc("GET /v1/projects/{project}/secrets/{secret}/versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.PathValue("version"), ":access") {
+			notFound(w)
+			return
+		}
+		value, ok := secrets[r.PathValue("project")+"/"+r.PathValue("secret")]
+		if !ok {
+			notFound(w)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"payload": map[string]any{"data": []byte(value)},
+		})
+	})
+	getSecret := func(w http.ResponseWriter, r *http.Request) {
+		value, ok := secrets[r.PathValue("secret")]
+		if !ok || r.URL.Query().Get("api-version") == "" {
+			notFound(w)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"value": value})
+	}
+	http.HandleFunc("GET /secrets/{secret}", getSecret)
+	http.HandleFunc("GET /secrets/{secret}/{version}", getSecret)
+	if err := http.ListenAndServe(":8080", nil); err != nil {
+		panic(err)
+	}
+}
+`
+
+func secretManagerEmulator(ctx context.Context, t *testctx.T, c *dagger.Client, secrets map[string]string) *dagger.Service {
+	secretsJSON, err := json.Marshal(secrets)
+	require.NoError(t, err)
+	emulator, err := c.Container().
+		From(golangImage).
+		WithNewFile("/src/main.go", secretManagerEmulatorSrc).
+		WithEnvVariable("SECRETS", string(secretsJSON)).
+		WithExposedPort(8080).
+		AsService(dagger.ContainerAsServiceOpts{
+			Args: []string{"go", "run", "/src/main.go"},
+		}).Start(ctx)
+	require.NoError(t, err)
+	return emulator
+}
+
+func (SecretProvider) TestGCPSecretManager(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	secretValue := "secret" + identity.NewID()
+	emulator := secretManagerEmulator(ctx, t, c, map[string]string{
+		"my-project/string-secret": secretValue,
+		"my-project/json-secret":   fmt.Sprintf(`{"username":"admin","password":"%s"}`, secretValue),
+	})
+
+	ctr := c.Container().
+		From(golangImage).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithServiceBinding("emulator", emulator).
+		WithEnvVariable("SECRET_MANAGER_EMULATOR_HOST", "emulator:8080").
+		WithEnvVariable("GOOGLE_CLOUD_PROJECT", "my-project")
+
+	for _, uri := range []string{
+		"gcp+sm://my-project/string-secret",
+		"gcp+sm://my-project/string-secret/latest",
+		"gcp+sm://string-secret",
+		"gcp+sm://my-project/json-secret?field=password",
+		"gcp+sm://my-project/string-secret?ttl=1m",
+	} {
+		out, err := fetchSecret(ctx, ctr, uri, dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true})
+		require.NoError(t, err, uri)
+		require.Equal(t, secretValue, out, uri)
+	}
+
+	_, err := fetchSecret(
+		ctx,
+		ctr,
+		"gcp+sm://my-project/nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "secret not found")
+
+	_, err = fetchSecret(
+		ctx,
+		ctr,
+		"gcp+sm://my-project/json-secret?field=nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "not found in JSON secret")
+}
+
+func (SecretProvider) TestAzureKeyVault(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	secretValue := "secret" + identity.NewID()
+	emulator := secretManagerEmulator(ctx, t, c, map[string]string{
+		"string-secret": secretValue,
+		"json-secret":   fmt.Sprintf(`{"username":"admin","password":"%s"}`, secretValue),
+	})
+
+	ctr := c.Container().
+		From(golangImage).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithServiceBinding("emulator", emulator).
+		WithEnvVariable("AZURE_KEYVAULT_EMULATOR_URL", "http://emulator:8080")
+
+	for _, uri := range []string{
+		"azure+kv://my-vault/string-secret",
+		"azure+kv://my-vault/string-secret/0123456789abcdef",
+		"azure+kv://my-vault/json-secret?field=password",
+		"azure+kv://my-vault/string-secret?ttl=1m",
+	} {
+		out, err := fetchSecret(ctx, ctr, uri, dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true})
+		require.NoError(t, err, uri)
+		require.Equal(t, secretValue, out, uri)
+	}
+
+	_, err := fetchSecret(
+		ctx,
+		ctr,
+		"azure+kv://my-vault/nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "secret not found")
+
+	_, err = fetchSecret(
+		ctx,
+		ctr,
+		"azure+kv://my-vault",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "expected <vault>/<secret>[/<version>]")
+
+	// tokens are only sent to Key Vault hosts
+	_, err = fetchSecret(
+		ctx,
+		ctr.WithoutEnvVariable("AZURE_KEYVAULT_EMULATOR_URL"),
+		"azure+kv://attacker.example.com/string-secret",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, `invalid vault "attacker.example.com"`)
+}
+
+func (SecretProvider) TestSops(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	secretValue := "secret" + identity.NewID()
+	ctr := c.Container().
+		From(golangImage).
+		WithExec([]string{"apk", "add", "sops", "age"}).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithExec([]string{"age-keygen", "-o", "/root/age.txt"}).
+		WithEnvVariable("SOPS_AGE_KEY_FILE", "/root/age.txt").
+		WithNewFile("/work/secrets.yaml", fmt.Sprintf("database:\n  password: %s\nhosts:\n  - %s\n", secretValue, secretValue)).
+		WithNewFile("/work/secrets.json", fmt.Sprintf(`{"token": %q}`, secretValue)).
+		WithExec([]string{"sh", "-c", `sops --encrypt --age "$(age-keygen -y /root/age.txt)" --in-place /work/secrets.yaml`}).
+		WithExec([]string{"sh", "-c", `sops --encrypt --age "$(age-keygen -y /root/age.txt)" --in-place /work/secrets.json`}).
+		// sanity check the files are encrypted
+		WithExec([]string{"sh", "-c", "! grep -r " + secretValue + " /work"})
+
+	for _, uri := range []string{
+		"sops:///work/secrets.yaml#database.password",
+		"sops:///work/secrets.yaml#hosts.0",
+		`sops:///work/secrets.yaml#["database"]["password"]`,
+		"sops:///work/secrets.json#token",
+		"sops:///work/secrets.json?ttl=1m#token",
+	} {
+		out, err := fetchSecret(ctx, ctr, uri, dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true})
+		require.NoError(t, err, uri)
+		require.Equal(t, secretValue, out, uri)
+	}
+
+	_, err := fetchSecret(
+		ctx,
+		ctr,
+		"sops:///work/secrets.yaml#database.nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "unable to decrypt")
+
+	_, err = fetchSecret(
+		ctx,
+		ctr.WithEnvVariable("SOPS_AGE_KEY_FILE", "/nonexistent"),
+		"sops:///work/secrets.yaml#database.password",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "unable to decrypt")
+}
+
+func (SecretProvider) TestPass(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	secretValue := "secret" + identity.NewID()
+	ctr := c.Container().
+		From(golangImage).
+		WithExec([]string{"apk", "add", "pass", "gnupg"}).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithExec([]string{"gpg", "--batch", "--passphrase", "", "--quick-gen-key", "test@dagger.io", "default", "default", "never"}).
+		WithExec([]string{"pass", "init", "test@dagger.io"}).
+		WithExec([]string{"sh", "-c", fmt.Sprintf(`printf '%%s\nlogin: admin\n' %s | pass insert --multiline github/token`, secretValue)})
+
+	out, err := fetchSecret(
+		ctx,
+		ctr,
+		"pass://github/token",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, secretValue, out)
+
+	out, err = fetchSecret(
+		ctx,
+		ctr,
+		"pass://github/token?field=login",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, "admin", out)
+
+	out, err = fetchSecret(
+		ctx,
+		ctr,
+		"pass://github/token?all",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	require.NoError(t, err)
+	require.Equal(t, secretValue+"\nlogin: admin\n", out)
+
+	_, err = fetchSecret(
+		ctx,
+		ctr,
+		"pass://github/nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, "is not in the password store")
+
+	_, err = fetchSecret(
+		ctx,
+		ctr,
+		"pass://github/token?field=nonexistent",
+		dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true},
+	)
+	requireErrOut(t, err, `field "nonexistent" not found`)
+}
+
+func (SecretProvider) TestPlugin(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	plugin := `#!/bin/sh
+set -e
+[ "$1" = get ] || exit 2
+req=$(cat)
+case "$req" in
+  *'"version":1,'*) ;;
+  *) echo "unsupported protocol: $req" >&2; exit 1 ;;
+esac
+case "$req" in
+  *'"path":"db/password'*) printf '{"value":"%s"}' "$SECRET_VALUE" ;;
+  *'"path":"binary"'*) printf '{"value":"%s","encoding":"base64"}' "$(printf %s "$SECRET_VALUE" | base64)" ;;
+  *'"path":"missing"'*) printf '{"notFound":true,"error":"no such secret"}' ;;
+  *'"path":"denied"'*) printf '{"error":"access denied"}'; exit 1 ;;
+  *) echo "unexpected request: $req" >&2; exit 1 ;;
+esac
+`
+
+	secretValue := "secret" + identity.NewID()
+	ctr := c.Container().
+		From(golangImage).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithNewFile("/usr/local/bin/dagger-secret-test", plugin, dagger.ContainerWithNewFileOpts{
+			Permissions: 0755,
+		}).
+		WithEnvVariable("SECRET_VALUE", secretValue)
+
+	for _, uri := range []string{
+		"plugin+test://db/password",
+		"plugin+test://db/password?ttl=1m",
+		"plugin+test://binary",
+	} {
+		out, err := fetchSecret(ctx, ctr, uri, dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true})
+		require.NoError(t, err, uri)
+		require.Equal(t, secretValue, out, uri)
+	}
+
+	for uri, errOut := range map[string]string{
+		"plugin+test://missing":      "no such secret",
+		"plugin+test://denied":       "access denied",
+		"plugin+test://other":        "unexpected request",
+		"plugin+nonexistent://foo":   `secret provider plugin "dagger-secret-nonexistent" not found`,
+		"plugin+../../bin/sh://path": "invalid secret provider plugin name",
+	} {
+		_, err := fetchSecret(ctx, ctr, uri, dagger.ContainerWithExecOpts{ExperimentalPrivilegedNesting: true})
+		requireErrOut(t, err, errOut, uri)
+	}
+}
+
 func fetchSecret(ctx context.Context, ctr *dagger.Container, url string, opts dagger.ContainerWithExecOpts) (string, error) {
 	query := fmt.Sprintf(`{secret(uri: %q) {plaintext}}`, url)
 	opts.Stdin = query
@@ -799,6 +799,143 @@ func (ServiceSuite) TestPortsSkipHealthCheck(ctx context.Context, t *testctx.T)
 	})
 }
 
+func (ServiceSuite) TestHealthcheck(ctx context.Context, t *testctx.T) {
+	// the service only serves after a delay, so that a started service is a
+	// ready one
+	const delayed = `sleep 3; echo ready; echo hello > index.html; exec httpd -f -v -p 8080`
+
+	curl := func(ctx context.Context, t *testctx.T, c *dagger.Client, srv *dagger.Service) {
+		t.Helper()
+		_, err := srv.Start(ctx)
+		require.NoError(t, err)
+		out, err := c.Container().
+			From(alpineImage).
+			WithServiceBinding("www", srv).
+			WithExec([]string{"wget", "-T", "1", "-O-", "http://www:8080"}).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, "hello\n", out)
+	}
+
+	t.Run("http", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		srv := c.Container().
+			From(alpineImage).
+			WithWorkdir("/srv").
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				HTTPPort: 8080,
+				HTTPPath: "/index.html",
+				Interval: "500ms",
+			}).
+			WithDefaultArgs([]string{"sh", "-c", delayed}).
+			AsService()
+		curl(ctx, t, c, srv)
+	})
+
+	t.Run("http unexpected status", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		srv := c.Container().
+			From(alpineImage).
+			WithWorkdir("/srv").
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				HTTPPort: 8080,
+				HTTPPath: "/missing",
+				Interval: "100ms",
+				Retries:  3,
+			}).
+			WithDefaultArgs([]string{"sh", "-c", "exec httpd -f -p 8080"}).
+			AsService()
+		_, err := srv.Start(ctx)
+		requireErrOut(t, err, "expected status 200, got 404")
+	})
+
+	t.Run("exec", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		srv := c.Container().
+			From(alpineImage).
+			WithWorkdir("/srv").
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				Args:     []string{"test", "-f", "/srv/index.html"},
+				Interval: "500ms",
+			}).
+			WithDefaultArgs([]string{"sh", "-c", delayed}).
+			AsService()
+		curl(ctx, t, c, srv)
+	})
+
+	t.Run("log pattern", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		srv := c.Container().
+			From(alpineImage).
+			WithWorkdir("/srv").
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				LogPattern: "^ready$",
+				Interval:   "500ms",
+			}).
+			// the log line is printed once the server serves
+			WithDefaultArgs([]string{"sh", "-c", `sleep 3; echo hello > index.html; httpd -p 8080; echo ready; sleep infinity`}).
+			AsService()
+		curl(ctx, t, c, srv)
+	})
+
+	t.Run("image healthcheck", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		// retries are at the start interval, not every minute
+		srv := c.Directory().
+			WithNewFile("Dockerfile", `FROM `+alpineImage+`
+WORKDIR /srv
+HEALTHCHECK --interval=1m --start-interval=500ms --timeout=1s CMD test -f /srv/index.html
+`).
+			DockerBuild().
+			WithImageHealthcheck().
+			WithDefaultArgs([]string{"sh", "-c", delayed}).
+			AsService()
+		curl(ctx, t, c, srv)
+
+		// failing image healthchecks fail to start the service
+		failing := c.Directory().
+			WithNewFile("Dockerfile", `FROM `+alpineImage+`
+HEALTHCHECK --interval=100ms --retries=2 CMD-SHELL echo unhealthy && false
+`).
+			DockerBuild().
+			WithDefaultArgs([]string{"sleep", "infinity"})
+		_, err := failing.WithImageHealthcheck().AsService().Start(ctx)
+		requireErrOut(t, err, "healthcheck failed 2 times")
+		requireErrOut(t, err, "unhealthy")
+
+		// unless not opted into
+		_, err = failing.AsService().Start(ctx)
+		require.NoError(t, err)
+
+		// or removed
+		_, err = failing.WithImageHealthcheck().WithoutHealthcheck().AsService().Start(ctx)
+		require.NoError(t, err)
+
+		_, err = c.Container().From(alpineImage).WithImageHealthcheck().Sync(ctx)
+		requireErrOut(t, err, "image has no healthcheck")
+	})
+
+	t.Run("invalid", func(ctx context.Context, t *testctx.T) {
+		c := connect(ctx, t)
+		_, err := c.Container().
+			From(alpineImage).
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				Args:       []string{"true"},
+				LogPattern: "ready",
+			}).
+			Sync(ctx)
+		requireErrOut(t, err, "exactly one of args, httpPort and logPattern must be set")
+
+		_, err = c.Container().
+			From(alpineImage).
+			WithHealthcheck(dagger.ContainerWithHealthcheckOpts{
+				LogPattern: "(",
+			}).
+			Sync(ctx)
+		requireErrOut(t, err, "invalid log pattern")
+	})
+}
+
 func (ServiceSuite) TestPortLifecycle(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
@@ -1844,6 +1981,111 @@ func (ServiceSuite) TestStartStopKill(ctx context.Context, t *testctx.T) {
 	require.Empty(t, out)
 }
 
+func (ServiceSuite) TestStartTimeout(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	content := identity.NewID()
+
+	httpSrv, httpURL := httpService(ctx, t, c, content)
+
+	fetch := func() (string, error) {
+		return c.Container().
+			From(alpineImage).
+			WithEnvVariable("BUST", identity.NewID()).
+			WithExec([]string{"wget", "-O-", httpURL}).
+			Stdout(ctx)
+	}
+
+	_, err := httpSrv.Start(ctx, dagger.ServiceStartOpts{Timeout: "10s"})
+	require.NoError(t, err)
+
+	out, err := fetch()
+	require.NoError(t, err)
+	require.Equal(t, content, out)
+
+	require.Eventually(t, func() bool {
+		_, err := fetch()
+		return err != nil
+	}, time.Minute, time.Second)
+
+	t.Run("invalid timeout", func(ctx context.Context, t *testctx.T) {
+		_, err := httpSrv.Start(ctx, dagger.ServiceStartOpts{Timeout: "soon"})
+		require.ErrorContains(t, err, `failed to parse timeout duration "soon"`)
+	})
+
+	t.Run("keeps a service already running", func(ctx context.Context, t *testctx.T) {
+		content := identity.NewID()
+		httpSrv, httpURL := httpService(ctx, t, c, content)
+
+		// bound to a container until it's done fetching, which starts it
+		fetched := make(chan error, 1)
+		go func() {
+			_, err := c.Container().
+				From(alpineImage).
+				WithServiceBinding("www", httpSrv).
+				WithEnvVariable("BUST", identity.NewID()).
+				WithExec([]string{"sh", "-c", "wget -O- " + httpURL + " && sleep 10 && wget -O- " + httpURL}).
+				Sync(ctx)
+			fetched <- err
+		}()
+		require.Eventually(t, func() bool {
+			_, err := c.Container().
+				From(alpineImage).
+				WithEnvVariable("BUST", identity.NewID()).
+				WithExec([]string{"wget", "-O-", httpURL}).
+				Stdout(ctx)
+			return err == nil
+		}, time.Minute, time.Second)
+
+		_, err := httpSrv.Start(ctx, dagger.ServiceStartOpts{Timeout: "1s"})
+		require.NoError(t, err)
+
+		require.NoError(t, <-fetched)
+	})
+}
+
+func (ServiceSuite) TestUpTimeout(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	t.Run("stops the service it started", func(ctx context.Context, t *testctx.T) {
+		content := identity.NewID()
+		httpSrv, httpURL := httpService(ctx, t, c, content)
+
+		err := httpSrv.Up(ctx, dagger.ServiceUpOpts{Random: true, Timeout: "5s"})
+		require.ErrorContains(t, err, "service timed out after 5s")
+
+		_, err = c.Container().
+			From(alpineImage).
+			WithEnvVariable("BUST", identity.NewID()).
+			WithExec([]string{"wget", "-O-", httpURL}).
+			Stdout(ctx)
+		require.Error(t, err)
+	})
+
+	t.Run("keeps a service already running", func(ctx context.Context, t *testctx.T) {
+		content := identity.NewID()
+		httpSrv, httpURL := httpService(ctx, t, c, content)
+
+		_, err := httpSrv.Start(ctx)
+		require.NoError(t, err)
+		defer func() {
+			_, err := httpSrv.Stop(ctx)
+			require.NoError(t, err)
+		}()
+
+		err = httpSrv.Up(ctx, dagger.ServiceUpOpts{Random: true, Timeout: "5s"})
+		require.ErrorContains(t, err, "service timed out after 5s")
+
+		out, err := c.Container().
+			From(alpineImage).
+			WithEnvVariable("BUST", identity.NewID()).
+			WithExec([]string{"wget", "-O-", httpURL}).
+			Stdout(ctx)
+		require.NoError(t, err)
+		require.Equal(t, content, out)
+	})
+}
+
 // TestNoCrossTalk shows that services spawned in one client cannot be
 // reached by another client.
 func (ServiceSuite) TestNoCrossTalk(ctx context.Context, t *testctx.T) {
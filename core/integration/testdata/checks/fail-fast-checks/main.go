@@ -0,0 +1,22 @@
+// A module with checks to cancel with fail-fast
+package main
+
+import (
+	"context"
+)
+
+type FailFastChecks struct{}
+
+// Returns a failing check
+// +check
+func (m *FailFastChecks) FailingCheck(ctx context.Context) error {
+	_, err := dag.Container().From("alpine:3").WithExec([]string{"sh", "-c", "exit 1"}).Sync(ctx)
+	return err
+}
+
+// Returns a check that passes after a while, to be canceled by fail-fast
+// +check
+func (m *FailFastChecks) SlowCheck(ctx context.Context) error {
+	_, err := dag.Container().From("alpine:3").WithExec([]string{"sh", "-c", "sleep 20"}).Sync(ctx)
+	return err
+}
@@ -466,6 +466,46 @@ func (ToolchainSuite) TestToolchainIgnoreChecks(ctx context.Context, t *testctx.
 	})
 }
 
+func (ToolchainSuite) TestToolchainQuarantineChecks(ctx context.Context, t *testctx.T) {
+	c := connect(ctx, t)
+
+	modGen := c.Container().
+		From(alpineImage).
+		WithExec([]string{"apk", "add", "git"}).
+		WithExec([]string{"git", "init"}).
+		WithMountedFile(testCLIBinPath, daggerCliFile(t, c)).
+		WithDirectory(".", c.Host().Directory("./testdata/checks")).
+		WithDirectory("app", c.Directory()).
+		WithWorkdir("app").
+		With(daggerExec("init")).
+		With(daggerExec("toolchain", "install", "../hello-with-checks")).
+		WithNewFile("dagger.json", `{
+  "name": "app",
+  "engineVersion": "v0.16.0",
+  "toolchains": [
+    {
+      "name": "hello-with-checks",
+      "source": "../hello-with-checks",
+      "quarantineChecks": [
+        "failing-*"
+      ]
+    }
+  ]
+}`)
+
+	// failures of quarantined checks are reported, but don't fail the run
+	checked := modGen.With(daggerExec("--progress=report", "check", "--report-file=report.json"))
+	out, err := checked.CombinedOutput(ctx)
+	require.NoError(t, err)
+	require.Regexp(t, `passingCheck.*OK`, out)
+	require.Regexp(t, `failingCheck.*ERROR`, out)
+
+	report, err := checked.File("report.json").Contents(ctx)
+	require.NoError(t, err)
+	require.Contains(t, report, `"quarantined": 2`)
+	require.Contains(t, report, `"failed": 0`)
+}
+
 func (ToolchainSuite) TestToolchainMultipleVersions(ctx context.Context, t *testctx.T) {
 	c := connect(ctx, t)
 
@@ -27,6 +27,9 @@ import (
 	"github.com/dagger/dagger/dagql"
 	"github.com/dagger/dagger/engine"
 	"github.com/dagger/dagger/engine/client/secretprovider"
+	"github.com/dagger/dagger/engine/config"
+	"github.com/dagger/dagger/engine/slog"
+	enginetel "github.com/dagger/dagger/engine/telemetry"
 )
 
 func init() {
@@ -67,8 +70,15 @@ type LLM struct {
 	maxAPICalls int
 	apiCalls    int
 
+	// Token and cost budget; zero means unlimited
+	maxTokens int64
+	maxCost   float64
+
 	model string
 
+	// Records or replays the requests sent to the model
+	cassette *LLMCassette
+
 	endpoint    *LLMEndpoint
 	endpointMtx *sync.Mutex
 
@@ -76,6 +86,10 @@ type LLM struct {
 	once        *sync.Once
 	err         error
 
+	// Events emitted while syncing
+	stream     *llmStream
+	streamOnce *sync.Once
+
 	// History of messages
 	messages []*ModelMessage
 
@@ -87,8 +101,14 @@ type LLMEndpoint struct {
 	Model    string
 	BaseURL  string
 	Key      string
+	Headers  map[string]string
 	Provider LLMProvider
 	Client   LLMClient
+	// The price of the model, if known
+	Price *config.LLMModelPrice
+	// The budgets of the session and of each of its modules, if any
+	SessionBudget *config.LLMBudget
+	ModuleBudget  *config.LLMBudget
 }
 
 type LLMProvider string
@@ -106,11 +126,12 @@ type LLMResponse struct {
 }
 
 type LLMTokenUsage struct {
-	InputTokens       int64 `field:"true" json:"input_tokens"`
-	OutputTokens      int64 `field:"true" json:"output_tokens"`
-	CachedTokenReads  int64 `field:"true" json:"cached_token_reads"`
-	CachedTokenWrites int64 `field:"true" json:"cached_token_writes"`
-	TotalTokens       int64 `field:"true" json:"total_tokens"`
+	InputTokens       int64   `field:"true" json:"input_tokens"`
+	OutputTokens      int64   `field:"true" json:"output_tokens"`
+	CachedTokenReads  int64   `field:"true" json:"cached_token_reads"`
+	CachedTokenWrites int64   `field:"true" json:"cached_token_writes"`
+	TotalTokens       int64   `field:"true" json:"total_tokens"`
+	Cost              float64 `field:"true" json:"cost,omitempty" doc:"The estimated cost in USD, if the price of the model is known."`
 }
 
 func (*LLMTokenUsage) Type() *ast.Type {
@@ -144,7 +165,9 @@ type FuncCall struct {
 const (
 	OpenAI    LLMProvider = "openai"
 	Anthropic LLMProvider = "anthropic"
+	Bedrock   LLMProvider = "bedrock"
 	Google    LLMProvider = "google"
+	Ollama    LLMProvider = "ollama"
 	Meta      LLMProvider = "meta"
 	Mistral   LLMProvider = "mistral"
 	DeepSeek  LLMProvider = "deepseek"
@@ -166,6 +189,25 @@ type LLMRouter struct {
 	GeminiAPIKey  string
 	GeminiBaseURL string
 	GeminiModel   string
+
+	// Path of a client-side LLM config file, merged over the engine's
+	ConfigPath string
+	// Named endpoints, aliases and default model
+	Config *config.LLMConfig
+	// Prices of the client-side config, which can only raise the prices of
+	// the engine's config and the built-in ones
+	ClientPrices map[string]config.LLMModelPrice
+
+	resolveSecret func(context.Context, string) (string, error)
+}
+
+// loadSecret resolves a secret reference of the config, or returns the
+// plaintext value as is.
+func (r *LLMRouter) loadSecret(ctx context.Context, uriOrPlaintext string) (string, error) {
+	if r.resolveSecret == nil || uriOrPlaintext == "" {
+		return uriOrPlaintext, nil
+	}
+	return r.resolveSecret(ctx, uriOrPlaintext)
 }
 
 func (r *LLMRouter) isAnthropicModel(model string) bool {
@@ -268,6 +310,9 @@ func (r *LLMRouter) routeReplayModel(model string) (*LLMEndpoint, error) {
 
 // Return a default model, if configured
 func (r *LLMRouter) DefaultModel() string {
+	if r.Config != nil && r.Config.DefaultModel != "" {
+		return r.Config.DefaultModel
+	}
 	for _, model := range []string{r.OpenAIModel, r.AnthropicModel, r.GeminiModel} {
 		if model != "" {
 			return model
@@ -290,12 +335,21 @@ func (r *LLMRouter) DefaultModel() string {
 
 // Return an endpoint for the requested model
 // If the model name is not set, a default will be selected.
-func (r *LLMRouter) Route(model string) (*LLMEndpoint, error) {
+func (r *LLMRouter) Route(ctx context.Context, model string) (*LLMEndpoint, error) {
 	if model == "" {
 		model = r.DefaultModel()
-	} else {
-		model = resolveModelAlias(model)
 	}
+	endpointName, model := r.resolveConfigModel(model)
+	if endpointName != "" {
+		endpoint, err := r.routeConfigEndpoint(ctx, endpointName, model)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.Price = r.price(model)
+		r.setBudgets(endpoint)
+		return endpoint, nil
+	}
+	model = resolveModelAlias(model)
 	var endpoint *LLMEndpoint
 	var err error
 	switch {
@@ -319,9 +373,36 @@ func (r *LLMRouter) Route(model string) (*LLMEndpoint, error) {
 		endpoint = r.routeOtherModel()
 	}
 	endpoint.Model = model
+	endpoint.Price = r.price(model)
+	r.setBudgets(endpoint)
 	return endpoint, nil
 }
 
+// price returns the price of a model, if known. The prices of the client can
+// only raise it, so that the budgets can't be worked around by lowering the
+// estimated costs.
+func (r *LLMRouter) price(model string) *config.LLMModelPrice {
+	price := lookupLLMPrice(r.Config, model)
+	clientPrice, ok := matchLLMPrice(r.ClientPrices, model)
+	if !ok {
+		return price
+	}
+	if price != nil {
+		clientPrice = maxLLMPrice(*price, clientPrice)
+	}
+	return &clientPrice
+}
+
+// setBudgets sets the session and module budgets of the config, if any, on an
+// endpoint.
+func (r *LLMRouter) setBudgets(endpoint *LLMEndpoint) {
+	if r.Config == nil {
+		return
+	}
+	endpoint.SessionBudget = r.Config.SessionBudget
+	endpoint.ModuleBudget = r.Config.ModuleBudget
+}
+
 func (r *LLMRouter) LoadConfig(ctx context.Context, getenv func(context.Context, string) (string, error)) error {
 	if getenv == nil {
 		getenv = func(_ context.Context, key string) (string, error) { //nolint:unparam
@@ -374,6 +455,10 @@ func (r *LLMRouter) LoadConfig(ctx context.Context, getenv func(context.Context,
 		return save("GEMINI_MODEL", &r.GeminiModel)
 	})
 
+	eg.Go(func() error {
+		return save("DAGGER_LLM_CONFIG", &r.ConfigPath)
+	})
+
 	var (
 		openAIDisableStreaming string
 	)
@@ -398,7 +483,9 @@ func (r *LLMRouter) LoadConfig(ctx context.Context, getenv func(context.Context,
 	return nil
 }
 
-func NewLLMRouter(ctx context.Context, srv *dagql.Server) (_ *LLMRouter, rerr error) {
+// NewLLMRouter loads the LLM configuration of the client, merged over the
+// given engine configuration.
+func NewLLMRouter(ctx context.Context, srv *dagql.Server, engineConfig *config.LLMConfig) (_ *LLMRouter, rerr error) {
 	router := new(LLMRouter)
 	// Get the secret plaintext, from either a URI (provider lookup) or a plaintext (no-op)
 	loadSecret := func(ctx context.Context, uriOrPlaintext string) (string, error) {
@@ -442,7 +529,35 @@ func NewLLMRouter(ctx context.Context, srv *dagql.Server) (_ *LLMRouter, rerr er
 		}
 		return "", nil
 	})
-	return router, err
+	if err != nil {
+		return nil, err
+	}
+	router.resolveSecret = loadSecret
+
+	var clientConfig *config.LLMConfig
+	if router.ConfigPath != "" {
+		contents, err := loadSecret(ctx, "file://"+router.ConfigPath)
+		if err != nil {
+			return nil, fmt.Errorf("load LLM config %s: %w", router.ConfigPath, err)
+		}
+		clientConfig = new(config.LLMConfig)
+		if err := json.Unmarshal([]byte(contents), clientConfig); err != nil {
+			return nil, fmt.Errorf("parse LLM config %s: %w", router.ConfigPath, err)
+		}
+		if err := clientConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("LLM config %s: %w", router.ConfigPath, err)
+		}
+	}
+	if engineConfig != nil || clientConfig != nil {
+		router.Config = engineConfig.Merge(clientConfig)
+		if clientConfig != nil {
+			router.ClientPrices = clientConfig.Prices
+		}
+		if err := router.Config.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return router, nil
 }
 
 func (q *Query) NewLLM(ctx context.Context, model string, maxAPICalls int) (*LLM, error) {
@@ -462,6 +577,8 @@ func (q *Query) NewLLM(ctx context.Context, model string, maxAPICalls int) (*LLM
 		mcp:         newMCP(env),
 		once:        &sync.Once{},
 		endpointMtx: &sync.Mutex{},
+		stream:      newLLMStream(),
+		streamOnce:  &sync.Once{},
 	}, nil
 }
 
@@ -482,7 +599,7 @@ func loadLLMRouter(ctx context.Context, query *Query) (*LLMRouter, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewLLMRouter(ctx, mainSrv)
+	return NewLLMRouter(ctx, mainSrv, query.LLMConfig())
 }
 
 func (*LLM) Type() *ast.Type {
@@ -500,6 +617,8 @@ func (llm *LLM) Clone() *LLM {
 	cp.endpointMtx = &sync.Mutex{}
 	cp.once = &sync.Once{}
 	cp.err = nil
+	cp.stream = newLLMStream()
+	cp.streamOnce = &sync.Once{}
 	return &cp
 }
 
@@ -511,6 +630,12 @@ func (llm *LLM) Endpoint(ctx context.Context) (*LLMEndpoint, error) {
 		return llm.endpoint, nil
 	}
 
+	if llm.cassette != nil && llm.cassette.Mode == LLMCassetteModeReplay {
+		// replay offline, without any configured endpoint
+		llm.endpoint = llm.cassette.Endpoint(llm.model)
+		return llm.endpoint, nil
+	}
+
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return nil, err
@@ -519,7 +644,7 @@ func (llm *LLM) Endpoint(ctx context.Context) (*LLMEndpoint, error) {
 	if err != nil {
 		return nil, err
 	}
-	endpoint, err := router.Route(llm.model)
+	endpoint, err := router.Route(ctx, llm.model)
 	if err != nil {
 		return nil, err
 	}
@@ -549,6 +674,99 @@ func (llm *LLM) ToolsDoc(ctx context.Context) (string, error) {
 	return result, nil
 }
 
+// WithCassette records the requests sent to the model with their responses
+// in a cassette, or replays them from it.
+func (llm *LLM) WithCassette(cassette *LLMCassette) *LLM {
+	llm = llm.Clone()
+	llm.cassette = cassette
+
+	llm.endpointMtx.Lock()
+	defer llm.endpointMtx.Unlock()
+	llm.endpoint = nil
+
+	return llm
+}
+
+// Cassette returns the cassette of the LLM, if any.
+func (llm *LLM) Cassette() *LLMCassette {
+	return llm.cassette
+}
+
+// WithBudget limits the tokens and the estimated cost of the LLM. Zero means
+// unlimited.
+func (llm *LLM) WithBudget(maxTokens int64, maxCost float64) *LLM {
+	llm = llm.Clone()
+	llm.maxTokens = maxTokens
+	llm.maxCost = maxCost
+	return llm
+}
+
+// llmCaller returns the module calling an LLM, if any, and the LLM usage of its
+// session.
+func llmCaller(ctx context.Context) (*Module, *LLMSessionUsage, error) {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	sessionUsage, err := query.LLMUsage(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var module *Module
+	if mod, err := query.CurrentModule(ctx); err == nil {
+		module = mod
+	}
+	return module, sessionUsage, nil
+}
+
+// llmBudgetKey returns the key of the LLM usage and budget of a module, if
+// any: the digest of its source, so that distinct modules with the same name
+// don't share a budget.
+func llmBudgetKey(mod *Module) string {
+	if mod == nil {
+		return ""
+	}
+	if src := mod.GetSource(); src != nil && src.Digest != "" {
+		return src.Digest
+	}
+	return mod.Name()
+}
+
+// recordUsage adds the usage of an API call to the usage of the session, and
+// emits it as metrics, attributed to the calling module and session.
+func (llm *LLM) recordUsage(ctx context.Context, ep *LLMEndpoint, module *Module, sessionUsage *LLMSessionUsage, usage LLMTokenUsage) {
+	sessionUsage.Add(llmBudgetKey(module), usage)
+
+	attrs := []attribute.KeyValue{
+		attribute.String(enginetel.LLMModelAttr, ep.Model),
+		attribute.String(enginetel.LLMProviderAttr, string(ep.Provider)),
+	}
+	if module != nil {
+		attrs = append(attrs, attribute.String(enginetel.LLMModuleAttr, module.Name()))
+	}
+	if md, err := engine.ClientMetadataFromContext(ctx); err == nil {
+		attrs = append(attrs, attribute.String(enginetel.LLMSessionAttr, md.SessionID))
+	}
+	err := enginetel.RecordLLMUsage(ctx, enginetel.LLMUsage{
+		InputTokens:       usage.InputTokens,
+		OutputTokens:      usage.OutputTokens,
+		CachedTokenReads:  usage.CachedTokenReads,
+		CachedTokenWrites: usage.CachedTokenWrites,
+		Cost:              usage.Cost,
+	}, attrs...)
+	if err != nil {
+		slog.Warn("failed to record LLM usage", "error", err)
+	}
+}
+
+// checkBudget returns an error if the message history exceeds the budget.
+func (llm *LLM) checkBudget() error {
+	return checkLLMBudget("llm", llm.usage(), &config.LLMBudget{
+		MaxTokens: llm.maxTokens,
+		MaxCost:   llm.maxCost,
+	})
+}
+
 func (llm *LLM) WithModel(model string) *LLM {
 	llm = llm.Clone()
 	llm.model = model
@@ -702,7 +920,7 @@ func (llm *LLM) Sync(ctx context.Context) error {
 		return err
 	}
 	llm.once.Do(func() {
-		err := llm.loop(ctx)
+		err := llm.loop(WithLLMStream(ctx, llm.stream.add))
 		if err != nil && ctx.Err() == nil {
 			// Consider an interrupt to be successful, so we can still use the result
 			// of a partially completed sequence (e.g. accessing its Env). The user
@@ -789,6 +1007,59 @@ func (llm *LLM) autoInterject(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// sendLLMQuery sends a query to the model, retrying it with the given backoff
+// while the client can. The text of the reply is streamed: a failed attempt
+// may have streamed some already, so a RETRY event is emitted before trying
+// again.
+func sendLLMQuery(ctx context.Context, client LLMClient, messages []*ModelMessage, tools []LLMTool, b backoff.BackOff) (*LLMResponse, error) {
+	var res *LLMResponse
+	var streamedText bool
+	queryCtx := WithLLMStream(ctx, func(event *LLMStreamEvent) {
+		if event.Kind == LLMStreamEventKindTextDelta {
+			streamedText = true
+		}
+		emitLLMStreamEvent(ctx, event)
+	})
+	err := backoff.Retry(func() error {
+		if streamedText {
+			streamLLMRetry(ctx)
+			streamedText = false
+		}
+		var sendErr error
+		ctx, span := Tracer(queryCtx).Start(queryCtx, "LLM query", telemetry.Reveal(), trace.WithAttributes(
+			attribute.String(telemetry.UIActorEmojiAttr, "🤖"),
+			attribute.String(telemetry.UIMessageAttr, telemetry.UIMessageReceived),
+			attribute.String(telemetry.LLMRoleAttr, telemetry.LLMRoleAssistant),
+		))
+		res, sendErr = client.SendQuery(ctx, messages, tools)
+		telemetry.EndWithCause(span, &sendErr)
+		if sendErr != nil {
+			var finished *ModelFinishedError
+			if errors.As(sendErr, &finished) {
+				// Don't retry if the model finished explicitly, treat as permanent.
+				return backoff.Permanent(sendErr)
+			}
+			if !client.IsRetryable(sendErr) {
+				// Maybe an invalid request - give up.
+				return backoff.Permanent(sendErr)
+			}
+			// Log retry attempts? Maybe with increasing severity?
+			// For now, just return the error to signal backoff to retry.
+			return sendErr
+		}
+		// Success, stop retrying
+		return nil
+	}, backoff.WithContext(b, ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !streamedText {
+		// the client didn't stream the reply, e.g. it was replayed
+		streamLLMText(ctx, res.Content)
+	}
+	return res, nil
+}
+
 func (llm *LLM) loop(ctx context.Context) error {
 	var hasUserMessage bool
 	for _, message := range llm.messages {
@@ -813,6 +1084,9 @@ func (llm *LLM) loop(ctx context.Context) error {
 		if llm.maxAPICalls > 0 && llm.apiCalls >= llm.maxAPICalls {
 			return fmt.Errorf("reached API call limit: %d", llm.apiCalls)
 		}
+		if err := llm.checkBudget(); err != nil {
+			return err
+		}
 		llm.apiCalls++
 
 		tools, err := llm.mcp.Tools(ctx)
@@ -863,33 +1137,36 @@ func (llm *LLM) loop(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		client := ep.Client
-		err = backoff.Retry(func() error {
-			var sendErr error
-			ctx, span := Tracer(ctx).Start(ctx, "LLM query", telemetry.Reveal(), trace.WithAttributes(
-				attribute.String(telemetry.UIActorEmojiAttr, "🤖"),
-				attribute.String(telemetry.UIMessageAttr, telemetry.UIMessageReceived),
-				attribute.String(telemetry.LLMRoleAttr, telemetry.LLMRoleAssistant),
-			))
-			res, sendErr = client.SendQuery(ctx, messagesToSend, tools)
-			telemetry.EndWithCause(span, &sendErr)
-			if sendErr != nil {
-				var finished *ModelFinishedError
-				if errors.As(sendErr, &finished) {
-					// Don't retry if the model finished explicitly, treat as permanent.
-					return backoff.Permanent(sendErr)
+		if ep.Price == nil {
+			// don't let the cost go unchecked
+			for _, budget := range []*config.LLMBudget{
+				{MaxCost: llm.maxCost},
+				ep.SessionBudget,
+				ep.ModuleBudget,
+			} {
+				if budget != nil && budget.MaxCost > 0 {
+					return fmt.Errorf("cannot enforce the maximum cost of %g USD: the price of model %q is unknown, set it in the prices of the llm engine configuration", budget.MaxCost, ep.Model)
 				}
-				if !client.IsRetryable(sendErr) {
-					// Maybe an invalid request - give up.
-					return backoff.Permanent(sendErr)
-				}
-				// Log retry attempts? Maybe with increasing severity?
-				// For now, just return the error to signal backoff to retry.
-				return sendErr
 			}
-			// Success, stop retrying
-			return nil
-		}, backoff.WithContext(b, ctx))
+		}
+		module, sessionUsage, err := llmCaller(ctx)
+		if err != nil {
+			return err
+		}
+		// Don't call the model once the session or module is over budget,
+		// e.g. because of other LLMs
+		if err := sessionUsage.Check(llmBudgetKey(module), ep.SessionBudget, ep.ModuleBudget); err != nil {
+			return err
+		}
+		client := ep.Client
+		if llm.cassette != nil {
+			secrets, err := llmCassetteSecrets(ctx)
+			if err != nil {
+				return fmt.Errorf("load secrets to scrub from the cassette: %w", err)
+			}
+			client = llm.cassette.Client(ep, llm.messages, secrets)
+		}
+		res, err = sendLLMQuery(ctx, client, messagesToSend, tools, b)
 
 		// Check the final error after retries (if any)
 		if err != nil {
@@ -910,6 +1187,12 @@ func (llm *LLM) loop(ctx context.Context) error {
 			return fmt.Errorf("not retrying: %w", err)
 		}
 
+		if ep.Price != nil {
+			res.TokenUsage.Cost = llmCost(ep.Provider, *ep.Price, res.TokenUsage)
+		}
+		llm.recordUsage(ctx, ep, module, sessionUsage, res.TokenUsage)
+		streamLLMTokenUsage(ctx, res.TokenUsage)
+
 		// Add the model reply to the history
 		llm.messages = append(llm.messages, &ModelMessage{
 			Role:       "assistant",
@@ -918,6 +1201,14 @@ func (llm *LLM) loop(ctx context.Context) error {
 			TokenUsage: res.TokenUsage,
 		})
 
+		// Stop before calling any tool if the reply went over budget
+		if err := llm.checkBudget(); err != nil {
+			return err
+		}
+		if err := sessionUsage.Check(llmBudgetKey(module), ep.SessionBudget, ep.ModuleBudget); err != nil {
+			return err
+		}
+
 		// Handle tool calls
 		if len(res.ToolCalls) == 0 {
 			if interjected, interjectErr := llm.autoInterject(ctx); interjectErr != nil {
@@ -1099,17 +1390,61 @@ func (llm *LLM) BindResult(ctx context.Context, dag *dagql.Server, name string)
 	return res, nil
 }
 
+// Stream syncs the LLM in the background, and returns the events emitted
+// while doing so past the first ones, as soon as there are any. It returns no
+// events once the LLM is synced and all of them were returned.
+//
+// Syncing is interrupted when the session ends, or when the stream isn't read
+// for llmStreamIdleTimeout.
+func (llm *LLM) Stream(ctx context.Context, after int) ([]*LLMStreamEvent, error) {
+	if after < 0 {
+		return nil, fmt.Errorf("after must not be negative, got %d", after)
+	}
+	if err := llm.allowed(ctx); err != nil {
+		return nil, err
+	}
+	llm.streamOnce.Do(func() {
+		// keep syncing between the calls reading the stream, for as long as
+		// the session lasts
+		query, err := CurrentQuery(ctx)
+		if err != nil {
+			llm.stream.finish(err)
+			return
+		}
+		sessionCtx, err := query.SessionContext(ctx)
+		if err != nil {
+			llm.stream.finish(err)
+			return
+		}
+		go llm.stream.run(sessionCtx, llmStreamIdleTimeout, llm.Sync)
+	})
+	return llm.stream.Next(ctx, after)
+}
+
 func (llm *LLM) TokenUsage(ctx context.Context, dag *dagql.Server) (*LLMTokenUsage, error) {
 	if err := llm.Sync(ctx); err != nil {
 		return nil, err
 	}
+	res := llm.usage()
+	return &res, nil
+}
+
+// usage returns the token usage of the message history.
+func (llm *LLM) usage() LLMTokenUsage {
 	var res LLMTokenUsage
 	for _, msg := range llm.messages {
-		res.InputTokens += msg.TokenUsage.InputTokens
-		res.OutputTokens += msg.TokenUsage.OutputTokens
-		res.CachedTokenReads += msg.TokenUsage.CachedTokenReads
-		res.CachedTokenWrites += msg.TokenUsage.CachedTokenWrites
-		res.TotalTokens += msg.TokenUsage.TotalTokens
+		res = res.add(msg.TokenUsage)
 	}
-	return &res, nil
+	return res
+}
+
+// add returns the sum of two token usages.
+func (usage LLMTokenUsage) add(other LLMTokenUsage) LLMTokenUsage {
+	usage.InputTokens += other.InputTokens
+	usage.OutputTokens += other.OutputTokens
+	usage.CachedTokenReads += other.CachedTokenReads
+	usage.CachedTokenWrites += other.CachedTokenWrites
+	usage.TotalTokens += other.TotalTokens
+	usage.Cost += other.Cost
+	return usage
 }
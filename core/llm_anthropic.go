@@ -29,6 +29,9 @@ func newAnthropicClient(endpoint *LLMEndpoint) *AnthropicClient {
 	if endpoint.BaseURL != "" {
 		opts = append(opts, option.WithBaseURL(endpoint.BaseURL))
 	}
+	for k, v := range endpoint.Headers {
+		opts = append(opts, option.WithHeader(k, v))
+	}
 	client := anthropic.NewClient(opts...)
 	return &AnthropicClient{
 		client:   &client,
@@ -238,6 +241,7 @@ func (c *AnthropicClient) SendQuery(ctx context.Context, history []*ModelMessage
 			if delta.Delta.Text != "" {
 				// Lazily initialize telemetry/logging on first text response.
 				fmt.Fprint(markdownW, delta.Delta.Text)
+				streamLLMText(ctx, delta.Delta.Text)
 			}
 		}
 	}
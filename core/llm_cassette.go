@@ -0,0 +1,320 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/dagger/dagger/engine/buildkit"
+	"github.com/dagger/dagger/util/scrub"
+)
+
+type LLMCassetteMode string
+
+var LLMCassetteModes = dagql.NewEnum[LLMCassetteMode]()
+
+var (
+	LLMCassetteModeRecord = LLMCassetteModes.Register("RECORD",
+		`Send requests to the model, and record them with their responses`,
+	)
+	LLMCassetteModeReplay = LLMCassetteModes.Register("REPLAY",
+		`Serve requests from the recorded responses, without calling the model, and fail on requests that weren't recorded`,
+	)
+)
+
+func (mode LLMCassetteMode) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "LLMCassetteMode",
+		NonNull:   true,
+	}
+}
+
+func (mode LLMCassetteMode) TypeDescription() string {
+	return "How an LLM uses its cassette"
+}
+
+func (mode LLMCassetteMode) Decoder() dagql.InputDecoder {
+	return LLMCassetteModes
+}
+
+func (mode LLMCassetteMode) ToLiteral() call.Literal {
+	return LLMCassetteModes.Literal(mode)
+}
+
+// The version of the cassette file format
+const llmCassetteVersion = 1
+
+// An LLMCassette records the requests sent to a model with their responses,
+// to replay them later without calling the model.
+//
+// A cassette is shared by all the LLMs derived from the one it was set on, so
+// that it records the whole conversation, including its branches.
+type LLMCassette struct {
+	Mode LLMCassetteMode
+
+	mu   sync.Mutex
+	data llmCassetteData
+}
+
+type llmCassetteData struct {
+	Version      int               `json:"version"`
+	Model        string            `json:"model,omitempty"`
+	Provider     LLMProvider       `json:"provider,omitempty"`
+	Interactions []*LLMInteraction `json:"interactions"`
+}
+
+// An LLMInteraction is a request sent to a model, and its response.
+type LLMInteraction struct {
+	Request  LLMCassetteRequest  `json:"request"`
+	Response LLMCassetteResponse `json:"response"`
+}
+
+type LLMCassetteRequest struct {
+	// The message history, without the default system prompt
+	Messages []*ModelMessage `json:"messages"`
+	// The names of the tools available to the model
+	Tools []string `json:"tools,omitempty"`
+}
+
+type LLMCassetteResponse struct {
+	Content    string        `json:"content"`
+	ToolCalls  []LLMToolCall `json:"tool_calls,omitempty"`
+	TokenUsage LLMTokenUsage `json:"token_usage,omitzero"`
+}
+
+// NewLLMCassette creates a cassette. In replay mode, the recording is loaded
+// from the given contents; in record mode, any previous recording is
+// discarded.
+func NewLLMCassette(mode LLMCassetteMode, contents []byte) (*LLMCassette, error) {
+	cassette := &LLMCassette{
+		Mode: mode,
+		data: llmCassetteData{Version: llmCassetteVersion},
+	}
+	if mode != LLMCassetteModeReplay {
+		return cassette, nil
+	}
+	if err := json.Unmarshal(contents, &cassette.data); err != nil {
+		return nil, fmt.Errorf("invalid cassette: %w", err)
+	}
+	if cassette.data.Version != llmCassetteVersion {
+		return nil, fmt.Errorf("unsupported cassette version %d", cassette.data.Version)
+	}
+	return cassette, nil
+}
+
+// Endpoint returns the endpoint a replayed conversation was recorded with.
+func (c *LLMCassette) Endpoint(model string) *LLMEndpoint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if model == "" {
+		model = c.data.Model
+	}
+	return &LLMEndpoint{
+		Model:    model,
+		Provider: c.data.Provider,
+		Price:    lookupLLMPrice(nil, model),
+	}
+}
+
+// MarshalJSON returns the contents of the cassette file.
+func (c *LLMCassette) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return json.MarshalIndent(c.data, "", "  ")
+}
+
+// Client returns a client sending a request with the given message history
+// through the cassette.
+//
+// The given secrets are scrubbed from the recorded interactions, as they are
+// from the output of execs, so that cassettes can be committed. Requests are
+// scrubbed the same way before being matched on replay.
+func (c *LLMCassette) Client(endpoint *LLMEndpoint, history []*ModelMessage, secrets []string) LLMClient {
+	scrub := func(s string) string { return s }
+	if len(secrets) > 0 {
+		scrub = func(s string) string {
+			// reading from a strings.Reader can't fail
+			out, _ := io.ReadAll(buildkit.NewSecretValuesScrubReader(strings.NewReader(s), secrets))
+			return string(out)
+		}
+	}
+	return &llmCassetteClient{
+		cassette: c,
+		endpoint: endpoint,
+		history:  slices.Clone(history),
+		scrub:    scrub,
+	}
+}
+
+// llmCassetteSecrets returns the plaintexts of the secrets of the current
+// client, to scrub from the cassettes it records.
+func llmCassetteSecrets(ctx context.Context) ([]string, error) {
+	query, err := CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := query.Secrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return store.Plaintexts(ctx), nil
+}
+
+type llmCassetteClient struct {
+	cassette *LLMCassette
+	endpoint *LLMEndpoint
+	history  []*ModelMessage
+	scrub    func(string) string
+}
+
+var _ LLMClient = (*llmCassetteClient)(nil)
+
+func (c *llmCassetteClient) IsRetryable(err error) bool {
+	if c.cassette.Mode == LLMCassetteModeReplay || c.endpoint.Client == nil {
+		return false
+	}
+	return c.endpoint.Client.IsRetryable(err)
+}
+
+func (c *llmCassetteClient) SendQuery(ctx context.Context, history []*ModelMessage, tools []LLMTool) (*LLMResponse, error) {
+	req := LLMCassetteRequest{Messages: make([]*ModelMessage, 0, len(c.history))}
+	for _, msg := range c.history {
+		scrubbed := *msg
+		scrubbed.Content = c.scrub(msg.Content)
+		scrubbed.ToolCalls = c.scrubToolCalls(msg.ToolCalls)
+		req.Messages = append(req.Messages, &scrubbed)
+	}
+	for _, tool := range tools {
+		req.Tools = append(req.Tools, tool.Name)
+	}
+	if c.cassette.Mode == LLMCassetteModeReplay {
+		return c.cassette.replay(req)
+	}
+	res, err := c.endpoint.Client.SendQuery(ctx, history, tools)
+	if err != nil {
+		return nil, err
+	}
+	c.cassette.record(c.endpoint, req, LLMCassetteResponse{
+		Content:    c.scrub(res.Content),
+		ToolCalls:  c.scrubToolCalls(res.ToolCalls),
+		TokenUsage: res.TokenUsage,
+	})
+	return res, nil
+}
+
+func (c *llmCassetteClient) scrubToolCalls(calls []LLMToolCall) []LLMToolCall {
+	if calls == nil {
+		return nil
+	}
+	scrubbed := make([]LLMToolCall, len(calls))
+	for i, call := range calls {
+		call.Function.Arguments, _ = c.scrubValue(call.Function.Arguments).(map[string]any)
+		scrubbed[i] = call
+	}
+	return scrubbed
+}
+
+// scrubValue scrubs the strings of a decoded JSON value.
+func (c *llmCassetteClient) scrubValue(val any) any {
+	switch val := val.(type) {
+	case string:
+		return c.scrub(val)
+	case map[string]any:
+		if val == nil {
+			return val
+		}
+		scrubbed := make(map[string]any, len(val))
+		for k, v := range val {
+			scrubbed[k] = c.scrubValue(v)
+		}
+		return scrubbed
+	case []any:
+		scrubbed := make([]any, len(val))
+		for i, v := range val {
+			scrubbed[i] = c.scrubValue(v)
+		}
+		return scrubbed
+	default:
+		return val
+	}
+}
+
+func (c *LLMCassette) record(endpoint *LLMEndpoint, req LLMCassetteRequest, res LLMCassetteResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.data.Model = endpoint.Model
+	c.data.Provider = endpoint.Provider
+	c.data.Interactions = append(c.data.Interactions, &LLMInteraction{
+		Request:  req,
+		Response: res,
+	})
+}
+
+func (c *LLMCassette) replay(req LLMCassetteRequest) (*LLMResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	want := req.normalize()
+	var closest *LLMInteraction
+	var closestLen int
+	for _, interaction := range c.data.Interactions {
+		got := interaction.Request.normalize()
+		if cmp.Equal(got, want) {
+			return &LLMResponse{
+				Content:    interaction.Response.Content,
+				ToolCalls:  slices.Clone(interaction.Response.ToolCalls),
+				TokenUsage: interaction.Response.TokenUsage,
+			}, nil
+		}
+		if n := commonPrefixLen(got.Messages, want.Messages); closest == nil || n > closestLen {
+			closest, closestLen = interaction, n
+		}
+	}
+	if closest == nil {
+		return nil, fmt.Errorf("cassette divergence: no interaction recorded")
+	}
+	return nil, fmt.Errorf("cassette divergence: no recorded request matches, closest recorded request differs (-recorded +actual):\n%s",
+		cmp.Diff(closest.Request.normalize(), want))
+}
+
+// normalize strips the parts of a request that aren't matched on replay.
+func (req LLMCassetteRequest) normalize() LLMCassetteRequest {
+	norm := LLMCassetteRequest{
+		Messages: make([]*ModelMessage, 0, len(req.Messages)),
+		Tools:    slices.Sorted(slices.Values(req.Tools)),
+	}
+	for _, msg := range req.Messages {
+		norm.Messages = append(norm.Messages, &ModelMessage{
+			Role:        msg.Role,
+			Content:     scrub.Stabilize(msg.Content),
+			ToolCalls:   msg.ToolCalls,
+			ToolCallID:  msg.ToolCallID,
+			ToolErrored: msg.ToolErrored,
+		})
+	}
+	// compare recorded and live requests in the same representation, e.g.
+	// numbers of tool call arguments
+	if js, err := json.Marshal(norm); err == nil {
+		var roundtripped LLMCassetteRequest
+		if err := json.Unmarshal(js, &roundtripped); err == nil {
+			return roundtripped
+		}
+	}
+	return norm
+}
+
+func commonPrefixLen(a, b []*ModelMessage) int {
+	n := 0
+	for n < len(a) && n < len(b) && cmp.Equal(a[n], b[n]) {
+		n++
+	}
+	return n
+}
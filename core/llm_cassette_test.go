@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLLMClient struct {
+	calls int
+}
+
+func (c *fakeLLMClient) SendQuery(ctx context.Context, history []*ModelMessage, tools []LLMTool) (*LLMResponse, error) {
+	c.calls++
+	last := history[len(history)-1]
+	if last.ToolCallID != "" {
+		return &LLMResponse{Content: "done: " + last.Content}, nil
+	}
+	return &LLMResponse{
+		ToolCalls: []LLMToolCall{{
+			ID:       "call_1",
+			Function: FuncCall{Name: "read", Arguments: map[string]any{"path": "README.md", "limit": 10}},
+			Type:     "function",
+		}},
+		TokenUsage: LLMTokenUsage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15},
+	}, nil
+}
+
+func (c *fakeLLMClient) IsRetryable(err error) bool {
+	return false
+}
+
+func TestLLMCassette(t *testing.T) {
+	ctx := context.Background()
+	inner := &fakeLLMClient{}
+	endpoint := &LLMEndpoint{Model: "fake-model", Provider: OpenAI, Client: inner}
+	tools := []LLMTool{{Name: "read"}, {Name: "write"}}
+	system := &ModelMessage{Role: "system", Content: "default system prompt"}
+
+	// record a conversation with a tool call
+	recorder, err := NewLLMCassette(LLMCassetteModeRecord, nil)
+	require.NoError(t, err)
+	history := []*ModelMessage{{Role: "user", Content: "read the readme"}}
+	res, err := recorder.Client(endpoint, history, nil).SendQuery(ctx, append([]*ModelMessage{system}, history...), tools)
+	require.NoError(t, err)
+	history = append(history,
+		&ModelMessage{Role: "assistant", ToolCalls: res.ToolCalls, TokenUsage: res.TokenUsage},
+		&ModelMessage{Role: "user", ToolCallID: "call_1", Content: "# Hello"},
+	)
+	res, err = recorder.Client(endpoint, history, nil).SendQuery(ctx, append([]*ModelMessage{system}, history...), tools)
+	require.NoError(t, err)
+	require.Equal(t, "done: # Hello", res.Content)
+	require.Equal(t, 2, inner.calls)
+
+	contents, err := json.Marshal(recorder)
+	require.NoError(t, err)
+
+	// replay it without calling the model
+	player, err := NewLLMCassette(LLMCassetteModeReplay, contents)
+	require.NoError(t, err)
+	replayEndpoint := player.Endpoint("")
+	require.Equal(t, "fake-model", replayEndpoint.Model)
+	require.Equal(t, OpenAI, replayEndpoint.Provider)
+
+	// the default system prompt, and the order of tools, don't matter
+	replayed, err := player.Client(replayEndpoint, history, nil).SendQuery(ctx, history, []LLMTool{{Name: "write"}, {Name: "read"}})
+	require.NoError(t, err)
+	require.Equal(t, "done: # Hello", replayed.Content)
+
+	replayed, err = player.Client(replayEndpoint, history[:1], nil).SendQuery(ctx, history[:1], tools)
+	require.NoError(t, err)
+	require.Len(t, replayed.ToolCalls, 1)
+	require.Equal(t, "read", replayed.ToolCalls[0].Function.Name)
+	require.Equal(t, int64(15), replayed.TokenUsage.TotalTokens)
+	require.Equal(t, 2, inner.calls)
+
+	// diverging requests fail
+	diverged := []*ModelMessage{history[0], history[1], {Role: "user", ToolCallID: "call_1", Content: "# Goodbye"}}
+	_, err = player.Client(replayEndpoint, diverged, nil).SendQuery(ctx, diverged, tools)
+	require.ErrorContains(t, err, "cassette divergence")
+	require.ErrorContains(t, err, "# Goodbye")
+	require.False(t, player.Client(replayEndpoint, diverged, nil).IsRetryable(err))
+
+	_, err = NewLLMCassette(LLMCassetteModeReplay, []byte(`{"version": 2}`))
+	require.ErrorContains(t, err, "unsupported cassette version 2")
+}
+
+func TestLLMCassetteScrubsSecrets(t *testing.T) {
+	ctx := context.Background()
+	inner := &fakeLLMClient{}
+	endpoint := &LLMEndpoint{Model: "fake-model", Provider: OpenAI, Client: inner}
+	tools := []LLMTool{{Name: "read"}}
+	secrets := []string{"hunter2-hunter2"}
+
+	recorder, err := NewLLMCassette(LLMCassetteModeRecord, nil)
+	require.NoError(t, err)
+	history := []*ModelMessage{
+		{Role: "user", Content: "read the readme"},
+		{Role: "assistant", ToolCalls: []LLMToolCall{{
+			ID:       "call_1",
+			Function: FuncCall{Name: "read", Arguments: map[string]any{"token": "hunter2-hunter2"}},
+			Type:     "function",
+		}}},
+		{Role: "user", ToolCallID: "call_1", Content: "token=hunter2-hunter2"},
+	}
+	res, err := recorder.Client(endpoint, history, secrets).SendQuery(ctx, history, tools)
+	require.NoError(t, err)
+	// the response itself isn't scrubbed
+	require.Equal(t, "done: token=hunter2-hunter2", res.Content)
+
+	contents, err := json.Marshal(recorder)
+	require.NoError(t, err)
+	require.NotContains(t, string(contents), "hunter2")
+	require.Contains(t, string(contents), "done: token=***")
+
+	// requests are scrubbed before being matched on replay
+	player, err := NewLLMCassette(LLMCassetteModeReplay, contents)
+	require.NoError(t, err)
+	replayed, err := player.Client(player.Endpoint(""), history, secrets).SendQuery(ctx, history, tools)
+	require.NoError(t, err)
+	require.Equal(t, "done: token=***", replayed.Content)
+}
@@ -26,8 +26,18 @@ type GenaiClient struct {
 
 func newGenaiClient(endpoint *LLMEndpoint) (*GenaiClient, error) {
 	ctx := context.Background() // FIXME: should we wire this through from somewhere else?
+	httpOpts := genai.HTTPOptions{
+		BaseURL: endpoint.BaseURL,
+	}
+	if len(endpoint.Headers) > 0 {
+		httpOpts.Headers = http.Header{}
+		for k, v := range endpoint.Headers {
+			httpOpts.Headers.Set(k, v)
+		}
+	}
 	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: endpoint.Key,
+		APIKey:      endpoint.Key,
+		HTTPOptions: httpOpts,
 	})
 	if err != nil {
 		return nil, err
@@ -289,7 +299,7 @@ func (c *GenaiClient) SendQuery(ctx context.Context, history []*ModelMessage, to
 
 	content, toolCalls, tokenUsage, err := c.processStreamResponse(
 		stream,
-		stdio.Stdout,
+		io.MultiWriter(stdio.Stdout, llmStreamWriter(ctx)),
 		tokenHandler,
 	)
 	if err != nil {
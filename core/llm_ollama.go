@@ -0,0 +1,273 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"dagger.io/dagger/telemetry"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const ollamaDefaultBaseURL = "http://localhost:11434"
+
+// OllamaClient talks to the native chat API of Ollama, and compatible model
+// servers.
+type OllamaClient struct {
+	httpClient *http.Client
+	endpoint   *LLMEndpoint
+}
+
+func newOllamaClient(endpoint *LLMEndpoint) *OllamaClient {
+	return &OllamaClient{
+		httpClient: http.DefaultClient,
+		endpoint:   endpoint,
+	}
+}
+
+var _ LLMClient = (*OllamaClient)(nil)
+
+type ollamaChatRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []ollamaTool    `json:"tools,omitempty"`
+	Stream   bool            `json:"stream"`
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+	ToolName  string           `json:"tool_name,omitempty"`
+}
+
+type ollamaTool struct {
+	Type     string             `json:"type"`
+	Function ollamaToolFunction `json:"function"`
+}
+
+type ollamaToolFunction struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function ollamaToolCallFunction `json:"function"`
+}
+
+type ollamaToolCallFunction struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type ollamaChatResponse struct {
+	Message         ollamaMessage `json:"message"`
+	Done            bool          `json:"done"`
+	DoneReason      string        `json:"done_reason"`
+	PromptEvalCount int64         `json:"prompt_eval_count"`
+	EvalCount       int64         `json:"eval_count"`
+	Error           string        `json:"error"`
+}
+
+// ollamaError is an error response of the Ollama API.
+type ollamaError struct {
+	StatusCode int
+	Message    string
+}
+
+func (err *ollamaError) Error() string {
+	return fmt.Sprintf("ollama: %s (status %d)", err.Message, err.StatusCode)
+}
+
+func (c *OllamaClient) IsRetryable(err error) bool {
+	var apiErr *ollamaError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	// the model may still be loading, or the server overloaded
+	return apiErr.StatusCode == http.StatusTooManyRequests ||
+		apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+func (c *OllamaClient) SendQuery(ctx context.Context, history []*ModelMessage, tools []LLMTool) (_ *LLMResponse, rerr error) {
+	stdio := telemetry.SpanStdio(ctx, InstrumentationLibrary,
+		log.String(telemetry.ContentTypeAttr, "text/markdown"))
+	defer stdio.Close()
+
+	m := telemetry.Meter(ctx, InstrumentationLibrary)
+	spanCtx := trace.SpanContextFromContext(ctx)
+	attrs := []attribute.KeyValue{
+		attribute.String(telemetry.MetricsTraceIDAttr, spanCtx.TraceID().String()),
+		attribute.String(telemetry.MetricsSpanIDAttr, spanCtx.SpanID().String()),
+		attribute.String("model", c.endpoint.Model),
+		attribute.String("provider", string(c.endpoint.Provider)),
+	}
+
+	inputTokens, err := m.Int64Gauge(telemetry.LLMInputTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	outputTokens, err := m.Int64Gauge(telemetry.LLMOutputTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	req := ollamaChatRequest{
+		Model:    c.endpoint.Model,
+		Messages: ollamaMessages(history),
+		Stream:   true,
+	}
+	for _, tool := range tools {
+		req.Tools = append(req.Tools, ollamaTool{
+			Type: "function",
+			Function: ollamaToolFunction{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Schema,
+			},
+		})
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshal chat request: %w", err)
+	}
+	baseURL := c.endpoint.BaseURL
+	if baseURL == "" {
+		baseURL = ollamaDefaultBaseURL
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(baseURL, "/")+"/api/chat", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.endpoint.Key != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.endpoint.Key)
+	}
+	for k, v := range c.endpoint.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		var errResp ollamaChatResponse
+		if json.Unmarshal(msg, &errResp) == nil && errResp.Error != "" {
+			msg = []byte(errResp.Error)
+		}
+		return nil, &ollamaError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(msg))}
+	}
+
+	// the response is streamed as newline-delimited JSON
+	var content strings.Builder
+	var toolCalls []LLMToolCall
+	var last ollamaChatResponse
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var chunk ollamaChatResponse
+		if err := json.Unmarshal(scanner.Bytes(), &chunk); err != nil {
+			return nil, fmt.Errorf("decode chat response: %w", err)
+		}
+		if chunk.Error != "" {
+			return nil, &ollamaError{StatusCode: resp.StatusCode, Message: chunk.Error}
+		}
+		if chunk.Message.Content != "" {
+			content.WriteString(chunk.Message.Content)
+			fmt.Fprint(stdio.Stdout, chunk.Message.Content)
+			streamLLMText(ctx, chunk.Message.Content)
+		}
+		for _, call := range chunk.Message.ToolCalls {
+			toolCalls = append(toolCalls, LLMToolCall{
+				// Ollama doesn't identify tool calls, so number them
+				ID: fmt.Sprintf("call_%d_%d", len(history), len(toolCalls)),
+				Function: FuncCall{
+					Name:      call.Function.Name,
+					Arguments: call.Function.Arguments,
+				},
+				Type: "function",
+			})
+		}
+		last = chunk
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read chat response: %w", err)
+	}
+
+	if last.PromptEvalCount > 0 {
+		inputTokens.Record(ctx, last.PromptEvalCount, metric.WithAttributes(attrs...))
+	}
+	if last.EvalCount > 0 {
+		outputTokens.Record(ctx, last.EvalCount, metric.WithAttributes(attrs...))
+	}
+
+	if content.Len() == 0 && len(toolCalls) == 0 {
+		return nil, &ModelFinishedError{
+			Reason: last.DoneReason,
+		}
+	}
+
+	return &LLMResponse{
+		Content:   content.String(),
+		ToolCalls: toolCalls,
+		TokenUsage: LLMTokenUsage{
+			InputTokens:  last.PromptEvalCount,
+			OutputTokens: last.EvalCount,
+			TotalTokens:  last.PromptEvalCount + last.EvalCount,
+		},
+	}, nil
+}
+
+// ollamaMessages converts the history to Ollama messages. Tool results are
+// identified by the name of the tool rather than by the ID of the call.
+func ollamaMessages(history []*ModelMessage) []ollamaMessage {
+	toolNames := map[string]string{}
+	msgs := make([]ollamaMessage, 0, len(history))
+	for _, msg := range history {
+		if msg.ToolCallID != "" {
+			content := msg.Content
+			if msg.ToolErrored {
+				content = "error: " + content
+			}
+			msgs = append(msgs, ollamaMessage{
+				Role:     "tool",
+				Content:  content,
+				ToolName: toolNames[msg.ToolCallID],
+			})
+			continue
+		}
+		ollamaMsg := ollamaMessage{
+			Role:    msg.Role,
+			Content: msg.Content,
+		}
+		for _, call := range msg.ToolCalls {
+			toolNames[call.ID] = call.Function.Name
+			ollamaMsg.ToolCalls = append(ollamaMsg.ToolCalls, ollamaToolCall{
+				Function: ollamaToolCallFunction{
+					Name:      call.Function.Name,
+					Arguments: call.Function.Arguments,
+				},
+			})
+		}
+		msgs = append(msgs, ollamaMsg)
+	}
+	return msgs
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOllamaSendQuery(t *testing.T) {
+	var req ollamaChatRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/chat", r.URL.Path)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "ci", r.Header.Get("X-Team"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":"Let me "}}`)
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":"check.","tool_calls":[{"function":{"name":"read","arguments":{"path":"README.md"}}}]}}`)
+		fmt.Fprintln(w, `{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":12,"eval_count":5}`)
+	}))
+	defer srv.Close()
+
+	client := newOllamaClient(&LLMEndpoint{
+		Model:    "qwen2.5-coder",
+		BaseURL:  srv.URL,
+		Key:      "secret",
+		Headers:  map[string]string{"X-Team": "ci"},
+		Provider: Ollama,
+	})
+	history := []*ModelMessage{
+		{Role: "user", Content: "list the files"},
+		{Role: "assistant", ToolCalls: []LLMToolCall{{
+			ID:       "call_1_0",
+			Function: FuncCall{Name: "ls", Arguments: map[string]any{}},
+			Type:     "function",
+		}}},
+		{Role: "user", ToolCallID: "call_1_0", Content: "README.md"},
+	}
+	res, err := client.SendQuery(context.Background(), history, []LLMTool{{
+		Name:        "read",
+		Description: "Read a file",
+		Schema:      map[string]any{"type": "object"},
+	}})
+	require.NoError(t, err)
+
+	require.Equal(t, "qwen2.5-coder", req.Model)
+	require.True(t, req.Stream)
+	require.Equal(t, []ollamaTool{{
+		Type: "function",
+		Function: ollamaToolFunction{
+			Name:        "read",
+			Description: "Read a file",
+			Parameters:  map[string]any{"type": "object"},
+		},
+	}}, req.Tools)
+	// tool results are identified by the name of the tool
+	require.Equal(t, ollamaMessage{Role: "tool", Content: "README.md", ToolName: "ls"}, req.Messages[2])
+
+	require.Equal(t, "Let me check.", res.Content)
+	require.Len(t, res.ToolCalls, 1)
+	require.Equal(t, "read", res.ToolCalls[0].Function.Name)
+	require.Equal(t, map[string]any{"path": "README.md"}, res.ToolCalls[0].Function.Arguments)
+	require.Equal(t, LLMTokenUsage{InputTokens: 12, OutputTokens: 5, TotalTokens: 17}, res.TokenUsage)
+}
+
+func TestOllamaIsRetryable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":"server busy"}`)
+	}))
+	defer srv.Close()
+
+	client := newOllamaClient(&LLMEndpoint{Model: "llama3.2", BaseURL: srv.URL, Provider: Ollama})
+	_, err := client.SendQuery(context.Background(), []*ModelMessage{{Role: "user", Content: "hi"}}, nil)
+	require.ErrorContains(t, err, "server busy")
+	require.True(t, client.IsRetryable(err))
+	require.False(t, client.IsRetryable(fmt.Errorf("boom")))
+}
@@ -25,6 +25,9 @@ type OpenAIClient struct {
 func newOpenAIClient(endpoint *LLMEndpoint, azureVersion string, disableStreaming bool) *OpenAIClient {
 	var opts []option.RequestOption
 	opts = append(opts, option.WithHeader("Content-Type", "application/json"))
+	for k, v := range endpoint.Headers {
+		opts = append(opts, option.WithHeader(k, v))
+	}
 	if azureVersion != "" {
 		opts = append(opts, azure.WithEndpoint(endpoint.BaseURL, azureVersion))
 		if endpoint.Key != "" {
@@ -224,6 +227,7 @@ func (c *OpenAIClient) queryWithStreaming(
 		if len(res.Choices) > 0 {
 			if content := res.Choices[0].Delta.Content; content != "" {
 				fmt.Fprint(stdio.Stdout, content)
+				streamLLMText(ctx, content)
 			}
 		}
 	}
@@ -258,6 +262,7 @@ func (c *OpenAIClient) queryWithoutStreaming(
 	if len(compl.Choices) > 0 {
 		if content := compl.Choices[0].Message.Content; content != "" {
 			fmt.Fprint(stdio.Stdout, content)
+			streamLLMText(ctx, content)
 		}
 	}
 
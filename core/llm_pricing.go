@@ -0,0 +1,189 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"sync"
+
+	"github.com/dagger/dagger/engine/config"
+)
+
+// llmPrices are the built-in prices of models, in USD per million tokens,
+// keyed by glob patterns matching model names. When several patterns match a
+// model, the longest one wins.
+var llmPrices = map[string]config.LLMModelPrice{
+	"claude-opus-4*":     {Input: 15, Output: 75, CacheReads: 1.5, CacheWrites: 18.75},
+	"claude-opus-4-5*":   {Input: 5, Output: 25, CacheReads: 0.5, CacheWrites: 6.25},
+	"claude-sonnet-4*":   {Input: 3, Output: 15, CacheReads: 0.3, CacheWrites: 3.75},
+	"claude-3-7-sonnet*": {Input: 3, Output: 15, CacheReads: 0.3, CacheWrites: 3.75},
+	"claude-3-5-sonnet*": {Input: 3, Output: 15, CacheReads: 0.3, CacheWrites: 3.75},
+	"claude-haiku-4-5*":  {Input: 1, Output: 5, CacheReads: 0.1, CacheWrites: 1.25},
+	"claude-3-5-haiku*":  {Input: 0.8, Output: 4, CacheReads: 0.08, CacheWrites: 1},
+
+	"gpt-5*":        {Input: 1.25, Output: 10, CacheReads: 0.125},
+	"gpt-5-mini*":   {Input: 0.25, Output: 2, CacheReads: 0.025},
+	"gpt-5-nano*":   {Input: 0.05, Output: 0.4, CacheReads: 0.005},
+	"gpt-4.1*":      {Input: 2, Output: 8, CacheReads: 0.5},
+	"gpt-4.1-mini*": {Input: 0.4, Output: 1.6, CacheReads: 0.1},
+	"gpt-4.1-nano*": {Input: 0.1, Output: 0.4, CacheReads: 0.025},
+	"gpt-4o*":       {Input: 2.5, Output: 10, CacheReads: 1.25},
+	"gpt-4o-mini*":  {Input: 0.15, Output: 0.6, CacheReads: 0.075},
+
+	"gemini-2.5-pro*":   {Input: 1.25, Output: 10, CacheReads: 0.31},
+	"gemini-2.5-flash*": {Input: 0.3, Output: 2.5, CacheReads: 0.075},
+	"gemini-2.0-flash*": {Input: 0.1, Output: 0.4, CacheReads: 0.025},
+}
+
+// lookupLLMPrice returns the price of a model, from the configured prices if
+// any match it, or else from the built-in ones.
+func lookupLLMPrice(cfg *config.LLMConfig, model string) *config.LLMModelPrice {
+	if cfg != nil {
+		if price, ok := matchLLMPrice(cfg.Prices, model); ok {
+			return &price
+		}
+	}
+	if price, ok := matchLLMPrice(llmPrices, model); ok {
+		return &price
+	}
+	return nil
+}
+
+func matchLLMPrice(prices map[string]config.LLMModelPrice, model string) (config.LLMModelPrice, bool) {
+	if price, ok := prices[model]; ok {
+		return price, true
+	}
+	var best string
+	for pattern := range prices {
+		if ok, _ := path.Match(pattern, model); ok && len(pattern) > len(best) {
+			best = pattern
+		}
+	}
+	if best == "" {
+		return config.LLMModelPrice{}, false
+	}
+	return prices[best], true
+}
+
+// maxLLMPrice returns the highest of two prices, per kind of token.
+func maxLLMPrice(a, b config.LLMModelPrice) config.LLMModelPrice {
+	return config.LLMModelPrice{
+		Input:       max(a.Input, b.Input),
+		Output:      max(a.Output, b.Output),
+		CacheReads:  max(a.CacheReads, b.CacheReads),
+		CacheWrites: max(a.CacheWrites, b.CacheWrites),
+	}
+}
+
+// llmCost returns the cost of the token usage of a model, in USD.
+func llmCost(provider LLMProvider, price config.LLMModelPrice, usage LLMTokenUsage) float64 {
+	input := usage.InputTokens
+	switch provider {
+	case Anthropic, Bedrock:
+		// cached tokens are reported separately from the input tokens
+	default:
+		input -= usage.CachedTokenReads
+	}
+	return (float64(input)*price.Input +
+		float64(usage.OutputTokens)*price.Output +
+		float64(usage.CachedTokenReads)*price.CacheReads +
+		float64(usage.CachedTokenWrites)*price.CacheWrites) / 1e6
+}
+
+// LLMBudgetExceededError is returned when an LLM exceeds the token or cost
+// budget configured with LLM.withBudget, or the session or module budget of
+// the LLM configuration.
+type LLMBudgetExceededError struct {
+	// The scope of the exceeded budget: "llm", "session" or "module"
+	Scope string
+	// The exceeded limit: "tokens" or "cost"
+	Limit string
+	// The usage and maximum of the limit, in tokens or USD
+	Used float64
+	Max  float64
+}
+
+func (e *LLMBudgetExceededError) Error() string {
+	budget := "LLM budget"
+	if e.Scope != "llm" {
+		budget = "LLM " + e.Scope + " budget"
+	}
+	if e.Limit == "cost" {
+		return fmt.Sprintf("%s exceeded: cost $%.4f over maximum $%.4f", budget, e.Used, e.Max)
+	}
+	return fmt.Sprintf("%s exceeded: %d tokens over maximum %d", budget, int64(e.Used), int64(e.Max))
+}
+
+func (e *LLMBudgetExceededError) Extensions() map[string]any {
+	return map[string]any{
+		"_type": "LLM_BUDGET_EXCEEDED",
+		"scope": e.Scope,
+		"limit": e.Limit,
+		"used":  e.Used,
+		"max":   e.Max,
+	}
+}
+
+// checkLLMBudget returns an LLMBudgetExceededError if the usage exceeds the
+// budget, if any.
+func checkLLMBudget(scope string, usage LLMTokenUsage, budget *config.LLMBudget) error {
+	if budget == nil {
+		return nil
+	}
+	if budget.MaxTokens > 0 && usage.TotalTokens > budget.MaxTokens {
+		return &LLMBudgetExceededError{
+			Scope: scope,
+			Limit: "tokens",
+			Used:  float64(usage.TotalTokens),
+			Max:   float64(budget.MaxTokens),
+		}
+	}
+	if budget.MaxCost > 0 && usage.Cost > budget.MaxCost {
+		return &LLMBudgetExceededError{
+			Scope: scope,
+			Limit: "cost",
+			Used:  usage.Cost,
+			Max:   budget.MaxCost,
+		}
+	}
+	return nil
+}
+
+// LLMSessionUsage is the LLM usage of a session, in total and per module, so
+// that the session and module budgets hold across all the LLMs of a session.
+type LLMSessionUsage struct {
+	mu      sync.Mutex
+	total   LLMTokenUsage
+	modules map[string]LLMTokenUsage
+}
+
+func NewLLMSessionUsage() *LLMSessionUsage {
+	return &LLMSessionUsage{
+		modules: map[string]LLMTokenUsage{},
+	}
+}
+
+// Add adds the usage of an API call made by a module, keyed by the digest of
+// its source, or by a client of the session if module is empty.
+func (u *LLMSessionUsage) Add(module string, usage LLMTokenUsage) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.total = u.total.add(usage)
+	if module != "" {
+		u.modules[module] = u.modules[module].add(usage)
+	}
+}
+
+// Check returns an LLMBudgetExceededError if the usage of the session exceeds
+// the session budget, or if the usage of the module, if any, exceeds the
+// module budget.
+func (u *LLMSessionUsage) Check(module string, sessionBudget, moduleBudget *config.LLMBudget) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if err := checkLLMBudget("session", u.total, sessionBudget); err != nil {
+		return err
+	}
+	if module == "" {
+		return nil
+	}
+	return checkLLMBudget("module", u.modules[module], moduleBudget)
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/engine/config"
+)
+
+func TestLookupLLMPrice(t *testing.T) {
+	// the longest matching pattern wins
+	price := lookupLLMPrice(nil, "claude-opus-4-5-20251101")
+	require.NotNil(t, price)
+	require.Equal(t, 5.0, price.Input)
+	price = lookupLLMPrice(nil, "claude-opus-4-1-20250805")
+	require.NotNil(t, price)
+	require.Equal(t, 15.0, price.Input)
+	price = lookupLLMPrice(nil, "gpt-4.1-mini")
+	require.NotNil(t, price)
+	require.Equal(t, 0.4, price.Input)
+
+	require.Nil(t, lookupLLMPrice(nil, "qwen2.5-coder"))
+
+	// configured prices take precedence
+	cfg := &config.LLMConfig{Prices: map[string]config.LLMModelPrice{
+		"qwen*":  {Input: 0.1, Output: 0.2},
+		"gpt-5*": {Input: 1, Output: 2},
+	}}
+	price = lookupLLMPrice(cfg, "qwen2.5-coder")
+	require.NotNil(t, price)
+	require.Equal(t, config.LLMModelPrice{Input: 0.1, Output: 0.2}, *price)
+	price = lookupLLMPrice(cfg, "gpt-5-mini")
+	require.NotNil(t, price)
+	require.Equal(t, 1.0, price.Input)
+}
+
+func TestLLMRouterPrice(t *testing.T) {
+	router := &LLMRouter{
+		Config: (&config.LLMConfig{Prices: map[string]config.LLMModelPrice{
+			"qwen*": {Input: 0.1, Output: 0.2},
+		}}).Merge(&config.LLMConfig{Prices: map[string]config.LLMModelPrice{
+			"qwen*": {Input: 0, Output: 0},
+		}}),
+		ClientPrices: map[string]config.LLMModelPrice{
+			"qwen2.5*":  {Input: 0.05, Output: 0.4},
+			"claude-*":  {},
+			"llama3.3*": {Input: 0.2, Output: 0.2},
+		},
+	}
+	// the client can't lower the engine's prices, but can raise them
+	price := router.price("qwen2.5-coder")
+	require.NotNil(t, price)
+	require.Equal(t, config.LLMModelPrice{Input: 0.1, Output: 0.4}, *price)
+	// nor the built-in ones
+	price = router.price("claude-sonnet-4-5")
+	require.NotNil(t, price)
+	require.Equal(t, 3.0, price.Input)
+	// and can price unknown models
+	price = router.price("llama3.3")
+	require.NotNil(t, price)
+	require.Equal(t, config.LLMModelPrice{Input: 0.2, Output: 0.2}, *price)
+	require.Nil(t, router.price("mistral"))
+}
+
+func TestLLMCost(t *testing.T) {
+	price := config.LLMModelPrice{Input: 3, Output: 15, CacheReads: 0.3, CacheWrites: 3.75}
+	usage := LLMTokenUsage{
+		InputTokens:       1_000_000,
+		OutputTokens:      100_000,
+		CachedTokenReads:  500_000,
+		CachedTokenWrites: 200_000,
+	}
+	// Anthropic reports cached tokens separately from input tokens
+	require.InDelta(t, 3+1.5+0.15+0.75, llmCost(Anthropic, price, usage), 1e-9)
+	// OpenAI counts cached tokens in the input tokens
+	require.InDelta(t, 1.5+1.5+0.15+0.75, llmCost(OpenAI, price, usage), 1e-9)
+}
+
+func TestLLMCheckBudget(t *testing.T) {
+	llm := &LLM{
+		messages: []*ModelMessage{
+			{Role: "user", Content: "hi"},
+			{Role: "assistant", TokenUsage: LLMTokenUsage{TotalTokens: 600, Cost: 0.02}},
+			{Role: "assistant", TokenUsage: LLMTokenUsage{TotalTokens: 600, Cost: 0.02}},
+		},
+	}
+	require.NoError(t, llm.checkBudget())
+
+	llm.maxTokens = 1000
+	var budgetErr *LLMBudgetExceededError
+	require.True(t, errors.As(llm.checkBudget(), &budgetErr))
+	require.Equal(t, "tokens", budgetErr.Limit)
+	require.Equal(t, 1200.0, budgetErr.Used)
+	require.Equal(t, "LLM_BUDGET_EXCEEDED", budgetErr.Extensions()["_type"])
+
+	llm.maxTokens = 2000
+	llm.maxCost = 0.03
+	require.True(t, errors.As(llm.checkBudget(), &budgetErr))
+	require.Equal(t, "cost", budgetErr.Limit)
+	require.ErrorContains(t, budgetErr, "cost $0.0400 over maximum $0.0300")
+
+	llm.maxCost = 0.05
+	require.NoError(t, llm.checkBudget())
+}
+
+func TestLLMSessionUsage(t *testing.T) {
+	usage := NewLLMSessionUsage()
+	sessionBudget := &config.LLMBudget{MaxTokens: 1000}
+	moduleBudget := &config.LLMBudget{MaxCost: 0.03}
+
+	usage.Add("", LLMTokenUsage{TotalTokens: 400, Cost: 0.01})
+	usage.Add("foo", LLMTokenUsage{TotalTokens: 400, Cost: 0.02})
+	require.NoError(t, usage.Check("foo", sessionBudget, moduleBudget))
+
+	// the usage of the module adds up across its LLMs
+	usage.Add("foo", LLMTokenUsage{TotalTokens: 100, Cost: 0.02})
+	var budgetErr *LLMBudgetExceededError
+	require.True(t, errors.As(usage.Check("foo", sessionBudget, moduleBudget), &budgetErr))
+	require.Equal(t, "module", budgetErr.Scope)
+	require.Equal(t, "cost", budgetErr.Limit)
+	require.ErrorContains(t, budgetErr, "LLM module budget exceeded")
+
+	// other modules have their own budget
+	require.NoError(t, usage.Check("bar", sessionBudget, moduleBudget))
+
+	// the usage of the session adds up across all of its modules
+	usage.Add("bar", LLMTokenUsage{TotalTokens: 200})
+	require.True(t, errors.As(usage.Check("bar", sessionBudget, moduleBudget), &budgetErr))
+	require.Equal(t, "session", budgetErr.Scope)
+	require.Equal(t, 1100.0, budgetErr.Used)
+	require.Equal(t, "session", budgetErr.Extensions()["scope"])
+
+	// budgets of the engine and client config can only be tightened
+	merged := (&config.LLMConfig{SessionBudget: &config.LLMBudget{MaxTokens: 1000}}).
+		Merge(&config.LLMConfig{SessionBudget: &config.LLMBudget{MaxTokens: 5000, MaxCost: 1}})
+	require.Equal(t, &config.LLMBudget{MaxTokens: 1000, MaxCost: 1}, merged.SessionBudget)
+}
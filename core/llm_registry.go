@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"path"
+	"strings"
+
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/bedrock"
+	"github.com/anthropics/anthropic-sdk-go/option"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+
+	"github.com/dagger/dagger/engine/config"
+)
+
+// An llmProviderFactory creates the client of an endpoint configured with a
+// provider. Secret references of the config are resolved.
+type llmProviderFactory func(endpoint *LLMEndpoint, cfg config.LLMEndpointConfig) (LLMClient, error)
+
+// llmProviders are the providers that LLM endpoints can be configured with,
+// keyed by the provider name used in the config.
+var llmProviders = map[LLMProvider]llmProviderFactory{
+	Anthropic: func(endpoint *LLMEndpoint, _ config.LLMEndpointConfig) (LLMClient, error) {
+		return newAnthropicClient(endpoint), nil
+	},
+	Bedrock: func(endpoint *LLMEndpoint, cfg config.LLMEndpointConfig) (LLMClient, error) {
+		return newBedrockClient(endpoint, cfg.Region, cfg.SecretKey), nil
+	},
+	OpenAI: func(endpoint *LLMEndpoint, cfg config.LLMEndpointConfig) (LLMClient, error) {
+		return newOpenAIClient(endpoint, cfg.AzureVersion, cfg.DisableStreaming), nil
+	},
+	Google: func(endpoint *LLMEndpoint, _ config.LLMEndpointConfig) (LLMClient, error) {
+		return newGenaiClient(endpoint)
+	},
+	Ollama: func(endpoint *LLMEndpoint, _ config.LLMEndpointConfig) (LLMClient, error) {
+		return newOllamaClient(endpoint), nil
+	},
+}
+
+// newBedrockClient creates a client for Anthropic models on Amazon Bedrock,
+// authenticating with the access key ID of the endpoint and the given secret
+// access key.
+func newBedrockClient(endpoint *LLMEndpoint, region, secretKey string) *AnthropicClient {
+	awsCfg := aws.Config{
+		Region:      region,
+		Credentials: credentials.NewStaticCredentialsProvider(endpoint.Key, secretKey, ""),
+	}
+	opts := []option.RequestOption{bedrock.WithConfig(awsCfg)}
+	if endpoint.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(endpoint.BaseURL))
+	}
+	for k, v := range endpoint.Headers {
+		opts = append(opts, option.WithHeader(k, v))
+	}
+	client := anthropic.NewClient(opts...)
+	return &AnthropicClient{
+		client:   &client,
+		endpoint: endpoint,
+	}
+}
+
+// resolveConfigModel resolves a model alias of the config, and the endpoint
+// the model is routed to, if any. Models prefixed with the name of an
+// endpoint are routed to it, then models matching the model patterns of an
+// endpoint, in the order of the endpoint names.
+func (r *LLMRouter) resolveConfigModel(model string) (endpointName, _ string) {
+	if r.Config == nil {
+		return "", model
+	}
+	if alias, ok := r.Config.Aliases[model]; ok {
+		model = alias
+	}
+	if name, rest, ok := strings.Cut(model, "/"); ok {
+		if _, ok := r.Config.Endpoints[name]; ok {
+			return name, rest
+		}
+	}
+	for _, name := range r.Config.EndpointNames() {
+		for _, pattern := range r.Config.Endpoints[name].Models {
+			// patterns are validated when loading the config
+			if ok, _ := path.Match(pattern, model); ok {
+				return name, model
+			}
+		}
+	}
+	return "", model
+}
+
+// routeConfigEndpoint returns an endpoint for a model served by a configured
+// endpoint, resolving its secrets.
+func (r *LLMRouter) routeConfigEndpoint(ctx context.Context, name, model string) (*LLMEndpoint, error) {
+	cfg := r.Config.Endpoints[name]
+	factory, ok := llmProviders[LLMProvider(cfg.Provider)]
+	if !ok {
+		return nil, fmt.Errorf("llm endpoint %q: unknown provider %q", name, cfg.Provider)
+	}
+	var err error
+	if cfg.Key, err = r.loadSecret(ctx, cfg.Key); err != nil {
+		return nil, fmt.Errorf("llm endpoint %q: load key: %w", name, err)
+	}
+	if cfg.SecretKey, err = r.loadSecret(ctx, cfg.SecretKey); err != nil {
+		return nil, fmt.Errorf("llm endpoint %q: load secret key: %w", name, err)
+	}
+	headers := maps.Clone(cfg.Headers)
+	for k, v := range headers {
+		if headers[k], err = r.loadSecret(ctx, v); err != nil {
+			return nil, fmt.Errorf("llm endpoint %q: load header %q: %w", name, k, err)
+		}
+	}
+	cfg.Headers = headers
+
+	endpoint := &LLMEndpoint{
+		Model:    model,
+		BaseURL:  cfg.BaseURL,
+		Key:      cfg.Key,
+		Headers:  cfg.Headers,
+		Provider: LLMProvider(cfg.Provider),
+	}
+	endpoint.Client, err = factory(endpoint, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("llm endpoint %q: %w", name, err)
+	}
+	return endpoint, nil
+}
@@ -0,0 +1,301 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+)
+
+type LLMStreamEventKind string
+
+var LLMStreamEventKinds = dagql.NewEnum[LLMStreamEventKind]()
+
+var (
+	LLMStreamEventKindTextDelta = LLMStreamEventKinds.Register("TEXT_DELTA",
+		`A chunk of text generated by the model`,
+	)
+	LLMStreamEventKindToolCallStart = LLMStreamEventKinds.Register("TOOL_CALL_START",
+		`A tool call requested by the model started`,
+	)
+	LLMStreamEventKindToolCallFinish = LLMStreamEventKinds.Register("TOOL_CALL_FINISH",
+		`A tool call requested by the model finished`,
+	)
+	LLMStreamEventKindTokenUsage = LLMStreamEventKinds.Register("TOKEN_USAGE",
+		`The model replied, using tokens`,
+	)
+	LLMStreamEventKindRetry = LLMStreamEventKinds.Register("RETRY",
+		`The model query failed and is retried, discarding the text streamed since the last TOKEN_USAGE event`,
+	)
+)
+
+func (kind LLMStreamEventKind) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "LLMStreamEventKind",
+		NonNull:   true,
+	}
+}
+
+func (kind LLMStreamEventKind) TypeDescription() string {
+	return "The kind of an LLM stream event"
+}
+
+func (kind LLMStreamEventKind) Decoder() dagql.InputDecoder {
+	return LLMStreamEventKinds
+}
+
+func (kind LLMStreamEventKind) ToLiteral() call.Literal {
+	return LLMStreamEventKinds.Literal(kind)
+}
+
+// An LLMStreamEvent is emitted while an LLM is evaluated, as the model
+// generates text, calls tools, and uses tokens.
+type LLMStreamEvent struct {
+	Kind LLMStreamEventKind `field:"true" doc:"The kind of event."`
+
+	Text string `field:"true" doc:"The text generated by the model, for TEXT_DELTA events."`
+
+	ToolCallID    string `field:"true" name:"toolCallID" doc:"The ID of the tool call, for TOOL_CALL_START and TOOL_CALL_FINISH events."`
+	ToolName      string `field:"true" doc:"The name of the tool, for TOOL_CALL_START and TOOL_CALL_FINISH events."`
+	ToolArguments string `field:"true" doc:"The JSON-encoded arguments of the tool call, for TOOL_CALL_START events."`
+	ToolResult    string `field:"true" doc:"The result of the tool call, for TOOL_CALL_FINISH events."`
+	ToolErrored   bool   `field:"true" doc:"Whether the tool call failed, for TOOL_CALL_FINISH events."`
+
+	TokenUsage dagql.Nullable[*LLMTokenUsage] `field:"true" doc:"The token usage of the model reply, for TOKEN_USAGE events."`
+}
+
+func (*LLMStreamEvent) Type() *ast.Type {
+	return &ast.Type{
+		NamedType: "LLMStreamEvent",
+		NonNull:   true,
+	}
+}
+
+func (*LLMStreamEvent) TypeDescription() string {
+	return "An event emitted while an LLM is evaluated"
+}
+
+type llmStreamKey struct{}
+
+// LLMStreamFunc receives the events emitted while an LLM is evaluated.
+type LLMStreamFunc func(*LLMStreamEvent)
+
+// WithLLMStream returns a context that sends the LLM stream events emitted
+// with it to the given function.
+func WithLLMStream(ctx context.Context, fn LLMStreamFunc) context.Context {
+	return context.WithValue(ctx, llmStreamKey{}, fn)
+}
+
+func emitLLMStreamEvent(ctx context.Context, event *LLMStreamEvent) {
+	if fn, ok := ctx.Value(llmStreamKey{}).(LLMStreamFunc); ok {
+		fn(event)
+	}
+}
+
+// streamLLMText emits a chunk of text generated by the model.
+func streamLLMText(ctx context.Context, text string) {
+	if text == "" {
+		return
+	}
+	emitLLMStreamEvent(ctx, &LLMStreamEvent{
+		Kind: LLMStreamEventKindTextDelta,
+		Text: text,
+	})
+}
+
+// llmStreamWriter returns a writer emitting the text written to it.
+func llmStreamWriter(ctx context.Context) io.Writer {
+	return llmStreamTextWriter{ctx}
+}
+
+type llmStreamTextWriter struct {
+	ctx context.Context
+}
+
+func (w llmStreamTextWriter) Write(p []byte) (int, error) {
+	streamLLMText(w.ctx, string(p))
+	return len(p), nil
+}
+
+// streamLLMToolCall emits the start of a tool call, and returns a function
+// emitting its finish.
+func streamLLMToolCall(ctx context.Context, toolCall LLMToolCall) func(result string, errored bool) {
+	var args string
+	if toolCall.Function.Arguments != nil {
+		if js, err := json.Marshal(toolCall.Function.Arguments); err == nil {
+			args = string(js)
+		}
+	}
+	emitLLMStreamEvent(ctx, &LLMStreamEvent{
+		Kind:          LLMStreamEventKindToolCallStart,
+		ToolCallID:    toolCall.ID,
+		ToolName:      toolCall.Function.Name,
+		ToolArguments: args,
+	})
+	return func(result string, errored bool) {
+		emitLLMStreamEvent(ctx, &LLMStreamEvent{
+			Kind:        LLMStreamEventKindToolCallFinish,
+			ToolCallID:  toolCall.ID,
+			ToolName:    toolCall.Function.Name,
+			ToolResult:  result,
+			ToolErrored: errored,
+		})
+	}
+}
+
+// streamLLMRetry emits the retry of a model query, after its failed attempt
+// streamed text.
+func streamLLMRetry(ctx context.Context) {
+	emitLLMStreamEvent(ctx, &LLMStreamEvent{
+		Kind: LLMStreamEventKindRetry,
+	})
+}
+
+// streamLLMTokenUsage emits the token usage of a model reply.
+func streamLLMTokenUsage(ctx context.Context, usage LLMTokenUsage) {
+	emitLLMStreamEvent(ctx, &LLMStreamEvent{
+		Kind:       LLMStreamEventKindTokenUsage,
+		TokenUsage: dagql.NonNull(&usage),
+	})
+}
+
+// llmStreamIdleTimeout is the time after which an LLM evaluated in the
+// background for its stream is interrupted if nobody reads the stream, e.g.
+// because the client went away.
+var llmStreamIdleTimeout = time.Minute
+
+// llmStream collects the events emitted while an LLM is evaluated, for them
+// to be read as they happen. Tool calls may run in parallel, so it is safe for
+// concurrent use.
+type llmStream struct {
+	mu     sync.Mutex
+	events []*LLMStreamEvent
+	// closed and replaced whenever an event is added or the stream finishes
+	changed chan struct{}
+	done    bool
+	err     error
+
+	// the number of calls to Next in progress, and when the last one returned
+	readers  int
+	lastRead time.Time
+}
+
+func newLLMStream() *llmStream {
+	return &llmStream{changed: make(chan struct{})}
+}
+
+func (s *llmStream) add(event *LLMStreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+	s.notify()
+}
+
+// run evaluates an LLM with the given function, collecting its events
+// until it finishes. The evaluation is interrupted when the context is
+// canceled, or when the stream isn't read for the idle timeout.
+func (s *llmStream) run(ctx context.Context, idleTimeout time.Duration, evaluate func(context.Context) error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go s.cancelWhenIdle(ctx, cancel, idleTimeout)
+	err := evaluate(ctx)
+	if err == nil && ctx.Err() != nil {
+		// the LLM keeps the result of an interrupted evaluation, but readers
+		// of the stream should know why it stopped
+		err = context.Cause(ctx)
+	}
+	s.finish(err)
+}
+
+// cancelWhenIdle cancels the context once the stream hasn't been read for the
+// idle timeout, until the context is done.
+func (s *llmStream) cancelWhenIdle(ctx context.Context, cancel context.CancelCauseFunc, timeout time.Duration) {
+	s.mu.Lock()
+	if s.lastRead.IsZero() {
+		s.lastRead = time.Now()
+	}
+	s.mu.Unlock()
+	for {
+		wait := timeout
+		s.mu.Lock()
+		if s.readers == 0 {
+			idle := time.Since(s.lastRead)
+			if idle >= timeout {
+				s.mu.Unlock()
+				cancel(fmt.Errorf("LLM stream not read for %s", timeout))
+				return
+			}
+			wait = timeout - idle
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// finish marks the end of the evaluation, with its error if it failed.
+func (s *llmStream) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.done = true
+	s.err = err
+	s.notify()
+}
+
+func (s *llmStream) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *llmStream) Events() []*LLMStreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events)
+}
+
+// Next waits for events past the first ones, and returns them. It returns no
+// events once the stream is finished and all of them were read.
+func (s *llmStream) Next(ctx context.Context, after int) ([]*LLMStreamEvent, error) {
+	s.mu.Lock()
+	s.readers++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.readers--
+		s.lastRead = time.Now()
+		s.mu.Unlock()
+	}()
+	for {
+		s.mu.Lock()
+		if len(s.events) > after {
+			events := slices.Clone(s.events[after:])
+			s.mu.Unlock()
+			return events, nil
+		}
+		if s.done {
+			err := s.err
+			s.mu.Unlock()
+			return nil, err
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLLMStream(t *testing.T) {
+	stream := newLLMStream()
+	ctx := WithLLMStream(context.Background(), stream.add)
+
+	// without a stream, events are dropped
+	streamLLMText(context.Background(), "dropped")
+
+	streamLLMText(ctx, "Let me ")
+	fmt.Fprint(llmStreamWriter(ctx), "read it")
+	streamLLMText(ctx, "")
+	finished := streamLLMToolCall(ctx, LLMToolCall{
+		ID:       "call_1",
+		Function: FuncCall{Name: "read", Arguments: map[string]any{"path": "README.md"}},
+	})
+	finished("# Hello", false)
+	streamLLMTokenUsage(ctx, LLMTokenUsage{InputTokens: 10, OutputTokens: 5, TotalTokens: 15})
+
+	events := stream.Events()
+	require.Len(t, events, 5)
+	require.Equal(t, LLMStreamEventKindTextDelta, events[0].Kind)
+	require.Equal(t, "Let me ", events[0].Text)
+	require.Equal(t, "read it", events[1].Text)
+	require.Equal(t, LLMStreamEventKindToolCallStart, events[2].Kind)
+	require.Equal(t, "call_1", events[2].ToolCallID)
+	require.Equal(t, "read", events[2].ToolName)
+	require.JSONEq(t, `{"path": "README.md"}`, events[2].ToolArguments)
+	require.Equal(t, LLMStreamEventKindToolCallFinish, events[3].Kind)
+	require.Equal(t, "# Hello", events[3].ToolResult)
+	require.False(t, events[3].ToolErrored)
+	require.Equal(t, LLMStreamEventKindTokenUsage, events[4].Kind)
+	require.True(t, events[4].TokenUsage.Valid)
+	require.Equal(t, int64(15), events[4].TokenUsage.Value.TotalTokens)
+
+	// tool calls may finish concurrently
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			streamLLMToolCall(ctx, LLMToolCall{ID: fmt.Sprintf("call_%d", i)})("", true)
+		}()
+	}
+	wg.Wait()
+	require.Len(t, stream.Events(), 25)
+
+	// clones of an LLM stream their own events
+	llm := &LLM{mcp: &MCP{}, stream: stream}
+	require.Empty(t, llm.Clone().stream.Events())
+}
+
+func TestLLMStreamNext(t *testing.T) {
+	ctx := context.Background()
+	stream := newLLMStream()
+
+	streamLLMText(WithLLMStream(ctx, stream.add), "hello")
+	events, err := stream.Next(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	// waits for the next event
+	next := make(chan []*LLMStreamEvent)
+	go func() {
+		events, err := stream.Next(ctx, 1)
+		assert.NoError(t, err)
+		next <- events
+	}()
+	select {
+	case <-next:
+		t.Fatal("returned before the next event")
+	case <-time.After(50 * time.Millisecond):
+	}
+	streamLLMText(WithLLMStream(ctx, stream.add), " world")
+	events = <-next
+	require.Len(t, events, 1)
+	require.Equal(t, " world", events[0].Text)
+
+	// returns the error of the evaluation once all events are read
+	stream.finish(fmt.Errorf("boom"))
+	events, err = stream.Next(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	_, err = stream.Next(ctx, 2)
+	require.ErrorContains(t, err, "boom")
+
+	// stops waiting when canceled
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = newLLMStream().Next(ctx, 0)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLLMStreamRun(t *testing.T) {
+	// evaluate stands for an agent loop, which only stops once interrupted
+	evaluate := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	t.Run("stops when the session ends", func(t *testing.T) {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		stream := newLLMStream()
+		done := make(chan struct{})
+		go func() {
+			stream.run(ctx, time.Minute, evaluate)
+			close(done)
+		}()
+		cancel(errors.New("session shutdown called"))
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("kept evaluating after the session ended")
+		}
+		_, err := stream.Next(context.Background(), 0)
+		require.ErrorContains(t, err, "session shutdown called")
+	})
+
+	t.Run("stops when the stream isn't read", func(t *testing.T) {
+		stream := newLLMStream()
+		done := make(chan struct{})
+		go func() {
+			stream.run(context.Background(), 100*time.Millisecond, evaluate)
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("kept evaluating while nobody read the stream")
+		}
+		_, err := stream.Next(context.Background(), 0)
+		require.ErrorContains(t, err, "LLM stream not read for 100ms")
+	})
+
+	t.Run("keeps going while the stream is read", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		stream := newLLMStream()
+		done := make(chan struct{})
+		go func() {
+			stream.run(ctx, 100*time.Millisecond, evaluate)
+			close(done)
+		}()
+		// a reader waiting for events longer than the idle timeout
+		readCtx, stopReading := context.WithTimeout(ctx, 300*time.Millisecond)
+		defer stopReading()
+		_, err := stream.Next(readCtx, 0)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		select {
+		case <-done:
+			t.Fatal("stopped evaluating while the stream was read")
+		default:
+		}
+	})
+}
+
+// flakyLLMClient streams part of a reply, then fails, a number of times
+// before replying.
+type flakyLLMClient struct {
+	failures int
+	streamed bool
+}
+
+func (c *flakyLLMClient) SendQuery(ctx context.Context, _ []*ModelMessage, _ []LLMTool) (*LLMResponse, error) {
+	if c.failures > 0 {
+		c.failures--
+		streamLLMText(ctx, "Hel")
+		return nil, errors.New("connection reset")
+	}
+	if c.streamed {
+		streamLLMText(ctx, "Hello")
+	}
+	return &LLMResponse{Content: "Hello"}, nil
+}
+
+func (c *flakyLLMClient) IsRetryable(error) bool { return true }
+
+func TestSendLLMQueryRetry(t *testing.T) {
+	kinds := func(events []*LLMStreamEvent) []LLMStreamEventKind {
+		var kinds []LLMStreamEventKind
+		for _, event := range events {
+			kinds = append(kinds, event.Kind)
+		}
+		return kinds
+	}
+
+	for _, streamed := range []bool{true, false} {
+		t.Run(fmt.Sprintf("streamed=%t", streamed), func(t *testing.T) {
+			stream := newLLMStream()
+			ctx := WithLLMStream(context.Background(), stream.add)
+			res, err := sendLLMQuery(ctx, &flakyLLMClient{failures: 2, streamed: streamed}, nil, nil, &backoff.ZeroBackOff{})
+			require.NoError(t, err)
+			require.Equal(t, "Hello", res.Content)
+
+			events := stream.Events()
+			require.Equal(t, []LLMStreamEventKind{
+				LLMStreamEventKindTextDelta,
+				LLMStreamEventKindRetry,
+				LLMStreamEventKindTextDelta,
+				LLMStreamEventKindRetry,
+				LLMStreamEventKindTextDelta,
+			}, kinds(events))
+			require.Equal(t, "Hello", events[4].Text)
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/engine/config"
 )
 
 type LLMTestQuery struct{}
@@ -50,6 +52,7 @@ func TestLlmConfig(t *testing.T) {
 		"env://GEMINI_API_KEY":           "gemini-api-key",
 		"env://GEMINI_BASE_URL":          "gemini-base-url",
 		"env://GEMINI_MODEL":             "gemini-model",
+		"env://DAGGER_LLM_CONFIG":        "",
 	}
 
 	dagql.Fields[LLMTestQuery]{
@@ -70,7 +73,7 @@ func TestLlmConfig(t *testing.T) {
 	}.Install(srv)
 
 	ctx := context.Background()
-	r, err := NewLLMRouter(ctx, srv)
+	r, err := NewLLMRouter(ctx, srv, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "anthropic-api-key", r.AnthropicAPIKey)
 	assert.Equal(t, "anthropic-base-url", r.AnthropicBaseURL)
@@ -146,7 +149,7 @@ func TestLlmConfigDisableStreaming(t *testing.T) {
 			}.Install(srv)
 
 			ctx := context.Background()
-			r, err := NewLLMRouter(ctx, srv)
+			r, err := NewLLMRouter(ctx, srv, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expected, r.OpenAIDisableStreaming)
 		})
@@ -188,7 +191,7 @@ GEMINI_MODEL=gemini-model`, nil
 	}.Install(srv)
 
 	ctx := context.Background()
-	r, err := NewLLMRouter(ctx, srv)
+	r, err := NewLLMRouter(ctx, srv, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "anthropic-api-key", r.AnthropicAPIKey)
 	assert.Equal(t, "anthropic-base-url", r.AnthropicBaseURL)
@@ -202,3 +205,138 @@ GEMINI_MODEL=gemini-model`, nil
 	assert.Equal(t, "gemini-base-url", r.GeminiBaseURL)
 	assert.Equal(t, "gemini-model", r.GeminiModel)
 }
+
+func TestLlmConfigEndpoints(t *testing.T) {
+	q := LLMTestQuery{}
+
+	baseCache, err := dagql.NewCache(context.Background(), "")
+	assert.NoError(t, err)
+	srv := dagql.NewServer(q, dagql.NewSessionCache(baseCache))
+	dagql.Fields[LLMTestQuery]{
+		dagql.Func("secret", func(ctx context.Context, self LLMTestQuery, args struct {
+			URI string
+		}) (mockSecret, error) {
+			return mockSecret{uri: args.URI}, nil
+		}),
+	}.Install(srv)
+
+	dagql.Fields[mockSecret]{
+		dagql.Func("plaintext", func(ctx context.Context, self mockSecret, _ struct{}) (string, error) {
+			switch self.uri {
+			case "file://.env":
+				return "DAGGER_LLM_CONFIG=/home/user/llm.json\nANTHROPIC_API_KEY=anthropic-api-key", nil
+			case "file:///home/user/llm.json":
+				return `{
+					"defaultModel": "coder",
+					"aliases": {"coder": "local/qwen2.5-coder"},
+					"endpoints": {
+						"gpu": {"provider": "openai", "baseURL": "https://gpu.example.com/v1", "models": ["qwen*"]},
+						"local": {"provider": "ollama", "models": ["qwen*", "llama*"]},
+						"proxy": {
+							"provider": "openai",
+							"baseURL": "https://llm.example.com/v1",
+							"key": "env://PROXY_KEY",
+							"headers": {"X-Team": "ci"},
+							"models": ["gpt-*"]
+						}
+					}
+				}`, nil
+			case "env://PROXY_KEY":
+				return "proxy-key", nil
+			}
+			return "", nil
+		}),
+	}.Install(srv)
+
+	engineConfig := &config.LLMConfig{
+		DefaultModel: "llama3.2",
+		Endpoints: map[string]config.LLMEndpointConfig{
+			"gpu": {Provider: "openai", BaseURL: "http://gpu:8080/v1", Models: []string{"qwen*"}},
+		},
+	}
+
+	ctx := context.Background()
+	r, err := NewLLMRouter(ctx, srv, engineConfig)
+	require.NoError(t, err)
+	require.Equal(t, "coder", r.DefaultModel())
+
+	// the default model is an alias to a model of an endpoint
+	endpoint, err := r.Route(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, Ollama, endpoint.Provider)
+	require.Equal(t, "qwen2.5-coder", endpoint.Model)
+	require.IsType(t, &OllamaClient{}, endpoint.Client)
+
+	// endpoints are matched in the order of their names, and the client can't
+	// replace an endpoint of the engine
+	endpoint, err = r.Route(ctx, "qwen3")
+	require.NoError(t, err)
+	require.Equal(t, OpenAI, endpoint.Provider)
+	require.Equal(t, "http://gpu:8080/v1", endpoint.BaseURL)
+
+	// secrets of the endpoint are resolved
+	endpoint, err = r.Route(ctx, "gpt-5")
+	require.NoError(t, err)
+	require.Equal(t, "gpt-5", endpoint.Model)
+	require.Equal(t, "proxy-key", endpoint.Key)
+	require.Equal(t, map[string]string{"X-Team": "ci"}, endpoint.Headers)
+
+	// models are routed by prefix when no endpoint matches
+	endpoint, err = r.Route(ctx, "claude")
+	require.NoError(t, err)
+	require.Equal(t, Anthropic, endpoint.Provider)
+	require.Equal(t, "anthropic-api-key", endpoint.Key)
+}
+
+func TestLlmConfigValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		config config.LLMConfig
+		err    string
+	}{
+		{
+			"valid",
+			config.LLMConfig{Endpoints: map[string]config.LLMEndpointConfig{
+				"local": {Provider: "ollama", Models: []string{"qwen*"}},
+			}},
+			"",
+		},
+		{
+			"unknown provider",
+			config.LLMConfig{Endpoints: map[string]config.LLMEndpointConfig{
+				"local": {Provider: "mistral"},
+			}},
+			`unknown provider "mistral"`,
+		},
+		{
+			"invalid name",
+			config.LLMConfig{Endpoints: map[string]config.LLMEndpointConfig{
+				"my/local": {Provider: "ollama"},
+			}},
+			`invalid llm endpoint name "my/local"`,
+		},
+		{
+			"invalid pattern",
+			config.LLMConfig{Endpoints: map[string]config.LLMEndpointConfig{
+				"local": {Provider: "ollama", Models: []string{"qwen["}},
+			}},
+			`invalid model pattern "qwen["`,
+		},
+		{
+			"bedrock without region",
+			config.LLMConfig{Endpoints: map[string]config.LLMEndpointConfig{
+				"aws": {Provider: "bedrock"},
+			}},
+			"bedrock endpoints require a region",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.config.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
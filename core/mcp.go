@@ -1026,6 +1026,9 @@ func (m *MCP) LookupTool(name string, tools []LLMTool) (*LLMTool, error) {
 }
 
 func (m *MCP) Call(ctx context.Context, tools []LLMTool, toolCall LLMToolCall) (res string, failed bool) {
+	finished := streamLLMToolCall(ctx, toolCall)
+	defer func() { finished(res, failed) }()
+
 	tool, err := m.LookupTool(toolCall.Function.Name, tools)
 	if err != nil {
 		return err.Error(), true
@@ -76,7 +76,7 @@ func (node *ModTreeNode) Run(
 	runLeaf func(context.Context, *ModTreeNode, *engine.ClientMetadata) error,
 	// called inside a defer. Used to set properties to traces, for instance the CheckPassed attribute for checks.
 	// if there's an error, it's already added to the telemetry, no need to do it here
-	onDefer func(trace.Span, error),
+	onDefer func(*ModTreeNode, trace.Span, error),
 	// telemetry attribute to set with the name of the node
 	telemetryNameAttr string,
 	include, exclude []string,
@@ -92,7 +92,7 @@ func (node *ModTreeNode) Run(
 		),
 	)
 	defer func() {
-		onDefer(span, rerr)
+		onDefer(node, span, rerr)
 		telemetry.EndWithCause(span, &rerr)
 	}()
 
@@ -128,8 +128,10 @@ func (node *ModTreeNode) Run(
 	return jobs.Run(ctx) // don't suppress the error. That can be handled by the top-level caller if necessary
 }
 
-func (node *ModTreeNode) RunCheck(ctx context.Context, include, exclude []string) error {
-	return node.Run(ctx,
+// RunCheck runs the check, or all checks below the node. It returns the span
+// context of the node's span.
+func (node *ModTreeNode) RunCheck(ctx context.Context, include, exclude []string) (spanCtx trace.SpanContext, _ error) {
+	err := node.Run(ctx,
 		func(n *ModTreeNode) bool { return n.IsCheck },
 		func(ctx context.Context, n *ModTreeNode, clientMD *engine.ClientMetadata) error {
 			// Try scale-out if enabled (will be false for scaled-out sessions)
@@ -140,11 +142,15 @@ func (node *ModTreeNode) RunCheck(ctx context.Context, include, exclude []string
 			}
 			return n.runCheckLocally(ctx)
 		},
-		func(span trace.Span, err error) {
+		func(n *ModTreeNode, span trace.Span, err error) {
 			span.SetAttributes(attribute.Bool(telemetry.CheckPassedAttr, err == nil))
+			if n == node {
+				spanCtx = span.SpanContext()
+			}
 		},
 		telemetry.CheckNameAttr,
 		include, exclude)
+	return spanCtx, err
 }
 
 func (node *ModTreeNode) runCheckLocally(ctx context.Context) error {
@@ -221,7 +227,7 @@ func (node *ModTreeNode) RunGenerator(ctx context.Context, include, exclude []st
 			cs = changes
 			return err
 		},
-		func(_ trace.Span, _ error) {},
+		func(_ *ModTreeNode, _ trace.Span, _ error) {},
 		telemetry.GeneratorNameAttr,
 		include, exclude)
 	return cs, err
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dagger/dagger/util/patternmatcher"
+)
+
+// ModTreeInput is a path of the context directory that a node of the module
+// tree may read: the default path of a contextual argument, or the source
+// code of a module.
+type ModTreeInput struct {
+	// The path, relative to the context directory
+	Path string
+	// Ignore patterns applied to the path, relative to it
+	Ignore []string
+	// Where the input is declared, for explaining a selection
+	Origin string
+}
+
+// Contains reports whether a changed path, relative to the context directory,
+// may affect the input.
+func (input ModTreeInput) Contains(changed string) (bool, error) {
+	changed = strings.TrimSuffix(changed, "/")
+	var rel string
+	switch {
+	case input.Path == ".":
+		rel = changed
+	case changed == input.Path:
+		return true, nil
+	case strings.HasPrefix(changed, input.Path+"/"):
+		rel = strings.TrimPrefix(changed, input.Path+"/")
+	case strings.HasPrefix(input.Path, changed+"/"):
+		// a parent directory of the input was added or removed
+		return true, nil
+	default:
+		return false, nil
+	}
+	if len(input.Ignore) == 0 {
+		return true, nil
+	}
+	pm, err := patternmatcher.New(input.Ignore)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid ignore patterns: %w", input.Origin, err)
+	}
+	ignored, err := pm.MatchesOrParentMatches(rel)
+	if err != nil {
+		return false, err
+	}
+	return !ignored, nil
+}
+
+// Inputs returns the paths of the context directory that the node may read:
+// the default paths of the contextual arguments of every function called to
+// reach it, and the source code of the modules defining them.
+func (node *ModTreeNode) Inputs() []ModTreeInput {
+	var chain []*ModTreeNode
+	for n := node; n != nil; n = n.Parent {
+		chain = append(chain, n)
+	}
+	slices.Reverse(chain)
+
+	var inputs []ModTreeInput
+	var mods []*Module
+	for _, n := range chain {
+		if !slices.Contains(mods, n.OriginalModule) {
+			mods = append(mods, n.OriginalModule)
+			inputs = append(inputs, moduleSourceInputs(n.OriginalModule)...)
+		}
+		var fn *Function
+		if n.Parent == nil {
+			if obj := n.ObjectType(); obj != nil && obj.Constructor.Valid {
+				fn = obj.Constructor.Value
+			}
+		} else if obj := n.Parent.ObjectType(); obj != nil {
+			for _, f := range obj.Functions {
+				if f.Name == n.Name {
+					fn = f
+					break
+				}
+			}
+		}
+		if fn == nil {
+			continue
+		}
+		fnName := n.PathString()
+		if n.Parent == nil {
+			fnName = "constructor"
+		}
+		for _, arg := range fn.Args {
+			if arg.DefaultPath == "" {
+				continue
+			}
+			inputs = append(inputs, ModTreeInput{
+				Path:   contextPath(n.OriginalModule, arg.DefaultPath),
+				Ignore: arg.Ignore,
+				Origin: fmt.Sprintf("argument %q of %s", arg.Name, fnName),
+			})
+		}
+	}
+	return inputs
+}
+
+// moduleSourceInputs returns the config and source code of a module loaded
+// from the local context directory, and of its local dependencies.
+func moduleSourceInputs(mod *Module) []ModTreeInput {
+	src := mod.GetSource()
+	if src == nil {
+		return nil
+	}
+	return sourceInputs(src, fmt.Sprintf("source of module %q", mod.Name()), map[string]bool{})
+}
+
+func sourceInputs(src *ModuleSource, origin string, seen map[string]bool) []ModTreeInput {
+	if src.Kind != ModuleSourceKindLocal || seen[src.SourceRootSubpath] {
+		return nil
+	}
+	seen[src.SourceRootSubpath] = true
+	inputs := []ModTreeInput{{
+		Path:   path.Join(src.SourceRootSubpath, "dagger.json"),
+		Origin: origin,
+	}}
+	if src.SourceSubpath != "" {
+		inputs = append(inputs, ModTreeInput{
+			Path:   path.Clean(src.SourceSubpath),
+			Origin: origin,
+		})
+	}
+	// toolchains aren't included: the nodes they define have their own source
+	deps := slices.Clone(src.Dependencies)
+	if src.Blueprint.Self() != nil {
+		deps = append(deps, src.Blueprint)
+	}
+	for _, dep := range deps {
+		depOrigin := fmt.Sprintf("source of module %q, a dependency of %s", dep.Self().ModuleName, strings.TrimPrefix(origin, "source of "))
+		inputs = append(inputs, sourceInputs(dep.Self(), depOrigin, seen)...)
+	}
+	return inputs
+}
+
+// contextPath resolves a default path like LoadContextDir does: absolute
+// paths are relative to the context directory, others are relative to the
+// module root directory.
+func contextPath(mod *Module, p string) string {
+	var rootSubpath string
+	if src := mod.GetContextSource(); src != nil {
+		rootSubpath = src.SourceRootSubpath
+	} else if src := mod.GetSource(); src != nil {
+		rootSubpath = src.SourceRootSubpath
+	}
+	p = filepath.ToSlash(p)
+	if path.IsAbs(p) {
+		p = strings.TrimPrefix(path.Clean(p), "/")
+	} else {
+		p = path.Join(rootSubpath, p)
+	}
+	if p == "" {
+		return "."
+	}
+	return path.Clean(p)
+}
+
+// AffectedBy reports whether the node may be affected by the given changed
+// paths, and why.
+func (node *ModTreeNode) AffectedBy(changed []string) (bool, string, error) {
+	inputs := node.Inputs()
+	if len(inputs) == 0 {
+		// we can't tell what it reads, so always select it
+		return true, "no declared inputs", nil
+	}
+	for _, input := range inputs {
+		for _, p := range changed {
+			contains, err := input.Contains(p)
+			if err != nil {
+				return false, "", err
+			}
+			if contains {
+				return true, fmt.Sprintf("%s changed, in %s (%s)", strings.TrimSuffix(p, "/"), input.Path, input.Origin), nil
+			}
+		}
+	}
+	paths := make([]string, 0, len(inputs))
+	for _, input := range inputs {
+		if !slices.Contains(paths, input.Path) {
+			paths = append(paths, input.Path)
+		}
+	}
+	return false, "no changes in " + strings.Join(paths, ", "), nil
+}
+
+// changedPaths returns all the paths added, modified or removed by the
+// changeset.
+func (ch *Changeset) changedPaths(ctx context.Context) ([]string, error) {
+	paths, err := ch.ComputePaths(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Concat(paths.Added, paths.Modified, paths.AllRemoved), nil
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/dagger/dagger/dagql"
+	"github.com/dagger/dagger/dagql/call"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestModTreeInputContains(t *testing.T) {
+	for _, tc := range []struct {
+		input    ModTreeInput
+		changed  string
+		contains bool
+	}{
+		{ModTreeInput{Path: "."}, "README.md", true},
+		{ModTreeInput{Path: "src"}, "src", true},
+		{ModTreeInput{Path: "src"}, "src/main.go", true},
+		{ModTreeInput{Path: "src"}, "src/pkg/", true},
+		{ModTreeInput{Path: "src"}, "srcs/main.go", false},
+		{ModTreeInput{Path: "src"}, "docs/index.md", false},
+		// a parent directory of the input was added or removed
+		{ModTreeInput{Path: "src/pkg"}, "src/", true},
+		{ModTreeInput{Path: "src", Ignore: []string{"*.md"}}, "src/README.md", false},
+		{ModTreeInput{Path: "src", Ignore: []string{"*.md"}}, "src/main.go", true},
+		{ModTreeInput{Path: ".", Ignore: []string{"docs"}}, "docs/index.md", false},
+		{ModTreeInput{Path: ".", Ignore: []string{"**", "!src"}}, "src/main.go", true},
+		{ModTreeInput{Path: ".", Ignore: []string{"**", "!src"}}, "README.md", false},
+	} {
+		contains, err := tc.input.Contains(tc.changed)
+		require.NoError(t, err)
+		require.Equal(t, tc.contains, contains, "%+v contains %q", tc.input, tc.changed)
+	}
+}
+
+func TestModTreeNodeAffectedBy(t *testing.T) {
+	mod := &Module{NameField: "my-mod"}
+	dirType := &TypeDef{Kind: TypeDefKindObject}
+	mainObj := &ObjectTypeDef{
+		Name: "MyMod",
+		Constructor: dagql.NonNull(&Function{
+			Args: []*FunctionArg{
+				{Name: "source", TypeDef: dirType, DefaultPath: "/", Ignore: []string{"docs", "*.md", ".*"}},
+			},
+		}),
+		Functions: []*Function{
+			{Name: "lint", Args: []*FunctionArg{
+				{Name: "config", TypeDef: dirType, DefaultPath: "/.golangci.yml"},
+			}},
+			{Name: "test"},
+		},
+	}
+	root := &ModTreeNode{
+		Module:         mod,
+		OriginalModule: mod,
+		Type:           &TypeDef{Kind: TypeDefKindObject, AsObject: dagql.NonNull(mainObj)},
+	}
+	lint := &ModTreeNode{Parent: root, Name: "lint", Module: mod, OriginalModule: mod, IsCheck: true}
+	test := &ModTreeNode{Parent: root, Name: "test", Module: mod, OriginalModule: mod, IsCheck: true}
+
+	require.Equal(t, []ModTreeInput{
+		{Path: ".", Ignore: []string{"docs", "*.md", ".*"}, Origin: `argument "source" of constructor`},
+		{Path: ".golangci.yml", Origin: `argument "config" of lint`},
+	}, lint.Inputs())
+
+	affected, reason, err := test.AffectedBy([]string{"main.go"})
+	require.NoError(t, err)
+	require.True(t, affected)
+	require.Equal(t, `main.go changed, in . (argument "source" of constructor)`, reason)
+
+	affected, reason, err = test.AffectedBy([]string{"docs/", "docs/index.md", "README.md"})
+	require.NoError(t, err)
+	require.False(t, affected)
+	require.Equal(t, "no changes in .", reason)
+
+	affected, reason, err = lint.AffectedBy([]string{".golangci.yml"})
+	require.NoError(t, err)
+	require.True(t, affected)
+	require.Equal(t, `.golangci.yml changed, in .golangci.yml (argument "config" of lint)`, reason)
+
+	affected, _, err = test.AffectedBy([]string{".golangci.yml"})
+	require.NoError(t, err)
+	require.False(t, affected)
+
+	// without declared inputs, nodes are always selected
+	bare := &ModTreeNode{Module: mod, OriginalModule: mod, Type: &TypeDef{
+		Kind:     TypeDefKindObject,
+		AsObject: dagql.NonNull(&ObjectTypeDef{Name: "Bare"}),
+	}}
+	check := &ModTreeNode{Parent: bare, Name: "check", Module: mod, OriginalModule: mod, IsCheck: true}
+	affected, reason, err = check.AffectedBy([]string{"main.go"})
+	require.NoError(t, err)
+	require.True(t, affected)
+	require.Equal(t, "no declared inputs", reason)
+}
+
+func TestModuleSourceInputs(t *testing.T) {
+	srcResult := func(src *ModuleSource) dagql.ObjectResult[*ModuleSource] {
+		res, err := dagql.NewResultForID(src, call.New().Append(&ast.Type{}, src.ModuleName))
+		require.NoError(t, err)
+		return dagql.ObjectResult[*ModuleSource]{Result: res}
+	}
+	lib := &ModuleSource{
+		ModuleName:        "lib",
+		Kind:              ModuleSourceKindLocal,
+		SourceRootSubpath: "lib",
+		SourceSubpath:     "lib/src",
+	}
+	remote := &ModuleSource{ModuleName: "remote", Kind: ModuleSourceKindGit}
+	src := &ModuleSource{
+		ModuleName:        "my-mod",
+		Kind:              ModuleSourceKindLocal,
+		SourceRootSubpath: "ci",
+		SourceSubpath:     "ci",
+		Dependencies: dagql.ObjectResultArray[*ModuleSource]{
+			srcResult(lib),
+			srcResult(remote),
+		},
+	}
+	mod := &Module{
+		NameField: "my-mod",
+		Source:    dagql.NonNull(srcResult(src)),
+	}
+	require.Equal(t, []ModTreeInput{
+		{Path: "ci/dagger.json", Origin: `source of module "my-mod"`},
+		{Path: "ci", Origin: `source of module "my-mod"`},
+		{Path: "lib/dagger.json", Origin: `source of module "lib", a dependency of module "my-mod"`},
+		{Path: "lib/src", Origin: `source of module "lib", a dependency of module "my-mod"`},
+	}, moduleSourceInputs(mod))
+}
@@ -211,6 +211,10 @@ type ModuleConfigDependency struct {
 	// Patterns can use glob syntax to match check names.
 	IgnoreChecks []string `json:"ignoreChecks,omitempty"`
 
+	// QuarantineChecks is a list of check patterns whose failures are reported,
+	// but don't fail the checks run. Patterns can use glob syntax to match check names.
+	QuarantineChecks []string `json:"quarantineChecks,omitempty"`
+
 	// IgnoreGenerators is a list of generator patterns to exclude from this toolchain.
 	// Patterns can use glob syntax to match generator names.
 	IgnoreGenerators []string `json:"ignoreGenerators,omitempty"`
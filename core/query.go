@@ -22,6 +22,7 @@ import (
 	"github.com/dagger/dagger/engine/buildkit"
 	engineclient "github.com/dagger/dagger/engine/client"
 	"github.com/dagger/dagger/engine/clientdb"
+	"github.com/dagger/dagger/engine/config"
 	"github.com/dagger/dagger/engine/filesync"
 	"github.com/dagger/dagger/engine/server/resource"
 )
@@ -98,6 +99,11 @@ type Server interface {
 	// The services for the current client's session
 	Services(context.Context) (*Services, error)
 
+	// A context with the values of the given one, canceled when the current
+	// client's session shuts down rather than when the given one is, for work
+	// outliving the request starting it
+	SessionContext(context.Context) (context.Context, error)
+
 	// The default platform for the engine as a whole
 	Platform() Platform
 
@@ -107,6 +113,12 @@ type Server interface {
 	// The dns configuration for the engine as a whole
 	DNS() *oci.DNSConfig
 
+	// The LLM configuration for the engine as a whole
+	LLMConfig() *config.LLMConfig
+
+	// The LLM usage of the current client's session
+	LLMUsage(context.Context) (*LLMSessionUsage, error)
+
 	// The lease manager for the engine as a whole
 	LeaseManager() *leaseutil.Manager
 
@@ -2,6 +2,7 @@ package schema
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
@@ -17,12 +18,36 @@ func (s checksSchema) Install(srv *dagql.Server) {
 			Doc("Return a list of individual checks and their details"),
 
 		dagql.Func("run", s.run).
-			Doc("Execute all selected checks"),
+			Doc("Execute all selected checks").
+			Args(
+				dagql.Arg("failFast").Doc("Cancel the remaining checks as soon as one fails. Quarantined checks don't trigger cancellation."),
+				dagql.Arg("retries").Doc("Number of times a failed check is retried before it is reported as failed"),
+				dagql.Arg("noFail").Doc("Record failed checks in the results instead of returning an error, e.g. to generate a report of the failures"),
+			),
+
+		dagql.Func("affectedBy", s.affectedBy).
+			Doc("Skip the checks whose inputs are not affected by the given changes",
+				`A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.`).
+			Args(
+				dagql.Arg("changes").Doc("The changes to select checks for, relative to the context directory"),
+			),
+
+		dagql.Func("affectedByPaths", s.affectedByPaths).
+			Doc("Skip the checks whose inputs are not affected by the given changed paths",
+				`Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.`).
+			Args(
+				dagql.Arg("paths").Doc("The added, modified or removed paths, relative to the context directory"),
+			),
 
 		dagql.Func("report", s.report).
-			Doc("Generate a markdown report"),
+			Doc("Generate a report of the check results").
+			Args(
+				dagql.Arg("format").Doc("The format of the report"),
+			),
 	}.Install(srv)
 
+	core.CheckReportFormatEnum.Install(srv)
+
 	// Check methods
 	dagql.Fields[*core.Check]{
 		dagql.Func("name", s.name).
@@ -37,7 +62,10 @@ func (s checksSchema) Install(srv *dagql.Server) {
 		dagql.Func("resultEmoji", s.resultEmoji).
 			Doc("An emoji representing the result of the check"),
 		dagql.Func("run", s.runSingleCheck).
-			Doc("Execute the check"),
+			Doc("Execute the check").
+			Args(
+				dagql.Arg("retries").Doc("Number of times the check is retried if it fails"),
+			),
 	}.Install(srv)
 }
 
@@ -65,14 +93,62 @@ func (s checksSchema) list(ctx context.Context, parent *core.CheckGroup, args st
 	return parent.List(), nil
 }
 
-func (s checksSchema) run(ctx context.Context, parent *core.CheckGroup, args struct{}) (*core.CheckGroup, error) {
-	return parent.Run(ctx)
+type checksRunArgs struct {
+	FailFast bool `default:"false"`
+	Retries  int  `default:"0"`
+	NoFail   bool `default:"false"`
+}
+
+func (s checksSchema) run(ctx context.Context, parent *core.CheckGroup, args checksRunArgs) (*core.CheckGroup, error) {
+	if args.Retries < 0 {
+		return nil, fmt.Errorf("retries must not be negative, got %d", args.Retries)
+	}
+	return parent.Run(ctx, core.CheckRunOpts{
+		FailFast: args.FailFast,
+		Retries:  args.Retries,
+		NoFail:   args.NoFail,
+	})
+}
+
+type checksAffectedByArgs struct {
+	Changes dagql.ID[*core.Changeset]
+}
+
+func (s checksSchema) affectedBy(ctx context.Context, parent *core.CheckGroup, args checksAffectedByArgs) (*core.CheckGroup, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := args.Changes.Load(ctx, srv)
+	if err != nil {
+		return nil, err
+	}
+	return parent.AffectedBy(ctx, changes.Self())
+}
+
+type checksAffectedByPathsArgs struct {
+	Paths []string
+}
+
+func (s checksSchema) affectedByPaths(ctx context.Context, parent *core.CheckGroup, args checksAffectedByPathsArgs) (*core.CheckGroup, error) {
+	return parent.AffectedByPaths(args.Paths)
+}
+
+type checksReportArgs struct {
+	Format core.CheckReportFormat `default:"MARKDOWN"`
+}
+
+func (s checksSchema) report(ctx context.Context, parent *core.CheckGroup, args checksReportArgs) (*core.File, error) {
+	return parent.Report(ctx, args.Format)
 }
 
-func (s checksSchema) report(ctx context.Context, parent *core.CheckGroup, args struct{}) (*core.File, error) {
-	return parent.Report(ctx)
+type checkRunArgs struct {
+	Retries int `default:"0"`
 }
 
-func (s checksSchema) runSingleCheck(ctx context.Context, parent *core.Check, args struct{}) (*core.Check, error) {
-	return parent.Run(ctx)
+func (s checksSchema) runSingleCheck(ctx context.Context, parent *core.Check, args checkRunArgs) (*core.Check, error) {
+	if args.Retries < 0 {
+		return nil, fmt.Errorf("retries must not be negative, got %d", args.Retries)
+	}
+	return parent.Run(ctx, args.Retries)
 }
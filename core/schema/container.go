@@ -3,6 +3,7 @@ package schema
 import (
 	"cmp"
 	"context"
+	"crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,7 +11,9 @@ import (
 	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
 	"slices"
+	"strings"
 	"time"
 
 	"dagger.io/dagger/telemetry"
@@ -23,6 +26,7 @@ import (
 	"github.com/dagger/dagger/internal/buildkit/util/leaseutil"
 	"github.com/dagger/dagger/util/hashutil"
 	"github.com/distribution/reference"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/go-digest"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/vektah/gqlparser/v2/ast"
@@ -68,6 +72,12 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("address").Doc(
 					`Address of the container image to download, in standard OCI ref format. Example:"registry.dagger.io/engine:latest"`,
 				),
+				dagql.Arg("verify").Doc(
+					`PEM encoded public key to verify the image signature with.`,
+					`If set, the image is rejected unless it has a cosign compatible
+					signature verified by this key, attached as an OCI referrer or
+					with the cosign tag scheme.`,
+				),
 			),
 		dagql.NodeFunc("build", s.build).
 			View(BeforeVersion("v0.19.0")).
@@ -495,6 +505,17 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 					`Skip the automatic init process injected into containers by default.`,
 					`Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.`,
 				),
+				dagql.Arg("timeout").Doc(
+					`Maximum duration the command may run, as a Go duration string (e.g. "30s", "5m").`,
+					`When it elapses, the command is sent SIGTERM, followed by SIGKILL after a grace period, and the exec fails with a timed out ExecError, which is never cached.`,
+					`A timed out exec fails regardless of "expect", even if the command handles SIGTERM and exits with an expected code. To expect a timeout, check whether the ExecError timed out.`),
+				dagql.Arg("networkMode").Doc(
+					`Network access granted to the command.`,
+					`OFFLINE only allows the loopback interface. SERVICES_ONLY allows bound services and DNS. ALLOW_LIST additionally allows the destinations in "networkAllow". Other connections are rejected.`,
+					`DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST the command can still send data out through the names it looks up. Use OFFLINE if this must be prevented.`),
+				dagql.Arg("networkAllow").Doc(
+					`Hostnames, IPv4 addresses and CIDRs the command may connect to with the ALLOW_LIST network mode. Example: ["proxy.golang.org", "10.0.0.0/8"]`,
+					`Hostnames are resolved once, when the command starts: connections to the addresses they resolve to afterwards (e.g. when a DNS record changes or rotates) are rejected.`),
 			),
 
 		dagql.Func("stdout", s.stdout).
@@ -568,6 +589,31 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 					`Defaults to "OCI", which is compatible with most recent
 				registries, but "Docker" may be needed for older registries without OCI
 				support.`),
+				dagql.Arg("sign").Doc(
+					`PEM encoded private key to sign the published image with.`,
+					`The signature is cosign compatible, and attached to the image both as
+					an OCI referrer and with the cosign tag scheme ("sha256-<hex>.sig"),
+					for cosign to find it with or without --experimental-oci11. Encrypted
+					cosign keys are decrypted with signPassword.`),
+				dagql.Arg("signPassword").Doc(
+					`Password of the private key to sign the published image with.`),
+				dagql.Arg("sbom").Doc(
+					`Attach a software bill of materials of the packages installed in the
+					container, in the given format, as an in-toto attestation.`),
+				dagql.Arg("provenance").Doc(
+					`Attach SLSA provenance of the calls that produced the container as an
+					in-toto attestation.`),
+			),
+
+		dagql.NodeFunc("attestations", s.attestations).
+			Doc(`The in-toto attestations that would be attached to the container image
+			when published or exported.`).
+			Args(
+				dagql.Arg("sbom").Doc(
+					`Include a software bill of materials of the packages installed in the
+					container, in the given format.`),
+				dagql.Arg("provenance").Doc(
+					`Include SLSA provenance of the calls that produced the container.`),
 			),
 
 		dagql.Func("platform", s.platform).
@@ -600,6 +646,12 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("expand").Doc(
 					`Replace "${VAR}" or "$VAR" in the value of path according to the current `+
 						`environment variables defined in the container (e.g. "/$VAR/foo").`),
+				dagql.Arg("sbom").Doc(
+					`Attach a software bill of materials of the packages installed in the
+					container, in the given format, as an in-toto attestation.`),
+				dagql.Arg("provenance").Doc(
+					`Attach SLSA provenance of the calls that produced the container as an
+					in-toto attestation.`),
 			),
 		dagql.NodeFuncWithCacheKey("export", DagOpWrapper(srv, s.exportLegacy), dagql.CachePerCall).
 			View(BeforeVersion("v0.12.0")).
@@ -690,6 +742,30 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 				dagql.Arg("protocol").Doc(`Port protocol to unexpose`),
 			),
 
+		dagql.Func("withHealthcheck", s.withHealthcheck).
+			Doc(`Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.`,
+				`The service is not considered started until the check passes, after the health checks of its exposed ports.`,
+				`Exactly one of args, httpPort and logPattern must be set.`).
+			Args(
+				dagql.Arg("args").Doc(`Command to run in the service container, ready once it exits zero. Example: ["pg_isready", "-U", "postgres"]`),
+				dagql.Arg("httpPort").Doc(`Port to send an HTTP GET request to, ready once it responds with httpStatus. Example: 8080`),
+				dagql.Arg("httpPath").Doc(`Path of the HTTP GET request. Example: "/healthz"`),
+				dagql.Arg("httpStatus").Doc(`Expected status of the HTTP response.`),
+				dagql.Arg("logPattern").Doc(`Regular expression matched against the lines of the service stdout, ready once a line matches. Example: "listening on .*:8080"`),
+				dagql.Arg("interval").Doc(`Time between checks, as a Go duration string. Example: "5s"`),
+				dagql.Arg("timeout").Doc(`Time after which a single check fails, as a Go duration string.`),
+				dagql.Arg("startPeriod").Doc(`Initialization time during which failed checks are not counted, as a Go duration string.`),
+				dagql.Arg("retries").Doc(`Number of consecutive failed checks after which the service fails to start.`),
+			),
+
+		dagql.Func("withImageHealthcheck", s.withImageHealthcheck).
+			Doc(`Use the HEALTHCHECK of the container image as its readiness check when started as a service.`,
+				`Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.`,
+				`Fails if the image has no healthcheck.`),
+
+		dagql.Func("withoutHealthcheck", s.withoutHealthcheck).
+			Doc(`Retrieves this container without its readiness check.`),
+
 		dagql.Func("exposedPorts", s.exposedPorts).
 			Doc(`Retrieves the list of exposed ports.`,
 				`This includes ports already exposed by the image, even if not explicitly added with dagger.`),
@@ -770,8 +846,35 @@ func (s *containerSchema) Install(srv *dagql.Server) {
 			Doc(`EXPERIMENTAL API! Subject to change/removal at any time.`,
 				`Configures all available GPUs on the host to be accessible to this container.`,
 				`This currently works for Nvidia devices only.`),
+
+		dagql.Func("withCPULimit", s.withCPULimit).
+			Doc(`Limits the CPU time available to commands run in this container.`).
+			Args(
+				dagql.Arg("cpus").Doc(`Number of CPUs the commands may use, e.g. 1.5. Set to 0 to remove the limit.`),
+			),
+
+		dagql.Func("withMemoryLimit", s.withMemoryLimit).
+			Doc(`Limits the memory available to commands run in this container.`,
+				`A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.`).
+			Args(
+				dagql.Arg("limit").Doc(`Maximum memory usage, including swap, as a number of bytes or with a unit suffix (e.g. "512MiB", "4GiB"). Units are powers of 1024. Set to "0" to remove the limit.`),
+			),
+
+		dagql.Func("withPidsLimit", s.withPidsLimit).
+			Doc(`Limits the number of processes that commands run in this container may create.`).
+			Args(
+				dagql.Arg("limit").Doc(`Maximum number of processes. Set to 0 to remove the limit.`),
+			),
+
+		dagql.Func("withIOWeight", s.withIOWeight).
+			Doc(`Sets the relative block IO weight of commands run in this container.`).
+			Args(
+				dagql.Arg("weight").Doc(`Weight between 10 and 1000, relative to other containers. Set to 0 to use the default.`),
+			),
 	}.Install(srv)
 
+	dagql.Fields[*core.ContainerAttestation]{}.Install(srv)
+
 	dagql.Fields[*core.TerminalLegacy]{
 		Syncer[*core.TerminalLegacy]().
 			Doc(`Forces evaluation of the pipeline in the engine.`,
@@ -800,6 +903,7 @@ func (s *containerSchema) container(ctx context.Context, parent *core.Query, arg
 
 type containerFromArgs struct {
 	Address string
+	Verify  string `default:""`
 
 	ContainerDagOpInternalArgs
 }
@@ -826,10 +930,15 @@ func (s *containerSchema) fromCacheKey(
 	if resp.CacheKey.ID == nil {
 		return nil, errors.New("cache key ID is nil")
 	}
-	resp.CacheKey.ID = resp.CacheKey.ID.WithDigest(hashutil.HashStrings(
+	keys := []string{
 		parent.ID().Digest().String(),
 		imageRef,
-	))
+	}
+	if args.Verify != "" {
+		// a verified image must not be served from an unverified result
+		keys = append(keys, args.Verify)
+	}
+	resp.CacheKey.ID = resp.CacheKey.ID.WithDigest(hashutil.HashStrings(keys...))
 	return resp, nil
 }
 
@@ -856,6 +965,15 @@ func (s *containerSchema) from(ctx context.Context, parent dagql.ObjectResult[*c
 	refName = reference.TagNameOnly(refName)
 
 	if refName, isCanonical := refName.(reference.Canonical); isCanonical {
+		if args.Verify != "" && !args.InDagOp() {
+			key, err := core.ParseImageVerifyingKey([]byte(args.Verify))
+			if err != nil {
+				return inst, err
+			}
+			if err := core.VerifyImageRef(ctx, refName.String(), key); err != nil {
+				return inst, err
+			}
+		}
 		if args.InDagOp() {
 			ctr, err := parent.Self().FromCanonicalRef(ctx, refName, nil)
 			if err != nil {
@@ -917,12 +1035,16 @@ func (s *containerSchema) from(ctx context.Context, parent dagql.ObjectResult[*c
 	)
 	defer telemetry.EndWithCause(span, nil)
 
+	fromArgs := []dagql.NamedInput{
+		{Name: "address", Value: dagql.String(refName.String())},
+	}
+	if args.Verify != "" {
+		fromArgs = append(fromArgs, dagql.NamedInput{Name: "verify", Value: dagql.String(args.Verify)})
+	}
 	err = srv.Select(ctx, parent, &inst,
 		dagql.Selector{
 			Field: "from",
-			Args: []dagql.NamedInput{
-				{Name: "address", Value: dagql.String(refName.String())},
-			},
+			Args:  fromArgs,
 		},
 	)
 	if err != nil {
@@ -1061,6 +1183,12 @@ func (s *containerSchema) withExec(ctx context.Context, parent dagql.ObjectResul
 	if args.Stdin != "" && args.RedirectStdin != "" {
 		return inst, fmt.Errorf("cannot set both stdin and redirectStdin")
 	}
+	if _, err := core.ParseExecTimeout(args.Timeout); err != nil {
+		return inst, err
+	}
+	if _, err := args.NetworkMode.NetworkPolicy(args.NetworkAllow); err != nil {
+		return inst, err
+	}
 
 	srv, err := core.CurrentDagqlServer(ctx)
 	if err != nil {
@@ -1252,6 +1380,34 @@ func (s *containerSchema) withAllGPUs(ctx context.Context, parent *core.Containe
 	return parent.WithGPU(ctx, core.ContainerGPUOpts{Devices: []string{"all"}})
 }
 
+func (s *containerSchema) withCPULimit(ctx context.Context, parent *core.Container, args struct {
+	CPUs float64
+}) (*core.Container, error) {
+	return parent.WithCPULimit(args.CPUs)
+}
+
+func (s *containerSchema) withMemoryLimit(ctx context.Context, parent *core.Container, args struct {
+	Limit string
+}) (*core.Container, error) {
+	bytes, err := units.RAMInBytes(args.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid memory limit: %w", err)
+	}
+	return parent.WithMemoryLimit(bytes)
+}
+
+func (s *containerSchema) withPidsLimit(ctx context.Context, parent *core.Container, args struct {
+	Limit int
+}) (*core.Container, error) {
+	return parent.WithPidsLimit(int64(args.Limit))
+}
+
+func (s *containerSchema) withIOWeight(ctx context.Context, parent *core.Container, args struct {
+	Weight int
+}) (*core.Container, error) {
+	return parent.WithIOWeight(args.Weight)
+}
+
 type containerWithEntrypointArgs struct {
 	Args            []string
 	KeepDefaultArgs bool `default:"false"`
@@ -1622,6 +1778,10 @@ type containerPublishArgs struct {
 	PlatformVariants  []core.ContainerID `default:"[]"`
 	ForcedCompression dagql.Optional[core.ImageLayerCompression]
 	MediaTypes        core.ImageMediaTypes `default:"OCI"`
+	Sign              dagql.Optional[core.SecretID]
+	SignPassword      dagql.Optional[core.SecretID]
+	SBOM              dagql.Optional[core.SBOMFormat] `name:"sbom"`
+	Provenance        bool                            `default:"false"`
 
 	RawDagOpInternalArgs
 }
@@ -1632,23 +1792,97 @@ func (s *containerSchema) publish(ctx context.Context, parent dagql.ObjectResult
 		return "", fmt.Errorf("failed to get server: %w", err)
 	}
 
-	variants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+	variants, err := dagql.LoadIDResults(ctx, srv, args.PlatformVariants)
+	if err != nil {
+		return "", err
+	}
+	attestations, err := core.NewImageAttestations(ctx, append([]dagql.ObjectResult[*core.Container]{parent}, variants...), core.ImageAttestationOpts{
+		Name:       args.Address.String(),
+		SBOM:       args.SBOM.Value,
+		Provenance: args.Provenance,
+	})
 	if err != nil {
 		return "", err
 	}
 	ref, err := parent.Self().Publish(
 		ctx,
 		args.Address.String(),
-		variants,
+		containerSelves(variants),
 		args.ForcedCompression.Value,
 		args.MediaTypes,
+		attestations,
 	)
 	if err != nil {
 		return "", err
 	}
+	if args.Sign.Valid {
+		signer, err := s.imageSigningKey(ctx, srv, args.Sign.Value, args.SignPassword)
+		if err != nil {
+			return "", err
+		}
+		if err := core.SignImageRef(ctx, ref, signer); err != nil {
+			return "", err
+		}
+	}
 	return dagql.NewString(ref), nil
 }
 
+type containerAttestationsArgs struct {
+	SBOM       dagql.Optional[core.SBOMFormat] `name:"sbom"`
+	Provenance bool                            `default:"false"`
+}
+
+func (s *containerSchema) attestations(ctx context.Context, parent dagql.ObjectResult[*core.Container], args containerAttestationsArgs) (dagql.Array[*core.ContainerAttestation], error) {
+	return parent.Self().Attestations(ctx, parent.ID(), core.ImageAttestationOpts{
+		SBOM:       args.SBOM.Value,
+		Provenance: args.Provenance,
+	})
+}
+
+func containerSelves(results []dagql.ObjectResult[*core.Container]) []*core.Container {
+	containers := make([]*core.Container, len(results))
+	for i, res := range results {
+		containers[i] = res.Self()
+	}
+	return containers
+}
+
+func (s *containerSchema) imageSigningKey(
+	ctx context.Context,
+	srv *dagql.Server,
+	keyID core.SecretID,
+	passwordID dagql.Optional[core.SecretID],
+) (crypto.Signer, error) {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return nil, err
+	}
+	secretStore, err := query.Secrets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret store: %w", err)
+	}
+	key, err := keyID.Load(ctx, srv)
+	if err != nil {
+		return nil, err
+	}
+	keyBytes, err := secretStore.GetSecretPlaintext(ctx, core.SecretIDDigest(key.ID()))
+	if err != nil {
+		return nil, err
+	}
+	var passwordBytes []byte
+	if passwordID.Valid {
+		password, err := passwordID.Value.Load(ctx, srv)
+		if err != nil {
+			return nil, err
+		}
+		passwordBytes, err = secretStore.GetSecretPlaintext(ctx, core.SecretIDDigest(password.ID()))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return core.ParseImageSigningKey(keyBytes, passwordBytes)
+}
+
 type containerWithMountedFileArgs struct {
 	Path   string
 	Source core.FileID
@@ -2175,8 +2409,10 @@ type containerExportArgs struct {
 	Path              string
 	PlatformVariants  []core.ContainerID `default:"[]"`
 	ForcedCompression dagql.Optional[core.ImageLayerCompression]
-	MediaTypes        core.ImageMediaTypes `default:"OCI"`
-	Expand            bool                 `default:"false"`
+	MediaTypes        core.ImageMediaTypes            `default:"OCI"`
+	Expand            bool                            `default:"false"`
+	SBOM              dagql.Optional[core.SBOMFormat] `name:"sbom"`
+	Provenance        bool                            `default:"false"`
 
 	RawDagOpInternalArgs
 }
@@ -2191,7 +2427,7 @@ func (s *containerSchema) export(ctx context.Context, parent dagql.ObjectResult[
 		return "", fmt.Errorf("failed to get server: %w", err)
 	}
 
-	variants, err := dagql.LoadIDs(ctx, srv, args.PlatformVariants)
+	variants, err := dagql.LoadIDResults(ctx, srv, args.PlatformVariants)
 	if err != nil {
 		return "", err
 	}
@@ -2201,14 +2437,24 @@ func (s *containerSchema) export(ctx context.Context, parent dagql.ObjectResult[
 		return "", err
 	}
 
+	attestations, err := core.NewImageAttestations(ctx, append([]dagql.ObjectResult[*core.Container]{parent}, variants...), core.ImageAttestationOpts{
+		Name:       filepath.Base(path),
+		SBOM:       args.SBOM.Value,
+		Provenance: args.Provenance,
+	})
+	if err != nil {
+		return "", err
+	}
+
 	_, err = parent.Self().Export(
 		ctx,
 		core.ExportOpts{
 			Dest:              path,
-			PlatformVariants:  variants,
+			PlatformVariants:  containerSelves(variants),
 			ForcedCompression: args.ForcedCompression.Value,
 			MediaTypes:        args.MediaTypes,
 			Tar:               true,
+			Attestations:      attestations,
 		},
 	)
 	if err != nil {
@@ -2563,6 +2809,76 @@ func (s *containerSchema) withoutExposedPort(ctx context.Context, parent *core.C
 	return parent.WithoutExposedPort(args.Port, args.Protocol)
 }
 
+type containerWithHealthcheckArgs struct {
+	Args        []string `default:"[]"`
+	HTTPPort    int      `name:"httpPort" default:"0"`
+	HTTPPath    string   `name:"httpPath" default:"/"`
+	HTTPStatus  int      `name:"httpStatus" default:"200"`
+	LogPattern  string   `default:""`
+	Interval    string   `default:"1s"`
+	Timeout     string   `default:"10s"`
+	StartPeriod string   `default:"0s"`
+	Retries     int      `default:"30"`
+}
+
+func (s *containerSchema) withHealthcheck(ctx context.Context, parent *core.Container, args containerWithHealthcheckArgs) (*core.Container, error) {
+	var checks int
+	for _, set := range []bool{len(args.Args) > 0, args.HTTPPort != 0, args.LogPattern != ""} {
+		if set {
+			checks++
+		}
+	}
+	if checks != 1 {
+		return nil, fmt.Errorf("exactly one of args, httpPort and logPattern must be set")
+	}
+	if args.Retries < 1 {
+		return nil, fmt.Errorf("retries must be at least 1")
+	}
+	hc := &core.Healthcheck{
+		Args:       args.Args,
+		HTTPPort:   args.HTTPPort,
+		HTTPStatus: args.HTTPStatus,
+		LogPattern: args.LogPattern,
+		Retries:    args.Retries,
+	}
+	if args.HTTPPort != 0 {
+		hc.HTTPPath = args.HTTPPath
+		if !strings.HasPrefix(hc.HTTPPath, "/") {
+			hc.HTTPPath = "/" + hc.HTTPPath
+		}
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+		dest  *time.Duration
+	}{
+		{"interval", args.Interval, &hc.Interval},
+		{"timeout", args.Timeout, &hc.Timeout},
+		{"startPeriod", args.StartPeriod, &hc.StartPeriod},
+	} {
+		dur, err := time.ParseDuration(d.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		if dur < 0 {
+			return nil, fmt.Errorf("invalid %s: must not be negative", d.name)
+		}
+		*d.dest = dur
+	}
+	if hc.Timeout == 0 {
+		return nil, fmt.Errorf("invalid timeout: must be positive")
+	}
+	return parent.WithHealthcheck(hc)
+}
+
+func (s *containerSchema) withImageHealthcheck(ctx context.Context, parent *core.Container, args struct{}) (*core.Container, error) {
+	return parent.WithImageHealthcheck()
+}
+
+func (s *containerSchema) withoutHealthcheck(ctx context.Context, parent *core.Container, args struct{}) (*core.Container, error) {
+	return parent.WithoutHealthcheck(), nil
+}
+
 func (s *containerSchema) exposedPorts(ctx context.Context, parent *core.Container, args struct{}) (dagql.Array[core.Port], error) {
 	// get descriptions from `Container.Ports` (not in the OCI spec)
 	ports := make(map[string]core.Port, len(parent.Ports))
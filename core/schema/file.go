@@ -54,6 +54,10 @@ func (s *fileSchema) Install(srv *dagql.Server) {
 			Args(
 				dagql.Arg("name").Doc(`Name to set file to.`),
 			),
+		dagql.NodeFunc("blame", DagOpWrapper(srv, s.blame)).
+			Doc(`Return the lines of this file with the commit that last changed them.`,
+				`The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.`,
+				`Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.`),
 		dagql.NodeFunc("search", DagOpWrapper(srv, s.search)).
 			Doc(
 				// NOTE: sync with Directory.search
@@ -215,6 +219,14 @@ func (s *fileSchema) search(ctx context.Context, parent dagql.ObjectResult[*core
 	return parent.Self().Search(ctx, args.SearchOpts, true)
 }
 
+type fileBlameArgs struct {
+	RawDagOpInternalArgs
+}
+
+func (s *fileSchema) blame(ctx context.Context, parent dagql.ObjectResult[*core.File], _ fileBlameArgs) (dagql.Array[*core.GitBlameLine], error) {
+	return parent.Self().Blame(ctx)
+}
+
 type fileReplaceArgs struct {
 	Search      string
 	Replacement string
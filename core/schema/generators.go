@@ -19,6 +19,20 @@ func (s generatorsSchema) Install(srv *dagql.Server) {
 		dagql.Func("run", s.run).
 			Doc("Execute all selected generators"),
 
+		dagql.Func("affectedBy", s.affectedBy).
+			Doc("Skip the generators whose inputs are not affected by the given changes",
+				`A generator's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Generators without any declared input are always selected.`).
+			Args(
+				dagql.Arg("changes").Doc("The changes to select generators for, relative to the context directory"),
+			),
+
+		dagql.Func("affectedByPaths", s.affectedByPaths).
+			Doc("Skip the generators whose inputs are not affected by the given changed paths",
+				`Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.`).
+			Args(
+				dagql.Arg("paths").Doc("The added, modified or removed paths, relative to the context directory"),
+			),
+
 		dagql.NodeFunc("isEmpty", DagOpWrapper(srv, s.groupIsEmpty)).
 			Doc("Whether the generated changeset is empty or not"),
 
@@ -61,6 +75,30 @@ func (s generatorsSchema) run(ctx context.Context, parent *core.GeneratorGroup,
 	return parent.Run(ctx)
 }
 
+type generatorsAffectedByArgs struct {
+	Changes dagql.ID[*core.Changeset]
+}
+
+func (s generatorsSchema) affectedBy(ctx context.Context, parent *core.GeneratorGroup, args generatorsAffectedByArgs) (*core.GeneratorGroup, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := args.Changes.Load(ctx, srv)
+	if err != nil {
+		return nil, err
+	}
+	return parent.AffectedBy(ctx, changes.Self())
+}
+
+type generatorsAffectedByPathsArgs struct {
+	Paths []string
+}
+
+func (s generatorsSchema) affectedByPaths(ctx context.Context, parent *core.GeneratorGroup, args generatorsAffectedByPathsArgs) (*core.GeneratorGroup, error) {
+	return parent.AffectedByPaths(args.Paths)
+}
+
 type generatorsGroupIsEmptyArgs struct {
 	DagOpInternalArgs
 }
@@ -69,6 +69,9 @@ func (s *gitSchema) Install(srv *dagql.Server) {
 				dagql.Arg("httpAuthToken").Doc(`Secret used to populate the password during basic HTTP Authorization`),
 				dagql.Arg("httpAuthHeader").Doc(`Secret used to populate the Authorization HTTP header`),
 				dagql.Arg("experimentalServiceHost").Doc(`A service which must be started before the repo is fetched.`),
+				dagql.Arg("filter").Doc(
+					`Partial clone filter to fetch the repository with, like "blob:none" or "blob:limit=1m".`,
+					`The file contents needed by a checkout are fetched when checking it out.`),
 			),
 	}.Install(srv)
 
@@ -117,6 +120,16 @@ func (s *gitSchema) Install(srv *dagql.Server) {
 			Doc(`(Internal-only) Cleans the git repository by removing untracked files and resetting modifications.`),
 		dagql.NodeFunc("uncommitted", s.uncommitted).
 			Doc("Returns the changeset of uncommitted changes in the git repository."),
+		dagql.NodeFunc("withCommit", s.withCommit).
+			Doc(`Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.`,
+				`The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.`).
+			Args(
+				dagql.Arg("changes").Doc(`The changes to commit, applied to the tree of HEAD.`),
+				dagql.Arg("message").Doc(`The commit message.`),
+				dagql.Arg("author").Doc(`The author and committer of the commit (e.g., "Dagger Bot <bot@example.com>").`),
+			),
+		dagql.NodeFunc("__withCommit", DagOpDirectoryWrapper(srv, s.withCommitDirectory)).
+			Doc(`(Internal-only) Commits changes on top of HEAD, returning the bare repository with the new commit.`),
 
 		dagql.Func("withAuthToken", s.withAuthToken).
 			Doc(`Token to authenticate the remote with.`).
@@ -143,6 +156,13 @@ func (s *gitSchema) Install(srv *dagql.Server) {
 					Doc(`Set to true to discard .git directory.`),
 				dagql.Arg("depth").
 					Doc(`The depth of the tree to fetch.`),
+				dagql.Arg("sparse").
+					Doc(`Directories to check out, in git's sparse checkout cone mode (e.g., ["services/api", "libs"]).`,
+						`Files at the root of the repository and in the parents of these directories are checked out too.`,
+						`Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"]) are checked out in non-cone mode instead, where only the files matching them, like in .gitignore files, are checked out.`,
+						`Without .git directory, the tree only changes when the checked out files change.`),
+				dagql.Arg("lfs").
+					Doc(`Set to true to download the Git LFS objects of the checked out files.`),
 				dagql.Arg("sshKnownHosts").
 					View(BeforeVersion("v0.12.0")).
 					Doc("This option should be passed to `git` instead.").Deprecated(),
@@ -159,7 +179,49 @@ func (s *gitSchema) Install(srv *dagql.Server) {
 			Args(
 				dagql.Arg("other").Doc(`The other ref to compare against.`),
 			),
+		dagql.NodeFunc("log", s.log).
+			Doc(`The history of this ref, most recent commits first.`).
+			Args(
+				dagql.Arg("paths").Doc(`Only include the commits changing these paths (e.g., ["docs", "README.md"]).`),
+				dagql.Arg("limit").Doc(`The maximum number of commits to return. All the commits are returned if 0.`),
+				dagql.Arg("since").Doc(`Only include the commits more recent than a date, in any format git understands (e.g., "2024-01-31" or "2 weeks ago").`),
+			),
+		dagql.NodeFunc("diff", s.diff).
+			Doc(`Return the changes from this ref to another ref, like "git diff".`,
+				`The .git directory isn't part of the changes.`).
+			Args(
+				dagql.Arg("other").Doc(`The ref to compare this ref against.`),
+			),
+		dagql.NodeFunc("verify", s.verify).
+			Doc(`Verify the signature of this ref, failing unless it's signed by one of the trusted signers.`,
+				`Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.`,
+				`Returns the verified ref, with its signer.`).
+			Args(
+				dagql.Arg("allowedSigners").
+					Doc(`An SSH allowed signers file, trusting SSH keys for their principals (see ssh-keygen(1)).`),
+				dagql.Arg("keys").
+					Doc(`Trusted public keys: armored GPG public keys, or SSH public keys trusted for the principal in their comment.`),
+			),
+		dagql.NodeFuncWithCacheKey("push", s.push, dagql.CachePerCall).
+			DoNotCache("side effect on an external system (git remote)").
+			Doc(`Push the commit of this ref to a remote repository.`,
+				`Returns the updated remote ref.`).
+			Args(
+				dagql.Arg("remote").Doc(`URL of the remote repository.`),
+				dagql.Arg("ref").Doc(`The remote ref to update (e.g., "refs/heads/fix" or "fix" for a branch). Defaults to the name of this ref.`),
+				dagql.Arg("force").Doc(`Update the remote ref even if it isn't an ancestor of the pushed commit.`),
+				dagql.Arg("sshKnownHosts").Doc(`Set SSH known hosts`),
+				dagql.Arg("sshAuthSocket").Doc(`Set SSH auth socket`),
+				dagql.Arg("httpAuthUsername").Doc(`Username used to populate the password during basic HTTP Authorization`),
+				dagql.Arg("httpAuthToken").Doc(`Secret used to populate the password during basic HTTP Authorization`),
+				dagql.Arg("httpAuthHeader").Doc(`Secret used to populate the Authorization HTTP header`),
+			),
+		dagql.Func("signer", s.signer).
+			Doc(`The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.`),
 	}.Install(srv)
+
+	dagql.Fields[*core.GitCommit]{}.Install(srv)
+	dagql.Fields[*core.GitBlameLine]{}.Install(srv)
 }
 
 type gitArgs struct {
@@ -175,6 +237,8 @@ type gitArgs struct {
 	HTTPAuthToken    dagql.Optional[core.SecretID] `name:"httpAuthToken"`
 	HTTPAuthHeader   dagql.Optional[core.SecretID] `name:"httpAuthHeader"`
 
+	Filter string `default:""`
+
 	// internal args that can override the HEAD ref+commit
 	Commit string `default:"" internal:"true"`
 	Ref    string `default:"" internal:"true"`
@@ -228,6 +292,14 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 				})
 			}
 		}
+		if args.Filter != "" {
+			for i := range try {
+				try[i] = append(try[i], dagql.NamedInput{
+					Name:  "filter",
+					Value: dagql.NewString(args.Filter),
+				})
+			}
+		}
 		if args.ExperimentalServiceHost.Valid {
 			for i := range try {
 				try[i] = append(try[i], dagql.NamedInput{
@@ -306,6 +378,9 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 	if err != nil {
 		return inst, fmt.Errorf("failed to parse Git URL: %w", err)
 	}
+	if args.Filter != "" && args.Filter != "blob:none" && !strings.HasPrefix(args.Filter, "blob:limit=") {
+		return inst, fmt.Errorf("unsupported filter %q: only \"blob:none\" and \"blob:limit=<n>\" are supported", args.Filter)
+	}
 
 	clientMetadata, err := engine.ClientMetadataFromContext(ctx)
 	if err != nil {
@@ -401,6 +476,12 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 						Value: dagql.Opt(args.KeepGitDir.Value),
 					})
 				}
+				if args.Filter != "" {
+					selectArgs = append(selectArgs, dagql.NamedInput{
+						Name:  "filter",
+						Value: dagql.NewString(args.Filter),
+					})
+				}
 				if args.ExperimentalServiceHost.Valid {
 					selectArgs = append(selectArgs, dagql.NamedInput{
 						Name:  "experimentalServiceHost",
@@ -467,6 +548,12 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 					Value: dagql.Opt(args.KeepGitDir.Value),
 				})
 			}
+			if args.Filter != "" {
+				selectArgs = append(selectArgs, dagql.NamedInput{
+					Name:  "filter",
+					Value: dagql.NewString(args.Filter),
+				})
+			}
 			if args.ExperimentalServiceHost.Valid {
 				selectArgs = append(selectArgs, dagql.NamedInput{
 					Name:  "experimentalServiceHost",
@@ -598,6 +685,12 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 					Value: dagql.Opt(args.KeepGitDir.Value),
 				})
 			}
+			if args.Filter != "" {
+				selectArgs = append(selectArgs, dagql.NamedInput{
+					Name:  "filter",
+					Value: dagql.NewString(args.Filter),
+				})
+			}
 			if args.Commit != "" {
 				selectArgs = append(selectArgs, dagql.NamedInput{
 					Name:  "commit",
@@ -647,6 +740,7 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 		AuthHeader:    httpAuthHeader,
 		Services:      gitServices,
 		Platform:      parent.Self().Platform(),
+		Filter:        args.Filter,
 	})
 	if err != nil {
 		return inst, err
@@ -671,6 +765,10 @@ func (s *gitSchema) git(ctx context.Context, parent dagql.ObjectResult[*core.Que
 		// a token but hits cache for a dir where a ssh sock was used)
 		// -> see below
 	}
+	if args.Filter != "" {
+		// a kept .git directory is a partial clone
+		dgstInputs = append(dgstInputs, "filter", args.Filter)
+	}
 
 	var resourceIDs []*resource.ID
 	if sshAuthSock.Self() != nil {
@@ -757,6 +855,9 @@ func (s *gitSchema) ref(ctx context.Context, parent dagql.ObjectResult[*core.Git
 		strconv.FormatBool(repo.DiscardGitDir),
 	}
 	if remoteRepo, ok := repo.Backend.(*core.RemoteGitRepository); ok {
+		if remoteRepo.Filter != "" {
+			dgstInputs = append(dgstInputs, "filter", remoteRepo.Filter)
+		}
 		if remoteRepo.SSHAuthSocket.Self() != nil {
 			dgstInputs = append(dgstInputs, "sshAuthSock", remoteRepo.SSHAuthSocket.Self().IDDigest.String())
 		}
@@ -969,8 +1070,10 @@ func (s *gitSchema) withAuthHeader(ctx context.Context, parent *core.GitReposito
 }
 
 type treeArgs struct {
-	DiscardGitDir bool `default:"false"`
-	Depth         int  `default:"1"`
+	DiscardGitDir bool                                           `default:"false"`
+	Depth         int                                            `default:"1"`
+	Sparse        dagql.Optional[dagql.ArrayInput[dagql.String]] `name:"sparse"`
+	LFS           bool                                           `name:"lfs" default:"false"`
 
 	SSHKnownHosts dagql.Optional[dagql.String]  `name:"sshKnownHosts"`
 	SSHAuthSocket dagql.Optional[core.SocketID] `name:"sshAuthSocket"`
@@ -991,8 +1094,24 @@ func (s *gitSchema) tree(ctx context.Context, parent dagql.ObjectResult[*core.Gi
 		return inst, fmt.Errorf("sshAuthSocket is no longer supported on `tree`")
 	}
 
+	var sparse []string
+	if args.Sparse.Valid {
+		for _, pattern := range args.Sparse.Value {
+			sparse = append(sparse, pattern.String())
+		}
+		sparse, err = core.NormalizeGitSparse(sparse)
+		if err != nil {
+			return inst, err
+		}
+	}
+
 	if args.IsDagOp {
-		dir, err := parent.Self().Tree(ctx, srv, args.DiscardGitDir, args.Depth)
+		dir, err := parent.Self().Tree(ctx, srv, core.GitTreeOptions{
+			DiscardGitDir: args.DiscardGitDir,
+			Depth:         args.Depth,
+			Sparse:        sparse,
+			LFS:           args.LFS,
+		})
 		if err != nil {
 			return inst, err
 		}
@@ -1021,6 +1140,12 @@ func (s *gitSchema) tree(ctx context.Context, parent dagql.ObjectResult[*core.Gi
 		return inst, err
 	}
 
+	if len(sparse) > 0 && (args.DiscardGitDir || parent.Self().Repo.Self().DiscardGitDir) {
+		// the checked out directories are usually a small part of the
+		// repository, so cache by their content for other commits to hit
+		return core.MakeDirectoryContentHashed(ctx, bk, inst)
+	}
+
 	remoteRepo, isRemoteRepo := parent.Self().Repo.Self().Backend.(*core.RemoteGitRepository)
 	if isRemoteRepo {
 		usedAuth := remoteRepo.AuthToken.Self() != nil ||
@@ -1083,3 +1208,254 @@ func (s *gitSchema) commonAncestor(
 	}
 	return dagql.NewObjectResultForCurrentID(ctx, srv, result)
 }
+
+type gitLogArgs struct {
+	Paths []string `default:"[]"`
+	Limit int      `default:"0"`
+	Since string   `default:""`
+}
+
+func (s *gitSchema) log(
+	ctx context.Context,
+	parent dagql.ObjectResult[*core.GitRef],
+	args gitLogArgs,
+) (dagql.Array[*core.GitCommit], error) {
+	if args.Limit < 0 {
+		return nil, fmt.Errorf("limit must be positive, got %d", args.Limit)
+	}
+	return parent.Self().Log(ctx, core.GitLogOptions{
+		Paths: args.Paths,
+		Limit: args.Limit,
+		Since: args.Since,
+	})
+}
+
+type gitDiffArgs struct {
+	Other core.GitRefID
+}
+
+func (s *gitSchema) diff(
+	ctx context.Context,
+	parent dagql.ObjectResult[*core.GitRef],
+	args gitDiffArgs,
+) (inst dagql.ObjectResult[*core.Changeset], _ error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, fmt.Errorf("failed to get current dagql server: %w", err)
+	}
+	other, err := args.Other.Load(ctx, srv)
+	if err != nil {
+		return inst, err
+	}
+
+	treeSelector := dagql.Selector{
+		Field: "tree",
+		Args: []dagql.NamedInput{
+			{Name: "discardGitDir", Value: dagql.Boolean(true)},
+		},
+	}
+	var before, after dagql.ObjectResult[*core.Directory]
+	if err := srv.Select(ctx, parent, &before, treeSelector); err != nil {
+		return inst, fmt.Errorf("failed to select tree: %w", err)
+	}
+	if err := srv.Select(ctx, other, &after, treeSelector); err != nil {
+		return inst, fmt.Errorf("failed to select other tree: %w", err)
+	}
+
+	if err := srv.Select(ctx, after, &inst,
+		dagql.Selector{
+			Field: "changes",
+			Args: []dagql.NamedInput{
+				{
+					Name:  "from",
+					Value: dagql.NewID[*core.Directory](before.ID()),
+				},
+			},
+		},
+	); err != nil {
+		return inst, fmt.Errorf("failed to select changes: %w", err)
+	}
+	return inst, nil
+}
+
+type verifyArgs struct {
+	AllowedSigners dagql.Optional[core.FileID]
+	Keys           []core.SecretID `default:"[]"`
+}
+
+func (s *gitSchema) verify(
+	ctx context.Context,
+	parent dagql.ObjectResult[*core.GitRef],
+	args verifyArgs,
+) (inst dagql.ObjectResult[*core.GitRef], _ error) {
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return inst, err
+	}
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, fmt.Errorf("failed to get current dagql server: %w", err)
+	}
+
+	var opts core.GitVerifyOptions
+	if args.AllowedSigners.Valid {
+		file, err := args.AllowedSigners.Value.Load(ctx, srv)
+		if err != nil {
+			return inst, err
+		}
+		opts.AllowedSigners, err = file.Self().Contents(ctx, nil, nil)
+		if err != nil {
+			return inst, fmt.Errorf("failed to read allowed signers: %w", err)
+		}
+	}
+	if len(args.Keys) > 0 {
+		secretStore, err := query.Secrets(ctx)
+		if err != nil {
+			return inst, fmt.Errorf("failed to get secret store: %w", err)
+		}
+		for _, id := range args.Keys {
+			secret, err := id.Load(ctx, srv)
+			if err != nil {
+				return inst, err
+			}
+			key, err := secretStore.GetSecretPlaintext(ctx, core.SecretIDDigest(secret.ID()))
+			if err != nil {
+				return inst, err
+			}
+			opts.Keys = append(opts.Keys, key)
+		}
+	}
+
+	signer, err := parent.Self().Verify(ctx, opts)
+	if err != nil {
+		return inst, err
+	}
+	ref := *parent.Self()
+	ref.Signer = signer
+	return dagql.NewObjectResultForCurrentID(ctx, srv, &ref)
+}
+
+func (s *gitSchema) signer(ctx context.Context, parent *core.GitRef, args struct{}) (dagql.String, error) {
+	if parent.Signer == "" {
+		return "", errors.New("git ref is not verified, call verify first")
+	}
+	return dagql.NewString(parent.Signer), nil
+}
+
+type withCommitArgs struct {
+	Changes dagql.ID[*core.Changeset]
+	Message string
+	Author  string
+}
+
+func (s *gitSchema) withCommit(ctx context.Context, parent dagql.ObjectResult[*core.GitRepository], args withCommitArgs) (inst dagql.ObjectResult[*core.GitRepository], _ error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, fmt.Errorf("failed to get current dagql server: %w", err)
+	}
+	// the repository is committed in an internal field for good caching
+	// behavior, and used as a local repository
+	err = srv.Select(ctx, parent, &inst,
+		dagql.Selector{
+			Field: "__withCommit",
+			Args: []dagql.NamedInput{
+				{Name: "changes", Value: args.Changes},
+				{Name: "message", Value: dagql.NewString(args.Message)},
+				{Name: "author", Value: dagql.NewString(args.Author)},
+			},
+		},
+		dagql.Selector{
+			Field: "asGit",
+		},
+	)
+	return inst, err
+}
+
+type withCommitDirectoryArgs struct {
+	withCommitArgs
+	DagOpInternalArgs
+}
+
+func (s *gitSchema) withCommitDirectory(ctx context.Context, parent dagql.ObjectResult[*core.GitRepository], args withCommitDirectoryArgs) (inst dagql.ObjectResult[*core.Directory], _ error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return inst, fmt.Errorf("failed to get current dagql server: %w", err)
+	}
+	changes, err := args.Changes.Load(ctx, srv)
+	if err != nil {
+		return inst, err
+	}
+	dir, err := parent.Self().WithCommit(ctx, changes.Self(), core.GitCommitOptions{
+		Message: args.Message,
+		Author:  args.Author,
+	})
+	if err != nil {
+		return inst, err
+	}
+	return dagql.NewObjectResultForCurrentID(ctx, srv, dir)
+}
+
+type pushArgs struct {
+	Remote string
+	Ref    string `default:""`
+	Force  bool   `default:"false"`
+
+	SSHKnownHosts string                        `name:"sshKnownHosts" default:""`
+	SSHAuthSocket dagql.Optional[core.SocketID] `name:"sshAuthSocket"`
+
+	HTTPAuthUsername string                        `name:"httpAuthUsername" default:""`
+	HTTPAuthToken    dagql.Optional[core.SecretID] `name:"httpAuthToken"`
+	HTTPAuthHeader   dagql.Optional[core.SecretID] `name:"httpAuthHeader"`
+}
+
+func (s *gitSchema) push(ctx context.Context, parent dagql.ObjectResult[*core.GitRef], args pushArgs) (dagql.String, error) {
+	srv, err := core.CurrentDagqlServer(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get current dagql server: %w", err)
+	}
+	query, err := core.CurrentQuery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	remoteURL, err := gitutil.ParseURL(args.Remote)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse Git URL: %w", err)
+	}
+	if remoteURL.Scheme == gitutil.SSHProtocol && remoteURL.User == nil {
+		remoteURL.User = url.User("git")
+	}
+	remote := &core.RemoteGitRepository{
+		URL:           remoteURL,
+		SSHKnownHosts: args.SSHKnownHosts,
+		AuthUsername:  args.HTTPAuthUsername,
+		Platform:      query.Platform(),
+	}
+	if args.SSHAuthSocket.Valid {
+		remote.SSHAuthSocket, err = args.SSHAuthSocket.Value.Load(ctx, srv)
+		if err != nil {
+			return "", err
+		}
+	}
+	if args.HTTPAuthToken.Valid {
+		remote.AuthToken, err = args.HTTPAuthToken.Value.Load(ctx, srv)
+		if err != nil {
+			return "", err
+		}
+	}
+	if args.HTTPAuthHeader.Valid {
+		remote.AuthHeader, err = args.HTTPAuthHeader.Value.Load(ctx, srv)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	dst, err := parent.Self().Push(ctx, remote, core.GitPushOptions{
+		Ref:   args.Ref,
+		Force: args.Force,
+	})
+	if err != nil {
+		return "", err
+	}
+	return dagql.NewString(dst), nil
+}
@@ -2,6 +2,8 @@ package schema
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
@@ -54,6 +56,24 @@ func (s llmSchema) Install(srv *dagql.Server) {
 			Args(
 				dagql.Arg("model").Doc("The model to use"),
 			),
+		dagql.Func("withBudget", s.withBudget).
+			Doc("Limit the tokens and estimated cost of the LLM.",
+				"When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.").
+			Args(
+				dagql.Arg("maxTokens").Doc("The maximum number of tokens, input and output, summed over all API calls. 0 means unlimited."),
+				dagql.Arg("maxCost").Doc("The maximum estimated cost in USD, based on the price of the model. 0 means unlimited.",
+					"Evaluating the LLM fails if the price of the model is unknown."),
+			),
+		dagql.Func("withCassette", s.withCassette).
+			Doc("Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.",
+				"Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.").
+			Args(
+				dagql.Arg("file").Doc("The cassette to replay. Required in REPLAY mode."),
+				dagql.Arg("mode").Doc("Whether to record or replay the cassette"),
+			),
+		dagql.Func("cassette", s.cassette).
+			Doc("Return the cassette recorded by the LLM, to replay with withCassette",
+				"Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is."),
 		dagql.Func("withPrompt", s.withPrompt).
 			Doc("append a prompt to the llm context").
 			Args(
@@ -114,8 +134,18 @@ func (s llmSchema) Install(srv *dagql.Server) {
 			Doc("returns the type of the current state"),
 		dagql.Func("tokenUsage", s.tokenUsage).
 			Doc("returns the token usage of the current state"),
+		dagql.Func("stream", s.stream).
+			DoNotCache("Returns the events emitted so far, which change as the LLM is synchronized.").
+			Doc("Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage",
+				"To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.").
+			Args(
+				dagql.Arg("after").Doc("The number of events to skip, i.e. the number of events received from previous calls"),
+			),
 	}.Install(srv)
 	dagql.Fields[*core.LLMTokenUsage]{}.Install(srv)
+	dagql.Fields[*core.LLMStreamEvent]{}.Install(srv)
+	core.LLMCassetteModes.Install(srv)
+	core.LLMStreamEventKinds.Install(srv)
 }
 
 func (s *llmSchema) withEnv(ctx context.Context, llm *core.LLM, args struct {
@@ -169,6 +199,60 @@ func (s *llmSchema) withModel(ctx context.Context, llm *core.LLM, args struct {
 	return llm.WithModel(args.Model), nil
 }
 
+func (s *llmSchema) withBudget(ctx context.Context, llm *core.LLM, args struct {
+	MaxTokens int     `default:"0"`
+	MaxCost   float64 `default:"0"`
+}) (*core.LLM, error) {
+	return llm.WithBudget(int64(args.MaxTokens), args.MaxCost), nil
+}
+
+func (s *llmSchema) withCassette(ctx context.Context, llm *core.LLM, args struct {
+	File dagql.Optional[core.FileID]
+	Mode core.LLMCassetteMode `default:"REPLAY"`
+}) (*core.LLM, error) {
+	var contents []byte
+	if args.Mode == core.LLMCassetteModeReplay {
+		if !args.File.Valid {
+			return nil, fmt.Errorf("a cassette file is required to replay")
+		}
+		file, err := args.File.Value.Load(ctx, s.srv)
+		if err != nil {
+			return nil, err
+		}
+		contents, err = file.Self().Contents(ctx, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	cassette, err := core.NewLLMCassette(args.Mode, contents)
+	if err != nil {
+		return nil, err
+	}
+	return llm.WithCassette(cassette), nil
+}
+
+func (s *llmSchema) cassette(ctx context.Context, llm *core.LLM, _ struct{}) (inst dagql.ObjectResult[*core.File], _ error) {
+	if err := llm.Sync(ctx); err != nil {
+		return inst, err
+	}
+	cassette := llm.Cassette()
+	if cassette == nil {
+		return inst, fmt.Errorf("no cassette: use withCassette to record one")
+	}
+	contents, err := json.Marshal(cassette)
+	if err != nil {
+		return inst, err
+	}
+	err = s.srv.Select(ctx, s.srv.Root(), &inst, dagql.Selector{
+		Field: "file",
+		Args: []dagql.NamedInput{
+			{Name: "name", Value: dagql.NewString("cassette.json")},
+			{Name: "contents", Value: dagql.NewString(string(contents))},
+		},
+	})
+	return inst, err
+}
+
 func (s *llmSchema) withPrompt(ctx context.Context, llm *core.LLM, args struct {
 	Prompt string
 }) (*core.LLM, error) {
@@ -292,6 +376,12 @@ func (s *llmSchema) tokenUsage(ctx context.Context, llm *core.LLM, _ struct{}) (
 	return llm.TokenUsage(ctx, s.srv)
 }
 
+func (s *llmSchema) stream(ctx context.Context, llm *core.LLM, args struct {
+	After int `default:"0"`
+}) (dagql.Array[*core.LLMStreamEvent], error) {
+	return llm.Stream(ctx, args.After)
+}
+
 func (s *llmSchema) withoutMessageHistory(ctx context.Context, llm *core.LLM, _ struct{}) (*core.LLM, error) {
 	return llm.WithoutMessageHistory(), nil
 }
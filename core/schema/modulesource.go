@@ -3521,6 +3521,9 @@ func (s *moduleSourceSchema) integrateToolchains(
 						if len(tcCfg.IgnoreChecks) > 0 {
 							entry.IgnoreChecks = tcCfg.IgnoreChecks
 						}
+						if len(tcCfg.QuarantineChecks) > 0 {
+							entry.QuarantineChecks = tcCfg.QuarantineChecks
+						}
 						if len(tcCfg.IgnoreGenerators) > 0 {
 							entry.IgnoreGenerators = tcCfg.IgnoreGenerators
 						}
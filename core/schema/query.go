@@ -41,10 +41,12 @@ func (s *querySchema) Install(srv *dagql.Server) {
 	core.NetworkProtocols.Install(srv)
 	core.ImageLayerCompressions.Install(srv)
 	core.ImageMediaTypesEnum.Install(srv)
+	core.SBOMFormats.Install(srv)
 	core.CacheSharingModes.Install(srv)
 	core.TypeDefKinds.Install(srv)
 	core.ModuleSourceKindEnum.Install(srv)
 	core.ReturnTypesEnum.Install(srv)
+	core.NetworkModesEnum.Install(srv)
 	core.ModuleSourceExperimentalFeatures.Install(srv)
 	core.FunctionCachePolicyEnum.Install(srv)
 
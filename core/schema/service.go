@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/dagger/dagger/core"
 	"github.com/dagger/dagger/dagql"
@@ -69,6 +70,9 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 				dagql.Arg("random").Doc(`Bind each tunnel port to a random port on the host.`),
 				dagql.Arg("ports").Doc(`List of frontend/backend port mappings to forward.`,
 					`Frontend is the port accepting traffic on the host, backend is the service port.`),
+				dagql.Arg("timeout").Doc(
+					`Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").`,
+					`When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.`),
 				dagql.Arg("args").Doc(
 					`Command to run instead of the container's default command (e.g., ["go", "run", "main.go"]).`,
 					`If empty, the container's default command is used.`),
@@ -123,7 +127,13 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 		dagql.NodeFunc("start", s.start).
 			DoNotCache("Imperatively mutates runtime state.").
 			Doc(`Start the service and wait for its health checks to succeed.`,
-				`Services bound to a Container do not need to be manually started.`),
+				`Services bound to a Container do not need to be manually started.`).
+			Args(
+				dagql.Arg("timeout").Doc(
+					`Maximum duration the service may run once started, as a Go duration string (e.g. "10m").`,
+					`When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period.`,
+					`A service that was already running, e.g. for a container it is bound to, is not stopped.`),
+			),
 
 		dagql.NodeFunc("up", s.up).
 			DoNotCache("Starts a host tunnel, possibly with ports that change each time it's started.").
@@ -132,6 +142,9 @@ func (s *serviceSchema) Install(srv *dagql.Server) {
 				dagql.Arg("ports").Doc(`List of frontend/backend port mappings to forward.`,
 					`Frontend is the port accepting traffic on the host, backend is the service port.`),
 				dagql.Arg("random").Doc(`Bind each tunnel port to a random port on the host.`),
+				dagql.Arg("timeout").Doc(
+					`Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").`,
+					`When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.`),
 			),
 
 		dagql.NodeFunc("stop", s.stop).
@@ -338,7 +351,11 @@ func (s *serviceSchema) endpoint(ctx context.Context, parent dagql.ObjectResult[
 	return dagql.NewResultForCurrentID(ctx, dagql.NewString(str))
 }
 
-func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*core.Service], args struct{}) (res dagql.Result[core.ServiceID], _ error) {
+type serviceStartArgs struct {
+	Timeout string `default:""`
+}
+
+func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*core.Service], args serviceStartArgs) (res dagql.Result[core.ServiceID], _ error) {
 	defer func() {
 		if err := recover(); err != nil {
 			debug.PrintStack()
@@ -346,7 +363,12 @@ func (s *serviceSchema) start(ctx context.Context, parent dagql.ObjectResult[*co
 		}
 	}()
 
-	if err := parent.Self().StartAndTrack(ctx, parent.ID()); err != nil {
+	timeout, err := core.ParseExecTimeout(args.Timeout)
+	if err != nil {
+		return res, err
+	}
+
+	if err := parent.Self().StartAndTrack(ctx, parent.ID(), timeout); err != nil {
 		return res, err
 	}
 
@@ -392,8 +414,9 @@ func (s *serviceSchema) terminal(ctx context.Context, parent dagql.ObjectResult[
 }
 
 type UpArgs struct {
-	Ports  []dagql.InputObject[core.PortForward] `default:"[]"`
-	Random bool                                  `default:"false"`
+	Ports   []dagql.InputObject[core.PortForward] `default:"[]"`
+	Random  bool                                  `default:"false"`
+	Timeout string                                `default:""`
 }
 
 const InstrumentationLibrary = "dagger.io/engine.schema"
@@ -406,6 +429,11 @@ func (s *serviceSchema) up(ctx context.Context, svc dagql.ObjectResult[*core.Ser
 		return res, fmt.Errorf("failed to get Dagger server: %w", err)
 	}
 
+	timeout, err := core.ParseExecTimeout(args.Timeout)
+	if err != nil {
+		return res, err
+	}
+
 	useNative := !args.Random && len(args.Ports) == 0
 
 	var hostSvc dagql.Result[*core.Service]
@@ -434,6 +462,12 @@ func (s *serviceSchema) up(ctx context.Context, svc dagql.ObjectResult[*core.Ser
 	if err != nil {
 		return res, fmt.Errorf("failed to get host services: %w", err)
 	}
+	// only a service started by this call is stopped on timeout, not one that
+	// was already running for something else
+	svcClientSpecific := svc.Self().TunnelUpstream.Self() != nil
+	_, err = svcs.Get(ctx, svc.ID(), svcClientSpecific)
+	startedSvc := err != nil
+
 	runningSvc, err := svcs.Start(ctx, hostSvc.ID(), hostSvc.Self(), true)
 	if err != nil {
 		return res, fmt.Errorf("failed to start host service: %w", err)
@@ -455,8 +489,29 @@ func (s *serviceSchema) up(ctx context.Context, svc dagql.ObjectResult[*core.Ser
 		)
 	}
 
-	// wait for the request to be canceled
-	<-ctx.Done()
+	if timeout == 0 {
+		// wait for the request to be canceled
+		<-ctx.Done()
+		return void, nil
+	}
 
-	return void, nil
+	// wait for the request to be canceled or the timeout to elapse
+	select {
+	case <-ctx.Done():
+		return void, nil
+	case <-time.After(timeout):
+	}
+
+	if err := svcs.Stop(ctx, hostSvc.ID(), false, true); err != nil {
+		return res, fmt.Errorf("failed to stop timed out tunnel: %w", err)
+	}
+	if startedSvc {
+		// stop the service too, even if something else has bound to it in the
+		// meantime, since the timeout bounds how long the service runs, not
+		// just the tunnel
+		if err := svcs.Stop(ctx, svc.ID(), false, svcClientSpecific); err != nil {
+			return res, fmt.Errorf("failed to stop timed out service: %w", err)
+		}
+	}
+	return res, fmt.Errorf("service timed out after %s", timeout)
 }
@@ -3,6 +3,8 @@ package core
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
 
 	"github.com/dagger/dagger/dagql"
@@ -221,6 +223,23 @@ func (store *SecretStore) GetSecretPlaintextDirect(ctx context.Context, secret *
 	return resp.Data, nil
 }
 
+// Plaintexts returns the plaintexts of all the secrets in the store, skipping
+// the ones that can't be retrieved.
+func (store *SecretStore) Plaintexts(ctx context.Context) []string {
+	store.mu.RLock()
+	stored := slices.Collect(maps.Values(store.secrets))
+	store.mu.RUnlock()
+	plaintexts := make([]string, 0, len(stored))
+	for _, secret := range stored {
+		plaintext, err := store.GetSecretPlaintextDirect(ctx, secret.Self())
+		if err != nil {
+			continue
+		}
+		plaintexts = append(plaintexts, string(plaintext))
+	}
+	return plaintexts
+}
+
 func (store *SecretStore) AsBuildkitSecretStore() secrets.SecretStore {
 	return &buildkitSecretStore{inner: store}
 }
@@ -236,7 +236,10 @@ func (svc *Service) Endpoint(ctx context.Context, id *call.ID, port int, scheme
 	return endpoint, nil
 }
 
-func (svc *Service) StartAndTrack(ctx context.Context, id *call.ID) error {
+// StartAndTrack starts the service and tracks it for the session. If timeout
+// is non-zero and the service wasn't already running, it is gracefully
+// stopped once the timeout has elapsed.
+func (svc *Service) StartAndTrack(ctx context.Context, id *call.ID, timeout time.Duration) error {
 	query, err := CurrentQuery(ctx)
 	if err != nil {
 		return err
@@ -245,8 +248,19 @@ func (svc *Service) StartAndTrack(ctx context.Context, id *call.ID) error {
 	if err != nil {
 		return err
 	}
-	_, err = svcs.Start(ctx, id, svc, svc.TunnelUpstream.Self() != nil)
-	return err
+	clientSpecific := svc.TunnelUpstream.Self() != nil
+	// only a service started by this call is stopped on timeout, not one that
+	// was already running for something else
+	_, err = svcs.Get(ctx, id, clientSpecific)
+	startedSvc := err != nil
+	running, err := svcs.Start(ctx, id, svc, clientSpecific)
+	if err != nil {
+		return err
+	}
+	if timeout > 0 && startedSvc {
+		svcs.StopAfter(ctx, running, timeout)
+	}
+	return nil
 }
 
 func (svc *Service) Stop(ctx context.Context, id *call.ID, kill bool) error {
@@ -527,6 +541,14 @@ func (svc *Service) startContainer(
 	if sio != nil && sio.Stdout != nil {
 		stdoutWriters = append(stdoutWriters, sio.Stdout)
 	}
+	var logPattern *logPatternWriter
+	if hc := ctr.Healthcheck; hc != nil && hc.LogPattern != "" {
+		logPattern, err = newLogPatternWriter(hc.LogPattern)
+		if err != nil {
+			return nil, err
+		}
+		stdoutWriters = append(stdoutWriters, logPattern)
+	}
 	stderrWriters := multiWriteCloser{errBufWC}
 	if sio != nil && sio.Stderr != nil {
 		stderrWriters = append(stderrWriters, sio.Stderr)
@@ -569,7 +591,32 @@ func (svc *Service) startContainer(
 
 	checked := make(chan error, 1)
 	go func() {
-		checked <- newHealth(bk, buildkit.NewDirectNS(svcID), fullHost, ctr.Ports).Check(ctx)
+		ns := buildkit.NewDirectNS(svcID)
+		if err := newHealth(bk, ns, fullHost, ctr.Ports).Check(ctx); err != nil {
+			checked <- err
+			return
+		}
+		if ctr.Healthcheck == nil {
+			checked <- nil
+			return
+		}
+		checked <- (&serviceHealthChecker{
+			hc:   ctr.Healthcheck,
+			bk:   bk,
+			ns:   ns,
+			host: fullHost,
+			exec: func(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+				meta := *meta
+				meta.Args = args
+				meta.Tty = false
+				return exec.Exec(ctx, svcID, executor.ProcessInfo{
+					Meta:   meta,
+					Stdout: nopWriteCloser{stdout},
+					Stderr: nopWriteCloser{stderr},
+				})
+			},
+			logs: logPattern,
+		}).Check(ctx)
 	}()
 
 	var stopped atomic.Bool
@@ -366,6 +366,30 @@ func (ss *Services) Detach(ctx context.Context, svc *RunningService) {
 	go ss.stopGraceful(context.WithoutCancel(ctx), running, TerminateGracePeriod)
 }
 
+// StopAfter gracefully stops the given service once the timeout has elapsed,
+// unless it exits on its own first. It does not block.
+func (ss *Services) StopAfter(ctx context.Context, running *RunningService, timeout time.Duration) {
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		cause := errors.New("service timed out")
+		waitCtx, cancel := context.WithTimeoutCause(ctx, timeout, cause)
+		defer cancel()
+		if running.Wait != nil {
+			running.Wait(waitCtx)
+		} else {
+			<-waitCtx.Done()
+		}
+		if context.Cause(waitCtx) != cause {
+			// exited before the timeout
+			return
+		}
+		slog.Info("stopping timed out service", "service", running.Host, "timeout", timeout)
+		if err := ss.stopGraceful(ctx, running, TerminateGracePeriod); err != nil {
+			slog.Warn("failed to stop timed out service", "service", running.Host, "error", err)
+		}
+	}()
+}
+
 func (ss *Services) stop(ctx context.Context, running *RunningService, force bool) error {
 	err := running.Stop(ctx, force)
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	"github.com/dagger/dagger/engine/buildkit"
 	engineclient "github.com/dagger/dagger/engine/client"
 	"github.com/dagger/dagger/engine/clientdb"
+	"github.com/dagger/dagger/engine/config"
 	"github.com/dagger/dagger/engine/filesync"
 	"github.com/dagger/dagger/engine/server/resource"
 	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
@@ -92,10 +93,17 @@ func (ms *mockServer) Auth(context.Context) (*auth.RegistryAuthProvider, error)
 func (ms *mockServer) Buildkit(context.Context) (*buildkit.Client, error) { return nil, nil }
 
 func (ms *mockServer) Services(context.Context) (*Services, error) { return nil, nil }
+func (ms *mockServer) SessionContext(ctx context.Context) (context.Context, error) {
+	return ctx, nil
+}
+func (ms *mockServer) LLMUsage(context.Context) (*LLMSessionUsage, error) {
+	return nil, nil
+}
 
 func (ms *mockServer) Platform() Platform               { return Platform{} }
 func (ms *mockServer) OCIStore() content.Store          { return nil }
 func (ms *mockServer) DNS() *oci.DNSConfig              { return nil }
+func (ms *mockServer) LLMConfig() *config.LLMConfig     { return nil }
 func (ms *mockServer) LeaseManager() *leaseutil.Manager { return nil }
 func (ms *mockServer) EngineLocalCacheEntries(context.Context) (*EngineCacheEntrySet, error) {
 	return nil, nil
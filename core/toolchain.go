@@ -25,6 +25,7 @@ type ToolchainEntry struct {
 	FieldName        string
 	ArgumentConfigs  []*modules.ModuleConfigArgument
 	IgnoreChecks     []string
+	QuarantineChecks []string
 	IgnoreGenerators []string
 }
 
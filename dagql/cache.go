@@ -48,6 +48,23 @@ type Cache interface {
 
 	// Run a blocking loop that periodically garbage collects expired entries from the cache db.
 	GCLoop(context.Context)
+
+	// Returns the persisted calls that haven't expired yet, to share them with
+	// other engines.
+	ExportCalls(context.Context) ([]PersistedCall, error)
+
+	// Persists calls exported by another engine. Calls persisted locally that
+	// haven't expired yet are kept.
+	ImportCalls(context.Context, []PersistedCall) error
+}
+
+// PersistedCall maps a call key to the key its result is stored under, until
+// it expires.
+type PersistedCall struct {
+	CallKey    string `json:"callKey"`
+	StorageKey string `json:"storageKey"`
+	// Unix timestamp, in seconds
+	Expiration int64 `json:"expiration"`
 }
 
 func ValueFunc(v AnyResult) func(context.Context) (AnyResult, error) {
@@ -669,6 +686,48 @@ func (c *cache) GCLoop(ctx context.Context) {
 	}
 }
 
+func (c *cache) ExportCalls(ctx context.Context) ([]PersistedCall, error) {
+	if c.db == nil {
+		return nil, nil
+	}
+	calls, err := c.db.SelectCalls(ctx, cachedb.SelectCallsParams{
+		Now: time.Now().Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("select calls: %w", err)
+	}
+	res := make([]PersistedCall, 0, len(calls))
+	for _, call := range calls {
+		res = append(res, PersistedCall{
+			CallKey:    call.CallKey,
+			StorageKey: call.StorageKey,
+			Expiration: call.Expiration,
+		})
+	}
+	return res, nil
+}
+
+func (c *cache) ImportCalls(ctx context.Context, calls []PersistedCall) error {
+	if c.db == nil {
+		return nil
+	}
+	now := time.Now().Unix()
+	for _, call := range calls {
+		if call.Expiration < now {
+			continue
+		}
+		if err := c.db.ImportCall(ctx, cachedb.ImportCallParams{
+			CallKey:    call.CallKey,
+			StorageKey: call.StorageKey,
+			Expiration: call.Expiration,
+			Now:        now,
+		}); err != nil {
+			return fmt.Errorf("import call %s: %w", call.CallKey, err)
+		}
+	}
+	return nil
+}
+
 func (c *cache) Size() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
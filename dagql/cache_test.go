@@ -1052,6 +1052,67 @@ func TestCacheTTLWithDBUsesStorageAndCallIndexes(t *testing.T) {
 	assert.Equal(t, 0, c.Size())
 }
 
+func TestCacheExportImportCalls(t *testing.T) {
+	t.Parallel()
+	keyID := cacheTestID("ttl-key")
+	ttlCall := func(ctx context.Context, c Cache) string {
+		t.Helper()
+		var storageKey string
+		res, err := c.GetOrInitCall(ctx, CacheKey{
+			ID:  keyID,
+			TTL: 60,
+		}, func(ctx context.Context) (AnyResult, error) {
+			storageKey = CurrentStorageKey(ctx)
+			return newDetachedResult(keyID, NewInt(5)).WithSafeToPersistCache(true), nil
+		})
+		assert.NilError(t, err)
+		assert.NilError(t, res.Release(ctx))
+		return storageKey
+	}
+
+	// run a call with a TTL on a first engine
+	ctx1 := engine.ContextWithClientMetadata(t.Context(), &engine.ClientMetadata{
+		ClientID:  "cache-test-client-1",
+		SessionID: "cache-test-session-1",
+	})
+	cache1, err := NewCache(ctx1, filepath.Join(t.TempDir(), "cache.db"))
+	assert.NilError(t, err)
+	storageKey1 := ttlCall(ctx1, cache1)
+	assert.Assert(t, storageKey1 != keyID.Digest().String())
+
+	calls, err := cache1.ExportCalls(ctx1)
+	assert.NilError(t, err)
+	assert.Equal(t, 1, len(calls))
+	assert.Equal(t, keyID.Digest().String(), calls[0].CallKey)
+	assert.Equal(t, storageKey1, calls[0].StorageKey)
+
+	// a second engine importing the calls stores results under the same key
+	ctx2 := engine.ContextWithClientMetadata(t.Context(), &engine.ClientMetadata{
+		ClientID:  "cache-test-client-2",
+		SessionID: "cache-test-session-2",
+	})
+	cache2, err := NewCache(ctx2, filepath.Join(t.TempDir(), "cache.db"))
+	assert.NilError(t, err)
+	assert.NilError(t, cache2.ImportCalls(ctx2, append(calls, PersistedCall{
+		CallKey:    "expired",
+		StorageKey: "expired",
+		Expiration: time.Now().Add(-time.Hour).Unix(),
+	})))
+	assert.Equal(t, storageKey1, ttlCall(ctx2, cache2))
+
+	exported, err := cache2.ExportCalls(ctx2)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, calls, exported)
+
+	// calls persisted locally take precedence over imported ones
+	cache3, err := NewCache(ctx2, filepath.Join(t.TempDir(), "cache.db"))
+	assert.NilError(t, err)
+	storageKey3 := ttlCall(ctx2, cache3)
+	assert.NilError(t, cache3.ImportCalls(ctx2, calls))
+	assert.Equal(t, storageKey3, ttlCall(ctx2, cache3))
+	assert.Assert(t, storageKey3 != storageKey1)
+}
+
 func TestCacheArbitraryRoundTripAndRelease(t *testing.T) {
 	t.Parallel()
 	ctx := t.Context()
@@ -38,6 +38,61 @@ func (q *Queries) SetExpiration(ctx context.Context, arg SetExpirationParams) er
 	return err
 }
 
+const selectCalls = `SELECT call_key, storage_key, expiration FROM calls WHERE expiration >= ? ORDER BY call_key`
+
+type SelectCallsParams struct {
+	Now int64
+}
+
+// Select the calls that haven't expired yet.
+func (q *Queries) SelectCalls(ctx context.Context, arg SelectCallsParams) ([]*Call, error) {
+	rows, err := q.query(ctx, nil, selectCalls, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []*Call
+	for rows.Next() {
+		var i Call
+		if err := rows.Scan(&i.CallKey, &i.StorageKey, &i.Expiration); err != nil {
+			return nil, err
+		}
+		items = append(items, &i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// Insert a call imported from elsewhere, only replacing an existing entry if
+// it has expired, so that results already cached locally keep being used.
+const importCall = `
+INSERT INTO calls (call_key, storage_key, expiration)
+VALUES (?, ?, ?)
+ON CONFLICT (call_key) DO UPDATE SET
+	expiration = EXCLUDED.expiration,
+	storage_key = EXCLUDED.storage_key
+WHERE calls.expiration < ?
+`
+
+type ImportCallParams struct {
+	CallKey    string
+	StorageKey string
+	Expiration int64
+	Now        int64
+}
+
+func (q *Queries) ImportCall(ctx context.Context, arg ImportCallParams) error {
+	_, err := q.exec(ctx, nil, importCall,
+		arg.CallKey, arg.StorageKey, arg.Expiration, arg.Now,
+	)
+	return err
+}
+
 const gcBatchSize = 1000
 const gcBatchSizeStr = "1000"
 
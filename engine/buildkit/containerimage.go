@@ -13,6 +13,7 @@ import (
 	bkcache "github.com/dagger/dagger/internal/buildkit/cache"
 	bkclient "github.com/dagger/dagger/internal/buildkit/client"
 	"github.com/dagger/dagger/internal/buildkit/exporter/containerimage/exptypes"
+	gatewaypb "github.com/dagger/dagger/internal/buildkit/frontend/gateway/pb"
 	solverresult "github.com/dagger/dagger/internal/buildkit/solver/result"
 	"github.com/dagger/dagger/util/containerutil"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
@@ -77,9 +78,17 @@ func (c *Client) PublishContainerImage(
 }
 
 type ContainerExport struct {
-	Ref         bkcache.ImmutableRef
-	Config      specs.ImageConfig
-	Annotations []containerutil.ContainerAnnotation
+	Ref          bkcache.ImmutableRef
+	Config       specs.ImageConfig
+	Annotations  []containerutil.ContainerAnnotation
+	Attestations []ImageAttestation
+}
+
+// ImageAttestation is an in-toto attestation attached to an exported image,
+// with the image manifest as its subject.
+type ImageAttestation struct {
+	PredicateType string
+	Predicate     []byte
 }
 
 func addAnnotations(inputByPlatform map[string]ContainerExport, opts map[string]string) error {
@@ -306,5 +315,36 @@ func (c *Client) combineContainerRefs(
 		combinedResult.AddMeta(exptypes.ExporterPlatformsKey, platformBytes)
 	}
 
+	for platformString, input := range inputByPlatform {
+		if len(inputByPlatform) == 1 {
+			// attestations are keyed by the platform ID the exporter resolves
+			// from the image config, which is normalized
+			ps, err := exptypes.ParsePlatforms(combinedResult.Metadata)
+			if err != nil {
+				return nil, err
+			}
+			platformString = ps.Platforms[0].ID
+		}
+		addImageAttestations(combinedResult, platformString, input.Attestations)
+	}
+
 	return combinedResult, nil
 }
+
+func addImageAttestations(res *solverresult.Result[bkcache.ImmutableRef], platformKey string, atts []ImageAttestation) {
+	for i, att := range atts {
+		res.AddAttestation(platformKey, solverresult.Attestation[bkcache.ImmutableRef]{
+			Kind: gatewaypb.AttestationKindInToto,
+			Path: fmt.Sprintf("attestation-%d.json", i),
+			ContentFunc: func() ([]byte, error) {
+				return att.Predicate, nil
+			},
+			InToto: solverresult.InTotoAttestation{
+				PredicateType: att.PredicateType,
+				Subjects: []solverresult.InTotoSubject{{
+					Kind: gatewaypb.InTotoSubjectKindSelf,
+				}},
+			},
+		})
+	}
+}
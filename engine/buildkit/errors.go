@@ -2,9 +2,11 @@ package buildkit
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	bkexecutor "github.com/dagger/dagger/internal/buildkit/executor"
 	bksession "github.com/dagger/dagger/internal/buildkit/session"
@@ -27,6 +29,12 @@ type ExecError struct {
 	ExitCode int
 	Stdout   string
 	Stderr   string
+
+	// TimedOut is set if the process was terminated for exceeding its timeout.
+	TimedOut bool
+
+	// OOMKilled is set if a process was killed for exceeding its memory limit.
+	OOMKilled bool
 }
 
 func (e *ExecError) Error() string {
@@ -39,12 +47,56 @@ func (e *ExecError) Unwrap() error {
 
 func (e *ExecError) Extensions() map[string]any {
 	return map[string]any{
-		"_type":    "EXEC_ERROR",
-		"cmd":      e.Cmd,
-		"exitCode": e.ExitCode,
-		"stdout":   e.Stdout,
-		"stderr":   e.Stderr,
+		"_type":     "EXEC_ERROR",
+		"cmd":       e.Cmd,
+		"exitCode":  e.ExitCode,
+		"stdout":    e.Stdout,
+		"stderr":    e.Stderr,
+		"timedOut":  e.TimedOut,
+		"oomKilled": e.OOMKilled,
+	}
+}
+
+// ExecTimeoutError occurs when a process is terminated for running longer
+// than its configured timeout.
+type ExecTimeoutError struct {
+	Timeout time.Duration
+
+	// Err is the error the process exited with after being terminated, if any.
+	Err error
+}
+
+func (e *ExecTimeoutError) Error() string {
+	msg := fmt.Sprintf("process timed out after %s", e.Timeout)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *ExecTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// ExecOOMError occurs when a process in a memory limited container is killed
+// by the kernel for exceeding the limit.
+type ExecOOMError struct {
+	MemoryLimit int64
+
+	// Err is the error the process exited with after being killed.
+	Err error
+}
+
+func (e *ExecOOMError) Error() string {
+	msg := fmt.Sprintf("process killed for exceeding memory limit of %d bytes", e.MemoryLimit)
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
 	}
+	return msg
+}
+
+func (e *ExecOOMError) Unwrap() error {
+	return e.Err
 }
 
 // RichError is an error that can occur while processing a container. It
@@ -101,11 +153,13 @@ func (e RichError) AsExecErr(ctx context.Context, client *Client) (*ExecError, b
 	}
 
 	execErr := &ExecError{
-		Err:      telemetry.TrackOrigin(e, spanCtx),
-		Cmd:      e.Meta.Args,
-		ExitCode: exitCode,
-		Stdout:   strings.TrimSpace(string(stdout)),
-		Stderr:   strings.TrimSpace(string(stderr)),
+		Err:       telemetry.TrackOrigin(e, spanCtx),
+		Cmd:       e.Meta.Args,
+		ExitCode:  exitCode,
+		Stdout:    strings.TrimSpace(string(stdout)),
+		Stderr:    strings.TrimSpace(string(stderr)),
+		TimedOut:  errors.As(e, new(*ExecTimeoutError)),
+		OOMKilled: errors.As(e, new(*ExecOOMError)),
 	}
 	return execErr, true, nil
 }
@@ -17,6 +17,8 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -100,6 +102,16 @@ type ExecutionMetadata struct {
 	// If true, skip injecting dagger-init into the container.
 	NoInit bool
 
+	// If non-zero, the maximum amount of time the process may run before it is
+	// sent SIGTERM, followed by SIGKILL after ExecTimeoutGracePeriod.
+	Timeout time.Duration
+
+	// cgroup limits to enforce on the execution
+	ResourceLimits ResourceLimits
+
+	// restrictions on the network egress of the execution
+	NetworkPolicy NetworkPolicy
+
 	// list of remote modules allowed to access LLM APIs
 	// any value of "all" bypasses restrictions, a nil slice imposes them
 	AllowedLLMModules []string
@@ -109,6 +121,24 @@ type ExecutionMetadata struct {
 	ClientVersionOverride string
 }
 
+// ResourceLimits are cgroup resource limits enforced on an execution. Zero
+// values mean no limit.
+type ResourceLimits struct {
+	// Number of CPUs the process may use, e.g. 1.5
+	CPUs float64 `json:",omitempty"`
+	// Maximum memory usage in bytes, including swap
+	MemoryBytes int64 `json:",omitempty"`
+	// Maximum number of processes
+	Pids int64 `json:",omitempty"`
+	// Relative block IO weight, between 10 and 1000
+	IOWeight uint16 `json:",omitempty"`
+}
+
+// IsZero reports whether no limits are set.
+func (l ResourceLimits) IsZero() bool {
+	return l == ResourceLimits{}
+}
+
 const executionMetadataKey = "dagger.executionMetadata"
 
 func executionMetadataFromVtx(vtx solver.Vertex) (*ExecutionMetadata, bool, error) {
@@ -173,6 +203,7 @@ func (w *Worker) Run(
 	state := newExecState(id, &procInfo, rootMount, mounts, started)
 	return nil, w.run(ctx, state,
 		w.setupNetwork,
+		w.setupNetworkPolicy,
 		w.injectInit,
 		w.generateBaseSpec,
 		w.filterEnvs,
@@ -184,6 +215,7 @@ func (w *Worker) Run(
 		w.setupSecretScrubbing,
 		w.setProxyEnvs,
 		w.enableGPU,
+		w.setResourceLimits,
 		w.createCWD,
 		w.setupNestedClient,
 		w.installCACerts,
@@ -513,11 +545,17 @@ func (k procKiller) Cleanup() {
 // If the process was created by `runc run` then we will use `runc kill`,
 // otherwise for `runc exec` we will read the pid from a pidfile and then
 // send the signal directly that process.
-func (k procKiller) Kill(ctx context.Context) (err error) {
-	bklog.G(ctx).Tracef("sending sigkill to process in container %s", k.id)
+func (k procKiller) Kill(ctx context.Context) error {
+	return k.Signal(ctx, syscall.SIGKILL)
+}
+
+// Signal will send the given signal to the process running inside the
+// container, following the same rules as Kill.
+func (k procKiller) Signal(ctx context.Context, sig syscall.Signal) (err error) {
+	bklog.G(ctx).Tracef("sending %s to process in container %s", sig, k.id)
 	defer func() {
 		if err != nil {
-			bklog.G(ctx).Errorf("failed to kill process in container id %s: %+v", k.id, err)
+			bklog.G(ctx).Errorf("failed to signal %s to process in container id %s: %+v", sig, k.id, err)
 		}
 	}()
 
@@ -529,7 +567,7 @@ func (k procKiller) Kill(ctx context.Context) (err error) {
 
 	if k.pidfile == "" {
 		// for `runc run` process we use `runc kill` to terminate the process
-		return k.runC.Kill(ctx, k.id, int(syscall.SIGKILL), nil)
+		return k.runC.Kill(ctx, k.id, int(sig), nil)
 	}
 
 	// `runc exec` will write the pidfile a few milliseconds after we
@@ -562,7 +600,86 @@ func (k procKiller) Kill(ctx context.Context) (err error) {
 		return fmt.Errorf("failed to find process for pid %d from pidfile: %w", pid, err)
 	}
 	defer process.Release()
-	return process.Signal(syscall.SIGKILL)
+	return process.Signal(sig)
+}
+
+// execDeadline enforces ExecutionMetadata.Timeout on a process: once the
+// timeout has elapsed since the process started, it is sent SIGTERM, and then
+// SIGKILL if it is still running after the grace period.
+type execDeadline struct {
+	timeout time.Duration
+	grace   time.Duration
+	killer  procKiller
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+	expired   atomic.Bool
+}
+
+func newExecDeadline(killer procKiller, timeout, grace time.Duration) *execDeadline {
+	return &execDeadline{
+		timeout: timeout,
+		grace:   grace,
+		killer:  killer,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Start begins counting down the timeout. It should be called once the
+// process has started; subsequent calls are no-ops.
+func (d *execDeadline) Start(ctx context.Context) {
+	d.startOnce.Do(func() {
+		go d.watch(context.WithoutCancel(ctx))
+	})
+}
+
+// Stop cancels the deadline. It should be called once the process has exited.
+func (d *execDeadline) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stop)
+	})
+}
+
+// Expired reports whether the deadline was reached before Stop was called.
+func (d *execDeadline) Expired() bool {
+	return d.expired.Load()
+}
+
+func (d *execDeadline) watch(ctx context.Context) {
+	timer := time.NewTimer(d.timeout)
+	defer timer.Stop()
+	select {
+	case <-d.stop:
+		return
+	case <-timer.C:
+	}
+
+	d.expired.Store(true)
+	trace.SpanFromContext(ctx).AddEvent("Container timed out", trace.WithAttributes(
+		attribute.String("exec.timeout", d.timeout.String()),
+	))
+	if err := d.killer.Signal(ctx, syscall.SIGTERM); err != nil {
+		bklog.G(ctx).WithError(err).Warn("failed to terminate timed out process, killing")
+	} else {
+		timer.Reset(d.grace)
+		select {
+		case <-d.stop:
+			return
+		case <-timer.C:
+		}
+	}
+
+	for {
+		if err := d.killer.Kill(ctx); err != nil && strings.Contains(err.Error(), "container not running") {
+			return
+		}
+		select {
+		case <-d.stop:
+			return
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
 }
 
 // procHandle is to track the process so we can send signals to it
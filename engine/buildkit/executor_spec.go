@@ -78,6 +78,13 @@ const (
 	cgroupSampleInterval     = 5 * time.Second
 	finalCgroupSampleTimeout = 5 * time.Second
 
+	// ExecTimeoutGracePeriod is the amount of time between when a timed out
+	// process is sent SIGTERM and when it is sent SIGKILL.
+	ExecTimeoutGracePeriod = 10 * time.Second
+
+	// the CFS period, in microseconds, used when enforcing CPU limits
+	cpuLimitPeriod = 100000
+
 	defaultHostname = "dagger"
 )
 
@@ -181,9 +188,7 @@ func (w *Worker) setupNetwork(ctx context.Context, state *execState) error {
 		return nil
 	}
 
-	extraSearchDomains := []string{}
-	extraSearchDomains = append(extraSearchDomains, w.execMD.ExtraSearchDomains...)
-	extraSearchDomains = append(extraSearchDomains, network.SessionDomain(w.execMD.SessionID))
+	extraSearchDomains := w.extraSearchDomains()
 
 	baseResolvFile, err := os.Open(state.resolvConfPath)
 	if err != nil {
@@ -271,25 +276,9 @@ func (w *Worker) setupNetwork(ctx context.Context, state *execState) error {
 	}
 
 	for target, aliases := range w.execMD.HostAliases {
-		var ips []net.IP
-		var errs error
-		for _, domain := range append([]string{""}, extraSearchDomains...) {
-			qualified := target
-			if domain != "" {
-				qualified += "." + domain
-			}
-
-			var err error
-			ips, err = net.LookupIP(qualified)
-			if err == nil {
-				errs = nil // ignore prior failures
-				break
-			}
-
-			errs = errors.Join(errs, err)
-		}
-		if errs != nil {
-			return fmt.Errorf("lookup %s for hosts file: %w", target, errs)
+		ips, err := lookupIPInDomains(target, extraSearchDomains)
+		if err != nil {
+			return fmt.Errorf("lookup %s for hosts file: %w", target, err)
 		}
 
 		for _, ip := range ips {
@@ -304,6 +293,35 @@ func (w *Worker) setupNetwork(ctx context.Context, state *execState) error {
 	return nil
 }
 
+// extraSearchDomains returns the DNS search domains added to the resolv.conf
+// of the execution, for it to reach services by their hostname.
+func (w *Worker) extraSearchDomains() []string {
+	extraSearchDomains := []string{}
+	extraSearchDomains = append(extraSearchDomains, w.execMD.ExtraSearchDomains...)
+	extraSearchDomains = append(extraSearchDomains, network.SessionDomain(w.execMD.SessionID))
+	return extraSearchDomains
+}
+
+// lookupIPInDomains looks up the IPs of a host, first as is, then in each of
+// the search domains, like a resolver honoring resolv.conf would.
+func lookupIPInDomains(target string, searchDomains []string) ([]net.IP, error) {
+	var errs error
+	for _, domain := range append([]string{""}, searchDomains...) {
+		qualified := target
+		if domain != "" {
+			qualified += "." + domain
+		}
+
+		ips, err := net.LookupIP(qualified)
+		if err == nil {
+			return ips, nil // ignore prior failures
+		}
+
+		errs = errors.Join(errs, err)
+	}
+	return nil, errs
+}
+
 type hostBindMount struct {
 	srcPath string
 }
@@ -949,6 +967,57 @@ func (w *Worker) enableGPU(_ context.Context, state *execState) error {
 	return nil
 }
 
+func (w *Worker) setResourceLimits(_ context.Context, state *execState) error {
+	if w.execMD == nil {
+		return nil
+	}
+	limits := w.execMD.ResourceLimits
+	if limits.IsZero() {
+		return nil
+	}
+
+	if state.spec.Linux == nil {
+		state.spec.Linux = &specs.Linux{}
+	}
+	if state.spec.Linux.Resources == nil {
+		state.spec.Linux.Resources = &specs.LinuxResources{}
+	}
+	res := state.spec.Linux.Resources
+
+	if limits.CPUs > 0 {
+		period := uint64(cpuLimitPeriod)
+		quota := int64(limits.CPUs * cpuLimitPeriod)
+		if res.CPU == nil {
+			res.CPU = &specs.LinuxCPU{}
+		}
+		res.CPU.Period = &period
+		res.CPU.Quota = &quota
+	}
+	if limits.MemoryBytes > 0 {
+		if res.Memory == nil {
+			res.Memory = &specs.LinuxMemory{}
+		}
+		memory := limits.MemoryBytes
+		// setting swap to the same value as the limit disallows swap usage, so
+		// the process is OOM killed rather than silently slowed down
+		swap := limits.MemoryBytes
+		res.Memory.Limit = &memory
+		res.Memory.Swap = &swap
+	}
+	if limits.Pids > 0 {
+		res.Pids = &specs.LinuxPids{Limit: limits.Pids}
+	}
+	if limits.IOWeight > 0 {
+		if res.BlockIO == nil {
+			res.BlockIO = &specs.LinuxBlockIO{}
+		}
+		weight := limits.IOWeight
+		res.BlockIO.Weight = &weight
+	}
+
+	return nil
+}
+
 func (w *Worker) createCWD(_ context.Context, state *execState) error {
 	newp, err := fs.RootPath(state.rootfsPath, state.procInfo.Meta.Cwd)
 	if err != nil {
@@ -1240,17 +1309,26 @@ func (w *Worker) runContainer(ctx context.Context, state *execState) (rerr error
 		})
 	}
 
+	killer := newRunProcKiller(w.runc, state.id)
+
+	var deadline *execDeadline
+	if w.execMD != nil && w.execMD.Timeout > 0 {
+		deadline = newExecDeadline(killer, w.execMD.Timeout, ExecTimeoutGracePeriod)
+		defer deadline.Stop()
+	}
+
 	startedCallback := func() {
 		state.startedOnce.Do(func() {
 			trace.SpanFromContext(ctx).AddEvent("Container started")
+			if deadline != nil {
+				deadline.Start(ctx)
+			}
 			if state.startedCh != nil {
 				close(state.startedCh)
 			}
 		})
 	}
 
-	killer := newRunProcKiller(w.runc, state.id)
-
 	runcCall := func(ctx context.Context, started chan<- int, io runc.IO, pidfile string) error {
 		/*
 			We need to avoid the following type of race condition, which can result in invalid overlapping overlay mounts:
@@ -1363,5 +1441,24 @@ func (w *Worker) runContainer(ctx context.Context, state *execState) (rerr error
 		return eg.Wait()
 	}
 
-	return exitError(ctx, state.exitCodePath, w.callWithIO(ctx, state.procInfo, startedCallback, killer, runcCall), state.procInfo.Meta.ValidExitCodes)
+	err = exitError(ctx, state.exitCodePath, w.callWithIO(ctx, state.procInfo, startedCallback, killer, runcCall), state.procInfo.Meta.ValidExitCodes)
+	if err != nil && cgroupPath != "" && w.execMD != nil && w.execMD.ResourceLimits.MemoryBytes > 0 {
+		// check for OOM kills before the cgroup is removed by runc delete
+		oomKills, oomErr := resources.OOMKillCount(cgroupPath)
+		if oomErr != nil {
+			bklog.G(ctx).WithError(oomErr).Warn("failed to check for OOM kills")
+		} else if oomKills > 0 {
+			err = &ExecOOMError{MemoryLimit: w.execMD.ResourceLimits.MemoryBytes, Err: err}
+		}
+	}
+	if deadline != nil {
+		deadline.Stop()
+		if deadline.Expired() {
+			// never let a timed out process count as a success, even if it
+			// handled SIGTERM and exited with a valid code: the error keeps
+			// the killed run out of the cache
+			return &ExecTimeoutError{Timeout: w.execMD.Timeout, Err: err}
+		}
+	}
+	return err
 }
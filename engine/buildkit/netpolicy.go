@@ -0,0 +1,290 @@
+package buildkit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dagger/dagger/internal/buildkit/solver/pb"
+)
+
+// NetworkMode determines which destinations an execution may connect to.
+type NetworkMode string
+
+const (
+	// NetworkModeUnrestricted allows all network access.
+	NetworkModeUnrestricted NetworkMode = ""
+	// NetworkModeOffline only allows access to the loopback interface.
+	NetworkModeOffline NetworkMode = "offline"
+	// NetworkModeServicesOnly only allows access to the services bound to the
+	// execution, and DNS.
+	NetworkModeServicesOnly NetworkMode = "services"
+	// NetworkModeAllowList allows access to the services bound to the
+	// execution, DNS, and the destinations in NetworkPolicy.Allow.
+	NetworkModeAllowList NetworkMode = "allowlist"
+)
+
+// NetworkPolicy restricts the network egress of an execution.
+type NetworkPolicy struct {
+	Mode NetworkMode `json:",omitempty"`
+
+	// Hostnames, IPs or CIDRs that may be reached in NetworkModeAllowList.
+	// Hostnames are resolved once, when the execution starts, so addresses
+	// they resolve to afterwards are not allowed.
+	Allow []string `json:",omitempty"`
+
+	// Hostnames of the services bound to the execution, which may be reached
+	// in all modes but NetworkModeOffline. They are resolved in the search
+	// domains of the execution when it starts.
+	Services []string `json:",omitempty"`
+}
+
+// IsZero reports whether the policy imposes no restrictions.
+func (p NetworkPolicy) IsZero() bool {
+	return p.Mode == NetworkModeUnrestricted
+}
+
+// Validate checks that the policy is well-formed, without resolving any
+// hostnames.
+func (p NetworkPolicy) Validate() error {
+	switch p.Mode {
+	case NetworkModeUnrestricted, NetworkModeOffline, NetworkModeServicesOnly:
+		if len(p.Allow) > 0 {
+			return fmt.Errorf("network allow list requires the allow list network mode")
+		}
+	case NetworkModeAllowList:
+		for _, dest := range p.Allow {
+			if _, err := parseAllowedNet(dest); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unknown network mode %q", p.Mode)
+	}
+	return nil
+}
+
+// resolveServices resolves the bound services to their IPv4 addresses.
+func (p NetworkPolicy) resolveServices(searchDomains []string) ([]net.IP, error) {
+	var ips []net.IP
+	for _, host := range p.Services {
+		hostIPs, err := lookupIPInDomains(host, searchDomains)
+		if err != nil {
+			return nil, fmt.Errorf("resolve bound service %q: %w", host, err)
+		}
+		for _, ip := range hostIPs {
+			if ip4 := ip.To4(); ip4 != nil {
+				ips = append(ips, ip4)
+			}
+		}
+	}
+	return ips, nil
+}
+
+// resolveAllowList resolves the allowed destinations to IPv4 networks.
+func (p NetworkPolicy) resolveAllowList(ctx context.Context) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, dest := range p.Allow {
+		ipNet, err := parseAllowedNet(dest)
+		if err != nil {
+			return nil, err
+		}
+		if ipNet != nil {
+			nets = append(nets, ipNet)
+			continue
+		}
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip4", dest)
+		if err != nil {
+			return nil, fmt.Errorf("resolve allowed host %q: %w", dest, err)
+		}
+		for _, ip := range ips {
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(32, 32)})
+		}
+	}
+	return nets, nil
+}
+
+// parseAllowedNet parses an allow list entry. It returns a nil network for
+// entries that are hostnames.
+func parseAllowedNet(dest string) (*net.IPNet, error) {
+	if dest == "" {
+		return nil, fmt.Errorf("empty network allow list entry")
+	}
+	if strings.Contains(dest, "/") {
+		_, ipNet, err := net.ParseCIDR(dest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed CIDR %q: %w", dest, err)
+		}
+		if ipNet.IP.To4() == nil {
+			return nil, fmt.Errorf("allowed CIDR %q: only IPv4 is supported", dest)
+		}
+		return ipNet, nil
+	}
+	if ip := net.ParseIP(dest); ip != nil {
+		if ip.To4() == nil {
+			return nil, fmt.Errorf("allowed IP %q: only IPv4 is supported", dest)
+		}
+		return &net.IPNet{IP: ip.To4(), Mask: net.CIDRMask(32, 32)}, nil
+	}
+	if strings.ContainsAny(dest, ":*") {
+		return nil, fmt.Errorf("invalid allowed host %q: expected a hostname, IP or CIDR", dest)
+	}
+	return nil, nil
+}
+
+// networkPolicyRules renders the iptables-restore input enforcing the policy
+// in an execution's network namespace.
+func networkPolicyRules(mode NetworkMode, nameservers, services []net.IP, allowed []*net.IPNet) string {
+	var buf bytes.Buffer
+	rule := func(format string, args ...any) {
+		fmt.Fprintf(&buf, format+"\n", args...)
+	}
+	rule("*filter")
+	rule(":INPUT ACCEPT [0:0]")
+	rule(":FORWARD ACCEPT [0:0]")
+	rule(":OUTPUT DROP [0:0]")
+	rule("-A OUTPUT -o lo -j ACCEPT")
+	// allow replies to inbound connections
+	rule("-A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT")
+	if mode != NetworkModeOffline {
+		// DNS queries are not filtered: a process can still leak data through
+		// the names it looks up, which only NetworkModeOffline prevents.
+		for _, ns := range nameservers {
+			rule("-A OUTPUT -d %s/32 -p udp --dport 53 -j ACCEPT", ns)
+			rule("-A OUTPUT -d %s/32 -p tcp --dport 53 -j ACCEPT", ns)
+		}
+		for _, svc := range services {
+			rule("-A OUTPUT -d %s/32 -j ACCEPT", svc)
+		}
+	}
+	if mode == NetworkModeAllowList {
+		for _, dest := range allowed {
+			rule("-A OUTPUT -d %s -j ACCEPT", dest)
+		}
+	}
+	// reject rather than drop so that clients fail fast
+	rule("-A OUTPUT -j REJECT")
+	rule("COMMIT")
+	return buf.String()
+}
+
+// network6PolicyRules renders the ip6tables-restore input enforcing a policy:
+// nameservers, services and allowed destinations are all IPv4, so only the
+// loopback interface is reachable over IPv6.
+func network6PolicyRules() string {
+	var buf bytes.Buffer
+	rule := func(format string, args ...any) {
+		fmt.Fprintf(&buf, format+"\n", args...)
+	}
+	rule("*filter")
+	rule(":INPUT ACCEPT [0:0]")
+	rule(":FORWARD ACCEPT [0:0]")
+	rule(":OUTPUT DROP [0:0]")
+	rule("-A OUTPUT -o lo -j ACCEPT")
+	rule("-A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT")
+	rule("-A OUTPUT -j REJECT")
+	rule("COMMIT")
+	return buf.String()
+}
+
+func (w *Worker) setupNetworkPolicy(ctx context.Context, state *execState) error {
+	if w.execMD == nil || w.execMD.NetworkPolicy.IsZero() {
+		return nil
+	}
+	policy := w.execMD.NetworkPolicy
+
+	switch state.procInfo.Meta.NetMode {
+	case pb.NetMode_UNSET:
+	case pb.NetMode_NONE:
+		// already offline
+		return nil
+	default:
+		return fmt.Errorf("network mode %q is not supported with network mode %s", policy.Mode, state.procInfo.Meta.NetMode)
+	}
+	if state.networkNamespace == nil {
+		return fmt.Errorf("network mode %q requires a network namespace", policy.Mode)
+	}
+
+	iptablesRestore, err := exec.LookPath("iptables-restore")
+	if err != nil {
+		return fmt.Errorf("network mode %q: %w", policy.Mode, err)
+	}
+	// IPv6 must be filtered too, unless the kernel doesn't support it at all
+	var ip6tablesRestore string
+	if _, err := os.Stat("/proc/net/if_inet6"); err == nil {
+		ip6tablesRestore, err = exec.LookPath("ip6tables-restore")
+		if err != nil {
+			return fmt.Errorf("network mode %q: %w", policy.Mode, err)
+		}
+	}
+
+	nameservers, err := resolvConfNameservers(state.resolvConfPath)
+	if err != nil {
+		return err
+	}
+	services, err := policy.resolveServices(w.extraSearchDomains())
+	if err != nil {
+		return err
+	}
+	allowed, err := policy.resolveAllowList(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = runInNetNS(ctx, state, func() (struct{}, error) {
+		// NB: the child processes inherit the network namespace of this thread
+		if err := restoreRules(iptablesRestore, networkPolicyRules(policy.Mode, nameservers, services, allowed)); err != nil {
+			return struct{}{}, err
+		}
+		if ip6tablesRestore != "" {
+			if err := restoreRules(ip6tablesRestore, network6PolicyRules()); err != nil {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("apply network policy: %w", err)
+	}
+	return nil
+}
+
+// restoreRules loads rules with iptables-restore or ip6tables-restore.
+func restoreRules(bin, rules string) error {
+	cmd := exec.Command(bin, "-w")
+	cmd.Stdin = strings.NewReader(rules)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", filepath.Base(bin), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func resolvConfNameservers(path string) ([]net.IP, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open resolv.conf: %w", err)
+	}
+	defer f.Close()
+
+	var nameservers []net.IP
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		if ip := net.ParseIP(fields[1]); ip != nil && ip.To4() != nil {
+			nameservers = append(nameservers, ip.To4())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read resolv.conf: %w", err)
+	}
+	return nameservers, nil
+}
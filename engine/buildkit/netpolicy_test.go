@@ -0,0 +1,74 @@
+package buildkit
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkPolicyValidate(t *testing.T) {
+	for _, tc := range []struct {
+		policy NetworkPolicy
+		err    string
+	}{
+		{policy: NetworkPolicy{}},
+		{policy: NetworkPolicy{Mode: NetworkModeOffline}},
+		{policy: NetworkPolicy{Mode: NetworkModeServicesOnly}},
+		{policy: NetworkPolicy{Mode: NetworkModeAllowList, Allow: []string{"dagger.io", "1.2.3.4", "10.0.0.0/8"}}},
+		{
+			policy: NetworkPolicy{Mode: NetworkModeServicesOnly, Allow: []string{"dagger.io"}},
+			err:    "requires the allow list network mode",
+		},
+		{
+			policy: NetworkPolicy{Mode: NetworkModeAllowList, Allow: []string{"10.0.0.0/99"}},
+			err:    "invalid allowed CIDR",
+		},
+		{
+			policy: NetworkPolicy{Mode: NetworkModeAllowList, Allow: []string{"fd00::/8"}},
+			err:    "only IPv4 is supported",
+		},
+		{
+			policy: NetworkPolicy{Mode: NetworkModeAllowList, Allow: []string{"*.dagger.io"}},
+			err:    "expected a hostname, IP or CIDR",
+		},
+		{
+			policy: NetworkPolicy{Mode: "bogus"},
+			err:    "unknown network mode",
+		},
+	} {
+		err := tc.policy.Validate()
+		if tc.err == "" {
+			require.NoError(t, err, tc.policy)
+		} else {
+			require.ErrorContains(t, err, tc.err, tc.policy)
+		}
+	}
+}
+
+func TestNetworkPolicyRules(t *testing.T) {
+	nameservers := []net.IP{net.ParseIP("10.87.0.1").To4()}
+	services := []net.IP{net.ParseIP("10.87.0.5").To4()}
+	_, allowed, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+
+	const header = "*filter\n" +
+		":INPUT ACCEPT [0:0]\n" +
+		":FORWARD ACCEPT [0:0]\n" +
+		":OUTPUT DROP [0:0]\n" +
+		"-A OUTPUT -o lo -j ACCEPT\n" +
+		"-A OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT\n"
+	const servicesRules = "-A OUTPUT -d 10.87.0.1/32 -p udp --dport 53 -j ACCEPT\n" +
+		"-A OUTPUT -d 10.87.0.1/32 -p tcp --dport 53 -j ACCEPT\n" +
+		"-A OUTPUT -d 10.87.0.5/32 -j ACCEPT\n"
+	const footer = "-A OUTPUT -j REJECT\n" +
+		"COMMIT\n"
+
+	require.Equal(t, header+footer,
+		networkPolicyRules(NetworkModeOffline, nameservers, services, nil))
+	require.Equal(t, header+servicesRules+footer,
+		networkPolicyRules(NetworkModeServicesOnly, nameservers, services, []*net.IPNet{allowed}))
+	require.Equal(t, header+servicesRules+"-A OUTPUT -d 192.0.2.0/24 -j ACCEPT\n"+footer,
+		networkPolicyRules(NetworkModeAllowList, nameservers, services, []*net.IPNet{allowed}))
+	require.Equal(t, header+footer, network6PolicyRules())
+}
@@ -0,0 +1,71 @@
+package buildkit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	bksession "github.com/dagger/dagger/internal/buildkit/session"
+	sessionauth "github.com/dagger/dagger/internal/buildkit/session/auth"
+)
+
+// RegistryRemote parses ref, and returns the options to access its
+// repository with go-containerregistry, authenticated with the registry
+// credentials of the client and following the registry configuration of the
+// engine.
+//
+// Mirrors are not used: the repository is accessed on its own registry.
+func (c *Client) RegistryRemote(ctx context.Context, ref string) (name.Reference, []remote.Option, error) {
+	parsed, err := name.ParseReference(ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse reference %q: %w", ref, err)
+	}
+	registry := parsed.Context().RegistryStr()
+
+	var nameOpts []name.Option
+	var transport http.RoundTripper = http.DefaultTransport
+	hosts, err := c.Worker.RegistryHosts(registry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get registry hosts of %s: %w", registry, err)
+	}
+	for _, host := range hosts {
+		if host.Host != registry {
+			continue
+		}
+		if host.Scheme == "http" {
+			nameOpts = append(nameOpts, name.Insecure)
+		}
+		if host.Client != nil && host.Client.Transport != nil {
+			transport = host.Client.Transport
+		}
+		break
+	}
+	if len(nameOpts) > 0 {
+		parsed, err = name.ParseReference(ref, nameOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("parse reference %q: %w", ref, err)
+		}
+	}
+
+	_, username, secret, err := sessionauth.CredentialsFunc(c.SessionManager, bksession.NewGroup(c.ID()))(registry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get credentials of %s: %w", registry, err)
+	}
+	auth := authn.Anonymous
+	switch {
+	case username != "":
+		auth = authn.FromConfig(authn.AuthConfig{Username: username, Password: secret})
+	case secret != "":
+		auth = authn.FromConfig(authn.AuthConfig{IdentityToken: secret})
+	}
+
+	return parsed, []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithTransport(transport),
+		remote.WithAuth(auth),
+	}, nil
+}
@@ -15,8 +15,25 @@ import (
 const (
 	memoryCurrentFile = "memory.current"
 	memoryPeakFile    = "memory.peak"
+	memoryEventsFile  = "memory.events"
 )
 
+// OOMKillCount returns the number of processes in the cgroup at the given
+// subpath that were killed by the OOM killer.
+func OOMKillCount(cgroupNSSubpath string) (int64, error) {
+	filePath := filepath.Join(defaultMountpoint, cgroupNSSubpath, memoryEventsFile)
+	bs, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	for key, value := range flatKeyValuesInt64(bs) {
+		if key == "oom_kill" {
+			return value, nil
+		}
+	}
+	return 0, nil
+}
+
 type memoryCurrentSampler struct {
 	memoryCurrentFilePath string
 	commonAttrs           attribute.Set
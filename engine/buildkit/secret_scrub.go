@@ -2,8 +2,12 @@ package buildkit
 
 import (
 	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,6 +34,12 @@ func NewSecretScrubReader(
 	}
 	secrets = append(secrets, fileSecrets...)
 
+	return NewSecretValuesScrubReader(r, secrets), nil
+}
+
+// NewSecretValuesScrubReader returns a reader replacing the given secrets, and
+// the forms in which tools commonly print them, with "***".
+func NewSecretValuesScrubReader(r io.Reader, secrets []string) io.Reader {
 	secretAsBytes := make([][]byte, 0)
 	for _, v := range secrets {
 		// Skip empty env:
@@ -41,9 +51,8 @@ func NewSecretScrubReader(
 
 	trie := &Trie{}
 	for _, s := range secretAsBytes {
-		trie.Insert(s, scrubString)
-		if strimmed := bytes.TrimSpace(s); len(strimmed) != len(s) {
-			trie.Insert(strimmed, scrubString)
+		for _, variant := range secretVariants(s) {
+			trie.Insert(variant, scrubString)
 		}
 	}
 	transformer := &censor{
@@ -54,7 +63,178 @@ func NewSecretScrubReader(
 		dstBuf: make([]byte, 0, 4096),
 	}
 
-	return transform.NewReader(r, transformer), nil
+	return transform.NewReader(r, transformer)
+}
+
+// minSecretVariantLen is the minimum length of the derived forms of a secret
+// that are scrubbed, like its encodings or the lines of a multi-line secret, so
+// that the ones of short secrets don't scrub unrelated output.
+const minSecretVariantLen = 8
+
+// base64LineLen is the length of the lines of base64(1) output.
+const base64LineLen = 76
+
+// secretVariants returns all the forms of a secret to scrub: the secret
+// itself, the forms in which tools commonly print it, and the secret lines of
+// a multi-line secret, like the body of a PEM key.
+func secretVariants(secret []byte) [][]byte {
+	var variants [][]byte
+	seen := map[string]struct{}{}
+	add := func(variant []byte, minLen int) {
+		if len(variant) == 0 || len(variant) < minLen {
+			return
+		}
+		if _, ok := seen[string(variant)]; ok {
+			return
+		}
+		seen[string(variant)] = struct{}{}
+		variants = append(variants, variant)
+	}
+
+	forms := [][]byte{secret}
+	if trimmed := bytes.TrimSpace(secret); len(trimmed) != len(secret) {
+		forms = append(forms, trimmed)
+	}
+	for _, form := range forms {
+		add(form, 0)
+		if bytes.Contains(form, []byte("\n")) && !bytes.Contains(form, []byte("\r\n")) {
+			add(bytes.ReplaceAll(form, []byte("\n"), []byte("\r\n")), 0)
+		}
+		for _, encoded := range encodedSecrets(form) {
+			add(encoded, minSecretVariantLen)
+		}
+		for _, encoded := range unalignedBase64Secrets(form) {
+			add(encoded, minSecretVariantLen)
+		}
+	}
+
+	if bytes.Contains(secret, []byte("\n")) {
+		// only the lines of PEM bodies and the random-looking ones are secret:
+		// others, like "apiVersion: v1" in a kubeconfig, would scrub unrelated
+		// output
+		var inPEM bool
+		for line := range bytes.Lines(secret) {
+			line = bytes.TrimSpace(line)
+			switch {
+			case bytes.HasPrefix(line, []byte("-----BEGIN ")):
+				inPEM = true
+			case bytes.HasPrefix(line, []byte("-----END ")):
+				inPEM = false
+			case inPEM || randomLine(line):
+				add(line, minSecretVariantLen)
+			}
+		}
+	}
+
+	return variants
+}
+
+// Minimum length and entropy, in bits per byte, of the lines of a multi-line
+// secret that look random.
+const (
+	minRandomLineLen     = 16
+	minRandomLineEntropy = 3.0
+)
+
+// randomLine returns whether a line looks like a token or a key: a single
+// base64 or hex word, with letters and digits, and of high entropy.
+func randomLine(line []byte) bool {
+	if len(line) < minRandomLineLen {
+		return false
+	}
+	var letters, digits bool
+	counts := map[byte]int{}
+	for _, c := range line {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+			letters = true
+		case '0' <= c && c <= '9':
+			digits = true
+		case c == '+', c == '/', c == '=', c == '-', c == '_':
+		default:
+			return false
+		}
+		counts[c]++
+	}
+	if !letters || !digits {
+		return false
+	}
+	var entropy float64
+	for _, n := range counts {
+		p := float64(n) / float64(len(line))
+		entropy -= p * math.Log2(p)
+	}
+	return entropy >= minRandomLineEntropy
+}
+
+// encodedSecrets returns the encodings of a secret that tools commonly print:
+// base64, URL encoding and JSON string escaping.
+func encodedSecrets(secret []byte) [][]byte {
+	var encoded [][]byte
+	for _, enc := range []*base64.Encoding{
+		base64.StdEncoding,
+		base64.RawStdEncoding,
+		base64.URLEncoding,
+		base64.RawURLEncoding,
+	} {
+		encoded = append(encoded, []byte(enc.EncodeToString(secret)))
+	}
+	if std := base64.StdEncoding.EncodeToString(secret); len(std) > base64LineLen {
+		// base64(1) wraps its output
+		var wrapped []byte
+		for len(std) > base64LineLen {
+			wrapped = append(wrapped, std[:base64LineLen]...)
+			wrapped = append(wrapped, '\n')
+			std = std[base64LineLen:]
+		}
+		encoded = append(encoded, append(wrapped, std...))
+	}
+
+	encoded = append(encoded,
+		[]byte(url.QueryEscape(string(secret))),
+		[]byte(url.PathEscape(string(secret))),
+	)
+
+	for _, escapeHTML := range []bool{true, false} {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(escapeHTML)
+		if err := enc.Encode(string(secret)); err != nil {
+			continue
+		}
+		// strip the quotes and the trailing newline
+		quoted := bytes.TrimSpace(buf.Bytes())
+		encoded = append(encoded, quoted[1:len(quoted)-1])
+	}
+
+	return encoded
+}
+
+// unalignedBase64Secrets returns the base64 encodings of a secret when it's
+// preceded by other data, like a password in the base64-encoded "user:password"
+// of an "Authorization: Basic" header or a registry auth config.
+//
+// Depending on the length of the data before it, a secret starts at any of 3
+// offsets in a base64 group, which changes its encoding. Only the characters
+// that solely encode bytes of the secret are kept, since the ones on the edges
+// depend on the surrounding data.
+func unalignedBase64Secrets(secret []byte) [][]byte {
+	var encoded [][]byte
+	for offset := range 3 {
+		data := make([]byte, offset+len(secret))
+		copy(data[offset:], secret)
+		// the first character that doesn't encode bits of the padding
+		start := (offset*8 + 5) / 6
+		// the character after the last one that encodes only bits of the secret
+		end := len(data) * 8 / 6
+		if end <= start {
+			continue
+		}
+		for _, enc := range []*base64.Encoding{base64.RawStdEncoding, base64.RawURLEncoding} {
+			encoded = append(encoded, []byte(enc.EncodeToString(data)[start:end]))
+		}
+	}
+	return encoded
 }
 
 // loadSecretsToScrubFromEnv loads secrets value from env if they are in secretsToScrub.
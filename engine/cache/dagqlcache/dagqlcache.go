@@ -0,0 +1,169 @@
+// Package dagqlcache exports the persisted dagql call cache to remote
+// backends, and imports it from them, so that function calls cached by an
+// engine hit the cache of other engines.
+//
+// The call cache maps call keys to the keys their results are stored under;
+// the results themselves are snapshots exported by the BuildKit cache
+// exporters configured alongside.
+package dagqlcache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/remotes"
+
+	"github.com/dagger/dagger/dagql"
+)
+
+const (
+	// The version of the exported calls format
+	callsVersion = 1
+
+	// The name of the file, or object, holding the exported calls
+	callsFileName = "dagql-calls.json"
+
+	// The media type of the exported calls, as pushed to a registry
+	MediaTypeCalls = "application/vnd.dagger.dagql.calls.v1+json"
+
+	// The maximum number of times an export is retried when other engines
+	// export to the same backend concurrently
+	maxExportAttempts = 5
+)
+
+var (
+	// ErrUnsupported is returned for cache types that can't hold the call cache.
+	ErrUnsupported = errors.New("unsupported dagql cache backend")
+
+	// ErrConflict is returned when saving calls that another engine saved
+	// since they were loaded.
+	ErrConflict = errors.New("dagql cache changed concurrently")
+)
+
+// Calls are the exported calls of one or more engines.
+type Calls struct {
+	Version int                   `json:"version"`
+	Calls   []dagql.PersistedCall `json:"calls"`
+
+	// The revision of the stored calls they were loaded from, e.g. an ETag
+	revision string
+}
+
+// A Backend stores exported calls.
+type Backend interface {
+	// Load returns the stored calls, or nil if none were stored yet.
+	Load(context.Context) (*Calls, error)
+	// Save replaces the stored calls with calls, merged from prev, the calls
+	// loaded before. It returns ErrConflict if the stored calls changed since
+	// prev was loaded, or were stored since if prev is nil.
+	Save(ctx context.Context, prev, calls *Calls) error
+}
+
+// HostFiles reads and writes files on the host of the client.
+type HostFiles interface {
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+	WriteFile(ctx context.Context, path string, data []byte) error
+}
+
+// Opts are the engine resources needed by the backends.
+type Opts struct {
+	// Used by the local backend
+	HostFiles HostFiles
+	// Used by the registry backend
+	Resolver func(ctx context.Context, ref string, push bool, insecure bool) remotes.Resolver
+}
+
+// NewBackend returns the backend of a cache config, as passed to BuildKit
+// cache importers and exporters.
+func NewBackend(ctx context.Context, typ string, attrs map[string]string, opts Opts) (Backend, error) {
+	switch typ {
+	case "local":
+		return newLocalBackend(attrs, opts.HostFiles)
+	case "registry":
+		return newRegistryBackend(attrs, opts.Resolver)
+	case "s3":
+		return newS3Backend(ctx, attrs)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupported, typ)
+	}
+}
+
+// Export saves the calls of the cache to the backend, merged with the calls
+// saved there before. If another engine saves calls concurrently, the export is
+// merged with them and retried.
+func Export(ctx context.Context, cache dagql.Cache, backend Backend) (int, error) {
+	calls, err := cache.ExportCalls(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for attempt := 1; ; attempt++ {
+		prev, err := backend.Load(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("load previous calls: %w", err)
+		}
+		merged := &Calls{
+			Version: callsVersion,
+			Calls:   calls,
+		}
+		if prev != nil {
+			merged.Calls = mergeCalls(prev.Calls, calls, time.Now().Unix())
+		}
+		err = backend.Save(ctx, prev, merged)
+		if errors.Is(err, ErrConflict) && attempt < maxExportAttempts {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("save calls: %w", err)
+		}
+		return len(merged.Calls), nil
+	}
+}
+
+// Import loads the calls saved to the backend into the cache.
+func Import(ctx context.Context, cache dagql.Cache, backend Backend) (int, error) {
+	calls, err := backend.Load(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("load calls: %w", err)
+	}
+	if calls == nil {
+		return 0, nil
+	}
+	if err := cache.ImportCalls(ctx, calls.Calls); err != nil {
+		return 0, err
+	}
+	return len(calls.Calls), nil
+}
+
+// mergeCalls merges unexpired calls, keeping the latest expiring entry of
+// each call key.
+func mergeCalls(prev, calls []dagql.PersistedCall, now int64) []dagql.PersistedCall {
+	byKey := map[string]dagql.PersistedCall{}
+	for _, call := range slices.Concat(prev, calls) {
+		if call.Expiration < now {
+			continue
+		}
+		if existing, ok := byKey[call.CallKey]; ok && existing.Expiration >= call.Expiration {
+			continue
+		}
+		byKey[call.CallKey] = call
+	}
+	merged := make([]dagql.PersistedCall, 0, len(byKey))
+	for _, call := range byKey {
+		merged = append(merged, call)
+	}
+	slices.SortFunc(merged, func(a, b dagql.PersistedCall) int {
+		return strings.Compare(a.CallKey, b.CallKey)
+	})
+	return merged
+}
+
+func checkVersion(calls *Calls) error {
+	if calls.Version != callsVersion {
+		return fmt.Errorf("unsupported dagql cache version %d", calls.Version)
+	}
+	return nil
+}
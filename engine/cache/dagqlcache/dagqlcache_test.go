@@ -0,0 +1,259 @@
+package dagqlcache
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/dagql"
+)
+
+type fakeHostFiles struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+func (f *fakeHostFiles) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	data, ok := f.files[path]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return data, nil
+}
+
+func (f *fakeHostFiles) WriteFile(ctx context.Context, path string, data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.files == nil {
+		f.files = map[string][]byte{}
+	}
+	f.files[path] = data
+	return nil
+}
+
+func newTestCache(t *testing.T, calls ...dagql.PersistedCall) dagql.Cache {
+	t.Helper()
+	cache, err := dagql.NewCache(t.Context(), filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err)
+	require.NoError(t, cache.ImportCalls(t.Context(), calls))
+	return cache
+}
+
+// racingBackend runs race before saving once, to simulate another engine
+// exporting concurrently.
+type racingBackend struct {
+	Backend
+	race func()
+}
+
+func (b *racingBackend) Save(ctx context.Context, prev, calls *Calls) error {
+	if race := b.race; race != nil {
+		b.race = nil
+		race()
+	}
+	return b.Backend.Save(ctx, prev, calls)
+}
+
+// testBackend exports calls from an engine, and imports them into another.
+func testBackend(t *testing.T, backend Backend) {
+	t.Helper()
+	ctx := t.Context()
+	exp := time.Now().Add(time.Hour).Unix()
+
+	calls, err := backend.Load(ctx)
+	require.NoError(t, err)
+	require.Nil(t, calls)
+
+	engine1 := newTestCache(t, dagql.PersistedCall{CallKey: "a", StorageKey: "a1", Expiration: exp})
+	n, err := Export(ctx, engine1, backend)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	// exports are merged
+	engine2 := newTestCache(t, dagql.PersistedCall{CallKey: "b", StorageKey: "b2", Expiration: exp})
+	n, err = Export(ctx, engine2, backend)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+
+	// concurrent exports don't overwrite each other
+	engine3 := newTestCache(t, dagql.PersistedCall{CallKey: "c", StorageKey: "c3", Expiration: exp})
+	engine4 := newTestCache(t, dagql.PersistedCall{CallKey: "d", StorageKey: "d4", Expiration: exp})
+	n, err = Export(ctx, engine3, &racingBackend{Backend: backend, race: func() {
+		_, err := Export(ctx, engine4, backend)
+		require.NoError(t, err)
+	}})
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+
+	engine5 := newTestCache(t)
+	n, err = Import(ctx, engine5, backend)
+	require.NoError(t, err)
+	require.Equal(t, 4, n)
+	imported, err := engine5.ExportCalls(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []dagql.PersistedCall{
+		{CallKey: "a", StorageKey: "a1", Expiration: exp},
+		{CallKey: "b", StorageKey: "b2", Expiration: exp},
+		{CallKey: "c", StorageKey: "c3", Expiration: exp},
+		{CallKey: "d", StorageKey: "d4", Expiration: exp},
+	}, imported)
+}
+
+func TestMergeCalls(t *testing.T) {
+	merged := mergeCalls(
+		[]dagql.PersistedCall{
+			{CallKey: "b", StorageKey: "b1", Expiration: 200},
+			{CallKey: "c", StorageKey: "c1", Expiration: 50},
+			{CallKey: "a", StorageKey: "a1", Expiration: 300},
+		},
+		[]dagql.PersistedCall{
+			{CallKey: "b", StorageKey: "b2", Expiration: 250},
+			{CallKey: "a", StorageKey: "a2", Expiration: 200},
+		},
+		100,
+	)
+	require.Equal(t, []dagql.PersistedCall{
+		{CallKey: "a", StorageKey: "a1", Expiration: 300},
+		{CallKey: "b", StorageKey: "b2", Expiration: 250},
+	}, merged)
+}
+
+func TestNewBackend(t *testing.T) {
+	ctx := t.Context()
+	_, err := NewBackend(ctx, "gha", map[string]string{}, Opts{})
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = NewBackend(ctx, "local", map[string]string{"dest": "/cache"}, Opts{})
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = NewBackend(ctx, "local", map[string]string{}, Opts{HostFiles: &fakeHostFiles{}})
+	require.ErrorContains(t, err, "requires src or dest")
+}
+
+func TestLocalBackend(t *testing.T) {
+	files := &fakeHostFiles{}
+	backend, err := NewBackend(t.Context(), "local", map[string]string{"dest": "/cache"}, Opts{HostFiles: files})
+	require.NoError(t, err)
+	testBackend(t, backend)
+	require.Contains(t, files.files, "/cache/dagql-calls.json")
+
+	backend, err = NewBackend(t.Context(), "local", map[string]string{"src": "/cache"}, Opts{HostFiles: files})
+	require.NoError(t, err)
+	calls, err := backend.Load(t.Context())
+	require.NoError(t, err)
+	require.Len(t, calls.Calls, 4)
+	require.ErrorContains(t, backend.Save(t.Context(), calls, calls), "requires dest")
+}
+
+func TestCallsRef(t *testing.T) {
+	ref, err := callsRef(map[string]string{"ref": "registry.example.com/cache:main"})
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com/cache:main-dagql", ref)
+	ref, err = callsRef(map[string]string{"ref": "cache"})
+	require.NoError(t, err)
+	require.Equal(t, "docker.io/library/cache:latest-dagql", ref)
+	ref, err = callsRef(map[string]string{"ref": "cache", "dagql-ref": "registry.example.com/calls"})
+	require.NoError(t, err)
+	require.Equal(t, "registry.example.com/calls:latest", ref)
+	_, err = callsRef(map[string]string{})
+	require.ErrorContains(t, err, "requires ref")
+}
+
+func TestRegistryBackend(t *testing.T) {
+	srv := httptest.NewServer(registry.New())
+	defer srv.Close()
+	host := strings.TrimPrefix(srv.URL, "http://")
+
+	resolver := func(ctx context.Context, ref string, push bool, insecure bool) remotes.Resolver {
+		return docker.NewResolver(docker.ResolverOptions{
+			Hosts: docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchAllHosts)),
+		})
+	}
+	backend, err := NewBackend(t.Context(), "registry", map[string]string{
+		"ref": fmt.Sprintf("%s/cache:main", host),
+	}, Opts{Resolver: resolver})
+	require.NoError(t, err)
+	testBackend(t, backend)
+}
+
+// fakeS3 is a minimal S3-compatible server, with path-style object URLs and
+// conditional writes.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func etag(data []byte) string {
+	return fmt.Sprintf("%q", fmt.Sprintf("%x", md5.Sum(data)))
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		prev, exists := s.objects[r.URL.Path]
+		if (r.Header.Get("If-None-Match") == "*" && exists) ||
+			(r.Header.Get("If-Match") != "" && (!exists || r.Header.Get("If-Match") != etag(prev))) {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>PreconditionFailed</Code><Message>At least one of the pre-conditions you specified did not hold</Message></Error>`)
+			return
+		}
+		if s.objects == nil {
+			s.objects = map[string][]byte{}
+		}
+		s.objects[r.URL.Path] = data
+		w.Header().Set("ETag", etag(data))
+	case http.MethodGet:
+		data, ok := s.objects[r.URL.Path]
+		if !ok {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		w.Header().Set("ETag", etag(data))
+		w.Write(data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestS3Backend(t *testing.T) {
+	s3 := &fakeS3{}
+	srv := httptest.NewServer(s3)
+	defer srv.Close()
+
+	backend, err := NewBackend(t.Context(), "s3", map[string]string{
+		"bucket":            "cache",
+		"region":            "us-east-1",
+		"prefix":            "ci/",
+		"endpoint_url":      srv.URL,
+		"use_path_style":    "true",
+		"access_key_id":     "minio",
+		"secret_access_key": "minio123",
+	}, Opts{})
+	require.NoError(t, err)
+	testBackend(t, backend)
+	require.Contains(t, s3.objects, "/cache/ci/dagql-calls.json")
+}
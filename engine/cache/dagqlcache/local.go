@@ -0,0 +1,93 @@
+package dagqlcache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// localBackend stores calls in a directory on the client's host, next to the
+// cache exported by the BuildKit local cache exporter. When calls are loaded
+// from the destination, the file is checked to be unchanged before it is
+// replaced; the host can't replace it atomically, so this only narrows the
+// window for concurrent exports to overwrite each other.
+type localBackend struct {
+	src   string
+	dest  string
+	files HostFiles
+}
+
+func newLocalBackend(attrs map[string]string, files HostFiles) (*localBackend, error) {
+	if files == nil {
+		return nil, fmt.Errorf("%w: local cache requires access to the client's host", ErrUnsupported)
+	}
+	if attrs["src"] == "" && attrs["dest"] == "" {
+		return nil, errors.New("local cache requires src or dest")
+	}
+	return &localBackend{
+		src:   attrs["src"],
+		dest:  attrs["dest"],
+		files: files,
+	}, nil
+}
+
+func (b *localBackend) Load(ctx context.Context) (*Calls, error) {
+	// exports are merged with what was exported to the destination before
+	dir := b.src
+	if dir == "" {
+		dir = b.dest
+	}
+	data, err := b.readFile(ctx, dir)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var calls Calls
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", callsFileName, err)
+	}
+	if err := checkVersion(&calls); err != nil {
+		return nil, err
+	}
+	calls.revision = digest.FromBytes(data).String()
+	return &calls, nil
+}
+
+func (b *localBackend) Save(ctx context.Context, prev, calls *Calls) error {
+	if b.dest == "" {
+		return errors.New("local cache export requires dest")
+	}
+	if b.src == "" || b.src == b.dest {
+		current, err := b.readFile(ctx, b.dest)
+		if err != nil {
+			return err
+		}
+		switch {
+		case prev == nil && current != nil,
+			prev != nil && digest.FromBytes(current).String() != prev.revision:
+			return ErrConflict
+		}
+	}
+	data, err := json.Marshal(calls)
+	if err != nil {
+		return err
+	}
+	return b.files.WriteFile(ctx, path.Join(b.dest, callsFileName), data)
+}
+
+// readFile returns the contents of the calls file of a directory, or nil if
+// it doesn't exist.
+func (b *localBackend) readFile(ctx context.Context, dir string) ([]byte, error) {
+	data, err := b.files.ReadFile(ctx, path.Join(dir, callsFileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return data, nil
+}
@@ -0,0 +1,236 @@
+package dagqlcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/errdefs"
+	"github.com/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// The maximum size of exported calls fetched from a registry
+const maxRegistryBlobSize = 256 << 20
+
+// registryBackend pushes calls to a registry as an OCI artifact, tagged after
+// the ref of the BuildKit registry cache with a "-dagql" suffix, unless
+// another ref is configured with the "dagql-ref" attribute. Registries can't
+// update tags conditionally, so the tag is checked to be unchanged right
+// before the manifest is pushed: this only narrows the window for concurrent
+// exports to overwrite each other.
+type registryBackend struct {
+	ref      string
+	insecure bool
+	resolver func(ctx context.Context, ref string, push bool, insecure bool) remotes.Resolver
+}
+
+func newRegistryBackend(
+	attrs map[string]string,
+	resolver func(ctx context.Context, ref string, push bool, insecure bool) remotes.Resolver,
+) (*registryBackend, error) {
+	if resolver == nil {
+		return nil, fmt.Errorf("%w: registry cache requires a resolver", ErrUnsupported)
+	}
+	ref, err := callsRef(attrs)
+	if err != nil {
+		return nil, err
+	}
+	var insecure bool
+	if v, ok := attrs["registry.insecure"]; ok {
+		insecure, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse registry.insecure: %w", err)
+		}
+	}
+	return &registryBackend{
+		ref:      ref,
+		insecure: insecure,
+		resolver: resolver,
+	}, nil
+}
+
+func callsRef(attrs map[string]string) (string, error) {
+	if ref := attrs["dagql-ref"]; ref != "" {
+		parsed, err := reference.ParseNormalizedNamed(ref)
+		if err != nil {
+			return "", fmt.Errorf("invalid dagql-ref: %w", err)
+		}
+		return reference.TagNameOnly(parsed).String(), nil
+	}
+	if attrs["ref"] == "" {
+		return "", errors.New("registry cache requires ref")
+	}
+	parsed, err := reference.ParseNormalizedNamed(attrs["ref"])
+	if err != nil {
+		return "", fmt.Errorf("invalid ref: %w", err)
+	}
+	tagged, ok := reference.TagNameOnly(parsed).(reference.Tagged)
+	if !ok {
+		return "", fmt.Errorf("invalid ref %q: a tag is required", attrs["ref"])
+	}
+	withTag, err := reference.WithTag(reference.TrimNamed(parsed), tagged.Tag()+"-dagql")
+	if err != nil {
+		return "", err
+	}
+	return withTag.String(), nil
+}
+
+// resolve returns the name and descriptor of the manifest the ref points to,
+// or a nil descriptor if it doesn't exist.
+func (b *registryBackend) resolve(ctx context.Context, resolver remotes.Resolver) (string, *ocispecs.Descriptor, error) {
+	name, desc, err := resolver.Resolve(ctx, b.ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return "", nil, nil
+		}
+		return "", nil, fmt.Errorf("resolve %s: %w", b.ref, err)
+	}
+	return name, &desc, nil
+}
+
+func (b *registryBackend) Load(ctx context.Context) (*Calls, error) {
+	resolver := b.resolver(ctx, b.ref, false, b.insecure)
+	name, desc, err := b.resolve(ctx, resolver)
+	if err != nil || desc == nil {
+		return nil, err
+	}
+	fetcher, err := resolver.Fetcher(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	manifestData, err := fetchBlob(ctx, fetcher, *desc)
+	if err != nil {
+		return nil, fmt.Errorf("fetch manifest: %w", err)
+	}
+	var manifest ocispecs.Manifest
+	if err := json.Unmarshal(manifestData, &manifest); err != nil {
+		return nil, fmt.Errorf("decode manifest: %w", err)
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType != MediaTypeCalls {
+			continue
+		}
+		data, err := fetchBlob(ctx, fetcher, layer)
+		if err != nil {
+			return nil, fmt.Errorf("fetch calls: %w", err)
+		}
+		var calls Calls
+		if err := json.Unmarshal(data, &calls); err != nil {
+			return nil, fmt.Errorf("decode calls: %w", err)
+		}
+		if err := checkVersion(&calls); err != nil {
+			return nil, err
+		}
+		calls.revision = desc.Digest.String()
+		return &calls, nil
+	}
+	return nil, fmt.Errorf("%s is not a dagql cache: no %s layer", b.ref, MediaTypeCalls)
+}
+
+func (b *registryBackend) Save(ctx context.Context, prev, calls *Calls) error {
+	callsData, err := json.Marshal(calls)
+	if err != nil {
+		return err
+	}
+	callsDesc := ocispecs.Descriptor{
+		MediaType: MediaTypeCalls,
+		Digest:    digest.FromBytes(callsData),
+		Size:      int64(len(callsData)),
+	}
+	configData := ocispecs.DescriptorEmptyJSON.Data
+	configDesc := ocispecs.DescriptorEmptyJSON
+	configDesc.Data = nil
+	manifestData, err := json.Marshal(ocispecs.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    ocispecs.MediaTypeImageManifest,
+		ArtifactType: MediaTypeCalls,
+		Config:       configDesc,
+		Layers:       []ocispecs.Descriptor{callsDesc},
+	})
+	if err != nil {
+		return err
+	}
+	manifestDesc := ocispecs.Descriptor{
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Digest:    digest.FromBytes(manifestData),
+		Size:      int64(len(manifestData)),
+	}
+
+	ctx = remotes.WithMediaTypeKeyPrefix(ctx, MediaTypeCalls, "layer")
+	pusher, err := b.resolver(ctx, b.ref, true, b.insecure).Pusher(ctx, b.ref)
+	if err != nil {
+		return err
+	}
+	for _, blob := range []struct {
+		desc ocispecs.Descriptor
+		data []byte
+	}{
+		{callsDesc, callsData},
+		{configDesc, configData},
+	} {
+		if err := pushBlob(ctx, pusher, blob.desc, blob.data); err != nil {
+			return fmt.Errorf("push %s: %w", blob.desc.MediaType, err)
+		}
+	}
+	// push the manifest last, once the blobs it references are pushed, unless
+	// the calls were saved since they were loaded
+	_, current, err := b.resolve(ctx, b.resolver(ctx, b.ref, false, b.insecure))
+	if err != nil {
+		return err
+	}
+	switch {
+	case prev == nil && current != nil,
+		prev != nil && (current == nil || current.Digest.String() != prev.revision):
+		return ErrConflict
+	}
+	if err := pushBlob(ctx, pusher, manifestDesc, manifestData); err != nil {
+		return fmt.Errorf("push %s: %w", manifestDesc.MediaType, err)
+	}
+	return nil
+}
+
+func fetchBlob(ctx context.Context, fetcher remotes.Fetcher, desc ocispecs.Descriptor) ([]byte, error) {
+	if desc.Size > maxRegistryBlobSize {
+		return nil, fmt.Errorf("blob %s too large: %d bytes", desc.Digest, desc.Size)
+	}
+	rc, err := fetcher.Fetch(ctx, desc)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxRegistryBlobSize))
+	if err != nil {
+		return nil, err
+	}
+	if dgst := digest.FromBytes(data); dgst != desc.Digest {
+		return nil, fmt.Errorf("digest mismatch: expected %s, got %s", desc.Digest, dgst)
+	}
+	return data, nil
+}
+
+func pushBlob(ctx context.Context, pusher remotes.Pusher, desc ocispecs.Descriptor, data []byte) error {
+	w, err := pusher.Push(ctx, desc)
+	if err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	defer w.Close()
+	if err := content.Copy(ctx, w, bytes.NewReader(data), desc.Size, desc.Digest); err != nil {
+		if errdefs.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
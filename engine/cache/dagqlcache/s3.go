@@ -0,0 +1,135 @@
+package dagqlcache
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// s3Backend stores calls in an object of an S3-compatible bucket, next to
+// the cache exported by the BuildKit S3 cache exporter. It takes the same
+// attributes. The object is replaced with conditional writes, so that engines
+// exporting concurrently don't overwrite each other's calls.
+type s3Backend struct {
+	client *s3.Client
+	bucket string
+	key    string
+}
+
+func newS3Backend(ctx context.Context, attrs map[string]string) (*s3Backend, error) {
+	bucket, ok := attrs["bucket"]
+	if !ok {
+		bucket, ok = os.LookupEnv("AWS_BUCKET")
+		if !ok {
+			return nil, errors.New("bucket ($AWS_BUCKET) not set for s3 cache")
+		}
+	}
+	region, ok := attrs["region"]
+	if !ok {
+		region, ok = os.LookupEnv("AWS_REGION")
+		if !ok {
+			return nil, errors.New("region ($AWS_REGION) not set for s3 cache")
+		}
+	}
+	var usePathStyle bool
+	if v, ok := attrs["use_path_style"]; ok {
+		var err error
+		usePathStyle, err = strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse use_path_style: %w", err)
+		}
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("load AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg, func(options *s3.Options) {
+		if attrs["access_key_id"] != "" && attrs["secret_access_key"] != "" {
+			options.Credentials = credentials.NewStaticCredentialsProvider(
+				attrs["access_key_id"],
+				attrs["secret_access_key"],
+				attrs["session_token"],
+			)
+		}
+		if endpoint := attrs["endpoint_url"]; endpoint != "" {
+			options.UsePathStyle = usePathStyle
+			options.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+	return &s3Backend{
+		client: client,
+		bucket: bucket,
+		key:    attrs["prefix"] + callsFileName,
+	}, nil
+}
+
+func (b *s3Backend) Load(ctx context.Context) (*Calls, error) {
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.key),
+	})
+	if err != nil {
+		var noSuchKey *s3types.NoSuchKey
+		var notFound *s3types.NotFound
+		if errors.As(err, &noSuchKey) || errors.As(err, &notFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get s3://%s/%s: %w", b.bucket, b.key, err)
+	}
+	defer out.Body.Close()
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	var calls Calls
+	if err := json.Unmarshal(data, &calls); err != nil {
+		return nil, fmt.Errorf("decode s3://%s/%s: %w", b.bucket, b.key, err)
+	}
+	if err := checkVersion(&calls); err != nil {
+		return nil, err
+	}
+	calls.revision = aws.ToString(out.ETag)
+	return &calls, nil
+}
+
+func (b *s3Backend) Save(ctx context.Context, prev, calls *Calls) error {
+	data, err := json.Marshal(calls)
+	if err != nil {
+		return err
+	}
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(b.bucket),
+		Key:         aws.String(b.key),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	}
+	if prev != nil {
+		input.IfMatch = aws.String(prev.revision)
+	} else {
+		input.IfNoneMatch = aws.String("*")
+	}
+	if _, err := b.client.PutObject(ctx, input); err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.ErrorCode() {
+			case "PreconditionFailed", "ConditionalRequestConflict":
+				return ErrConflict
+			}
+		}
+		return fmt.Errorf("put s3://%s/%s: %w", b.bucket, b.key, err)
+	}
+	return nil
+}
@@ -129,6 +129,10 @@ type Params struct {
 
 	CloudAuth           *auth.Cloud
 	EnableCloudScaleOut bool
+
+	// Command is the command line the client is started with, if any, to
+	// record its telemetry in the engine's history of runs.
+	Command string
 }
 
 type Client struct {
@@ -164,6 +168,7 @@ type Client struct {
 
 	hostname       string
 	stableClientID string
+	historyKey     string
 
 	nestedSessionPort int
 
@@ -265,6 +270,7 @@ func Connect(ctx context.Context, params Params) (_ *Client, rerr error) {
 	}
 
 	c.stableClientID = GetHostStableID(slog)
+	c.historyKey = GetHostHistoryKey(slog)
 
 	if err := c.startEngine(connectCtx, params); err != nil {
 		return nil, fmt.Errorf("start engine: %w", err)
@@ -1386,6 +1392,7 @@ func (c *Client) clientMetadata() engine.ClientMetadata {
 		ClientSecretToken:         c.SecretToken,
 		ClientHostname:            c.hostname,
 		ClientStableID:            c.stableClientID,
+		HistoryKey:                c.historyKey,
 		UpstreamCacheImportConfig: c.upstreamCacheImportOptions,
 		UpstreamCacheExportConfig: c.upstreamCacheExportOptions,
 		Labels:                    c.labels.AsMap(),
@@ -1399,6 +1406,7 @@ func (c *Client) clientMetadata() engine.ClientMetadata {
 		CloudAuth:                 c.CloudAuth,
 		EnableCloudScaleOut:       c.EnableCloudScaleOut,
 		CloudScaleOutEngineID:     remoteEngineID,
+		Command:                   c.Command,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -161,6 +162,17 @@ func (apple) ContainerIsRunning(ctx context.Context, name string) (bool, error)
 	return false, nil
 }
 
+func (apple) ContainerLogs(ctx context.Context, name string, follow bool, w io.Writer) error {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	cmd := exec.CommandContext(ctx, "container", append(args, name)...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
 func (apple) ContainerLs(ctx context.Context) ([]string, error) {
 	cmd := exec.CommandContext(ctx, "container", "ls", "-a", "--format", "json")
 	stdout, _, err := traceexec.ExecOutput(ctx, cmd)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"os"
@@ -98,6 +99,8 @@ type containerBackend interface {
 	ContainerRemove(ctx context.Context, name string) error
 	ContainerStart(ctx context.Context, name string) error
 	ContainerExists(ctx context.Context, name string) (bool, error)
+	ContainerIsRunning(ctx context.Context, name string) (bool, error)
+	ContainerLogs(ctx context.Context, name string, follow bool, w io.Writer) error
 	ContainerLs(ctx context.Context) ([]string, error)
 }
 
@@ -161,6 +164,53 @@ func (d *imageDriver) ImageLoader(ctx context.Context) imageload.Backend {
 	return d.backend.ImageLoader(ctx)
 }
 
+var _ EngineManager = &imageDriver{}
+
+func (d *imageDriver) Engines(ctx context.Context, target *url.URL) ([]Engine, error) {
+	var current string
+	if target != nil {
+		current = target.Query().Get("container")
+		if current == "" {
+			id, err := resolveImageID(target.Host + target.Path)
+			if err != nil {
+				return nil, err
+			}
+			current = containerNamePrefix + id
+		}
+	}
+
+	names, err := d.collectLeftoverEngines(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	engines := make([]Engine, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		running, err := d.backend.ContainerIsRunning(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		engines = append(engines, Engine{
+			Name: name,
+			// containers are named after the tag or digest of their image
+			Version: strings.TrimPrefix(name, containerNamePrefix),
+			Running: running,
+			Current: name == current,
+		})
+	}
+	return engines, nil
+}
+
+func (d *imageDriver) EngineLogs(ctx context.Context, name string, follow bool, w io.Writer) error {
+	return d.backend.ContainerLogs(ctx, name, follow, w)
+}
+
+func (d *imageDriver) StopEngine(ctx context.Context, name string) error {
+	return d.backend.ContainerRemove(ctx, name)
+}
+
 type containerConnector struct {
 	host    string
 	values  url.Values
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -177,6 +178,44 @@ func TestBackendContainerRunExec(t *testing.T) {
 	}
 }
 
+func TestBackendContainerLogsAndIsRunning(t *testing.T) {
+	if !shouldRun {
+		t.Skip()
+	}
+
+	for _, tc := range backends {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := t.Context()
+			containerName := "test-logs-container"
+			testImage := "alpine:3.18"
+
+			_ = tc.backend.ContainerRemove(ctx, containerName)
+			err := tc.backend.ImagePull(ctx, testImage)
+			require.NoError(t, err)
+
+			err = tc.backend.ContainerRun(ctx, containerName, runOpts{
+				image: testImage,
+				args:  []string{"sh", "-c", "echo hello world; sleep 30"},
+			})
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				ctx := context.WithoutCancel(ctx)
+				require.NoError(t, tc.backend.ContainerRemove(ctx, containerName))
+			})
+
+			running, err := tc.backend.ContainerIsRunning(ctx, containerName)
+			require.NoError(t, err)
+			require.True(t, running)
+
+			require.Eventually(t, func() bool {
+				var logs bytes.Buffer
+				err := tc.backend.ContainerLogs(ctx, containerName, false, &logs)
+				return err == nil && strings.Contains(logs.String(), "hello world")
+			}, 10*time.Second, 100*time.Millisecond)
+		})
+	}
+}
+
 func TestBackendContainerLs(t *testing.T) {
 	if !shouldRun {
 		t.Skip()
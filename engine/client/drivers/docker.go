@@ -2,6 +2,7 @@ package drivers
 
 import (
 	"context"
+	"io"
 	"net"
 	"os"
 	"os/exec"
@@ -144,6 +145,26 @@ func (d docker) ContainerExists(ctx context.Context, name string) (bool, error)
 	return false, err
 }
 
+func (d docker) ContainerIsRunning(ctx context.Context, name string) (bool, error) {
+	cmd := exec.CommandContext(ctx, d.cmd, "container", "inspect", name, "--format", "{{ .State.Running }}")
+	stdout, _, err := traceexec.ExecOutput(ctx, cmd, telemetry.Encapsulated())
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(stdout) == "true", nil
+}
+
+func (d docker) ContainerLogs(ctx context.Context, name string, follow bool, w io.Writer) error {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "--follow")
+	}
+	cmd := exec.CommandContext(ctx, d.cmd, append(args, name)...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+	return cmd.Run()
+}
+
 func (d docker) ContainerLs(ctx context.Context) ([]string, error) {
 	cmd := exec.CommandContext(ctx, d.cmd, "ps", "-a", "--format", "{{.Names}}")
 	stdout, _, err := traceexec.ExecOutput(ctx, cmd)
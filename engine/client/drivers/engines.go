@@ -0,0 +1,115 @@
+package drivers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+)
+
+// Engine is an engine provisioned on this host by a driver.
+type Engine struct {
+	// Driver is the scheme of the driver that provisioned the engine.
+	Driver string `json:"driver"`
+	// Name identifies the engine for its driver, like a container name.
+	Name string `json:"name"`
+	// Version of the engine, if known.
+	Version string `json:"version,omitempty"`
+	Running bool   `json:"running"`
+	// Current is set for the engine of the runner host the engines were
+	// listed for.
+	Current bool `json:"current"`
+}
+
+// EngineManager is implemented by drivers that provision engines on this
+// host, so that they can be managed outside of a client connection.
+type EngineManager interface {
+	// Engines lists the engines provisioned by the driver. The engine that
+	// target would connect to, if any, is the current one.
+	Engines(ctx context.Context, target *url.URL) ([]Engine, error)
+
+	// EngineLogs writes the logs of an engine to w, and keeps writing new
+	// logs until ctx is done if follow is set.
+	EngineLogs(ctx context.Context, name string, follow bool, w io.Writer) error
+
+	// StopEngine stops and removes an engine. It's provisioned again on the
+	// next connection.
+	StopEngine(ctx context.Context, name string) error
+}
+
+// managedDrivers are the drivers provisioning engines, by the most specific
+// scheme of each.
+var managedDrivers = []string{
+	"image+docker",
+	"image+podman",
+	"image+nerdctl",
+	"image+finch",
+	"image+apple",
+	"process",
+}
+
+// ListEngines lists the engines provisioned on this host by the available
+// drivers, marking the one runnerHost connects to as current.
+func ListEngines(ctx context.Context, runnerHost string) ([]Engine, error) {
+	var current Driver
+	target, err := url.Parse(runnerHost)
+	if err == nil {
+		// the runner host may be unavailable, and still list other engines
+		current, _ = GetDriver(ctx, target.Scheme)
+	}
+
+	var engines []Engine
+	var errs error
+	for _, scheme := range managedDrivers {
+		driver, mgr, ok := engineManager(ctx, scheme)
+		if !ok {
+			continue
+		}
+		var driverTarget *url.URL
+		if driver == current {
+			driverTarget = target
+		}
+		found, err := mgr.Engines(ctx, driverTarget)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: %w", scheme, err))
+			continue
+		}
+		for _, engine := range found {
+			engine.Driver = scheme
+			engines = append(engines, engine)
+		}
+	}
+	return engines, errs
+}
+
+// EngineLogs writes the logs of an engine listed by ListEngines.
+func EngineLogs(ctx context.Context, engine Engine, follow bool, w io.Writer) error {
+	_, mgr, ok := engineManager(ctx, engine.Driver)
+	if !ok {
+		return fmt.Errorf("driver %q is not available", engine.Driver)
+	}
+	return mgr.EngineLogs(ctx, engine.Name, follow, w)
+}
+
+// StopEngine stops and removes an engine listed by ListEngines.
+func StopEngine(ctx context.Context, engine Engine) error {
+	_, mgr, ok := engineManager(ctx, engine.Driver)
+	if !ok {
+		return fmt.Errorf("driver %q is not available", engine.Driver)
+	}
+	return mgr.StopEngine(ctx, engine.Name)
+}
+
+func engineManager(ctx context.Context, scheme string) (Driver, EngineManager, bool) {
+	if !slices.Contains(managedDrivers, scheme) {
+		return nil, nil, false
+	}
+	driver, err := GetDriver(ctx, scheme)
+	if err != nil {
+		return nil, nil, false
+	}
+	mgr, ok := driver.(EngineManager)
+	return driver, mgr, ok
+}
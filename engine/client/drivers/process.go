@@ -0,0 +1,661 @@
+package drivers
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"dagger.io/dagger/telemetry"
+	"github.com/adrg/xdg"
+	"github.com/containerd/continuity/fs"
+	"github.com/gofrs/flock"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"go.opentelemetry.io/otel"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/client/imageload"
+	"github.com/dagger/dagger/engine/config"
+	"github.com/dagger/dagger/engine/distconsts"
+	"github.com/dagger/dagger/engine/slog"
+)
+
+func init() {
+	register("process", &processDriver{})
+}
+
+// processDriver runs the engine as a rootless child process of the current
+// user, without any container runtime.
+//
+// The engine is either a dagger-engine binary, given as the URL path or found
+// in $PATH, or the engine image unpacked in the user cache and used as the
+// root filesystem of the process. The engine keeps running in the
+// background, and later clients reuse it.
+//
+// Examples:
+//
+//	process://
+//	process:///usr/local/bin/dagger-engine
+//	process://?image=registry.dagger.io/engine:v0.19.0
+type processDriver struct{}
+
+// processStateDir is the default directory of the state of the process
+// engine, reused between runs.
+var processStateDir = filepath.Join(xdg.CacheHome, "dagger", "engine")
+
+const (
+	processEngineBinary = "dagger-engine"
+
+	// how long to wait for a started engine to listen on its socket
+	processStartTimeout = 2 * time.Minute
+	// how long to wait for a stopped engine to exit before killing it
+	processStopTimeout = 30 * time.Second
+)
+
+func (d *processDriver) Available(ctx context.Context) (bool, error) {
+	return processAvailable()
+}
+
+func (d *processDriver) Provision(ctx context.Context, target *url.URL, opts *DriverOpts) (Connector, error) {
+	eng := &processEngine{dir: processDir(target)}
+
+	src, err := resolveProcessSource(target)
+	if err != nil {
+		return nil, err
+	}
+	if err := eng.start(ctx, src, opts); err != nil {
+		return nil, err
+	}
+	return processConnector{sockPath: eng.socketPath()}, nil
+}
+
+func (d *processDriver) ImageLoader(ctx context.Context) imageload.Backend {
+	return nil
+}
+
+var _ EngineManager = &processDriver{}
+
+// Engines lists the engine of the default state directory, and the one of
+// target.
+func (d *processDriver) Engines(ctx context.Context, target *url.URL) ([]Engine, error) {
+	dirs := []string{processStateDir}
+	var current processSource
+	if target != nil {
+		if dir := processDir(target); dir != processStateDir {
+			dirs = append(dirs, dir)
+		}
+		var err error
+		current, err = resolveProcessSource(target)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var engines []Engine
+	for _, dir := range dirs {
+		st, err := (&processEngine{dir: dir}).readState()
+		if err != nil {
+			return nil, err
+		}
+		if st == nil {
+			continue
+		}
+		engines = append(engines, Engine{
+			Name:    dir,
+			Version: st.Source.version(ctx),
+			Running: st.running(),
+			Current: target != nil && dir == processDir(target) && st.Source == current,
+		})
+	}
+	return engines, nil
+}
+
+func (d *processDriver) EngineLogs(ctx context.Context, name string, follow bool, w io.Writer) error {
+	f, err := os.Open((&processEngine{dir: name}).logPath())
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for {
+		if _, err := io.Copy(w, f); err != nil {
+			return err
+		}
+		if !follow {
+			return nil
+		}
+		// the log is truncated when the engine restarts
+		if fi, err := f.Stat(); err == nil {
+			if offset, err := f.Seek(0, io.SeekCurrent); err == nil && fi.Size() < offset {
+				if _, err := f.Seek(0, io.SeekStart); err != nil {
+					return err
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func (d *processDriver) StopEngine(ctx context.Context, name string) error {
+	eng := &processEngine{dir: name}
+	lock := flock.New(filepath.Join(eng.dir, "process.lock"))
+	if _, err := lock.TryLockContext(ctx, 100*time.Millisecond); err != nil {
+		return fmt.Errorf("could not acquire lock on %s: %w", eng.dir, err)
+	}
+	defer lock.Unlock()
+
+	st, err := eng.readState()
+	if err != nil || st == nil {
+		return err
+	}
+	return eng.stop(ctx, st)
+}
+
+func processDir(target *url.URL) string {
+	if dir := target.Query().Get("state"); dir != "" {
+		return dir
+	}
+	return processStateDir
+}
+
+type processConnector struct {
+	sockPath string
+}
+
+func (d processConnector) Connect(ctx context.Context) (net.Conn, error) {
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "unix", d.sockPath)
+}
+
+func (d processConnector) EngineID() string {
+	// not supported yet
+	return ""
+}
+
+// processSource is where the engine of a process driver comes from: exactly
+// one of a binary on the host, or an image to unpack.
+type processSource struct {
+	Binary string `json:"binary,omitempty"`
+	Image  string `json:"image,omitempty"`
+}
+
+func (src processSource) String() string {
+	if src.Binary != "" {
+		return src.Binary
+	}
+	return src.Image
+}
+
+// version returns the version of the engine, if known.
+func (src processSource) version(ctx context.Context) string {
+	if src.Image != "" {
+		ref, err := name.ParseReference(src.Image)
+		if err != nil {
+			return ""
+		}
+		if tag, ok := ref.(name.Tag); ok {
+			return tag.TagStr()
+		}
+		id, _ := resolveImageID(src.Image)
+		return id
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	// prints "<version> <tag> <platform>"
+	out, err := exec.CommandContext(ctx, src.Binary, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	version, _, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	return version
+}
+
+func resolveProcessSource(target *url.URL) (processSource, error) {
+	if target.Path != "" && target.Path != "/" {
+		return processSource{Binary: target.Path}, nil
+	}
+	if image := target.Query().Get("image"); image != "" {
+		return processSource{Image: image}, nil
+	}
+	if bin, err := exec.LookPath(processEngineBinary); err == nil {
+		bin, err = filepath.Abs(bin)
+		if err != nil {
+			return processSource{}, err
+		}
+		return processSource{Binary: bin}, nil
+	}
+	if engine.Tag == "" {
+		return processSource{}, fmt.Errorf("no %s binary found in $PATH, and no engine version to download: use process:///path/to/%s or process://?image=<ref>",
+			processEngineBinary, processEngineBinary)
+	}
+	tag := engine.Tag
+	if os.Getenv(EnvGPUSupport) != "" {
+		tag += "-gpu"
+	}
+	return processSource{Image: engine.EngineImageRepo + ":" + tag}, nil
+}
+
+// processEngine is the state directory of an engine run by the process
+// driver:
+//
+//	process.json  the running engine, see processState
+//	engine.log    the output of the engine
+//	run/          the engine socket
+//	state/        the engine state, like /var/lib/dagger in a container
+//	images/       the unpacked engine images
+type processEngine struct {
+	dir string
+}
+
+// processState describes a running engine.
+type processState struct {
+	Source processSource `json:"source"`
+	// PID of the engine, as seen from the host.
+	PID int `json:"pid"`
+	// Start time of the engine, to check that PID is still the engine.
+	StartTime uint64 `json:"startTime"`
+	// PID and start time of the network helper of the engine, if any.
+	NetworkPID       int    `json:"networkPid,omitempty"`
+	NetworkStartTime uint64 `json:"networkStartTime,omitempty"`
+}
+
+// running returns whether the engine is running, and its PID wasn't reused by
+// another process since.
+func (st *processState) running() bool {
+	return processRunning(st.PID, st.StartTime)
+}
+
+func (eng *processEngine) socketPath() string {
+	return filepath.Join(eng.dir, "run", "engine.sock")
+}
+
+func (eng *processEngine) logPath() string {
+	return filepath.Join(eng.dir, "engine.log")
+}
+
+func (eng *processEngine) statePath() string {
+	return filepath.Join(eng.dir, "process.json")
+}
+
+func (eng *processEngine) readState() (*processState, error) {
+	dt, err := os.ReadFile(eng.statePath())
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var st processState
+	if err := json.Unmarshal(dt, &st); err != nil {
+		return nil, fmt.Errorf("invalid engine state %s: %w", eng.statePath(), err)
+	}
+	return &st, nil
+}
+
+func (eng *processEngine) writeState(st *processState) error {
+	dt, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(eng.statePath(), dt, 0o600)
+}
+
+// start starts the engine from src, unless it's already running. An engine
+// running from another source is stopped first.
+func (eng *processEngine) start(ctx context.Context, src processSource, opts *DriverOpts) (rerr error) {
+	ctx, span := otel.Tracer("").Start(ctx, "start engine process "+src.String())
+	defer telemetry.EndWithCause(span, &rerr)
+	slog := slog.SpanLogger(ctx, InstrumentationLibrary)
+
+	for _, dir := range []string{eng.dir, filepath.Join(eng.dir, "run"), filepath.Join(eng.dir, "state")} {
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return err
+		}
+	}
+
+	// serialize clients starting and stopping the engine concurrently
+	lock := flock.New(filepath.Join(eng.dir, "process.lock"))
+	if _, err := lock.TryLockContext(ctx, 100*time.Millisecond); err != nil {
+		return fmt.Errorf("could not acquire lock on %s: %w", eng.dir, err)
+	}
+	defer lock.Unlock()
+
+	st, err := eng.readState()
+	if err != nil {
+		return err
+	}
+	if st != nil && st.running() {
+		if st.Source == src {
+			return nil
+		}
+		slog.Info("stopping engine of another version", "source", st.Source.String())
+		if err := eng.stop(ctx, st); err != nil {
+			return fmt.Errorf("failed to stop engine: %w", err)
+		}
+	}
+	// a leftover socket would make the new engine fail to listen
+	if err := os.Remove(eng.socketPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	stateDir := filepath.Join(eng.dir, "state")
+	runDir := filepath.Join(eng.dir, "run")
+	var spec processSpec
+	if src.Binary != "" {
+		spec.Args = []string{
+			src.Binary,
+			"--root", stateDir,
+			"--addr", "unix://" + eng.socketPath(),
+		}
+	} else {
+		rootfs, cfg, err := eng.unpack(ctx, src.Image)
+		if err != nil {
+			return fmt.Errorf("failed to unpack engine image: %w", err)
+		}
+		spec.Rootfs = rootfs
+		spec.Args = append(cfg.Entrypoint, cfg.Cmd...)
+		spec.Env = cfg.Env
+		spec.Dir = cfg.WorkingDir
+		spec.Mounts = map[string]string{
+			stateDir: distconsts.EngineDefaultStateDir,
+			runDir:   filepath.Dir(strings.TrimPrefix(distconsts.DefaultEngineSockAddr, "unix://")),
+		}
+		if _, err := os.Stat(engineConfigPath); err == nil {
+			spec.Mounts[engineConfigPath] = config.DefaultConfigPath()
+		}
+		if _, err := os.Stat(engineCertificatesPath); err == nil {
+			spec.Mounts[engineCertificatesPath] = distconsts.EngineCustomCACertsDir
+		}
+	}
+	spec.Args = append(spec.Args, "--debug")
+	if opts.DaggerCloudToken != "" {
+		spec.Env = append(spec.Env, fmt.Sprintf("%s=%s", EnvDaggerCloudToken, opts.DaggerCloudToken))
+	}
+	if opts.GPUSupport != "" {
+		spec.Env = append(spec.Env, fmt.Sprintf("%s=%s", EnvGPUSupport, opts.GPUSupport))
+	}
+
+	logFile, err := os.OpenFile(eng.logPath(), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	st = &processState{Source: src}
+	st.PID, st.NetworkPID, err = startEngineProcess(ctx, spec, logFile)
+	if err != nil {
+		return err
+	}
+	// without a start time, the engine already exited, which waitReady
+	// reports
+	st.StartTime, _ = processStartTime(st.PID)
+	if st.NetworkPID != 0 {
+		st.NetworkStartTime, _ = processStartTime(st.NetworkPID)
+	}
+	if err := eng.writeState(st); err != nil {
+		return err
+	}
+
+	if err := eng.waitReady(ctx, st); err != nil {
+		if stopErr := eng.stop(context.WithoutCancel(ctx), st); stopErr != nil {
+			slog.Warn("failed to stop engine", "error", stopErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// waitReady waits for the engine to listen on its socket.
+func (eng *processEngine) waitReady(ctx context.Context, st *processState) error {
+	ctx, cancel := context.WithTimeout(ctx, processStartTimeout)
+	defer cancel()
+	for {
+		conn, err := processConnector{sockPath: eng.socketPath()}.Connect(ctx)
+		if err == nil {
+			return conn.Close()
+		}
+		if !st.running() {
+			return fmt.Errorf("engine exited: %s", eng.logTail())
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("engine did not start: %w: %s", ctx.Err(), eng.logTail())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// stop terminates a running engine, and kills it if it doesn't exit in time.
+// Processes that reused the PIDs of the state are left alone.
+func (eng *processEngine) stop(ctx context.Context, st *processState) error {
+	defer func() {
+		if processRunning(st.NetworkPID, st.NetworkStartTime) {
+			_ = killProcess(st.NetworkPID)
+		}
+		_ = os.Remove(eng.statePath())
+	}()
+	if !st.running() {
+		return nil
+	}
+	if err := terminateProcess(st.PID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, processStopTimeout)
+	defer cancel()
+	for st.running() {
+		select {
+		case <-ctx.Done():
+			return killProcess(st.PID)
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	return nil
+}
+
+// logTail returns the last lines of the engine output, to explain a failed
+// start.
+func (eng *processEngine) logTail() string {
+	dt, err := os.ReadFile(eng.logPath())
+	if err != nil {
+		return err.Error()
+	}
+	lines := strings.Split(strings.TrimSpace(string(dt)), "\n")
+	if len(lines) > 20 {
+		lines = lines[len(lines)-20:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// processSpec is how to run an engine process.
+type processSpec struct {
+	Args []string
+	Env  []string
+	Dir  string
+
+	// Root filesystem of the engine, if not run from the host filesystem.
+	Rootfs string
+	// Host paths bind mounted to paths in the root filesystem.
+	Mounts map[string]string
+}
+
+// processImageConfig is the part of the image config needed to run it.
+type processImageConfig struct {
+	Entrypoint []string `json:"entrypoint,omitempty"`
+	Cmd        []string `json:"cmd,omitempty"`
+	Env        []string `json:"env,omitempty"`
+	WorkingDir string   `json:"workingDir,omitempty"`
+}
+
+// unpack pulls the engine image for the host platform, and extracts it to
+// the images directory, unless it already is.
+func (eng *processEngine) unpack(ctx context.Context, imageRef string) (_ string, _ *processImageConfig, rerr error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing image reference: %w", err)
+	}
+	id, err := resolveImageID(imageRef)
+	if err != nil {
+		return "", nil, err
+	}
+	dir := filepath.Join(eng.dir, "images", ref.Context().RepositoryStr(), id)
+	rootfs := filepath.Join(dir, "rootfs")
+	cfgPath := filepath.Join(dir, "config.json")
+
+	// tags are pulled again, in case they moved
+	if _, isDigest := ref.(name.Digest); isDigest {
+		if dt, err := os.ReadFile(cfgPath); err == nil {
+			var cfg processImageConfig
+			if err := json.Unmarshal(dt, &cfg); err == nil {
+				return rootfs, &cfg, nil
+			}
+		}
+	}
+
+	ctx, span := otel.Tracer("").Start(ctx, "pull "+imageRef)
+	defer telemetry.EndWithCause(span, &rerr)
+
+	img, err := remote.Image(ref,
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+		remote.WithPlatform(v1.Platform{OS: "linux", Architecture: runtime.GOARCH}))
+	if err != nil {
+		return "", nil, err
+	}
+	imgCfg, err := img.ConfigFile()
+	if err != nil {
+		return "", nil, err
+	}
+	cfg := &processImageConfig{
+		Entrypoint: imgCfg.Config.Entrypoint,
+		Cmd:        imgCfg.Config.Cmd,
+		Env:        imgCfg.Config.Env,
+		WorkingDir: imgCfg.Config.WorkingDir,
+	}
+
+	// extract next to the previous rootfs, and swap them
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", nil, err
+	}
+	tmp, err := os.MkdirTemp(dir, "rootfs-")
+	if err != nil {
+		return "", nil, err
+	}
+	defer os.RemoveAll(tmp)
+	layers := mutate.Extract(img)
+	defer layers.Close()
+	if err := untar(layers, tmp); err != nil {
+		return "", nil, err
+	}
+	if err := os.RemoveAll(rootfs); err != nil {
+		return "", nil, err
+	}
+	if err := os.Rename(tmp, rootfs); err != nil {
+		return "", nil, err
+	}
+
+	dt, err := json.Marshal(cfg)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.WriteFile(cfgPath, dt, 0o600); err != nil {
+		return "", nil, err
+	}
+	return rootfs, cfg, nil
+}
+
+// untar extracts a flattened image filesystem to dir. Files are owned by the
+// current user, which is root in the namespace of the engine.
+func untar(r io.Reader, dir string) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		path, err := untarPath(dir, hdr.Name)
+		if err != nil {
+			return err
+		}
+		mode := hdr.FileInfo().Mode()
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(path, mode.Perm()|0o700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			// replace a symlink instead of writing to its target
+			if fi, err := os.Lstat(path); err == nil && fi.Mode()&os.ModeSymlink != 0 {
+				if err := os.Remove(path); err != nil {
+					return err
+				}
+			}
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm()|0o600)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				return err
+			}
+			if err := os.Symlink(hdr.Linkname, path); err != nil {
+				return err
+			}
+		case tar.TypeLink:
+			target, err := untarPath(dir, hdr.Linkname)
+			if err != nil {
+				return err
+			}
+			if err := os.Link(target, path); err != nil {
+				return err
+			}
+		default:
+			// device nodes and fifos can't be created without privileges, and
+			// the engine doesn't need them
+		}
+	}
+}
+
+// untarPath returns the path of an entry of the image in dir. Symlinks in its
+// parent directories are resolved within dir, like in a chroot, so that an
+// entry can't be written outside of dir through a symlink extracted before.
+func untarPath(dir, name string) (string, error) {
+	clean := filepath.Clean("/" + name)
+	if clean == "/" {
+		return dir, nil
+	}
+	parent, err := fs.RootPath(dir, filepath.Dir(clean))
+	if err != nil {
+		return "", fmt.Errorf("invalid path in image: %q: %w", name, err)
+	}
+	return filepath.Join(parent, filepath.Base(clean)), nil
+}
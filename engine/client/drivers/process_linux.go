@@ -0,0 +1,414 @@
+package drivers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/moby/sys/reexec"
+	"golang.org/x/sys/unix"
+
+	"github.com/dagger/dagger/engine/slog"
+)
+
+func init() {
+	reexec.Register(processInitName, processInit)
+}
+
+const (
+	// processInitName is the name of the re-executed CLI that sets up the
+	// namespaces of the engine, then executes it.
+	processInitName = "dagger-engine-process-init"
+	processSpecEnv  = "_DAGGER_ENGINE_PROCESS_SPEC"
+
+	// DNS server of the slirp4netns network
+	processNameserver = "10.0.2.3"
+)
+
+func processAvailable() (bool, error) {
+	// unprivileged user namespaces can be disabled by the system
+	for _, knob := range []string{
+		"/proc/sys/user/max_user_namespaces",
+		"/proc/sys/kernel/unprivileged_userns_clone",
+	} {
+		if dt, err := os.ReadFile(knob); err == nil && strings.TrimSpace(string(dt)) == "0" {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// startEngineProcess starts the engine in new user, mount, pid and network
+// namespaces, with slirp4netns providing its network. The processes are
+// detached from the current one, and outlive it.
+func startEngineProcess(ctx context.Context, spec processSpec, logFile *os.File) (pid int, netPID int, rerr error) {
+	slirp, err := exec.LookPath("slirp4netns")
+	if err != nil {
+		return 0, 0, fmt.Errorf("slirp4netns is needed for the network of a rootless engine: %w", err)
+	}
+
+	// the network namespace has its own DNS server
+	resolvConf := filepath.Join(filepath.Dir(logFile.Name()), "resolv.conf")
+	if err := os.WriteFile(resolvConf, []byte("nameserver "+processNameserver+"\n"), 0o644); err != nil {
+		return 0, 0, err
+	}
+	if spec.Mounts == nil {
+		spec.Mounts = map[string]string{}
+	}
+	spec.Mounts[resolvConf] = "/etc/resolv.conf"
+
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the init waits for the IDs and network to be set up before executing
+	// the engine
+	syncR, syncW, err := os.Pipe()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer syncW.Close()
+
+	cmd := reexec.Command(processInitName)
+	cmd.Env = append(os.Environ(), processSpecEnv+"="+string(specBytes))
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.ExtraFiles = []*os.File{syncR}
+	// unlike reexec's default, the engine isn't killed when we exit
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setsid:     true,
+		Cloneflags: unix.CLONE_NEWUSER | unix.CLONE_NEWNS | unix.CLONE_NEWPID | unix.CLONE_NEWNET,
+	}
+	err = cmd.Start()
+	syncR.Close()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to start engine: %w", err)
+	}
+	// reap the process if it exits while we're still running
+	go cmd.Wait()
+	defer func() {
+		if rerr != nil {
+			cmd.Process.Kill()
+		}
+	}()
+
+	if err := mapProcessIDs(ctx, cmd.Process.Pid); err != nil {
+		return 0, 0, fmt.Errorf("failed to map user IDs: %w", err)
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer readyR.Close()
+	slirpCmd := exec.Command(slirp,
+		"--configure",
+		"--mtu=65520",
+		"--disable-host-loopback",
+		"--ready-fd=3",
+		strconv.Itoa(cmd.Process.Pid),
+		"tap0",
+	)
+	slirpCmd.Stdout = logFile
+	slirpCmd.Stderr = logFile
+	slirpCmd.ExtraFiles = []*os.File{readyW}
+	slirpCmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = slirpCmd.Start()
+	readyW.Close()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to start slirp4netns: %w", err)
+	}
+	go slirpCmd.Wait()
+	if _, err := readyR.Read(make([]byte, 1)); err != nil {
+		slirpCmd.Process.Kill()
+		return 0, 0, fmt.Errorf("slirp4netns did not configure the network: %w", err)
+	}
+
+	if _, err := syncW.Write([]byte{0}); err != nil {
+		slirpCmd.Process.Kill()
+		return 0, 0, fmt.Errorf("failed to resume engine: %w", err)
+	}
+	return cmd.Process.Pid, slirpCmd.Process.Pid, nil
+}
+
+// mapProcessIDs maps the current user to root in the user namespace of pid,
+// along with its subordinate IDs if it has any.
+func mapProcessIDs(ctx context.Context, pid int) error {
+	uid, gid := os.Getuid(), os.Getgid()
+	names := []string{strconv.Itoa(uid)}
+	if u, err := user.Current(); err == nil {
+		names = append(names, u.Username)
+	}
+
+	newuidmap, uidErr := exec.LookPath("newuidmap")
+	newgidmap, gidErr := exec.LookPath("newgidmap")
+	subUID, subUIDs, uidOK := findSubIDs("/etc/subuid", names...)
+	subGID, subGIDs, gidOK := findSubIDs("/etc/subgid", names...)
+	if uidErr == nil && gidErr == nil && uidOK && gidOK {
+		for _, args := range [][]string{
+			{newuidmap, strconv.Itoa(pid), "0", strconv.Itoa(uid), "1", "1", strconv.Itoa(subUID), strconv.Itoa(subUIDs)},
+			{newgidmap, strconv.Itoa(pid), "0", strconv.Itoa(gid), "1", "1", strconv.Itoa(subGID), strconv.Itoa(subGIDs)},
+		} {
+			if out, err := exec.Command(args[0], args[1:]...).CombinedOutput(); err != nil {
+				return fmt.Errorf("%s: %w: %s", filepath.Base(args[0]), err, strings.TrimSpace(string(out)))
+			}
+		}
+		return nil
+	}
+
+	// without subordinate IDs, only the current user can be mapped, so
+	// containers can't use other users
+	slog.SpanLogger(ctx, InstrumentationLibrary).Warn("no subordinate IDs for the current user, only mapping it to root",
+		"uid", uid, "gid", gid)
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+	// gid_map can only be written once setgroups is denied
+	for _, file := range []struct{ name, content string }{
+		{"uid_map", fmt.Sprintf("0 %d 1\n", uid)},
+		{"setgroups", "deny"},
+		{"gid_map", fmt.Sprintf("0 %d 1\n", gid)},
+	} {
+		if err := os.WriteFile(filepath.Join(procDir, file.name), []byte(file.content), 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findSubIDs returns the first range of subordinate IDs of a user in an
+// /etc/subuid or /etc/subgid file.
+func findSubIDs(path string, names ...string) (start int, count int, ok bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, false
+	}
+	defer f.Close()
+	return parseSubIDs(bufio.NewScanner(f), names...)
+}
+
+func parseSubIDs(scanner *bufio.Scanner, names ...string) (start int, count int, ok bool) {
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) != 3 {
+			continue
+		}
+		if !slices.Contains(names, fields[0]) {
+			continue
+		}
+		start, startErr := strconv.Atoi(fields[1])
+		count, countErr := strconv.Atoi(fields[2])
+		if startErr != nil || countErr != nil || count == 0 {
+			continue
+		}
+		return start, count, true
+	}
+	return 0, 0, false
+}
+
+// processInit runs in the namespaces of the engine, as root of its user
+// namespace: it mounts its filesystem, then executes it.
+func processInit() {
+	if err := runProcessInit(); err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", processInitName, err)
+		os.Exit(1)
+	}
+}
+
+func runProcessInit() error {
+	sync := os.NewFile(3, "sync")
+	_, err := sync.Read(make([]byte, 1))
+	sync.Close()
+	if err != nil {
+		return fmt.Errorf("engine setup was interrupted: %w", err)
+	}
+
+	var spec processSpec
+	if err := json.Unmarshal([]byte(os.Getenv(processSpecEnv)), &spec); err != nil {
+		return fmt.Errorf("invalid engine spec: %w", err)
+	}
+	if len(spec.Args) == 0 {
+		return errors.New("invalid engine spec: no command")
+	}
+
+	// don't propagate any mount to the host
+	if err := unix.Mount("", "/", "", unix.MS_REC|unix.MS_PRIVATE, ""); err != nil {
+		return fmt.Errorf("failed to make mounts private: %w", err)
+	}
+
+	root := "/"
+	env := spec.Env
+	if spec.Rootfs != "" {
+		root = spec.Rootfs
+		if err := mountRootfs(root); err != nil {
+			return err
+		}
+	} else {
+		// a host binary runs with the environment of the client
+		for _, kv := range os.Environ() {
+			if !strings.HasPrefix(kv, processSpecEnv+"=") {
+				env = append([]string{kv}, env...)
+			}
+		}
+	}
+	for src, dst := range spec.Mounts {
+		target := filepath.Join(root, dst)
+		if spec.Rootfs != "" {
+			if err := ensureMountpoint(src, target); err != nil {
+				return err
+			}
+		}
+		if err := unix.Mount(src, target, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+			return fmt.Errorf("failed to mount %s to %s: %w", src, dst, err)
+		}
+	}
+
+	if spec.Rootfs != "" {
+		if err := unix.Chdir(root); err != nil {
+			return err
+		}
+		if err := unix.Chroot("."); err != nil {
+			return fmt.Errorf("failed to chroot to %s: %w", root, err)
+		}
+		if err := unix.Chdir("/"); err != nil {
+			return err
+		}
+	}
+	if spec.Dir != "" {
+		if err := unix.Chdir(spec.Dir); err != nil {
+			return err
+		}
+	}
+
+	// resolve the command with the PATH of the engine
+	os.Clearenv()
+	for _, kv := range env {
+		k, v, _ := strings.Cut(kv, "=")
+		os.Setenv(k, v)
+	}
+	bin, err := exec.LookPath(spec.Args[0])
+	if err != nil {
+		return err
+	}
+	return unix.Exec(bin, spec.Args, os.Environ())
+}
+
+// mountRootfs mounts the kernel filesystems of the engine in its root
+// filesystem.
+func mountRootfs(root string) error {
+	if err := unix.Mount(root, root, "", unix.MS_BIND|unix.MS_REC, ""); err != nil {
+		return fmt.Errorf("failed to mount rootfs: %w", err)
+	}
+	for _, m := range []struct {
+		src, dst, fstype string
+		flags            uintptr
+	}{
+		{"proc", "/proc", "proc", unix.MS_NOSUID | unix.MS_NODEV | unix.MS_NOEXEC},
+		{"/dev", "/dev", "", unix.MS_BIND | unix.MS_REC},
+		{"/sys", "/sys", "", unix.MS_BIND | unix.MS_REC},
+		{"tmpfs", "/tmp", "tmpfs", unix.MS_NOSUID | unix.MS_NODEV},
+	} {
+		target := filepath.Join(root, m.dst)
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return err
+		}
+		if err := unix.Mount(m.src, target, m.fstype, m.flags, ""); err != nil {
+			return fmt.Errorf("failed to mount %s: %w", m.dst, err)
+		}
+	}
+	return nil
+}
+
+// ensureMountpoint creates the target of a bind mount in the root
+// filesystem, replacing any symlink so the mount stays in the rootfs.
+func ensureMountpoint(src, target string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	if tfi, err := os.Lstat(target); err == nil && tfi.Mode()&os.ModeSymlink != 0 {
+		if err := os.Remove(target); err != nil {
+			return err
+		}
+	}
+	if fi.IsDir() {
+		return os.MkdirAll(target, 0o755)
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+func processRunning(pid int, startTime uint64) bool {
+	if pid <= 0 {
+		return false
+	}
+	state, start, err := processStat(pid)
+	if err != nil {
+		return false
+	}
+	// a zombie is not running, though it can still be signalled, and a
+	// process started at another time reused the PID
+	return state != "Z" && start == startTime
+}
+
+// processStartTime returns the start time of a process, in clock ticks since
+// boot, to tell it apart from a later process reusing its PID.
+func processStartTime(pid int) (uint64, error) {
+	_, start, err := processStat(pid)
+	return start, err
+}
+
+// processStat returns the state and start time of a process, from
+// /proc/<pid>/stat.
+func processStat(pid int) (state string, startTime uint64, err error) {
+	dt, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return "", 0, err
+	}
+	// the command name before the state is in parentheses, and can contain
+	// spaces and parentheses
+	i := strings.LastIndex(string(dt), ") ")
+	if i < 0 {
+		return "", 0, fmt.Errorf("invalid stat of process %d", pid)
+	}
+	fields := strings.Fields(string(dt[i+2:]))
+	// the state is the 3rd field, and the start time the 22nd
+	if len(fields) < 20 {
+		return "", 0, fmt.Errorf("invalid stat of process %d", pid)
+	}
+	startTime, err = strconv.ParseUint(fields[19], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid stat of process %d: %w", pid, err)
+	}
+	return fields[0], startTime, nil
+}
+
+func terminateProcess(pid int) error {
+	return ignoreProcessDone(unix.Kill(pid, unix.SIGTERM))
+}
+
+func killProcess(pid int) error {
+	return ignoreProcessDone(unix.Kill(pid, unix.SIGKILL))
+}
+
+func ignoreProcessDone(err error) error {
+	if errors.Is(err, unix.ESRCH) {
+		return nil
+	}
+	return err
+}
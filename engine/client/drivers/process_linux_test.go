@@ -0,0 +1,45 @@
+package drivers
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSubIDs(t *testing.T) {
+	const subuid = `# comment
+root:100000:65536
+alice:165536:0
+alice:231072:65536
+1001:296608:65536
+`
+	parse := func(names ...string) (int, int, bool) {
+		return parseSubIDs(bufio.NewScanner(strings.NewReader(subuid)), names...)
+	}
+
+	start, count, ok := parse("1000", "alice")
+	require.True(t, ok)
+	require.Equal(t, 231072, start)
+	require.Equal(t, 65536, count)
+
+	start, _, ok = parse("1001", "bob")
+	require.True(t, ok)
+	require.Equal(t, 296608, start)
+
+	_, _, ok = parse("1002", "carol")
+	require.False(t, ok)
+}
+
+func TestProcessRunning(t *testing.T) {
+	start, err := processStartTime(os.Getpid())
+	require.NoError(t, err)
+	require.NotZero(t, start)
+
+	require.True(t, processRunning(os.Getpid(), start))
+	// the PID was reused by another process
+	require.False(t, processRunning(os.Getpid(), start-1))
+	require.False(t, processRunning(0, 0))
+}
@@ -0,0 +1,34 @@
+//go:build !linux
+
+package drivers
+
+import (
+	"context"
+	"errors"
+	"os"
+)
+
+func processAvailable() (bool, error) {
+	// the engine needs Linux namespaces
+	return false, nil
+}
+
+func startEngineProcess(context.Context, processSpec, *os.File) (int, int, error) {
+	return 0, 0, errors.New("the process driver is only supported on Linux")
+}
+
+func processRunning(int, uint64) bool {
+	return false
+}
+
+func processStartTime(int) (uint64, error) {
+	return 0, errors.New("the process driver is only supported on Linux")
+}
+
+func terminateProcess(int) error {
+	return nil
+}
+
+func killProcess(int) error {
+	return nil
+}
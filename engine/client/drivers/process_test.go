@@ -0,0 +1,124 @@
+package drivers
+
+import (
+	"archive/tar"
+	"bytes"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveProcessSource(t *testing.T) {
+	for _, tc := range []struct {
+		url    string
+		expect processSource
+	}{
+		{
+			url:    "process:///opt/dagger/dagger-engine",
+			expect: processSource{Binary: "/opt/dagger/dagger-engine"},
+		},
+		{
+			url:    "process://?image=registry.dagger.io/engine:v0.19.0",
+			expect: processSource{Image: "registry.dagger.io/engine:v0.19.0"},
+		},
+	} {
+		t.Run(tc.url, func(t *testing.T) {
+			target, err := url.Parse(tc.url)
+			require.NoError(t, err)
+			src, err := resolveProcessSource(target)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, src)
+		})
+	}
+
+	t.Run("from PATH", func(t *testing.T) {
+		dir := t.TempDir()
+		bin := filepath.Join(dir, processEngineBinary)
+		require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\n"), 0o755))
+		t.Setenv("PATH", dir)
+
+		src, err := resolveProcessSource(&url.URL{Scheme: "process"})
+		require.NoError(t, err)
+		require.Equal(t, processSource{Binary: bin}, src)
+	})
+}
+
+func TestUntar(t *testing.T) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "usr/", Typeflag: tar.TypeDir, Mode: 0o755},
+		{Name: "usr/local/bin/dagger-engine", Typeflag: tar.TypeReg, Mode: 0o755, Size: 6},
+		{Name: "usr/local/bin/buildctl", Typeflag: tar.TypeSymlink, Linkname: "dagger-engine"},
+		{Name: "usr/local/bin/runc", Typeflag: tar.TypeLink, Linkname: "usr/local/bin/dagger-engine"},
+		{Name: "dev/null", Typeflag: tar.TypeChar, Mode: 0o666},
+	} {
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte("engine"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	dir := t.TempDir()
+	require.NoError(t, untar(&buf, dir))
+
+	fi, err := os.Stat(filepath.Join(dir, "usr/local/bin/dagger-engine"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0o755), fi.Mode().Perm())
+	target, err := os.Readlink(filepath.Join(dir, "usr/local/bin/buildctl"))
+	require.NoError(t, err)
+	require.Equal(t, "dagger-engine", target)
+	dt, err := os.ReadFile(filepath.Join(dir, "usr/local/bin/runc"))
+	require.NoError(t, err)
+	require.Equal(t, "engine", string(dt))
+	require.NoFileExists(t, filepath.Join(dir, "dev/null"))
+
+	// paths can't escape the directory
+	path, err := untarPath(dir, "../../etc/passwd")
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "etc/passwd"), path)
+}
+
+func TestUntarSymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "passwd"), []byte("root"), 0o644))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, hdr := range []*tar.Header{
+		{Name: "abs", Typeflag: tar.TypeSymlink, Linkname: outside},
+		{Name: "abs/passwd", Typeflag: tar.TypeReg, Mode: 0o644, Size: 6},
+		{Name: "rel", Typeflag: tar.TypeSymlink, Linkname: "../../../../../../.." + outside},
+		{Name: "rel/passwd", Typeflag: tar.TypeReg, Mode: 0o644, Size: 6},
+		{Name: "file", Typeflag: tar.TypeSymlink, Linkname: filepath.Join(outside, "passwd")},
+		{Name: "file", Typeflag: tar.TypeReg, Mode: 0o644, Size: 6},
+	} {
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte("pwned!"))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	dir := t.TempDir()
+	require.NoError(t, untar(&buf, dir))
+
+	// symlinks are resolved within the directory
+	dt, err := os.ReadFile(filepath.Join(outside, "passwd"))
+	require.NoError(t, err)
+	require.Equal(t, "root", string(dt))
+	dt, err = os.ReadFile(filepath.Join(dir, outside, "passwd"))
+	require.NoError(t, err)
+	require.Equal(t, "pwned!", string(dt))
+
+	// files replace symlinks instead of writing to their target
+	fi, err := os.Lstat(filepath.Join(dir, "file"))
+	require.NoError(t, err)
+	require.True(t, fi.Mode().IsRegular())
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/dagger/dagger/engine"
+)
+
+// History returns the past runs of commands started from this host, most
+// recent first. At most limit runs are returned if it's positive.
+func (c *Client) History(ctx context.Context, limit int) ([]*engine.HistoryRun, error) {
+	u := "http://dagger" + engine.HistoryEndpoint
+	if limit > 0 {
+		u += "?" + url.Values{"limit": {strconv.Itoa(limit)}}.Encode()
+	}
+	body, err := c.historyRequest(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	var runs []*engine.HistoryRun
+	if err := json.NewDecoder(body).Decode(&runs); err != nil {
+		return nil, fmt.Errorf("decode history: %w", err)
+	}
+	return runs, nil
+}
+
+// HistoryTelemetry returns the telemetry of a past run, in the OTLP JSON lines
+// format read by telemetry.ReplayFile.
+func (c *Client) HistoryTelemetry(ctx context.Context, clientID string) (io.ReadCloser, error) {
+	return c.historyRequest(ctx, "http://dagger"+engine.HistoryEndpoint+"/"+url.PathEscape(clientID))
+}
+
+func (c *Client) historyRequest(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do history: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("history: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return resp.Body, nil
+}
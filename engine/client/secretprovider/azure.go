@@ -0,0 +1,208 @@
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+const (
+	azureKeyVaultAPIVersion = "7.4"
+	azureKeyVaultScope      = "https://vault.azure.net/.default"
+)
+
+// azureKeyVaultDNSSuffixes are the DNS suffixes of Key Vault in the Azure
+// clouds. Tokens are only sent to vaults under them.
+var azureKeyVaultDNSSuffixes = []string{
+	".vault.azure.net",
+	".vault.azure.cn",
+	".vault.usgovcloudapi.net",
+	".vault.microsoftazure.de",
+}
+
+var (
+	azureMutex      sync.Mutex
+	azureCredential azcore.TokenCredential
+	azureCache      secretCache
+)
+
+// azureKeyVaultProvider reads secrets from Azure Key Vault.
+//
+// Format:
+// - azure+kv://<vault>/<secret>
+// - azure+kv://<vault>/<secret>/<version>
+//
+// The vault is either the name of a vault, at https://<vault>.vault.azure.net,
+// or the host of its URL, for other clouds, which must be a Key Vault host so
+// that Azure tokens aren't sent to other servers. The "field" query parameter
+// extracts a field of a JSON secret, and "ttl" sets how long the secret is
+// cached.
+//
+// Credentials are looked up with the default Azure credential chain. If
+// AZURE_KEYVAULT_EMULATOR_URL is set, unauthenticated requests are sent to
+// that URL instead.
+func azureKeyVaultProvider(ctx context.Context, pathWithQuery string) ([]byte, error) {
+	vault, secret, version, query, err := parseAzureKeyVaultURI(pathWithQuery)
+	if err != nil {
+		return nil, err
+	}
+	secretPath := secret
+	if version != "" {
+		secretPath += "/" + version
+	}
+	ttl, err := parseTTL(query, secretPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var vaultURL string
+	if emulatorURL := os.Getenv("AZURE_KEYVAULT_EMULATOR_URL"); emulatorURL != "" {
+		vaultURL = strings.TrimSuffix(emulatorURL, "/")
+	} else {
+		vaultURL, err = azureKeyVaultURL(vault)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name := vault + "/" + secretPath
+	data, err := azureCache.get(ctx, name, ttl, func(ctx context.Context) ([]byte, error) {
+		return azureKeyVaultGet(ctx, vaultURL, secret, version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if field := query.Get("field"); field != "" {
+		data, err = extractJSONField(data, field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract field %q from secret %q: %w", field, name, err)
+		}
+	}
+	return data, nil
+}
+
+// parseAzureKeyVaultURI parses the part of an azure+kv:// URI after the scheme:
+// <vault>/<secret>[/<version>], with a query.
+func parseAzureKeyVaultURI(pathWithQuery string) (vault, secret, version string, query url.Values, err error) {
+	parsed, err := url.Parse("azure+kv://" + pathWithQuery)
+	if err != nil {
+		return "", "", "", nil, fmt.Errorf("failed to parse azure+kv:// URI: %w", err)
+	}
+	vault = parsed.Host
+	secret, version, _ = strings.Cut(strings.TrimPrefix(parsed.Path, "/"), "/")
+	if vault == "" || secret == "" || strings.Contains(version, "/") {
+		return "", "", "", nil, fmt.Errorf("invalid secret %q: expected <vault>/<secret>[/<version>]", pathWithQuery)
+	}
+	return vault, secret, version, parsed.Query(), nil
+}
+
+// azureKeyVaultURL returns the URL of a vault, given its name or host.
+func azureKeyVaultURL(vault string) (string, error) {
+	if !strings.Contains(vault, ".") {
+		return "https://" + vault + azureKeyVaultDNSSuffixes[0], nil
+	}
+	host := strings.ToLower(vault)
+	for _, suffix := range azureKeyVaultDNSSuffixes {
+		if name, ok := strings.CutSuffix(host, suffix); ok && name != "" && !strings.Contains(name, ".") {
+			return "https://" + host, nil
+		}
+	}
+	return "", fmt.Errorf("invalid vault %q: expected a vault name or a host ending with one of %s", vault, strings.Join(azureKeyVaultDNSSuffixes, ", "))
+}
+
+// azureToken returns a token to access Key Vault, or an empty token for an
+// emulator.
+func azureToken(ctx context.Context) (string, error) {
+	if os.Getenv("AZURE_KEYVAULT_EMULATOR_URL") != "" {
+		return "", nil
+	}
+
+	azureMutex.Lock()
+	defer azureMutex.Unlock()
+
+	if azureCredential == nil {
+		cred, err := azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			return "", fmt.Errorf("unable to load Azure credentials: %w", err)
+		}
+		azureCredential = cred
+	}
+	token, err := azureCredential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes: []string{azureKeyVaultScope},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to get Azure token: %w", err)
+	}
+	return token.Token, nil
+}
+
+// azureKeyVaultGet reads a version of a secret with the REST API.
+func azureKeyVaultGet(ctx context.Context, vaultURL, secret, version string) ([]byte, error) {
+	token, err := azureToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u := vaultURL + "/secrets/" + url.PathEscape(secret)
+	if version != "" {
+		u += "/" + url.PathEscape(version)
+	}
+	u += "?" + url.Values{"api-version": {azureKeyVaultAPIVersion}}.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret %q: %w", secret, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret %q: %w", secret, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(body, &errResp)
+		switch {
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, fmt.Errorf("secret not found: %q", secret)
+		case resp.StatusCode == http.StatusForbidden, resp.StatusCode == http.StatusUnauthorized:
+			return nil, fmt.Errorf("access denied to secret %q: check the access policies of the vault", secret)
+		case errResp.Error.Message != "":
+			return nil, fmt.Errorf("failed to retrieve secret %q: %s: %s", secret, errResp.Error.Code, errResp.Error.Message)
+		}
+		return nil, fmt.Errorf("failed to retrieve secret %q: %s", secret, resp.Status)
+	}
+
+	var result struct {
+		Value *string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode secret %q: %w", secret, err)
+	}
+	if result.Value == nil {
+		return nil, fmt.Errorf("secret %q has no value", secret)
+	}
+	return []byte(*result.Value), nil
+}
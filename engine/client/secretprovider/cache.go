@@ -0,0 +1,82 @@
+package secretprovider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"resenje.org/singleflight"
+)
+
+// secretCache caches the secrets read by a provider, like the vault provider
+// does: a secret is cached for the duration of its "ttl" query parameter, or
+// for the lifetime of the client without one.
+type secretCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedSecret
+
+	// fetches deduplicates concurrent fetches of the same secret
+	fetches singleflight.Group[string, []byte]
+}
+
+type cachedSecret struct {
+	expiresAt time.Time
+	data      []byte
+}
+
+// get returns the cached secret for the key, or fetches and caches it. The
+// cache isn't locked while fetching, so that a slow provider only blocks the
+// callers of the same key.
+func (c *secretCache) get(ctx context.Context, key string, ttl time.Duration, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	if data, ok := c.lookup(key); ok {
+		return data, nil
+	}
+	data, _, err := c.fetches.Do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		// the secret may have been cached by a fetch that just completed
+		if data, ok := c.lookup(key); ok {
+			return data, nil
+		}
+		data, err := fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		entry := cachedSecret{data: data}
+		if ttl > 0 {
+			entry.expiresAt = time.Now().Add(ttl)
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.entries == nil {
+			c.entries = make(map[string]cachedSecret)
+		}
+		c.entries[key] = entry
+		return data, nil
+	})
+	return data, err
+}
+
+func (c *secretCache) lookup(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	existing, ok := c.entries[key]
+	if !ok || (!existing.expiresAt.IsZero() && !existing.expiresAt.After(time.Now())) {
+		return nil, false
+	}
+	return existing.data, true
+}
+
+// parseTTL parses the "ttl" query parameter of a secret.
+func parseTTL(query url.Values, name string) (time.Duration, error) {
+	ttlStr := strings.TrimSpace(query.Get("ttl"))
+	if ttlStr == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q provided for secret %q: %w", ttlStr, name, err)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,180 @@
+package secretprovider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+
+var (
+	// gcpMutex guards the client and project of the default credentials,
+	// which are only read by gcpClient
+	gcpMutex      sync.Mutex
+	gcpHTTPClient *http.Client
+	gcpProjectID  string
+	gcpCache      secretCache
+)
+
+// gcpSecretManagerProvider reads secrets from Google Cloud Secret Manager.
+//
+// Format:
+// - gcp+sm://<project>/<secret>
+// - gcp+sm://<project>/<secret>/<version>
+// - gcp+sm://<secret>, in the project of the default credentials
+//
+// The version defaults to "latest". The "field" query parameter extracts a
+// field of a JSON secret, and "ttl" sets how long the secret is cached.
+//
+// Credentials are looked up with the Application Default Credentials. If
+// SECRET_MANAGER_EMULATOR_HOST is set, unauthenticated requests are sent to
+// that host instead, like Google Cloud emulators.
+func gcpSecretManagerProvider(ctx context.Context, pathWithQuery string) ([]byte, error) {
+	parsed, err := url.Parse(pathWithQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse gcp+sm:// URI: %w", err)
+	}
+	query := parsed.Query()
+	ttl, err := parseTTL(query, parsed.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	project, secret, version, err := parseGCPSecretName(parsed.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	client, endpoint, defaultProject, err := gcpClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if project == "" {
+		project = defaultProject
+		if project == "" {
+			return nil, fmt.Errorf("no project found in the default credentials for secret %q: use gcp+sm://<project>/%s", secret, secret)
+		}
+	}
+
+	name := fmt.Sprintf("projects/%s/secrets/%s/versions/%s", project, secret, version)
+	data, err := gcpCache.get(ctx, name, ttl, func(ctx context.Context) ([]byte, error) {
+		return gcpSecretManagerAccess(ctx, client, endpoint, name)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if field := query.Get("field"); field != "" {
+		data, err = extractJSONField(data, field)
+		if err != nil {
+			return nil, fmt.Errorf("failed to extract field %q from secret %q: %w", field, name, err)
+		}
+	}
+	return data, nil
+}
+
+// parseGCPSecretName parses the name of a secret: <project>/<secret>, with
+// an optional /<version>, or just <secret>, in the default project. The
+// version defaults to "latest".
+func parseGCPSecretName(name string) (project, secret, version string, err error) {
+	switch parts := strings.Split(name, "/"); len(parts) {
+	case 1:
+		secret = parts[0]
+	case 2:
+		project, secret = parts[0], parts[1]
+	case 3:
+		project, secret, version = parts[0], parts[1], parts[2]
+	default:
+		return "", "", "", fmt.Errorf("invalid secret name %q: expected <project>/<secret>[/<version>]", name)
+	}
+	if secret == "" {
+		return "", "", "", fmt.Errorf("invalid secret name %q: missing secret", name)
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return project, secret, version, nil
+}
+
+// gcpClient returns the HTTP client and endpoint to access Secret Manager,
+// along with the project of the default credentials, if any.
+func gcpClient(ctx context.Context) (*http.Client, string, string, error) {
+	if host := os.Getenv("SECRET_MANAGER_EMULATOR_HOST"); host != "" {
+		return http.DefaultClient, "http://" + host, os.Getenv("GOOGLE_CLOUD_PROJECT"), nil
+	}
+
+	gcpMutex.Lock()
+	defer gcpMutex.Unlock()
+
+	if gcpHTTPClient == nil {
+		creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+		if err != nil {
+			return nil, "", "", fmt.Errorf("unable to find Google Cloud credentials: %w", err)
+		}
+		gcpProjectID = creds.ProjectID
+		if project := os.Getenv("GOOGLE_CLOUD_PROJECT"); project != "" {
+			gcpProjectID = project
+		}
+		// the client outlives the request that creates it
+		gcpHTTPClient = oauth2.NewClient(context.WithoutCancel(ctx), creds.TokenSource)
+	}
+	return gcpHTTPClient, gcpSecretManagerEndpoint, gcpProjectID, nil
+}
+
+// gcpSecretManagerAccess reads a version of a secret with the REST API.
+func gcpSecretManagerAccess(ctx context.Context, client *http.Client, endpoint, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v1/"+name+":access", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret %q: %w", name, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secret %q: %w", name, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		_ = json.Unmarshal(body, &errResp)
+		switch resp.StatusCode {
+		case http.StatusNotFound:
+			return nil, fmt.Errorf("secret not found: %q", name)
+		case http.StatusForbidden, http.StatusUnauthorized:
+			return nil, fmt.Errorf("access denied to secret %q: check IAM permissions", name)
+		}
+		msg := errResp.Error.Message
+		if msg == "" {
+			msg = strings.TrimSpace(string(body))
+		}
+		return nil, fmt.Errorf("failed to retrieve secret %q: %s: %s", name, resp.Status, msg)
+	}
+
+	var result struct {
+		Payload struct {
+			// Data is base64-encoded, which encoding/json decodes for []byte
+			Data []byte `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode secret %q: %w", name, err)
+	}
+	return result.Payload.Data, nil
+}
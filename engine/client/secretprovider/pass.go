@@ -0,0 +1,90 @@
+package secretprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+var passCache secretCache
+
+// passProvider reads secrets from pass, the standard unix password manager,
+// using its CLI. PASSWORD_STORE_DIR and the GnuPG configuration are honored.
+//
+// Format:
+// - pass://<entry>, for the password, on the first line of the entry
+// - pass://<entry>?field=<name>, for a "<name>: <value>" line of the entry
+// - pass://<entry>?all, for the whole entry
+//
+// The "ttl" query parameter sets how long the secret is cached.
+func passProvider(ctx context.Context, pathWithQuery string) ([]byte, error) {
+	entry, query, err := parsePassURI(pathWithQuery)
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := parseTTL(query, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := passCache.get(ctx, entry, ttl, func(ctx context.Context) ([]byte, error) {
+		if _, err := exec.LookPath("pass"); err != nil {
+			return nil, fmt.Errorf("unable to lookup %q: `pass` binary is not present", entry)
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "pass", "show", entry)
+		cmd.Stderr = &stderr
+		plaintext, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && stderr.Len() > 0 {
+				return nil, fmt.Errorf("unable to lookup %q: %s", entry, strings.TrimSpace(stderr.String()))
+			}
+			return nil, fmt.Errorf("unable to lookup %q: %w", entry, err)
+		}
+		return plaintext, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return passSecret(data, entry, query)
+}
+
+// parsePassURI parses the part of a pass:// URI after the scheme: the entry,
+// with a query.
+func parsePassURI(pathWithQuery string) (string, url.Values, error) {
+	parsed, err := url.Parse(pathWithQuery)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to parse pass:// URI: %w", err)
+	}
+	entry := strings.Trim(parsed.Path, "/")
+	if entry == "" {
+		return "", nil, fmt.Errorf("invalid pass entry %q", pathWithQuery)
+	}
+	return entry, parsed.Query(), nil
+}
+
+// passSecret returns the part of a pass entry selected by the query: the
+// password on its first line, a "<name>: <value>" field, or all of it.
+func passSecret(data []byte, entry string, query url.Values) ([]byte, error) {
+	if query.Has("all") {
+		return data, nil
+	}
+	password, rest, _ := bytes.Cut(data, []byte("\n"))
+	field := query.Get("field")
+	if field == "" {
+		return password, nil
+	}
+	for line := range bytes.Lines(rest) {
+		name, value, ok := bytes.Cut(bytes.TrimRight(line, "\r\n"), []byte(":"))
+		if ok && strings.EqualFold(string(bytes.TrimSpace(name)), field) {
+			return bytes.TrimSpace(value), nil
+		}
+	}
+	return nil, fmt.Errorf("field %q not found in pass entry %q", field, entry)
+}
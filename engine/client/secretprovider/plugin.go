@@ -0,0 +1,144 @@
+package secretprovider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/dagger/dagger/internal/buildkit/session/secrets"
+)
+
+// PluginSchemePrefix is the prefix of the schemes of secret provider plugins:
+// secrets of plugin+<name>://<path> are read by the dagger-secret-<name>
+// executable, found in $PATH.
+const PluginSchemePrefix = "plugin+"
+
+// PluginProtocolVersion is the version of the protocol between the client and
+// secret provider plugins.
+const PluginProtocolVersion = 1
+
+var (
+	pluginNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	pluginCache      secretCache
+)
+
+// PluginRequest is written as JSON to the stdin of a secret provider plugin,
+// which is run with the "get" argument, like git or docker credential helpers.
+type PluginRequest struct {
+	// Version is the version of the protocol.
+	Version int `json:"version"`
+	// URI is the full URI of the secret, e.g. plugin+mycorp://path?key=value.
+	URI string `json:"uri"`
+	// Path is the part of the URI after the scheme, e.g. path?key=value.
+	Path string `json:"path"`
+}
+
+// PluginResponse is written as JSON to the stdout of a secret provider plugin.
+//
+// A plugin that fails exits with a non-zero status; it can still write a
+// response with an error, or else its stderr is reported.
+type PluginResponse struct {
+	// Value is the value of the secret.
+	Value string `json:"value,omitempty"`
+	// Encoding is the encoding of the value: empty for plain text, or
+	// "base64" for binary secrets.
+	Encoding string `json:"encoding,omitempty"`
+
+	// Error is the reason why the secret couldn't be read.
+	Error string `json:"error,omitempty"`
+	// NotFound is set if the secret doesn't exist, so that optional secrets
+	// can be skipped.
+	NotFound bool `json:"notFound,omitempty"`
+}
+
+// pluginResolver returns the resolver of a secret provider plugin.
+//
+// The "ttl" query parameter sets how long a secret is cached, like with the
+// built-in providers; it's passed to the plugin as well.
+func pluginResolver(scheme string) (SecretResolver, error) {
+	name := strings.TrimPrefix(scheme, PluginSchemePrefix)
+	if !pluginNameRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid secret provider plugin name: %q", name)
+	}
+	bin := "dagger-secret-" + name
+
+	return func(ctx context.Context, pathWithQuery string) ([]byte, error) {
+		parsed, err := url.Parse(pathWithQuery)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s:// URI: %w", scheme, err)
+		}
+		ttl, err := parseTTL(parsed.Query(), parsed.Path)
+		if err != nil {
+			return nil, err
+		}
+		uri := scheme + "://" + pathWithQuery
+		return pluginCache.get(ctx, uri, ttl, func(ctx context.Context) ([]byte, error) {
+			return pluginGet(ctx, bin, PluginRequest{
+				Version: PluginProtocolVersion,
+				URI:     uri,
+				Path:    pathWithQuery,
+			})
+		})
+	}, nil
+}
+
+func pluginGet(ctx context.Context, bin string, req PluginRequest) ([]byte, error) {
+	path, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, fmt.Errorf("secret provider plugin %q not found in $PATH: %w", bin, err)
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "get")
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	var resp PluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		if runErr != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("secret provider plugin %q failed: %s", bin, msg)
+			}
+			return nil, fmt.Errorf("secret provider plugin %q failed: %w", bin, runErr)
+		}
+		return nil, fmt.Errorf("secret provider plugin %q returned an invalid response: %w", bin, err)
+	}
+
+	switch {
+	case resp.NotFound:
+		msg := resp.Error
+		if msg == "" {
+			msg = req.URI
+		}
+		return nil, fmt.Errorf("%s: %w", msg, secrets.ErrNotFound)
+	case resp.Error != "":
+		return nil, fmt.Errorf("secret provider plugin %q failed: %s", bin, resp.Error)
+	case runErr != nil:
+		return nil, fmt.Errorf("secret provider plugin %q failed: %w", bin, runErr)
+	}
+
+	switch resp.Encoding {
+	case "":
+		return []byte(resp.Value), nil
+	case "base64":
+		data, err := base64.StdEncoding.DecodeString(resp.Value)
+		if err != nil {
+			return nil, fmt.Errorf("secret provider plugin %q returned an invalid base64 value: %w", bin, err)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("secret provider plugin %q returned an unsupported encoding: %q", bin, resp.Encoding)
+	}
+}
@@ -24,6 +24,10 @@ var resolvers = map[string]SecretResolver{
 	"libsecret": libsecretProvider,
 	"aws+sm":    awsSecretManagerProvider,
 	"aws+ps":    awsParameterStoreProvider,
+	"gcp+sm":    gcpSecretManagerProvider,
+	"azure+kv":  azureKeyVaultProvider,
+	"sops":      sopsProvider,
+	"pass":      passProvider,
 }
 
 func ResolverForID(id string) (SecretResolver, string, error) {
@@ -32,6 +36,14 @@ func ResolverForID(id string) (SecretResolver, string, error) {
 		return nil, "", fmt.Errorf("parse %q: malformed id", id)
 	}
 
+	if strings.HasPrefix(scheme, PluginSchemePrefix) {
+		resolver, err := pluginResolver(scheme)
+		if err != nil {
+			return nil, "", err
+		}
+		return resolver, pathWithQuery, nil
+	}
+
 	resolver, ok := resolvers[scheme]
 	if !ok {
 		return nil, "", fmt.Errorf("unsupported secret provider: %q", scheme)
@@ -0,0 +1,206 @@
+package secretprovider
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGCPSecretName(t *testing.T) {
+	for _, tc := range []struct {
+		name                     string
+		project, secret, version string
+		err                      string
+	}{
+		{name: "my-secret", secret: "my-secret", version: "latest"},
+		{name: "my-project/my-secret", project: "my-project", secret: "my-secret", version: "latest"},
+		{name: "my-project/my-secret/3", project: "my-project", secret: "my-secret", version: "3"},
+		{name: "my-project/", err: "missing secret"},
+		{name: "a/b/c/d", err: "expected <project>/<secret>[/<version>]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			project, secret, version, err := parseGCPSecretName(tc.name)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.project, project)
+			require.Equal(t, tc.secret, secret)
+			require.Equal(t, tc.version, version)
+		})
+	}
+}
+
+func TestParseAzureKeyVaultURI(t *testing.T) {
+	for _, tc := range []struct {
+		uri                    string
+		vault, secret, version string
+		field                  string
+		err                    string
+	}{
+		{uri: "my-vault/my-secret", vault: "my-vault", secret: "my-secret"},
+		{uri: "my-vault/my-secret/0123456789abcdef", vault: "my-vault", secret: "my-secret", version: "0123456789abcdef"},
+		{uri: "my-vault/my-secret?field=password", vault: "my-vault", secret: "my-secret", field: "password"},
+		{uri: "my-vault.vault.azure.cn/my-secret", vault: "my-vault.vault.azure.cn", secret: "my-secret"},
+		{uri: "my-vault", err: "expected <vault>/<secret>[/<version>]"},
+		{uri: "my-vault/a/b/c", err: "expected <vault>/<secret>[/<version>]"},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			vault, secret, version, query, err := parseAzureKeyVaultURI(tc.uri)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.vault, vault)
+			require.Equal(t, tc.secret, secret)
+			require.Equal(t, tc.version, version)
+			require.Equal(t, tc.field, query.Get("field"))
+		})
+	}
+}
+
+func TestAzureKeyVaultURL(t *testing.T) {
+	for _, tc := range []struct {
+		vault string
+		url   string
+	}{
+		{vault: "my-vault", url: "https://my-vault.vault.azure.net"},
+		{vault: "my-vault.vault.azure.net", url: "https://my-vault.vault.azure.net"},
+		{vault: "My-Vault.Vault.Azure.CN", url: "https://my-vault.vault.azure.cn"},
+		{vault: "my-vault.vault.usgovcloudapi.net", url: "https://my-vault.vault.usgovcloudapi.net"},
+		{vault: "my-vault.vault.microsoftazure.de", url: "https://my-vault.vault.microsoftazure.de"},
+		// tokens must not be sent to other hosts
+		{vault: "attacker.example.com"},
+		{vault: "vault.azure.net"},
+		{vault: ".vault.azure.net"},
+		{vault: "my-vault.vault.azure.net.example.com"},
+		{vault: "evil.my-vault.vault.azure.net"},
+		{vault: "my-vault.vault.azure.net:8443"},
+	} {
+		t.Run(tc.vault, func(t *testing.T) {
+			u, err := azureKeyVaultURL(tc.vault)
+			if tc.url == "" {
+				require.ErrorContains(t, err, "invalid vault")
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.url, u)
+		})
+	}
+}
+
+func TestSopsArgs(t *testing.T) {
+	for _, tc := range []struct {
+		key  string
+		args []string
+	}{
+		{key: "", args: []string{"--decrypt", "secrets.yaml"}},
+		{key: "password", args: []string{"--decrypt", "--extract", `["password"]`, "secrets.yaml"}},
+		{key: "database.hosts.0", args: []string{"--decrypt", "--extract", `["database"]["hosts"][0]`, "secrets.yaml"}},
+		{key: `["a.b"]`, args: []string{"--decrypt", "--extract", `["a.b"]`, "secrets.yaml"}},
+	} {
+		t.Run(tc.key, func(t *testing.T) {
+			require.Equal(t, tc.args, sopsArgs("secrets.yaml", tc.key))
+		})
+	}
+}
+
+func TestPassSecret(t *testing.T) {
+	const data = "hunter2\nusername: admin\nURL: https://example.com\n"
+	for _, tc := range []struct {
+		uri    string
+		entry  string
+		secret string
+		err    string
+	}{
+		{uri: "work/db", entry: "work/db", secret: "hunter2"},
+		{uri: "/work/db/", entry: "work/db", secret: "hunter2"},
+		{uri: "work/db?field=username", entry: "work/db", secret: "admin"},
+		{uri: "work/db?field=url", entry: "work/db", secret: "https://example.com"},
+		{uri: "work/db?all", entry: "work/db", secret: data},
+		{uri: "work/db?field=token", entry: "work/db", err: `field "token" not found`},
+		{uri: "?field=token", err: "invalid pass entry"},
+	} {
+		t.Run(tc.uri, func(t *testing.T) {
+			entry, query, err := parsePassURI(tc.uri)
+			if err == nil {
+				require.Equal(t, tc.entry, entry)
+				var secret []byte
+				secret, err = passSecret([]byte(data), entry, query)
+				if err == nil {
+					require.Equal(t, tc.secret, string(secret))
+				}
+			}
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestParseTTL(t *testing.T) {
+	ttl, err := parseTTL(url.Values{"ttl": {"1m"}}, "secret")
+	require.NoError(t, err)
+	require.Equal(t, "1m0s", ttl.String())
+
+	_, err = parseTTL(url.Values{"ttl": {"soon"}}, "secret")
+	require.ErrorContains(t, err, `invalid ttl "soon"`)
+}
+
+func TestSecretCache(t *testing.T) {
+	ctx := context.Background()
+	var cache secretCache
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	slowFetch := func(ctx context.Context) ([]byte, error) {
+		fetches.Add(1)
+		<-release
+		return []byte("slow"), nil
+	}
+
+	// concurrent gets of the same key fetch it once
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, err := cache.get(ctx, "slow", 0, slowFetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "slow", string(data))
+		}()
+	}
+
+	// a slow fetch doesn't block the other keys
+	data, err := cache.get(ctx, "fast", time.Hour, func(context.Context) ([]byte, error) {
+		return []byte("fast"), nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "fast", string(data))
+
+	close(release)
+	wg.Wait()
+	require.Equal(t, int32(1), fetches.Load())
+
+	data, err = cache.get(ctx, "slow", 0, slowFetch)
+	require.NoError(t, err)
+	require.Equal(t, "slow", string(data))
+	require.Equal(t, int32(1), fetches.Load())
+
+	// expired secrets are fetched again
+	_, err = cache.get(ctx, "expiring", time.Nanosecond, slowFetch)
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, err = cache.get(ctx, "expiring", time.Nanosecond, slowFetch)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), fetches.Load())
+}
@@ -0,0 +1,94 @@
+package secretprovider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/dagger/dagger/engine/client/pathutil"
+)
+
+var sopsCache secretCache
+
+// sopsProvider decrypts secrets from sops-encrypted YAML or JSON files, using
+// the sops CLI, so that the keys and configuration of sops are all supported:
+// age keys (SOPS_AGE_KEY_FILE), PGP keys from the GnuPG keyring, etc.
+//
+// Format:
+// - sops://<path>, for the whole decrypted file
+// - sops://<path>#<key>, for a value of the file, e.g. #database.password
+//
+// Keys are dot-separated paths in the file, where numbers are list indexes.
+// A key starting with "[" is passed as is to "sops --extract". The "ttl" query
+// parameter sets how long the secret is cached.
+func sopsProvider(ctx context.Context, pathWithQuery string) ([]byte, error) {
+	parsed, err := url.Parse(pathWithQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sops:// URI: %w", err)
+	}
+	ttl, err := parseTTL(parsed.Query(), parsed.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	path, err := pathutil.ExpandHomeDir(homeDir, parsed.Path)
+	if err != nil {
+		return nil, err
+	}
+	args := sopsArgs(path, parsed.Fragment)
+
+	return sopsCache.get(ctx, path+"#"+parsed.Fragment, ttl, func(ctx context.Context) ([]byte, error) {
+		if _, err := exec.LookPath("sops"); err != nil {
+			return nil, fmt.Errorf("unable to decrypt %q: `sops` binary is not present", path)
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "sops", args...)
+		cmd.Stderr = &stderr
+		plaintext, err := cmd.Output()
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && stderr.Len() > 0 {
+				return nil, fmt.Errorf("unable to decrypt %q: %s", pathWithQuery, strings.TrimSpace(stderr.String()))
+			}
+			return nil, fmt.Errorf("unable to decrypt %q: %w", pathWithQuery, err)
+		}
+		return plaintext, nil
+	})
+}
+
+// sopsArgs returns the arguments of sops to decrypt a file, or only a key of
+// it.
+func sopsArgs(path, key string) []string {
+	args := []string{"--decrypt"}
+	if key != "" {
+		args = append(args, "--extract", sopsExtractPath(key))
+	}
+	return append(args, path)
+}
+
+// sopsExtractPath converts a dot-separated key to a sops --extract path, e.g.
+// database.hosts.0 to ["database"]["hosts"][0].
+func sopsExtractPath(key string) string {
+	if strings.HasPrefix(key, "[") {
+		return key
+	}
+	var path strings.Builder
+	for part := range strings.SplitSeq(key, ".") {
+		if _, err := strconv.Atoi(part); err == nil {
+			fmt.Fprintf(&path, "[%s]", part)
+		} else {
+			fmt.Fprintf(&path, "[%s]", strconv.Quote(part))
+		}
+	}
+	return path.String()
+}
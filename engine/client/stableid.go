@@ -11,7 +11,10 @@ import (
 	"github.com/dagger/dagger/internal/buildkit/identity"
 )
 
-const StableIDFileName = "stable_client_id"
+const (
+	StableIDFileName   = "stable_client_id"
+	HistoryKeyFileName = "history_key"
+)
 
 // GetHostStableID returns a random ID that's persisted in the caller's XDG state directory.
 // It's currently used to identify clients that are executing on the same host in order to
@@ -25,12 +28,30 @@ func GetHostStableID(lg *slog.Logger) string {
 	return id
 }
 
+// GetHostHistoryKey returns a random secret that's persisted in the caller's XDG state
+// directory. Unlike the stable ID, it's never shared with nested clients or telemetry,
+// so the engine can use it to check that a client can read the history of a run.
+func GetHostHistoryKey(lg *slog.Logger) string {
+	key, err := internalGetStateID(filepath.Join(xdg.StateHome, "dagger"), HistoryKeyFileName)
+	if err != nil {
+		lg.Warn("failed to get history key, defaulting to random value", "error", err)
+		return identity.NewID()
+	}
+	return key
+}
+
 func internalGetStableID(parentDirPath string) (string, error) {
+	return internalGetStateID(parentDirPath, StableIDFileName)
+}
+
+// internalGetStateID returns the random ID persisted in a file of parentDirPath,
+// creating it if needed. The file is only readable by its owner.
+func internalGetStateID(parentDirPath, fileName string) (string, error) {
 	if parentDirPath == "" {
 		return "", errors.New("parentDirPath is not set")
 	}
 
-	stableIDPath := filepath.Join(parentDirPath, StableIDFileName)
+	stableIDPath := filepath.Join(parentDirPath, fileName)
 	if stableID, err := os.ReadFile(stableIDPath); err == nil {
 		// already exists
 		return string(stableID), nil
@@ -45,7 +66,7 @@ func internalGetStableID(parentDirPath string) (string, error) {
 	// there's a race in the (obscure) case of clients concurrently running here,
 	// but the worst case is some of the clients using different IDs, which is
 	// just a temporary mild performance deficiency, so not worth more complication
-	tmpFile, err := os.CreateTemp(parentDirPath, StableIDFileName)
+	tmpFile, err := os.CreateTemp(parentDirPath, fileName)
 	if err != nil {
 		return "", fmt.Errorf("failed to create stable ID temp file: %w", err)
 	}
@@ -45,12 +45,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.selectMetricsSinceStmt, err = db.PrepareContext(ctx, selectMetricsSince); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectMetricsSince: %w", err)
 	}
+	if q.selectPropertyStmt, err = db.PrepareContext(ctx, selectProperty); err != nil {
+		return nil, fmt.Errorf("error preparing query SelectProperty: %w", err)
+	}
 	if q.selectSpanStmt, err = db.PrepareContext(ctx, selectSpan); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectSpan: %w", err)
 	}
 	if q.selectSpansSinceStmt, err = db.PrepareContext(ctx, selectSpansSince); err != nil {
 		return nil, fmt.Errorf("error preparing query SelectSpansSince: %w", err)
 	}
+	if q.setPropertyStmt, err = db.PrepareContext(ctx, setProperty); err != nil {
+		return nil, fmt.Errorf("error preparing query SetProperty: %w", err)
+	}
 	return &q, nil
 }
 
@@ -91,6 +97,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing selectMetricsSinceStmt: %w", cerr)
 		}
 	}
+	if q.selectPropertyStmt != nil {
+		if cerr := q.selectPropertyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing selectPropertyStmt: %w", cerr)
+		}
+	}
 	if q.selectSpanStmt != nil {
 		if cerr := q.selectSpanStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing selectSpanStmt: %w", cerr)
@@ -101,6 +112,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing selectSpansSinceStmt: %w", cerr)
 		}
 	}
+	if q.setPropertyStmt != nil {
+		if cerr := q.setPropertyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setPropertyStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -147,8 +163,10 @@ type Queries struct {
 	selectLogsSinceStmt       *sql.Stmt
 	selectLogsTimespanStmt    *sql.Stmt
 	selectMetricsSinceStmt    *sql.Stmt
+	selectPropertyStmt        *sql.Stmt
 	selectSpanStmt            *sql.Stmt
 	selectSpansSinceStmt      *sql.Stmt
+	setPropertyStmt           *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
@@ -162,7 +180,9 @@ func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 		selectLogsSinceStmt:       q.selectLogsSinceStmt,
 		selectLogsTimespanStmt:    q.selectLogsTimespanStmt,
 		selectMetricsSinceStmt:    q.selectMetricsSinceStmt,
+		selectPropertyStmt:        q.selectPropertyStmt,
 		selectSpanStmt:            q.selectSpanStmt,
 		selectSpansSinceStmt:      q.selectSpansSinceStmt,
+		setPropertyStmt:           q.setPropertyStmt,
 	}
 }
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -21,15 +22,29 @@ import (
 type DBs struct {
 	Root string
 
+	// KeepDuration is the time after which the database of an inactive client
+	// is considered garbage and can be deleted. Defaults to
+	// DefaultKeepDuration.
+	KeepDuration time.Duration
+
+	// MaxUsedSpace is the maximum amount of disk space used by the databases.
+	// Beyond it, the oldest databases of inactive clients are deleted.
+	// Defaults to DefaultMaxUsedSpace.
+	MaxUsedSpace int64
+
 	open map[string]*DB
 	mu   sync.RWMutex // mutex just for reading writing map
 
 	perDBLock *locker.Locker // mutex for each DB
 }
 
-// CollectGarbageAfter is the time after which a database is considered
-// garbage and can be deleted.
-const CollectGarbageAfter = time.Hour
+// DefaultKeepDuration is the default time after which a database is
+// considered garbage and can be deleted.
+const DefaultKeepDuration = time.Hour
+
+// DefaultMaxUsedSpace is the default maximum amount of disk space used by the
+// databases, so that keeping them longer stays cheap on busy engines.
+const DefaultMaxUsedSpace = 2 << 30 // 2GiB
 
 func NewDBs(root string) *DBs {
 	return &DBs{
@@ -77,10 +92,6 @@ func (dbs *DBs) Open(ctx context.Context, clientID string) (_ *DB, rerr error) {
 			return nil, fmt.Errorf("mkdir %s: %w", filepath.Dir(dbPath), err)
 		}
 
-		// check whether the file exists already
-		_, statErr := os.Lstat(dbPath)
-		alreadyExists := statErr == nil
-
 		connURL := &url.URL{
 			Scheme: "file",
 			Host:   "",
@@ -105,10 +116,10 @@ func (dbs *DBs) Open(ctx context.Context, clientID string) (_ *DB, rerr error) {
 
 		db.inner = sqlDB
 
-		if !alreadyExists {
-			if _, err := db.inner.Exec(Schema); err != nil {
-				return nil, fmt.Errorf("migrate: %w", err)
-			}
+		// the schema only creates missing tables, so this also migrates the
+		// databases created by older engines
+		if _, err := db.inner.Exec(Schema); err != nil {
+			return nil, fmt.Errorf("migrate: %w", err)
 		}
 	} else {
 		lg.Trace("reusing open client DB", "clientID", clientID)
@@ -156,47 +167,52 @@ func (dbs *DBs) close(db *DB, lg *slog.Logger) (rerr error) {
 	return rerr
 }
 
-// GC removes databases that are older than CollectGarbageAfter based on mtime.
+// GC removes the databases of inactive clients that are older than
+// KeepDuration based on mtime, along with the oldest ones exceeding
+// MaxUsedSpace.
 func (dbs *DBs) GC(keep map[string]bool) error {
-	ents, err := os.ReadDir(dbs.Root)
+	files, err := dbs.list()
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			// no databases found
-			return nil
-		}
-		return fmt.Errorf("readdir %s: %w", dbs.Root, err)
+		return err
+	}
+	keepDuration := dbs.KeepDuration
+	if keepDuration == 0 {
+		keepDuration = DefaultKeepDuration
+	}
+	maxUsedSpace := dbs.MaxUsedSpace
+	if maxUsedSpace == 0 {
+		maxUsedSpace = DefaultMaxUsedSpace
 	}
 	var removed []string
 	var errs error
-	for _, ent := range ents {
-		info, err := ent.Info()
-		if err != nil {
-			return fmt.Errorf("stat %s: %w", ent.Name(), err)
-		}
-		clientID, _, ok := strings.Cut(ent.Name(), ".")
-		if !ok {
-			continue
-		}
-		if keep[clientID] {
+	var used int64
+	for _, f := range files {
+		used += f.size
+		if keep[f.clientID] {
 			// client still active; keep it around
 			continue
 		}
-		if time.Since(info.ModTime()) < CollectGarbageAfter {
-			// DB is still fresh; keep
+		expired := time.Since(f.modTime) >= keepDuration
+		overflow := used > maxUsedSpace
+		if !expired && !overflow {
+			// DB is still fresh and there's room for it; keep
 			continue
 		}
 		dbs.mu.RLock()
-		_, openBySomeone := dbs.open[clientID]
+		_, openBySomeone := dbs.open[f.clientID]
 		dbs.mu.RUnlock()
 		if openBySomeone {
 			// DB is still open by someone; keep it but log this since this is a weird case, possibly indicative of a leak
-			slog.Warn("skipping garbage collection of client DB that is still open", "clientID", clientID)
+			slog.Warn("skipping garbage collection of client DB that is still open", "clientID", f.clientID)
 			continue
 		}
-		if err := os.RemoveAll(filepath.Join(dbs.Root, ent.Name())); err != nil {
-			errs = errors.Join(errs, fmt.Errorf("remove %s: %w", ent.Name(), err))
+		for _, name := range f.names {
+			if err := os.RemoveAll(filepath.Join(dbs.Root, name)); err != nil {
+				errs = errors.Join(errs, fmt.Errorf("remove %s: %w", name, err))
+			}
+			removed = append(removed, name)
 		}
-		removed = append(removed, ent.Name())
+		used -= f.size
 	}
 	if len(removed) > 0 {
 		slog.ExtraDebug("removed client DBs", "clients", removed)
@@ -204,6 +220,57 @@ func (dbs *DBs) GC(keep map[string]bool) error {
 	return errs
 }
 
+// dbFiles are the files of a client's database, including its WAL.
+type dbFiles struct {
+	clientID string
+	names    []string
+	size     int64
+	modTime  time.Time
+}
+
+// list returns the files of each database, most recently modified first.
+func (dbs *DBs) list() ([]*dbFiles, error) {
+	ents, err := os.ReadDir(dbs.Root)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// no databases found
+			return nil, nil
+		}
+		return nil, fmt.Errorf("readdir %s: %w", dbs.Root, err)
+	}
+	byClient := map[string]*dbFiles{}
+	var files []*dbFiles
+	for _, ent := range ents {
+		clientID, _, ok := strings.Cut(ent.Name(), ".")
+		if !ok {
+			continue
+		}
+		info, err := ent.Info()
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				// removed in the meantime, e.g. the WAL of a closed DB
+				continue
+			}
+			return nil, fmt.Errorf("stat %s: %w", ent.Name(), err)
+		}
+		f, ok := byClient[clientID]
+		if !ok {
+			f = &dbFiles{clientID: clientID}
+			byClient[clientID] = f
+			files = append(files, f)
+		}
+		f.names = append(f.names, ent.Name())
+		f.size += info.Size()
+		if info.ModTime().After(f.modTime) {
+			f.modTime = info.ModTime()
+		}
+	}
+	slices.SortStableFunc(files, func(a, b *dbFiles) int {
+		return b.modTime.Compare(a.modTime)
+	})
+	return files, nil
+}
+
 func (dbs *DBs) path(clientID string) string {
 	return filepath.Join(dbs.Root, clientID+".db")
 }
@@ -2,7 +2,9 @@ package clientdb
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -85,3 +87,35 @@ func TestOpenWithNonDirRoot(t *testing.T) {
 	_, err := dbs.Open(t.Context(), "client1")
 	require.Error(t, err)
 }
+
+func TestGC(t *testing.T) {
+	root := t.TempDir()
+	dbs := NewDBs(root)
+	dbs.KeepDuration = time.Hour
+	dbs.MaxUsedSpace = 1500
+
+	age := func(clientID string, d time.Duration) {
+		modTime := time.Now().Add(-d)
+		require.NoError(t, os.Chtimes(filepath.Join(root, clientID+".db"), modTime, modTime))
+	}
+	for _, clientID := range []string{"active", "expired", "recent", "older", "oldest"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, clientID+".db"), make([]byte, 1000), 0600))
+	}
+	age("active", 2*time.Hour)
+	age("expired", 2*time.Hour)
+	age("recent", time.Minute)
+	age("older", 2*time.Minute)
+	age("oldest", 3*time.Minute)
+
+	require.NoError(t, dbs.GC(map[string]bool{"active": true}))
+
+	// the expired DB is removed regardless of space, and only the most recent
+	// of the others fits; the active one is kept no matter what
+	ents, err := os.ReadDir(root)
+	require.NoError(t, err)
+	var names []string
+	for _, ent := range ents {
+		names = append(names, ent.Name())
+	}
+	require.ElementsMatch(t, []string{"active.db", "recent.db"}, names)
+}
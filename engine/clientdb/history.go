@@ -0,0 +1,184 @@
+package clientdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"dagger.io/dagger/telemetry"
+	"go.opentelemetry.io/otel/codes"
+	otlpcommonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+)
+
+// Properties recorded for the clients started by a command, which are listed
+// in the history of runs.
+const (
+	CommandProperty        = "command"
+	HistoryKeyHashProperty = "history_key_hash"
+)
+
+// Run summarizes the telemetry of a client started by a command.
+type Run struct {
+	ClientID string
+	Command  string
+	// HistoryKeyHash is the hash of the history key of the client, which
+	// must be presented to read the run.
+	HistoryKeyHash string
+
+	Start time.Time
+	End   time.Time
+
+	// Failed is true if any of the top-level spans of the client failed.
+	Failed bool
+
+	// Calls is the number of spans of API calls and operations, of which
+	// CachedCalls hit the cache.
+	Calls       int
+	CachedCalls int
+}
+
+// Runs returns the runs of the clients with a database, most recent first.
+// Only the runs matching the filter are returned, if any, and at most limit
+// if it's positive.
+//
+// The filter is called before the spans of a run are summarized, with only
+// its ClientID, Command and HistoryKeyHash set, so that runs filtered out are
+// cheap to skip.
+func (dbs *DBs) Runs(ctx context.Context, limit int, filter func(*Run) bool) ([]*Run, error) {
+	files, err := dbs.list()
+	if err != nil {
+		return nil, err
+	}
+	runs := []*Run{}
+	for _, f := range files {
+		if limit > 0 && len(runs) >= limit {
+			break
+		}
+		run, err := dbs.run(ctx, f.clientID, filter)
+		if err != nil {
+			return nil, err
+		}
+		if run == nil {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+// Run returns the run of a client, or nil if the client doesn't have a
+// database or wasn't started by a command.
+func (dbs *DBs) Run(ctx context.Context, clientID string) (*Run, error) {
+	return dbs.run(ctx, clientID, nil)
+}
+
+func (dbs *DBs) run(ctx context.Context, clientID string, filter func(*Run) bool) (*Run, error) {
+	if !dbs.Exists(clientID) {
+		return nil, nil
+	}
+	db, err := dbs.Open(ctx, clientID)
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", clientID, err)
+	}
+	defer db.Close()
+	run, err := db.runProperties(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("summarize %s: %w", clientID, err)
+	}
+	if run == nil || (filter != nil && !filter(run)) {
+		return nil, nil
+	}
+	if err := db.summarizeRun(ctx, run); err != nil {
+		return nil, fmt.Errorf("summarize %s: %w", clientID, err)
+	}
+	return run, nil
+}
+
+// Exists returns whether the client has a database.
+func (dbs *DBs) Exists(clientID string) bool {
+	_, err := os.Stat(dbs.path(clientID))
+	return err == nil
+}
+
+// runProperties returns the run of the client with only the properties of
+// its command set, or nil if it wasn't started by a command.
+func (db *DB) runProperties(ctx context.Context) (*Run, error) {
+	run := &Run{ClientID: db.clientID}
+	var err error
+	run.Command, err = db.SelectProperty(ctx, CommandProperty)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	run.HistoryKeyHash, err = db.SelectProperty(ctx, HistoryKeyHashProperty)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	return run, nil
+}
+
+// summarizeRun sets the times and counts of a run from the spans of the
+// client.
+func (db *DB) summarizeRun(ctx context.Context, run *Run) error {
+	// spans are stored every time they're updated, so only keep their last
+	// state
+	spans := map[string]Span{}
+	var since int64
+	for {
+		page, err := db.SelectSpansSince(ctx, SelectSpansSinceParams{
+			ID:    since,
+			Limit: 1000,
+		})
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, span := range page {
+			spans[span.SpanID] = span
+			since = span.ID
+		}
+	}
+
+	for _, span := range spans {
+		start := time.Unix(0, span.StartTime)
+		if run.Start.IsZero() || start.Before(run.Start) {
+			run.Start = start
+		}
+		if span.EndTime.Valid {
+			if end := time.Unix(0, span.EndTime.Int64); end.After(run.End) {
+				run.End = end
+			}
+		}
+		if _, hasParent := spans[span.ParentSpanID.String]; !hasParent &&
+			codes.Code(span.StatusCode) == codes.Error {
+			run.Failed = true
+		}
+		var attrs []*otlpcommonv1.KeyValue
+		if err := UnmarshalProtoJSONs(span.Attributes, &otlpcommonv1.KeyValue{}, &attrs); err != nil {
+			return fmt.Errorf("span %s: %w", span.SpanID, err)
+		}
+		var isCall, cached bool
+		for _, attr := range attrs {
+			switch attr.GetKey() {
+			case telemetry.DagDigestAttr:
+				isCall = true
+			case telemetry.CachedAttr:
+				cached = attr.GetValue().GetBoolValue()
+			}
+		}
+		if isCall {
+			run.Calls++
+			if cached {
+				run.CachedCalls++
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package clientdb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"dagger.io/dagger/telemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestRuns(t *testing.T) {
+	ctx := t.Context()
+	dbs := NewDBs(t.TempDir())
+
+	start := time.Now().Add(-time.Minute)
+	insertSpan := func(db *DB, spanID, parentID string, offset time.Duration, status codes.Code, attrs ...attribute.KeyValue) {
+		attrsJSON, err := MarshalProtoJSONs(telemetry.KeyValues(attrs))
+		require.NoError(t, err)
+		_, err = db.InsertSpan(ctx, InsertSpanParams{
+			TraceID:      "0123456789abcdef0123456789abcdef",
+			SpanID:       spanID,
+			ParentSpanID: sql.NullString{String: parentID, Valid: parentID != ""},
+			Name:         spanID,
+			Kind:         "internal",
+			StartTime:    start.UnixNano(),
+			EndTime:      sql.NullInt64{Int64: start.Add(offset).UnixNano(), Valid: true},
+			Attributes:   attrsJSON,
+			StatusCode:   int64(status),
+		})
+		require.NoError(t, err)
+	}
+
+	db, err := dbs.Open(ctx, "run")
+	require.NoError(t, err)
+	require.NoError(t, db.SetProperty(ctx, SetPropertyParams{Key: CommandProperty, Value: "dagger call build"}))
+	require.NoError(t, db.SetProperty(ctx, SetPropertyParams{Key: HistoryKeyHashProperty, Value: "me"}))
+	insertSpan(db, "call1", "cli", time.Second, codes.Unset,
+		attribute.String(telemetry.DagDigestAttr, "sha256:1"))
+	// spans are updated by inserting them again
+	insertSpan(db, "call1", "cli", 2*time.Second, codes.Error,
+		attribute.String(telemetry.DagDigestAttr, "sha256:1"))
+	insertSpan(db, "call2", "call1", 3*time.Second, codes.Ok,
+		attribute.String(telemetry.DagDigestAttr, "sha256:2"),
+		attribute.Bool(telemetry.CachedAttr, true))
+	insertSpan(db, "other", "call1", time.Second, codes.Error)
+	require.NoError(t, db.Close())
+
+	// clients not started by a command aren't runs
+	nested, err := dbs.Open(ctx, "nested")
+	require.NoError(t, err)
+	insertSpan(nested, "call3", "call2", time.Second, codes.Ok)
+	require.NoError(t, nested.Close())
+
+	runs, err := dbs.Runs(ctx, 0, nil)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	run := runs[0]
+	require.Equal(t, "run", run.ClientID)
+	require.Equal(t, "dagger call build", run.Command)
+	require.Equal(t, "me", run.HistoryKeyHash)
+	require.True(t, run.Failed)
+	require.Equal(t, 2, run.Calls)
+	require.Equal(t, 1, run.CachedCalls)
+	require.Equal(t, start.UnixNano(), run.Start.UnixNano())
+	require.Equal(t, start.Add(3*time.Second).UnixNano(), run.End.UnixNano())
+
+	runs, err = dbs.Runs(ctx, 0, func(run *Run) bool {
+		// runs are filtered before being summarized
+		require.Zero(t, run.Calls)
+		return run.HistoryKeyHash == "someone else"
+	})
+	require.NoError(t, err)
+	require.Empty(t, runs)
+
+	run, err = dbs.Run(ctx, "missing")
+	require.NoError(t, err)
+	require.Nil(t, run)
+}
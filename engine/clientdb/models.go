@@ -27,6 +27,11 @@ type Metric struct {
 	Data []byte
 }
 
+type Property struct {
+	Key   string
+	Value string
+}
+
 type Span struct {
 	ID                     int64
 	TraceID                string
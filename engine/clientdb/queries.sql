@@ -150,3 +150,20 @@ ORDER BY
 LIMIT
   ?;
 ;
+
+-- name: SetProperty :exec
+INSERT INTO
+  properties (key, value)
+VALUES
+  (?, ?) ON CONFLICT (key) DO
+UPDATE
+SET
+  value = excluded.value;
+
+-- name: SelectProperty :one
+SELECT
+  value
+FROM
+  properties
+WHERE
+  key = ?;
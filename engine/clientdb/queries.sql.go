@@ -387,6 +387,22 @@ func (q *Queries) SelectMetricsSince(ctx context.Context, arg SelectMetricsSince
 	return items, nil
 }
 
+const selectProperty = `-- name: SelectProperty :one
+SELECT
+  value
+FROM
+  properties
+WHERE
+  key = ?
+`
+
+func (q *Queries) SelectProperty(ctx context.Context, key string) (string, error) {
+	row := q.queryRow(ctx, q.selectPropertyStmt, selectProperty, key)
+	var value string
+	err := row.Scan(&value)
+	return value, err
+}
+
 const selectSpan = `-- name: SelectSpan :one
 SELECT
   id, trace_id, span_id, trace_state, parent_span_id, flags, name, kind, start_time, end_time, attributes, dropped_attributes_count, events, dropped_events_count, links, dropped_links_count, status_code, status_message, instrumentation_scope, resource, resource_schema_url
@@ -493,3 +509,23 @@ func (q *Queries) SelectSpansSince(ctx context.Context, arg SelectSpansSincePara
 	}
 	return items, nil
 }
+
+const setProperty = `-- name: SetProperty :exec
+INSERT INTO
+  properties (key, value)
+VALUES
+  (?, ?) ON CONFLICT (key) DO
+UPDATE
+SET
+  value = excluded.value
+`
+
+type SetPropertyParams struct {
+	Key   string
+	Value string
+}
+
+func (q *Queries) SetProperty(ctx context.Context, arg SetPropertyParams) error {
+	_, err := q.exec(ctx, q.setPropertyStmt, setProperty, arg.Key, arg.Value)
+	return err
+}
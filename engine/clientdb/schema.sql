@@ -47,3 +47,10 @@ CREATE TABLE IF NOT EXISTS metrics (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     data BLOB -- JSON encoded otlpmetricsv1.ResourceMetrics
 ) STRICT;
+
+-- Properties of the client, like the command it was started with, which are
+-- used to browse the history of past clients.
+CREATE TABLE IF NOT EXISTS properties (
+    key TEXT PRIMARY KEY,
+    value TEXT NOT NULL
+) STRICT;
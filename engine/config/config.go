@@ -33,6 +33,14 @@ type Config struct {
 	// Registries configures custom registry mirrors, root CAs, and
 	// insecure/HTTP access.
 	Registries map[string]RegistryConfig `json:"registries,omitempty"`
+
+	// LLM configures the LLM endpoints and model aliases available to
+	// clients, in addition to the ones configured by their environment.
+	LLM *LLMConfig `json:"llm,omitempty"`
+
+	// History configures how long the telemetry of past runs is kept, to be
+	// browsed with "dagger history".
+	History *HistoryConfig `json:"history,omitempty"`
 }
 
 type LogLevel string
@@ -89,6 +97,16 @@ type RegistryConfig struct {
 	RootCAs   []string `json:"ca"`
 }
 
+type HistoryConfig struct {
+	// KeepDuration is the amount of time to keep the telemetry of a run after
+	// it completes. Defaults to 1 hour.
+	KeepDuration Duration `json:"keepDuration,omitempty"`
+
+	// MaxUsedSpace is the maximum amount of disk space used by the telemetry
+	// of past runs. Beyond it, the oldest runs are removed. Defaults to 2GB.
+	MaxUsedSpace DiskSpace `json:"maxUsedSpace,omitempty"`
+}
+
 type GCConfig struct {
 	// Enabled controls whether the garbage collector is enabled - it is
 	// switched on by default (and generally shouldn't be turned off, except
@@ -227,4 +245,11 @@ type Security struct {
 	// Disabling this option ensures that dagger build containers do not run as
 	// privileged, and is a basic form of security hardening.
 	InsecureRootCapabilities *bool `json:"insecureRootCapabilities,omitempty"`
+
+	// ImportCallCache controls whether the function call cache is imported
+	// from the cache importers configured by clients - it is disallowed by
+	// default. Imported calls are added to the cache shared by every client of
+	// the engine, so this should only be enabled if all of them, and the cache
+	// backends they import from, are trusted.
+	ImportCallCache *bool `json:"importCallCache,omitempty"`
 }
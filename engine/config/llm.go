@@ -0,0 +1,202 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+)
+
+type LLMConfig struct {
+	// DefaultModel is the model used when none is requested, either a model
+	// name, an alias, or a model prefixed with an endpoint name (e.g.
+	// "local/qwen2.5-coder").
+	DefaultModel string `json:"defaultModel,omitempty"`
+
+	// Endpoints are the named LLM endpoints models can be routed to.
+	Endpoints map[string]LLMEndpointConfig `json:"endpoints,omitempty"`
+
+	// Aliases maps model aliases to model names, which may be prefixed with an
+	// endpoint name (e.g. "coder": "local/qwen2.5-coder").
+	Aliases map[string]string `json:"aliases,omitempty"`
+
+	// Prices are the prices of models, used to estimate the cost of LLM usage,
+	// keyed by model name or glob pattern (e.g. "qwen*"). They take precedence
+	// over the built-in prices.
+	Prices map[string]LLMModelPrice `json:"prices,omitempty"`
+
+	// SessionBudget limits the LLM usage of each session, across all of its
+	// LLMs and modules.
+	SessionBudget *LLMBudget `json:"sessionBudget,omitempty"`
+
+	// ModuleBudget limits the LLM usage of each module within a session,
+	// across all of its LLMs.
+	ModuleBudget *LLMBudget `json:"moduleBudget,omitempty"`
+}
+
+// LLMBudget limits the usage of LLMs. Zero means unlimited.
+type LLMBudget struct {
+	// MaxTokens is the maximum number of tokens used.
+	MaxTokens int64 `json:"maxTokens,omitempty"`
+
+	// MaxCost is the maximum estimated cost, in USD. It can only be enforced
+	// for priced models.
+	MaxCost float64 `json:"maxCost,omitempty"`
+}
+
+// Merge returns the budget with the lowest limits of both, so that a budget
+// can only be tightened.
+func (budget *LLMBudget) Merge(other *LLMBudget) *LLMBudget {
+	if budget == nil {
+		return other
+	}
+	if other == nil {
+		return budget
+	}
+	return &LLMBudget{
+		MaxTokens: lowestLimit(budget.MaxTokens, other.MaxTokens),
+		MaxCost:   lowestLimit(budget.MaxCost, other.MaxCost),
+	}
+}
+
+// lowestLimit returns the lowest of two limits, where zero means unlimited.
+func lowestLimit[T int64 | float64](a, b T) T {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// LLMModelPrice is the price of a model, in USD per million tokens.
+type LLMModelPrice struct {
+	// Input is the price of input tokens.
+	Input float64 `json:"input"`
+
+	// Output is the price of output tokens.
+	Output float64 `json:"output"`
+
+	// CacheReads is the price of input tokens read from the prompt cache.
+	CacheReads float64 `json:"cacheReads,omitempty"`
+
+	// CacheWrites is the price of input tokens written to the prompt cache.
+	CacheWrites float64 `json:"cacheWrites,omitempty"`
+}
+
+type LLMEndpointConfig struct {
+	// Provider is the API spoken by the endpoint. The available providers are:
+	// "anthropic", "bedrock" (Anthropic models on Amazon Bedrock), "openai"
+	// (and OpenAI-compatible servers, such as llama.cpp's), "google", and
+	// "ollama".
+	Provider string `json:"provider" jsonschema:"enum=anthropic,enum=bedrock,enum=openai,enum=google,enum=ollama"`
+
+	// BaseURL is the URL of the endpoint's API. Defaults to the provider's.
+	BaseURL string `json:"baseURL,omitempty"`
+
+	// Key is the API key to authenticate with, or the AWS access key ID for
+	// "bedrock" endpoints. It can be a secret reference resolved on the client,
+	// e.g. "env://OPENAI_API_KEY" or "op://vault/llm/key".
+	Key string `json:"key,omitempty"`
+
+	// SecretKey is the AWS secret access key for "bedrock" endpoints. It can be
+	// a secret reference.
+	SecretKey string `json:"secretKey,omitempty"`
+
+	// Region is the AWS region of "bedrock" endpoints.
+	Region string `json:"region,omitempty"`
+
+	// Headers are additional HTTP headers sent to the endpoint, e.g. for
+	// authenticating with a proxy. Values can be secret references.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// Models are the model names routed to this endpoint, as glob patterns
+	// (e.g. "qwen*"). Models prefixed with the endpoint name (e.g.
+	// "local/qwen2.5-coder") are always routed to it.
+	Models []string `json:"models,omitempty"`
+
+	// AzureVersion is the Azure OpenAI API version, for Azure endpoints of the
+	// "openai" provider.
+	AzureVersion string `json:"azureVersion,omitempty"`
+
+	// DisableStreaming disables streaming responses, for servers that don't
+	// support streaming with tool calls.
+	DisableStreaming bool `json:"disableStreaming,omitempty"`
+}
+
+// LLMProviders are the providers that LLM endpoints can be configured with.
+var LLMProviders = []string{"anthropic", "bedrock", "openai", "google", "ollama"}
+
+func (cfg *LLMConfig) Validate() error {
+	for _, name := range cfg.EndpointNames() {
+		endpoint := cfg.Endpoints[name]
+		if name == "" || strings.Contains(name, "/") {
+			return fmt.Errorf("invalid llm endpoint name %q", name)
+		}
+		if !slices.Contains(LLMProviders, endpoint.Provider) {
+			return fmt.Errorf("llm endpoint %q: unknown provider %q", name, endpoint.Provider)
+		}
+		if endpoint.Provider == "bedrock" && endpoint.Region == "" {
+			return fmt.Errorf("llm endpoint %q: bedrock endpoints require a region", name)
+		}
+		for _, pattern := range endpoint.Models {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("llm endpoint %q: invalid model pattern %q: %w", name, pattern, err)
+			}
+		}
+	}
+	for pattern := range cfg.Prices {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid llm price model pattern %q: %w", pattern, err)
+		}
+	}
+	for name, budget := range map[string]*LLMBudget{
+		"session": cfg.SessionBudget,
+		"module":  cfg.ModuleBudget,
+	} {
+		if budget != nil && (budget.MaxTokens < 0 || budget.MaxCost < 0) {
+			return fmt.Errorf("invalid llm %s budget: limits must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// EndpointNames returns the names of the endpoints, in the order they are
+// matched against models.
+func (cfg *LLMConfig) EndpointNames() []string {
+	return slices.Sorted(maps.Keys(cfg.Endpoints))
+}
+
+// Merge returns the config with the defaults and aliases of other taking
+// precedence. Endpoints of other are added, but can't replace the endpoints of
+// cfg with the same name, so that a client can't redirect the endpoints of the
+// engine. Budgets are merged to the lowest limits of both, so that a client
+// can't lift the budgets of the engine. Prices are those of cfg only, so that a
+// client can't lower the estimated costs the budgets are enforced on.
+func (cfg *LLMConfig) Merge(other *LLMConfig) *LLMConfig {
+	merged := &LLMConfig{
+		Endpoints: map[string]LLMEndpointConfig{},
+		Aliases:   map[string]string{},
+		Prices:    map[string]LLMModelPrice{},
+	}
+	for _, c := range []*LLMConfig{cfg, other} {
+		if c == nil {
+			continue
+		}
+		if c.DefaultModel != "" {
+			merged.DefaultModel = c.DefaultModel
+		}
+		for name, endpoint := range c.Endpoints {
+			if _, ok := merged.Endpoints[name]; ok && c != cfg {
+				continue
+			}
+			merged.Endpoints[name] = endpoint
+		}
+		maps.Copy(merged.Aliases, c.Aliases)
+		if c == cfg {
+			maps.Copy(merged.Prices, c.Prices)
+		}
+		merged.SessionBudget = merged.SessionBudget.Merge(c.SessionBudget)
+		merged.ModuleBudget = merged.ModuleBudget.Merge(c.ModuleBudget)
+	}
+	return merged
+}
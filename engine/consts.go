@@ -37,6 +37,7 @@ const (
 	InitEndpoint               = "/init"
 	QueryEndpoint              = "/query"
 	ShutdownEndpoint           = "/shutdown"
+	HistoryEndpoint            = "/history"
 
 	// Buildkit-interpreted session keys, can't change
 	SessionIDMetaKey         = "X-Docker-Expose-Session-Uuid"
@@ -0,0 +1,19 @@
+package engine
+
+import "time"
+
+// HistoryRun summarizes a past run of a command, as listed by the
+// HistoryEndpoint. The telemetry of a run can be fetched in the OTLP JSON
+// lines format from the HistoryEndpoint followed by its client ID.
+type HistoryRun struct {
+	ClientID string    `json:"client_id"`
+	Command  string    `json:"command"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Failed   bool      `json:"failed"`
+
+	// Calls is the number of API calls and operations of the run, of which
+	// CachedCalls hit the cache.
+	Calls       int `json:"calls"`
+	CachedCalls int `json:"cached_calls"`
+}
@@ -103,6 +103,15 @@ type ClientMetadata struct {
 	// TODO: This is a bit convoluted; it would be nicer if an engine could figure out its own ID
 	// rather than being told what it is by the client connecting to it.
 	CloudScaleOutEngineID string `json:"cloud_scale_out_engine_id,omitempty"`
+
+	// If set, the command line the client was started with, which records its
+	// telemetry in the history of runs.
+	Command string `json:"command,omitempty"`
+
+	// HistoryKey is a secret that's persisted in a client's XDG state directory.
+	// Only main clients with the key of the client that recorded a run can read
+	// its history; the engine only stores its hash.
+	HistoryKey string `json:"history_key,omitempty"`
 }
 
 type clientMetadataCtxKey struct{}
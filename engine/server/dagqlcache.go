@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/fs"
+
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/distribution/reference"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dagger/dagger/engine/buildkit"
+	"github.com/dagger/dagger/engine/cache/dagqlcache"
+	bksession "github.com/dagger/dagger/internal/buildkit/session"
+	"github.com/dagger/dagger/internal/buildkit/util/bklog"
+	"github.com/dagger/dagger/internal/buildkit/util/resolver"
+	resolverconfig "github.com/dagger/dagger/internal/buildkit/util/resolver/config"
+)
+
+// importDagqlCache imports the dagql call cache from the cache importers
+// configured by the client, so that its function calls can hit the cache of
+// the engines that exported it.
+//
+// The calls are imported into the cache shared by every client of the engine,
+// so this is a no-op unless the engine config allows it.
+func (srv *Server) importDagqlCache(ctx context.Context, client *daggerClient) {
+	if !srv.allowDagqlCacheImport {
+		bklog.G(ctx).Debugf("skipping dagql cache import for client %s: disabled in the engine config", client.clientID)
+		return
+	}
+	opts := srv.dagqlCacheOpts(client)
+	for _, cfg := range client.daggerSession.cacheImporterCfgs {
+		backend, err := dagqlcache.NewBackend(ctx, cfg.Type, cfg.Attrs, opts)
+		if err != nil {
+			if !errors.Is(err, dagqlcache.ErrUnsupported) {
+				bklog.G(ctx).WithError(err).Errorf("error configuring dagql cache import for client %s", client.clientID)
+			}
+			continue
+		}
+		n, err := dagqlcache.Import(ctx, srv.baseDagqlCache, backend)
+		if err != nil {
+			bklog.G(ctx).WithError(err).Errorf("error running dagql cache import for client %s", client.clientID)
+			continue
+		}
+		bklog.G(ctx).Debugf("imported %d dagql calls from %s cache", n, cfg.Type)
+	}
+}
+
+// exportDagqlCache exports the dagql call cache to the cache exporters
+// configured by the client.
+func (srv *Server) exportDagqlCache(ctx context.Context, client *daggerClient) {
+	opts := srv.dagqlCacheOpts(client)
+	for _, cfg := range client.daggerSession.cacheExporterCfgs {
+		backend, err := dagqlcache.NewBackend(ctx, cfg.Type, cfg.Attrs, opts)
+		if err != nil {
+			if !errors.Is(err, dagqlcache.ErrUnsupported) {
+				bklog.G(ctx).WithError(err).Errorf("error configuring dagql cache export for client %s", client.clientID)
+			}
+			continue
+		}
+		n, err := dagqlcache.Export(ctx, srv.baseDagqlCache, backend)
+		if err != nil {
+			bklog.G(ctx).WithError(err).Errorf("error running dagql cache export for client %s", client.clientID)
+			continue
+		}
+		bklog.G(ctx).Debugf("exported %d dagql calls to %s cache", n, cfg.Type)
+	}
+}
+
+func (srv *Server) dagqlCacheOpts(client *daggerClient) dagqlcache.Opts {
+	return dagqlcache.Opts{
+		HostFiles: clientHostFiles{client.bkClient},
+		Resolver: func(ctx context.Context, ref string, push bool, insecure bool) remotes.Resolver {
+			scope := "pull"
+			if push {
+				scope = "push"
+			}
+			hosts := srv.registryHosts
+			if named, err := reference.ParseNormalizedNamed(ref); err == nil && insecure {
+				insecureTrue := true
+				httpTrue := true
+				hosts = resolver.NewRegistryConfig(map[string]resolverconfig.RegistryConfig{
+					reference.Domain(named): {
+						Insecure:  &insecureTrue,
+						PlainHTTP: &httpTrue,
+					},
+				})
+				scope += ":insecure"
+			}
+			return resolver.DefaultPool.GetResolver(hosts, ref, scope, srv.bkSessionManager, bksession.NewGroup(client.clientID))
+		},
+	}
+}
+
+// clientHostFiles reads and writes files on the host of a client.
+type clientHostFiles struct {
+	bk *buildkit.Client
+}
+
+func (f clientHostFiles) ReadFile(ctx context.Context, path string) ([]byte, error) {
+	if _, err := f.bk.StatCallerHostPath(ctx, path, false); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, fs.ErrNotExist
+		}
+		return nil, err
+	}
+	return f.bk.ReadCallerHostFile(ctx, path)
+}
+
+func (f clientHostFiles) WriteFile(ctx context.Context, path string, data []byte) error {
+	return f.bk.IOReaderExport(ctx, bytes.NewReader(data), path, 0o644)
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"dagger.io/dagger/telemetry"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/clientdb"
+)
+
+// recordRun records the properties of a client started by a command, so its
+// telemetry is listed in the history of runs.
+func recordRun(ctx context.Context, db *clientdb.DB, md *engine.ClientMetadata) error {
+	if md.Command == "" {
+		return nil
+	}
+	for key, value := range map[string]string{
+		clientdb.CommandProperty:        md.Command,
+		clientdb.HistoryKeyHashProperty: historyKeyHash(md.HistoryKey),
+	} {
+		if err := db.SetProperty(ctx, clientdb.SetPropertyParams{Key: key, Value: value}); err != nil {
+			return fmt.Errorf("set %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// historyKeyHash returns the hash of a history key stored with a run, or an
+// empty string for an empty key.
+func historyKeyHash(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// historyAllowed returns whether the client can see the run, which must have
+// been started from the same host: unlike its stable ID, which is shared with
+// nested clients, a client can only present the history key of a run if it
+// can read the secret persisted on that host.
+func historyAllowed(client *daggerClient, run *clientdb.Run) bool {
+	hash := historyKeyHash(client.clientMetadata.HistoryKey)
+	return run.HistoryKeyHash != "" && hash != "" &&
+		subtle.ConstantTimeCompare([]byte(run.HistoryKeyHash), []byte(hash)) == 1
+}
+
+func (srv *Server) serveHistory(w http.ResponseWriter, r *http.Request, client *daggerClient) error {
+	if len(client.parents) > 0 {
+		return httpErr(errors.New("history is only available to the main client"), http.StatusForbidden)
+	}
+	var limit int
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		limit, err = strconv.Atoi(v)
+		if err != nil {
+			return httpErr(fmt.Errorf("invalid limit: %w", err), http.StatusBadRequest)
+		}
+	}
+	runs, err := srv.clientDBs.Runs(r.Context(), limit, func(run *clientdb.Run) bool {
+		return run.ClientID != client.clientID && historyAllowed(client, run)
+	})
+	if err != nil {
+		return httpErr(fmt.Errorf("list runs: %w", err), http.StatusInternalServerError)
+	}
+	history := make([]*engine.HistoryRun, len(runs))
+	for i, run := range runs {
+		history[i] = &engine.HistoryRun{
+			ClientID:    run.ClientID,
+			Command:     run.Command,
+			Start:       run.Start,
+			End:         run.End,
+			Failed:      run.Failed,
+			Calls:       run.Calls,
+			CachedCalls: run.CachedCalls,
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(history)
+}
+
+// serveHistoryRun writes the telemetry of a run in the OTLP JSON lines
+// format, like written by "dagger --record": its spans, then its logs and
+// metrics.
+func (srv *Server) serveHistoryRun(w http.ResponseWriter, r *http.Request, client *daggerClient) error {
+	ctx := r.Context()
+	if len(client.parents) > 0 {
+		return httpErr(errors.New("history is only available to the main client"), http.StatusForbidden)
+	}
+	clientID := r.PathValue("clientID")
+	run, err := srv.clientDBs.Run(ctx, clientID)
+	if err != nil {
+		return httpErr(err, http.StatusInternalServerError)
+	}
+	if run == nil || !historyAllowed(client, run) {
+		return httpErr(fmt.Errorf("run %q not found", clientID), http.StatusNotFound)
+	}
+	db, err := srv.clientDBs.Open(ctx, clientID)
+	if err != nil {
+		return httpErr(err, http.StatusInternalServerError)
+	}
+	defer db.Close()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	bw := bufio.NewWriter(w)
+	writeLine := func(msg proto.Message) error {
+		line, err := protojson.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		_, err = bw.Write(append(line, '\n'))
+		return err
+	}
+
+	for since := int64(0); ; {
+		spans, err := db.SelectSpansSince(ctx, clientdb.SelectSpansSinceParams{
+			ID:    since,
+			Limit: otlpBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("select spans: %w", err)
+		}
+		if len(spans) == 0 {
+			break
+		}
+		roSpans := make([]sdktrace.ReadOnlySpan, len(spans))
+		for i, span := range spans {
+			roSpans[i] = span.ReadOnly()
+			since = span.ID
+		}
+		if err := writeLine(&coltracepb.ExportTraceServiceRequest{
+			ResourceSpans: telemetry.SpansToPB(roSpans),
+		}); err != nil {
+			return fmt.Errorf("write spans: %w", err)
+		}
+	}
+
+	for since := int64(0); ; {
+		logs, err := db.SelectLogsSince(ctx, clientdb.SelectLogsSinceParams{
+			ID:    since,
+			Limit: otlpBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("select logs: %w", err)
+		}
+		if len(logs) == 0 {
+			break
+		}
+		since = logs[len(logs)-1].ID
+		if err := writeLine(&collogspb.ExportLogsServiceRequest{
+			ResourceLogs: clientdb.LogsToPB(logs),
+		}); err != nil {
+			return fmt.Errorf("write logs: %w", err)
+		}
+	}
+
+	for since := int64(0); ; {
+		metrics, err := db.SelectMetricsSince(ctx, clientdb.SelectMetricsSinceParams{
+			ID:    since,
+			Limit: otlpBatchSize,
+		})
+		if err != nil {
+			return fmt.Errorf("select metrics: %w", err)
+		}
+		if len(metrics) == 0 {
+			break
+		}
+		since = metrics[len(metrics)-1].ID
+		if err := writeLine(&colmetricspb.ExportMetricsServiceRequest{
+			ResourceMetrics: clientdb.MetricsToPB(metrics),
+		}); err != nil {
+			return fmt.Errorf("write metrics: %w", err)
+		}
+	}
+
+	return bw.Flush()
+}
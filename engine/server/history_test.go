@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/dagger/dagger/engine"
+	"github.com/dagger/dagger/engine/clientdb"
+)
+
+func TestHistoryAllowed(t *testing.T) {
+	run := &clientdb.Run{ClientID: "run", HistoryKeyHash: historyKeyHash("key")}
+	client := func(md engine.ClientMetadata) *daggerClient {
+		return &daggerClient{clientMetadata: &md}
+	}
+
+	require.True(t, historyAllowed(client(engine.ClientMetadata{HistoryKey: "key"}), run))
+	require.False(t, historyAllowed(client(engine.ClientMetadata{HistoryKey: "other"}), run))
+	require.False(t, historyAllowed(client(engine.ClientMetadata{}), run))
+	// the stable ID is shared with nested clients, so it doesn't give access
+	require.False(t, historyAllowed(client(engine.ClientMetadata{ClientStableID: "key"}), run))
+
+	// runs recorded without key are not readable
+	require.False(t, historyAllowed(client(engine.ClientMetadata{}), &clientdb.Run{ClientID: "run"}))
+}
@@ -53,6 +53,7 @@ import (
 	"github.com/dagger/dagger/internal/buildkit/solver/pb"
 	"github.com/dagger/dagger/internal/buildkit/source"
 	"github.com/dagger/dagger/internal/buildkit/util/archutil"
+	"github.com/dagger/dagger/internal/buildkit/util/disk"
 	"github.com/dagger/dagger/internal/buildkit/util/entitlements"
 	"github.com/dagger/dagger/internal/buildkit/util/leaseutil"
 	"github.com/dagger/dagger/internal/buildkit/util/network"
@@ -163,6 +164,12 @@ type Server struct {
 	registryHosts    docker.RegistryHosts
 	cleanMntNS       *os.File
 
+	//
+	// llm config
+	//
+
+	llmConfig *config.LLMConfig
+
 	//
 	// telemetry config+state
 	//
@@ -181,6 +188,8 @@ type Server struct {
 	// dagql cache
 	//
 	baseDagqlCache dagql.Cache
+	// whether clients may import calls into baseDagqlCache
+	allowDagqlCacheImport bool
 
 	//
 	// session+client state
@@ -223,6 +232,7 @@ func NewServer(ctx context.Context, opts *NewServerOpts) (*Server, error) {
 			Options:       bkcfg.DNS.Options,
 			SearchDomains: bkcfg.DNS.SearchDomains,
 		},
+		llmConfig: cfg.LLM,
 
 		daggerSessions: make(map[string]*daggerSession),
 
@@ -332,12 +342,21 @@ func NewServer(ctx context.Context, opts *NewServerOpts) (*Server, error) {
 
 	srv.clientDBDir = filepath.Join(srv.workerRootDir, "clientdbs")
 	srv.clientDBs = clientdb.NewDBs(srv.clientDBDir)
+	if cfg.History != nil {
+		srv.clientDBs.KeepDuration = cfg.History.KeepDuration.Duration
+		dstat, _ := disk.GetDiskStat(srv.rootDir)
+		srv.clientDBs.MaxUsedSpace = cfg.History.MaxUsedSpace.AsBytes(dstat)
+	}
 	srv.telemetryPubSub = NewPubSub(srv)
 
 	//
 	// setup config derived from engine config
 	//
 
+	if cfg.Security != nil && cfg.Security.ImportCallCache != nil {
+		srv.allowDagqlCacheImport = *cfg.Security.ImportCallCache
+	}
+
 	if cfg.Security != nil {
 		// prioritize out config first if it's set
 		if cfg.Security.InsecureRootCapabilities == nil || *cfg.Security.InsecureRootCapabilities {
@@ -54,6 +54,7 @@ import (
 	"github.com/dagger/dagger/engine/cache/cachemanager"
 	engineclient "github.com/dagger/dagger/engine/client"
 	"github.com/dagger/dagger/engine/clientdb"
+	"github.com/dagger/dagger/engine/config"
 	"github.com/dagger/dagger/engine/server/resource"
 	"github.com/dagger/dagger/engine/slog"
 	enginetel "github.com/dagger/dagger/engine/telemetry"
@@ -83,6 +84,8 @@ type daggerSession struct {
 
 	services *core.Services
 
+	llmUsage *core.LLMSessionUsage
+
 	analytics analytics.Tracker
 
 	authProvider *auth.RegistryAuthProvider
@@ -90,6 +93,10 @@ type daggerSession struct {
 	cacheExporterCfgs []bkgw.CacheOptionsEntry
 	cacheImporterCfgs []bkgw.CacheOptionsEntry
 
+	// imports the dagql call cache from the cache importers before the first
+	// query of the main client
+	dagqlCacheImportOnce sync.Once
+
 	refs   map[buildkit.Reference]struct{}
 	refsMu sync.Mutex
 
@@ -260,6 +267,7 @@ func (srv *Server) initializeDaggerSession(
 	sess.endpoints = map[string]http.Handler{}
 	sess.shutdownCh = make(chan struct{})
 	sess.services = core.NewServices()
+	sess.llmUsage = core.NewLLMSessionUsage()
 	sess.authProvider = auth.NewRegistryAuthProvider()
 	sess.refs = map[buildkit.Reference]struct{}{}
 	sess.containers = map[bkgw.Container]struct{}{}
@@ -850,6 +858,13 @@ func (srv *Server) getOrInitClient(
 			failureCleanups.Add("close client telemetry DB", func() error {
 				return db.Close()
 			})
+			if err := recordRun(ctx, db, opts.ClientMetadata); err != nil {
+				slog.Warn("failed to record client run; it won't be in the history",
+					"sessionID", sessionID,
+					"clientID", client.clientID,
+					"error", err,
+				)
+			}
 		}
 
 		parent, parentExists := sess.clients[opts.CallerClientID]
@@ -1064,6 +1079,8 @@ func (srv *Server) serveHTTPToClient(w http.ResponseWriter, r *http.Request, opt
 		mux.Handle(engine.QueryEndpoint, httpHandlerFunc(srv.serveQuery, client))
 		mux.Handle(engine.InitEndpoint, httpHandlerFunc(srv.serveInit, client))
 		mux.Handle(engine.ShutdownEndpoint, httpHandlerFunc(srv.serveShutdown, client))
+		mux.Handle("GET "+engine.HistoryEndpoint, httpHandlerFunc(srv.serveHistory, client))
+		mux.Handle("GET "+engine.HistoryEndpoint+"/{clientID}", httpHandlerFunc(srv.serveHistoryRun, client))
 		sess.endpointMu.RLock()
 		for path, handler := range sess.endpoints {
 			mux.Handle(path, handler)
@@ -1180,6 +1197,12 @@ func (srv *Server) serveQuery(w http.ResponseWriter, r *http.Request, client *da
 	// make query available via context to all APIs
 	ctx = core.ContextWithQuery(ctx, client.dagqlRoot)
 
+	if sess := client.daggerSession; client.clientID == sess.mainClientCallerID && len(sess.cacheImporterCfgs) > 0 {
+		sess.dagqlCacheImportOnce.Do(func() {
+			srv.importDagqlCache(ctx, client)
+		})
+	}
+
 	r = r.WithContext(ctx)
 
 	// get the schema we're gonna serve to this client based on which modules they have loaded, if any
@@ -1276,6 +1299,7 @@ func (srv *Server) serveShutdown(w http.ResponseWriter, r *http.Request, client
 			if err != nil {
 				bklog.G(ctx).WithError(err).Errorf("error running cache export for client %s", client.clientID)
 			}
+			srv.exportDagqlCache(ctx, client)
 			bklog.G(ctx).Infof("done running cache export for client %s", client.clientID)
 		}
 
@@ -1555,6 +1579,25 @@ func (srv *Server) Services(ctx context.Context) (*core.Services, error) {
 	return client.daggerSession.services, nil
 }
 
+// A context with the values of the given one, canceled when the current
+// client's session shuts down rather than when the given one is
+func (srv *Server) SessionContext(ctx context.Context) (context.Context, error) {
+	client, err := srv.clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.daggerSession.withShutdownCancel(context.WithoutCancel(ctx)), nil
+}
+
+// The LLM usage of the current client's session
+func (srv *Server) LLMUsage(ctx context.Context) (*core.LLMSessionUsage, error) {
+	client, err := srv.clientFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.daggerSession.llmUsage, nil
+}
+
 // The default platform for the engine as a whole
 func (srv *Server) Platform() core.Platform {
 	return core.Platform(srv.defaultPlatform)
@@ -1570,6 +1613,11 @@ func (srv *Server) DNS() *oci.DNSConfig {
 	return srv.dns
 }
 
+// The LLM configuration for the engine as a whole
+func (srv *Server) LLMConfig() *config.LLMConfig {
+	return srv.llmConfig
+}
+
 // The lease manager for the engine as a whole
 func (srv *Server) LeaseManager() *leaseutil.Manager {
 	return srv.leaseManager
@@ -0,0 +1,77 @@
+package telemetry
+
+import (
+	"context"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"dagger.io/dagger/telemetry"
+)
+
+const (
+	// OTel metric for the number of tokens used by LLMs, by token type
+	LLMUsageTokens = "dagger.io/metrics.llm.usage.tokens"
+
+	// OTel metric for the estimated cost of LLM usage, in USD
+	LLMUsageCost = "dagger.io/metrics.llm.usage.cost"
+
+	// The type of tokens counted: input, output, cache_read or cache_write.
+	LLMTokenTypeAttr = "dagger.io/llm.token.type"
+
+	// The model and provider of an LLM.
+	LLMModelAttr    = "dagger.io/llm.model"
+	LLMProviderAttr = "dagger.io/llm.provider"
+
+	// The module calling an LLM, if any.
+	LLMModuleAttr = "dagger.io/llm.module"
+
+	// The session of the client calling an LLM.
+	LLMSessionAttr = "dagger.io/llm.session"
+)
+
+const llmInstrumentationLibrary = "dagger.io/engine.llm"
+
+// LLMUsage is the usage of a single LLM API call.
+type LLMUsage struct {
+	InputTokens       int64
+	OutputTokens      int64
+	CachedTokenReads  int64
+	CachedTokenWrites int64
+	// Estimated cost in USD, zero if unknown
+	Cost float64
+}
+
+// RecordLLMUsage adds the usage of an LLM API call to the aggregate usage
+// counters, so that it can be broken down by model, provider, module and
+// session, e.g. for chargeback.
+func RecordLLMUsage(ctx context.Context, usage LLMUsage, attrs ...attribute.KeyValue) error {
+	m := telemetry.Meter(ctx, llmInstrumentationLibrary)
+
+	tokens, err := m.Int64Counter(LLMUsageTokens, metric.WithUnit("{token}"))
+	if err != nil {
+		return err
+	}
+	for typ, count := range map[string]int64{
+		"input":       usage.InputTokens,
+		"output":      usage.OutputTokens,
+		"cache_read":  usage.CachedTokenReads,
+		"cache_write": usage.CachedTokenWrites,
+	} {
+		if count == 0 {
+			continue
+		}
+		tokens.Add(ctx, count, metric.WithAttributes(
+			slices.Concat(attrs, []attribute.KeyValue{attribute.String(LLMTokenTypeAttr, typ)})...))
+	}
+
+	if usage.Cost > 0 {
+		cost, err := m.Float64Counter(LLMUsageCost, metric.WithUnit("[USD]"))
+		if err != nil {
+			return err
+		}
+		cost.Add(ctx, usage.Cost, metric.WithAttributes(attrs...))
+	}
+	return nil
+}
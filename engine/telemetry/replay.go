@@ -0,0 +1,113 @@
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"dagger.io/dagger/telemetry"
+	"github.com/dagger/dagger/engine/slog"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ReplayFile exports telemetry in the OTLP JSON lines format, in order, to
+// the given exporters. Each line is an OTLP export request, like written by
+// the OpenTelemetry Collector's file exporter, and by the engine when serving
+// the telemetry of a run from its history. The onSpans callback, if any, is called with each
+// batch of replayed spans.
+func ReplayFile(
+	ctx context.Context,
+	r io.Reader,
+	spans sdktrace.SpanExporter,
+	logs sdklog.Exporter,
+	metrics sdkmetric.Exporter,
+	onSpans func([]sdktrace.ReadOnlySpan),
+) error {
+	br := bufio.NewReader(r)
+	for lineNum := 1; ; lineNum++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			if err := replayLine(ctx, line, spans, logs, metrics, onSpans); err != nil {
+				return fmt.Errorf("line %d: %w", lineNum, err)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+func replayLine(
+	ctx context.Context,
+	line []byte,
+	spans sdktrace.SpanExporter,
+	logs sdklog.Exporter,
+	metrics sdkmetric.Exporter,
+	onSpans func([]sdktrace.ReadOnlySpan),
+) error {
+	// the type of the request is told by its only field
+	var kind struct {
+		ResourceSpans   json.RawMessage `json:"resourceSpans"`
+		ResourceLogs    json.RawMessage `json:"resourceLogs"`
+		ResourceMetrics json.RawMessage `json:"resourceMetrics"`
+	}
+	if err := json.Unmarshal(line, &kind); err != nil {
+		return err
+	}
+	switch {
+	case kind.ResourceSpans != nil:
+		var req coltracepb.ExportTraceServiceRequest
+		if err := protojson.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		replayed := telemetry.SpansFromPB(req.GetResourceSpans())
+		if len(replayed) == 0 {
+			return nil
+		}
+		if err := spans.ExportSpans(ctx, replayed); err != nil {
+			return err
+		}
+		if onSpans != nil {
+			onSpans(replayed)
+		}
+	case kind.ResourceLogs != nil:
+		var req collogspb.ExportLogsServiceRequest
+		if err := protojson.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		return telemetry.ReexportLogsFromPB(ctx, logs, &req)
+	case kind.ResourceMetrics != nil:
+		var req colmetricspb.ExportMetricsServiceRequest
+		if err := protojson.Unmarshal(line, &req); err != nil {
+			return err
+		}
+		for _, pb := range req.GetResourceMetrics() {
+			rm, err := telemetry.ResourceMetricsFromPB(pb)
+			if err != nil {
+				// only the metrics that can be displayed are supported
+				slog.Warn("skipping unsupported metrics", "error", err)
+				continue
+			}
+			if err := metrics.Export(ctx, rm); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
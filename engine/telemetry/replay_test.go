@@ -0,0 +1,124 @@
+package telemetry_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	sdktelemetry "dagger.io/dagger/telemetry"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	otlpmetricsv1 "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dagger/dagger/engine/telemetry"
+)
+
+type collectLogExporter struct {
+	logs []sdklog.Record
+}
+
+func (exp *collectLogExporter) Export(_ context.Context, logs []sdklog.Record) error {
+	for _, rec := range logs {
+		exp.logs = append(exp.logs, rec.Clone())
+	}
+	return nil
+}
+
+func (exp *collectLogExporter) Shutdown(context.Context) error   { return nil }
+func (exp *collectLogExporter) ForceFlush(context.Context) error { return nil }
+
+type collectMetricExporter struct {
+	sdkmetric.Exporter
+	metrics []*metricdata.ResourceMetrics
+}
+
+func (exp *collectMetricExporter) Export(_ context.Context, rm *metricdata.ResourceMetrics) error {
+	exp.metrics = append(exp.metrics, rm)
+	return nil
+}
+
+func TestReplayFile(t *testing.T) {
+	ctx := context.Background()
+
+	recordedSpans := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(recordedSpans))
+	ctx, root := tp.Tracer("test").Start(ctx, "dagger call build")
+	_, child := tp.Tracer("test").Start(ctx, "Container.withExec")
+	child.End()
+	root.End()
+
+	recordedLogs := &collectLogExporter{}
+	lp := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(recordedLogs)))
+	var logRec log.Record
+	logRec.SetBody(log.StringValue("hello\n"))
+	lp.Logger("test").Emit(ctx, logRec)
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	gauge, err := mp.Meter("test").Int64Gauge("bytes")
+	require.NoError(t, err)
+	gauge.Record(ctx, 42)
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	metricsPB, err := sdktelemetry.ResourceMetricsToPB(&rm)
+	require.NoError(t, err)
+
+	var file bytes.Buffer
+	for _, msg := range []proto.Message{
+		&coltracepb.ExportTraceServiceRequest{
+			ResourceSpans: sdktelemetry.SpansToPB(recordedSpans.GetSpans().Snapshots()),
+		},
+		&collogspb.ExportLogsServiceRequest{
+			ResourceLogs: sdktelemetry.LogsToPB(recordedLogs.logs),
+		},
+		&colmetricspb.ExportMetricsServiceRequest{
+			ResourceMetrics: []*otlpmetricsv1.ResourceMetrics{metricsPB},
+		},
+	} {
+		line, err := protojson.Marshal(msg)
+		require.NoError(t, err)
+		file.Write(append(line, '\n'))
+	}
+
+	spans := tracetest.NewInMemoryExporter()
+	logs := &collectLogExporter{}
+	metrics := &collectMetricExporter{}
+	var roots []string
+	err = telemetry.ReplayFile(ctx, &file, spans, logs, metrics, func(replayed []sdktrace.ReadOnlySpan) {
+		for _, span := range replayed {
+			if !span.Parent().IsValid() {
+				roots = append(roots, span.Name())
+			}
+		}
+	})
+	require.NoError(t, err)
+
+	replayed := spans.GetSpans()
+	require.Len(t, replayed, 2)
+	require.Equal(t, "Container.withExec", replayed[0].Name)
+	require.Equal(t, root.SpanContext().SpanID(), replayed[0].Parent.SpanID())
+	require.Equal(t, "dagger call build", replayed[1].Name)
+	require.Equal(t, []string{"dagger call build"}, roots)
+
+	require.Len(t, logs.logs, 1)
+	require.Equal(t, "hello\n", logs.logs[0].Body().AsString())
+	require.Equal(t, root.SpanContext().SpanID(), logs.logs[0].SpanID())
+
+	require.Len(t, metrics.metrics, 1)
+	require.Equal(t, "bytes", metrics.metrics[0].ScopeMetrics[0].Metrics[0].Name)
+}
+
+func TestReplayFileInvalid(t *testing.T) {
+	err := telemetry.ReplayFile(context.Background(), bytes.NewBufferString("{}\nnot json\n"), nil, nil, nil, nil)
+	require.ErrorContains(t, err, "line 2")
+}
@@ -113,6 +113,20 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type ContainerAttestation
+  """
+  @spec as_container_attestation(t()) :: Dagger.ContainerAttestation.t()
+  def as_container_attestation(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asContainerAttestation")
+
+    %Dagger.ContainerAttestation{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type Directory
   """
@@ -169,6 +183,34 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type GitBlameLine
+  """
+  @spec as_git_blame_line(t()) :: Dagger.GitBlameLine.t()
+  def as_git_blame_line(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asGitBlameLine")
+
+    %Dagger.GitBlameLine{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
+  @doc """
+  Retrieve the binding value, as type GitCommit
+  """
+  @spec as_git_commit(t()) :: Dagger.GitCommit.t()
+  def as_git_commit(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asGitCommit")
+
+    %Dagger.GitCommit{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type GitRef
   """
@@ -211,6 +253,20 @@ defmodule Dagger.Binding do
     }
   end
 
+  @doc """
+  Retrieve the binding value, as type LLMStreamEvent
+  """
+  @spec as_llm_stream_event(t()) :: Dagger.LLMStreamEvent.t()
+  def as_llm_stream_event(%__MODULE__{} = binding) do
+    query_builder =
+      binding.query_builder |> QB.select("asLLMStreamEvent")
+
+    %Dagger.LLMStreamEvent{
+      query_builder: query_builder,
+      client: binding.client
+    }
+  end
+
   @doc """
   Retrieve the binding value, as type Module
   """
@@ -15,6 +15,17 @@ defmodule Dagger.Check do
 
   @type t() :: %__MODULE__{}
 
+  @doc """
+  The number of times the check was run, including retries
+  """
+  @spec attempts(t()) :: {:ok, integer()} | {:error, term()}
+  def attempts(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("attempts")
+
+    Client.execute(check.client, query_builder)
+  end
+
   @doc """
   Whether the check completed
   """
@@ -37,6 +48,28 @@ defmodule Dagger.Check do
     Client.execute(check.client, query_builder)
   end
 
+  @doc """
+  How long the check took to run, in milliseconds
+  """
+  @spec duration_ms(t()) :: {:ok, integer()} | {:error, term()}
+  def duration_ms(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("durationMs")
+
+    Client.execute(check.client, query_builder)
+  end
+
+  @doc """
+  The error the check failed with, if any
+  """
+  @spec error_message(t()) :: {:ok, String.t()} | {:error, term()}
+  def error_message(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("errorMessage")
+
+    Client.execute(check.client, query_builder)
+  end
+
   @doc """
   A unique identifier for this Check.
   """
@@ -48,6 +81,17 @@ defmodule Dagger.Check do
     Client.execute(check.client, query_builder)
   end
 
+  @doc """
+  The last lines of output of the failed command, if the check failed running one
+  """
+  @spec log_excerpt(t()) :: {:ok, String.t()} | {:error, term()}
+  def log_excerpt(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("logExcerpt")
+
+    Client.execute(check.client, query_builder)
+  end
+
   @doc """
   Return the fully qualified name of the check
   """
@@ -81,6 +125,17 @@ defmodule Dagger.Check do
     Client.execute(check.client, query_builder)
   end
 
+  @doc """
+  Whether the check is quarantined: its failures are reported, but don't fail the group
+  """
+  @spec quarantined(t()) :: {:ok, boolean()} | {:error, term()}
+  def quarantined(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("quarantined")
+
+    Client.execute(check.client, query_builder)
+  end
+
   @doc """
   An emoji representing the result of the check
   """
@@ -95,16 +150,62 @@ defmodule Dagger.Check do
   @doc """
   Execute the check
   """
-  @spec run(t()) :: Dagger.Check.t()
-  def run(%__MODULE__{} = check) do
+  @spec run(t(), [{:retries, integer() | nil}]) :: Dagger.Check.t()
+  def run(%__MODULE__{} = check, optional_args \\ []) do
     query_builder =
-      check.query_builder |> QB.select("run")
+      check.query_builder
+      |> QB.select("run")
+      |> QB.maybe_put_arg("retries", optional_args[:retries])
 
     %Dagger.Check{
       query_builder: query_builder,
       client: check.client
     }
   end
+
+  @doc """
+  Why the check was selected or skipped by affectedBy
+  """
+  @spec selection_reason(t()) :: {:ok, String.t()} | {:error, term()}
+  def selection_reason(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("selectionReason")
+
+    Client.execute(check.client, query_builder)
+  end
+
+  @doc """
+  Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy
+  """
+  @spec skipped(t()) :: {:ok, boolean()} | {:error, term()}
+  def skipped(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("skipped")
+
+    Client.execute(check.client, query_builder)
+  end
+
+  @doc """
+  The ID of the span of the check
+  """
+  @spec span_id(t()) :: {:ok, String.t()} | {:error, term()}
+  def span_id(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("spanID")
+
+    Client.execute(check.client, query_builder)
+  end
+
+  @doc """
+  The ID of the trace the check ran in
+  """
+  @spec trace_id(t()) :: {:ok, String.t()} | {:error, term()}
+  def trace_id(%__MODULE__{} = check) do
+    query_builder =
+      check.query_builder |> QB.select("traceID")
+
+    Client.execute(check.client, query_builder)
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.Check do
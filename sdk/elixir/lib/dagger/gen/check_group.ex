@@ -15,6 +15,40 @@ defmodule Dagger.CheckGroup do
 
   @type t() :: %__MODULE__{}
 
+  @doc """
+  Skip the checks whose inputs are not affected by the given changes
+
+  A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.
+  """
+  @spec affected_by(t(), Dagger.Changeset.t()) :: Dagger.CheckGroup.t()
+  def affected_by(%__MODULE__{} = check_group, changes) do
+    query_builder =
+      check_group.query_builder
+      |> QB.select("affectedBy")
+      |> QB.put_arg("changes", Dagger.ID.id!(changes))
+
+    %Dagger.CheckGroup{
+      query_builder: query_builder,
+      client: check_group.client
+    }
+  end
+
+  @doc """
+  Skip the checks whose inputs are not affected by the given changed paths
+
+  Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+  """
+  @spec affected_by_paths(t(), [String.t()]) :: Dagger.CheckGroup.t()
+  def affected_by_paths(%__MODULE__{} = check_group, paths) do
+    query_builder =
+      check_group.query_builder |> QB.select("affectedByPaths") |> QB.put_arg("paths", paths)
+
+    %Dagger.CheckGroup{
+      query_builder: query_builder,
+      client: check_group.client
+    }
+  end
+
   @doc """
   A unique identifier for this CheckGroup.
   """
@@ -49,12 +83,14 @@ defmodule Dagger.CheckGroup do
   end
 
   @doc """
-  Generate a markdown report
+  Generate a report of the check results
   """
-  @spec report(t()) :: Dagger.File.t()
-  def report(%__MODULE__{} = check_group) do
+  @spec report(t(), [{:format, Dagger.CheckReportFormat.t() | nil}]) :: Dagger.File.t()
+  def report(%__MODULE__{} = check_group, optional_args \\ []) do
     query_builder =
-      check_group.query_builder |> QB.select("report")
+      check_group.query_builder
+      |> QB.select("report")
+      |> QB.maybe_put_arg("format", optional_args[:format])
 
     %Dagger.File{
       query_builder: query_builder,
@@ -65,10 +101,18 @@ defmodule Dagger.CheckGroup do
   @doc """
   Execute all selected checks
   """
-  @spec run(t()) :: Dagger.CheckGroup.t()
-  def run(%__MODULE__{} = check_group) do
+  @spec run(t(), [
+          {:fail_fast, boolean() | nil},
+          {:retries, integer() | nil},
+          {:no_fail, boolean() | nil}
+        ]) :: Dagger.CheckGroup.t()
+  def run(%__MODULE__{} = check_group, optional_args \\ []) do
     query_builder =
-      check_group.query_builder |> QB.select("run")
+      check_group.query_builder
+      |> QB.select("run")
+      |> QB.maybe_put_arg("failFast", optional_args[:fail_fast])
+      |> QB.maybe_put_arg("retries", optional_args[:retries])
+      |> QB.maybe_put_arg("noFail", optional_args[:no_fail])
 
     %Dagger.CheckGroup{
       query_builder: query_builder,
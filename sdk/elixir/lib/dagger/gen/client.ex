@@ -298,7 +298,8 @@ defmodule Dagger.Client do
           {:http_auth_username, String.t() | nil},
           {:http_auth_token, Dagger.SecretID.t() | nil},
           {:http_auth_header, Dagger.SecretID.t() | nil},
-          {:experimental_service_host, Dagger.ServiceID.t() | nil}
+          {:experimental_service_host, Dagger.ServiceID.t() | nil},
+          {:filter, String.t() | nil}
         ]) :: Dagger.GitRepository.t()
   def git(%__MODULE__{} = client, url, optional_args \\ []) do
     query_builder =
@@ -312,6 +313,7 @@ defmodule Dagger.Client do
       |> QB.maybe_put_arg("httpAuthToken", optional_args[:http_auth_token])
       |> QB.maybe_put_arg("httpAuthHeader", optional_args[:http_auth_header])
       |> QB.maybe_put_arg("experimentalServiceHost", optional_args[:experimental_service_host])
+      |> QB.maybe_put_arg("filter", optional_args[:filter])
 
     %Dagger.GitRepository{
       query_builder: query_builder,
@@ -492,6 +494,21 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a ContainerAttestation from its ID.
+  """
+  @spec load_container_attestation_from_id(t(), Dagger.ContainerAttestationID.t()) ::
+          Dagger.ContainerAttestation.t()
+  def load_container_attestation_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadContainerAttestationFromID") |> QB.put_arg("id", id)
+
+    %Dagger.ContainerAttestation{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a Container from its ID.
   """
@@ -790,6 +807,34 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a GitBlameLine from its ID.
+  """
+  @spec load_git_blame_line_from_id(t(), Dagger.GitBlameLineID.t()) :: Dagger.GitBlameLine.t()
+  def load_git_blame_line_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadGitBlameLineFromID") |> QB.put_arg("id", id)
+
+    %Dagger.GitBlameLine{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
+  @doc """
+  Load a GitCommit from its ID.
+  """
+  @spec load_git_commit_from_id(t(), Dagger.GitCommitID.t()) :: Dagger.GitCommit.t()
+  def load_git_commit_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadGitCommitFromID") |> QB.put_arg("id", id)
+
+    %Dagger.GitCommit{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a GitRef from its ID.
   """
@@ -889,6 +934,21 @@ defmodule Dagger.Client do
     }
   end
 
+  @doc """
+  Load a LLMStreamEvent from its ID.
+  """
+  @spec load_llm_stream_event_from_id(t(), Dagger.LLMStreamEventID.t()) ::
+          Dagger.LLMStreamEvent.t()
+  def load_llm_stream_event_from_id(%__MODULE__{} = client, id) do
+    query_builder =
+      client.query_builder |> QB.select("loadLLMStreamEventFromID") |> QB.put_arg("id", id)
+
+    %Dagger.LLMStreamEvent{
+      query_builder: query_builder,
+      client: client.client
+    }
+  end
+
   @doc """
   Load a LLMTokenUsage from its ID.
   """
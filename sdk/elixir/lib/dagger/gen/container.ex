@@ -76,6 +76,33 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  The in-toto attestations that would be attached to the container image when published or exported.
+  """
+  @spec attestations(t(), [{:sbom, Dagger.SBOMFormat.t() | nil}, {:provenance, boolean() | nil}]) ::
+          {:ok, [Dagger.ContainerAttestation.t()]} | {:error, term()}
+  def attestations(%__MODULE__{} = container, optional_args \\ []) do
+    query_builder =
+      container.query_builder
+      |> QB.select("attestations")
+      |> QB.maybe_put_arg("sbom", optional_args[:sbom])
+      |> QB.maybe_put_arg("provenance", optional_args[:provenance])
+      |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(container.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.ContainerAttestation{
+           query_builder:
+             QB.query()
+             |> QB.select("loadContainerAttestationFromID")
+             |> QB.put_arg("id", id),
+           client: container.client
+         }
+       end}
+    end
+  end
+
   @doc """
   The combined buffered standard output and standard error stream of the last executed command
 
@@ -241,7 +268,9 @@ defmodule Dagger.Container do
           {:platform_variants, [Dagger.ContainerID.t()]},
           {:forced_compression, Dagger.ImageLayerCompression.t() | nil},
           {:media_types, Dagger.ImageMediaTypes.t() | nil},
-          {:expand, boolean() | nil}
+          {:expand, boolean() | nil},
+          {:sbom, Dagger.SBOMFormat.t() | nil},
+          {:provenance, boolean() | nil}
         ]) :: {:ok, String.t()} | {:error, term()}
   def export(%__MODULE__{} = container, path, optional_args \\ []) do
     query_builder =
@@ -258,6 +287,8 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("forcedCompression", optional_args[:forced_compression])
       |> QB.maybe_put_arg("mediaTypes", optional_args[:media_types])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
+      |> QB.maybe_put_arg("sbom", optional_args[:sbom])
+      |> QB.maybe_put_arg("provenance", optional_args[:provenance])
 
     Client.execute(container.client, query_builder)
   end
@@ -337,10 +368,13 @@ defmodule Dagger.Container do
   @doc """
   Download a container image, and apply it to the container state. All previous state will be lost.
   """
-  @spec from(t(), String.t()) :: Dagger.Container.t()
-  def from(%__MODULE__{} = container, address) do
+  @spec from(t(), String.t(), [{:verify, String.t() | nil}]) :: Dagger.Container.t()
+  def from(%__MODULE__{} = container, address, optional_args \\ []) do
     query_builder =
-      container.query_builder |> QB.select("from") |> QB.put_arg("address", address)
+      container.query_builder
+      |> QB.select("from")
+      |> QB.put_arg("address", address)
+      |> QB.maybe_put_arg("verify", optional_args[:verify])
 
     %Dagger.Container{
       query_builder: query_builder,
@@ -450,7 +484,11 @@ defmodule Dagger.Container do
   @spec publish(t(), String.t(), [
           {:platform_variants, [Dagger.ContainerID.t()]},
           {:forced_compression, Dagger.ImageLayerCompression.t() | nil},
-          {:media_types, Dagger.ImageMediaTypes.t() | nil}
+          {:media_types, Dagger.ImageMediaTypes.t() | nil},
+          {:sign, Dagger.SecretID.t() | nil},
+          {:sign_password, Dagger.SecretID.t() | nil},
+          {:sbom, Dagger.SBOMFormat.t() | nil},
+          {:provenance, boolean() | nil}
         ]) :: {:ok, String.t()} | {:error, term()}
   def publish(%__MODULE__{} = container, address, optional_args \\ []) do
     query_builder =
@@ -466,6 +504,10 @@ defmodule Dagger.Container do
       )
       |> QB.maybe_put_arg("forcedCompression", optional_args[:forced_compression])
       |> QB.maybe_put_arg("mediaTypes", optional_args[:media_types])
+      |> QB.maybe_put_arg("sign", optional_args[:sign])
+      |> QB.maybe_put_arg("signPassword", optional_args[:sign_password])
+      |> QB.maybe_put_arg("sbom", optional_args[:sbom])
+      |> QB.maybe_put_arg("provenance", optional_args[:provenance])
 
     Client.execute(container.client, query_builder)
   end
@@ -565,6 +607,7 @@ defmodule Dagger.Container do
   @spec up(t(), [
           {:random, boolean() | nil},
           {:ports, [Dagger.PortForward.t()]},
+          {:timeout, String.t() | nil},
           {:args, [String.t()]},
           {:use_entrypoint, boolean() | nil},
           {:experimental_privileged_nesting, boolean() | nil},
@@ -578,6 +621,7 @@ defmodule Dagger.Container do
       |> QB.select("up")
       |> QB.maybe_put_arg("random", optional_args[:random])
       |> QB.maybe_put_arg("ports", optional_args[:ports])
+      |> QB.maybe_put_arg("timeout", optional_args[:timeout])
       |> QB.maybe_put_arg("args", optional_args[:args])
       |> QB.maybe_put_arg("useEntrypoint", optional_args[:use_entrypoint])
       |> QB.maybe_put_arg(
@@ -622,6 +666,20 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Limits the CPU time available to commands run in this container.
+  """
+  @spec with_cpu_limit(t(), float()) :: Dagger.Container.t()
+  def with_cpu_limit(%__MODULE__{} = container, cpus) do
+    query_builder =
+      container.query_builder |> QB.select("withCPULimit") |> QB.put_arg("cpus", cpus)
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Configures default arguments for future commands. Like CMD in Dockerfile.
   """
@@ -768,7 +826,10 @@ defmodule Dagger.Container do
           {:experimental_privileged_nesting, boolean() | nil},
           {:insecure_root_capabilities, boolean() | nil},
           {:expand, boolean() | nil},
-          {:no_init, boolean() | nil}
+          {:no_init, boolean() | nil},
+          {:timeout, String.t() | nil},
+          {:network_mode, Dagger.NetworkMode.t() | nil},
+          {:network_allow, [String.t()]}
         ]) :: Dagger.Container.t()
   def with_exec(%__MODULE__{} = container, args, optional_args \\ []) do
     query_builder =
@@ -788,6 +849,9 @@ defmodule Dagger.Container do
       |> QB.maybe_put_arg("insecureRootCapabilities", optional_args[:insecure_root_capabilities])
       |> QB.maybe_put_arg("expand", optional_args[:expand])
       |> QB.maybe_put_arg("noInit", optional_args[:no_init])
+      |> QB.maybe_put_arg("timeout", optional_args[:timeout])
+      |> QB.maybe_put_arg("networkMode", optional_args[:network_mode])
+      |> QB.maybe_put_arg("networkAllow", optional_args[:network_allow])
 
     %Dagger.Container{
       query_builder: query_builder,
@@ -875,6 +939,76 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+
+  The service is not considered started until the check passes, after the health checks of its exposed ports.
+
+  Exactly one of args, httpPort and logPattern must be set.
+  """
+  @spec with_healthcheck(t(), [
+          {:args, [String.t()]},
+          {:http_port, integer() | nil},
+          {:http_path, String.t() | nil},
+          {:http_status, integer() | nil},
+          {:log_pattern, String.t() | nil},
+          {:interval, String.t() | nil},
+          {:timeout, String.t() | nil},
+          {:start_period, String.t() | nil},
+          {:retries, integer() | nil}
+        ]) :: Dagger.Container.t()
+  def with_healthcheck(%__MODULE__{} = container, optional_args \\ []) do
+    query_builder =
+      container.query_builder
+      |> QB.select("withHealthcheck")
+      |> QB.maybe_put_arg("args", optional_args[:args])
+      |> QB.maybe_put_arg("httpPort", optional_args[:http_port])
+      |> QB.maybe_put_arg("httpPath", optional_args[:http_path])
+      |> QB.maybe_put_arg("httpStatus", optional_args[:http_status])
+      |> QB.maybe_put_arg("logPattern", optional_args[:log_pattern])
+      |> QB.maybe_put_arg("interval", optional_args[:interval])
+      |> QB.maybe_put_arg("timeout", optional_args[:timeout])
+      |> QB.maybe_put_arg("startPeriod", optional_args[:start_period])
+      |> QB.maybe_put_arg("retries", optional_args[:retries])
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
+  @doc """
+  Sets the relative block IO weight of commands run in this container.
+  """
+  @spec with_io_weight(t(), integer()) :: Dagger.Container.t()
+  def with_io_weight(%__MODULE__{} = container, weight) do
+    query_builder =
+      container.query_builder |> QB.select("withIOWeight") |> QB.put_arg("weight", weight)
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
+  @doc """
+  Use the HEALTHCHECK of the container image as its readiness check when started as a service.
+
+  Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.
+
+  Fails if the image has no healthcheck.
+  """
+  @spec with_image_healthcheck(t()) :: Dagger.Container.t()
+  def with_image_healthcheck(%__MODULE__{} = container) do
+    query_builder =
+      container.query_builder |> QB.select("withImageHealthcheck")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Retrieves this container plus the given label.
   """
@@ -892,6 +1026,22 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Limits the memory available to commands run in this container.
+
+  A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.
+  """
+  @spec with_memory_limit(t(), String.t()) :: Dagger.Container.t()
+  def with_memory_limit(%__MODULE__{} = container, limit) do
+    query_builder =
+      container.query_builder |> QB.select("withMemoryLimit") |> QB.put_arg("limit", limit)
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Retrieves this container plus a cache volume mounted at the given path.
   """
@@ -1029,6 +1179,20 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Limits the number of processes that commands run in this container may create.
+  """
+  @spec with_pids_limit(t(), integer()) :: Dagger.Container.t()
+  def with_pids_limit(%__MODULE__{} = container, limit) do
+    query_builder =
+      container.query_builder |> QB.select("withPidsLimit") |> QB.put_arg("limit", limit)
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Attach credentials for future publishing to a registry. Use in combination with publish
   """
@@ -1302,6 +1466,20 @@ defmodule Dagger.Container do
     }
   end
 
+  @doc """
+  Retrieves this container without its readiness check.
+  """
+  @spec without_healthcheck(t()) :: Dagger.Container.t()
+  def without_healthcheck(%__MODULE__{} = container) do
+    query_builder =
+      container.query_builder |> QB.select("withoutHealthcheck")
+
+    %Dagger.Container{
+      query_builder: query_builder,
+      client: container.client
+    }
+  end
+
   @doc """
   Retrieves this container minus the given environment label.
   """
@@ -310,6 +310,46 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type ContainerAttestation in the environment
+  """
+  @spec with_container_attestation_input(
+          t(),
+          String.t(),
+          Dagger.ContainerAttestation.t(),
+          String.t()
+        ) :: Dagger.Env.t()
+  def with_container_attestation_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerAttestationInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired ContainerAttestation output to be assigned in the environment
+  """
+  @spec with_container_attestation_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_container_attestation_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withContainerAttestationOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type Container in the environment
   """
@@ -501,6 +541,77 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type GitBlameLine in the environment
+  """
+  @spec with_git_blame_line_input(t(), String.t(), Dagger.GitBlameLine.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_git_blame_line_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withGitBlameLineInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired GitBlameLine output to be assigned in the environment
+  """
+  @spec with_git_blame_line_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_git_blame_line_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withGitBlameLineOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Create or update a binding of type GitCommit in the environment
+  """
+  @spec with_git_commit_input(t(), String.t(), Dagger.GitCommit.t(), String.t()) :: Dagger.Env.t()
+  def with_git_commit_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withGitCommitInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired GitCommit output to be assigned in the environment
+  """
+  @spec with_git_commit_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_git_commit_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withGitCommitOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Create or update a binding of type GitRef in the environment
   """
@@ -607,6 +718,42 @@ defmodule Dagger.Env do
     }
   end
 
+  @doc """
+  Create or update a binding of type LLMStreamEvent in the environment
+  """
+  @spec with_llm_stream_event_input(t(), String.t(), Dagger.LLMStreamEvent.t(), String.t()) ::
+          Dagger.Env.t()
+  def with_llm_stream_event_input(%__MODULE__{} = env, name, value, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withLLMStreamEventInput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("value", Dagger.ID.id!(value))
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
+  @doc """
+  Declare a desired LLMStreamEvent output to be assigned in the environment
+  """
+  @spec with_llm_stream_event_output(t(), String.t(), String.t()) :: Dagger.Env.t()
+  def with_llm_stream_event_output(%__MODULE__{} = env, name, description) do
+    query_builder =
+      env.query_builder
+      |> QB.select("withLLMStreamEventOutput")
+      |> QB.put_arg("name", name)
+      |> QB.put_arg("description", description)
+
+    %Dagger.Env{
+      query_builder: query_builder,
+      client: env.client
+    }
+  end
+
   @doc """
   Installs a module into the environment, exposing its functions to the model
 
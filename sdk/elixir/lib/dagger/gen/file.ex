@@ -45,6 +45,32 @@ defmodule Dagger.File do
     }
   end
 
+  @doc """
+  Return the lines of this file with the commit that last changed them.
+
+  The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.
+
+  Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.
+  """
+  @spec blame(t()) :: {:ok, [Dagger.GitBlameLine.t()]} | {:error, term()}
+  def blame(%__MODULE__{} = file) do
+    query_builder =
+      file.query_builder |> QB.select("blame") |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(file.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.GitBlameLine{
+           query_builder:
+             QB.query()
+             |> QB.select("loadGitBlameLineFromID")
+             |> QB.put_arg("id", id),
+           client: file.client
+         }
+       end}
+    end
+  end
+
   @doc """
   Change the owner of the file recursively.
   """
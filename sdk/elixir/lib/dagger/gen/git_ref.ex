@@ -42,6 +42,22 @@ defmodule Dagger.GitRef do
     }
   end
 
+  @doc """
+  Return the changes from this ref to another ref, like "git diff".
+
+  The .git directory isn't part of the changes.
+  """
+  @spec diff(t(), Dagger.GitRef.t()) :: Dagger.Changeset.t()
+  def diff(%__MODULE__{} = git_ref, other) do
+    query_builder =
+      git_ref.query_builder |> QB.select("diff") |> QB.put_arg("other", Dagger.ID.id!(other))
+
+    %Dagger.Changeset{
+      query_builder: query_builder,
+      client: git_ref.client
+    }
+  end
+
   @doc """
   A unique identifier for this GitRef.
   """
@@ -53,6 +69,64 @@ defmodule Dagger.GitRef do
     Client.execute(git_ref.client, query_builder)
   end
 
+  @doc """
+  The history of this ref, most recent commits first.
+  """
+  @spec log(t(), [{:paths, [String.t()]}, {:limit, integer() | nil}, {:since, String.t() | nil}]) ::
+          {:ok, [Dagger.GitCommit.t()]} | {:error, term()}
+  def log(%__MODULE__{} = git_ref, optional_args \\ []) do
+    query_builder =
+      git_ref.query_builder
+      |> QB.select("log")
+      |> QB.maybe_put_arg("paths", optional_args[:paths])
+      |> QB.maybe_put_arg("limit", optional_args[:limit])
+      |> QB.maybe_put_arg("since", optional_args[:since])
+      |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(git_ref.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.GitCommit{
+           query_builder:
+             QB.query()
+             |> QB.select("loadGitCommitFromID")
+             |> QB.put_arg("id", id),
+           client: git_ref.client
+         }
+       end}
+    end
+  end
+
+  @doc """
+  Push the commit of this ref to a remote repository.
+
+  Returns the updated remote ref.
+  """
+  @spec push(t(), String.t(), [
+          {:ref, String.t() | nil},
+          {:force, boolean() | nil},
+          {:ssh_known_hosts, String.t() | nil},
+          {:ssh_auth_socket, Dagger.SocketID.t() | nil},
+          {:http_auth_username, String.t() | nil},
+          {:http_auth_token, Dagger.SecretID.t() | nil},
+          {:http_auth_header, Dagger.SecretID.t() | nil}
+        ]) :: {:ok, String.t()} | {:error, term()}
+  def push(%__MODULE__{} = git_ref, remote, optional_args \\ []) do
+    query_builder =
+      git_ref.query_builder
+      |> QB.select("push")
+      |> QB.put_arg("remote", remote)
+      |> QB.maybe_put_arg("ref", optional_args[:ref])
+      |> QB.maybe_put_arg("force", optional_args[:force])
+      |> QB.maybe_put_arg("sshKnownHosts", optional_args[:ssh_known_hosts])
+      |> QB.maybe_put_arg("sshAuthSocket", optional_args[:ssh_auth_socket])
+      |> QB.maybe_put_arg("httpAuthUsername", optional_args[:http_auth_username])
+      |> QB.maybe_put_arg("httpAuthToken", optional_args[:http_auth_token])
+      |> QB.maybe_put_arg("httpAuthHeader", optional_args[:http_auth_header])
+
+    Client.execute(git_ref.client, query_builder)
+  end
+
   @doc """
   The resolved ref name at this ref.
   """
@@ -64,23 +138,65 @@ defmodule Dagger.GitRef do
     Client.execute(git_ref.client, query_builder)
   end
 
+  @doc """
+  The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.
+  """
+  @spec signer(t()) :: {:ok, String.t()} | {:error, term()}
+  def signer(%__MODULE__{} = git_ref) do
+    query_builder =
+      git_ref.query_builder |> QB.select("signer")
+
+    Client.execute(git_ref.client, query_builder)
+  end
+
   @doc """
   The filesystem tree at this ref.
   """
-  @spec tree(t(), [{:discard_git_dir, boolean() | nil}, {:depth, integer() | nil}]) ::
-          Dagger.Directory.t()
+  @spec tree(t(), [
+          {:discard_git_dir, boolean() | nil},
+          {:depth, integer() | nil},
+          {:sparse, [String.t()]},
+          {:lfs, boolean() | nil}
+        ]) :: Dagger.Directory.t()
   def tree(%__MODULE__{} = git_ref, optional_args \\ []) do
     query_builder =
       git_ref.query_builder
       |> QB.select("tree")
       |> QB.maybe_put_arg("discardGitDir", optional_args[:discard_git_dir])
       |> QB.maybe_put_arg("depth", optional_args[:depth])
+      |> QB.maybe_put_arg("sparse", optional_args[:sparse])
+      |> QB.maybe_put_arg("lfs", optional_args[:lfs])
 
     %Dagger.Directory{
       query_builder: query_builder,
       client: git_ref.client
     }
   end
+
+  @doc """
+  Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+
+  Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+
+  Returns the verified ref, with its signer.
+  """
+  @spec verify(t(), [{:allowed_signers, Dagger.FileID.t() | nil}, {:keys, [Dagger.SecretID.t()]}]) ::
+          Dagger.GitRef.t()
+  def verify(%__MODULE__{} = git_ref, optional_args \\ []) do
+    query_builder =
+      git_ref.query_builder
+      |> QB.select("verify")
+      |> QB.maybe_put_arg("allowedSigners", optional_args[:allowed_signers])
+      |> QB.maybe_put_arg(
+        "keys",
+        if(optional_args[:keys], do: Enum.map(optional_args[:keys], &Dagger.ID.id!/1), else: nil)
+      )
+
+    %Dagger.GitRef{
+      query_builder: query_builder,
+      client: git_ref.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.GitRef do
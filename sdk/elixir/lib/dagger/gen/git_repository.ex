@@ -160,6 +160,26 @@ defmodule Dagger.GitRepository do
 
     Client.execute(git_repository.client, query_builder)
   end
+
+  @doc """
+  Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.
+
+  The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.
+  """
+  @spec with_commit(t(), Dagger.Changeset.t(), String.t(), String.t()) :: Dagger.GitRepository.t()
+  def with_commit(%__MODULE__{} = git_repository, changes, message, author) do
+    query_builder =
+      git_repository.query_builder
+      |> QB.select("withCommit")
+      |> QB.put_arg("changes", Dagger.ID.id!(changes))
+      |> QB.put_arg("message", message)
+      |> QB.put_arg("author", author)
+
+    %Dagger.GitRepository{
+      query_builder: query_builder,
+      client: git_repository.client
+    }
+  end
 end
 
 defimpl Jason.Encoder, for: Dagger.GitRepository do
@@ -43,6 +43,22 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  Return the cassette recorded by the LLM, to replay with withCassette
+
+  Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is.
+  """
+  @spec cassette(t()) :: Dagger.File.t()
+  def cassette(%__MODULE__{} = llm) do
+    query_builder =
+      llm.query_builder |> QB.select("cassette")
+
+    %Dagger.File{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
   @doc """
   return the LLM's current environment
   """
@@ -168,6 +184,34 @@ defmodule Dagger.LLM do
     end
   end
 
+  @doc """
+  Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+
+  To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+  """
+  @spec stream(t(), [{:after, integer() | nil}]) ::
+          {:ok, [Dagger.LLMStreamEvent.t()]} | {:error, term()}
+  def stream(%__MODULE__{} = llm, optional_args \\ []) do
+    query_builder =
+      llm.query_builder
+      |> QB.select("stream")
+      |> QB.maybe_put_arg("after", optional_args[:after])
+      |> QB.select("id")
+
+    with {:ok, items} <- Client.execute(llm.client, query_builder) do
+      {:ok,
+       for %{"id" => id} <- items do
+         %Dagger.LLMStreamEvent{
+           query_builder:
+             QB.query()
+             |> QB.select("loadLLMStreamEventFromID")
+             |> QB.put_arg("id", id),
+           client: llm.client
+         }
+       end}
+    end
+  end
+
   @doc """
   synchronize LLM state
   """
@@ -230,6 +274,48 @@ defmodule Dagger.LLM do
     }
   end
 
+  @doc """
+  Limit the tokens and estimated cost of the LLM.
+
+  When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+  """
+  @spec with_budget(t(), [{:max_tokens, integer() | nil}, {:max_cost, float() | nil}]) ::
+          Dagger.LLM.t()
+  def with_budget(%__MODULE__{} = llm, optional_args \\ []) do
+    query_builder =
+      llm.query_builder
+      |> QB.select("withBudget")
+      |> QB.maybe_put_arg("maxTokens", optional_args[:max_tokens])
+      |> QB.maybe_put_arg("maxCost", optional_args[:max_cost])
+
+    %Dagger.LLM{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
+  @doc """
+  Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+
+  Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+  """
+  @spec with_cassette(t(), [
+          {:file, Dagger.FileID.t() | nil},
+          {:mode, Dagger.LLMCassetteMode.t() | nil}
+        ]) :: Dagger.LLM.t()
+  def with_cassette(%__MODULE__{} = llm, optional_args \\ []) do
+    query_builder =
+      llm.query_builder
+      |> QB.select("withCassette")
+      |> QB.maybe_put_arg("file", optional_args[:file])
+      |> QB.maybe_put_arg("mode", optional_args[:mode])
+
+    %Dagger.LLM{
+      query_builder: query_builder,
+      client: llm.client
+    }
+  end
+
   @doc """
   allow the LLM to interact with an environment via MCP
   """
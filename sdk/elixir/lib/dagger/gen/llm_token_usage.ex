@@ -31,6 +31,17 @@ defmodule Dagger.LLMTokenUsage do
     Client.execute(llm_token_usage.client, query_builder)
   end
 
+  @doc """
+  The estimated cost in USD, if the price of the model is known.
+  """
+  @spec cost(t()) :: {:ok, float()} | {:error, term()}
+  def cost(%__MODULE__{} = llm_token_usage) do
+    query_builder =
+      llm_token_usage.query_builder |> QB.select("cost")
+
+    Client.execute(llm_token_usage.client, query_builder)
+  end
+
   @doc """
   A unique identifier for this LLMTokenUsage.
   """
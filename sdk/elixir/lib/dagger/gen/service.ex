@@ -83,10 +83,12 @@ defmodule Dagger.Service do
 
   Services bound to a Container do not need to be manually started.
   """
-  @spec start(t()) :: {:ok, Dagger.Service.t()} | {:error, term()}
-  def start(%__MODULE__{} = service) do
+  @spec start(t(), [{:timeout, String.t() | nil}]) :: {:ok, Dagger.Service.t()} | {:error, term()}
+  def start(%__MODULE__{} = service, optional_args \\ []) do
     query_builder =
-      service.query_builder |> QB.select("start")
+      service.query_builder
+      |> QB.select("start")
+      |> QB.maybe_put_arg("timeout", optional_args[:timeout])
 
     with {:ok, id} <- Client.execute(service.client, query_builder) do
       {:ok,
@@ -156,14 +158,18 @@ defmodule Dagger.Service do
   @doc """
   Creates a tunnel that forwards traffic from the caller's network to this service.
   """
-  @spec up(t(), [{:ports, [Dagger.PortForward.t()]}, {:random, boolean() | nil}]) ::
-          :ok | {:error, term()}
+  @spec up(t(), [
+          {:ports, [Dagger.PortForward.t()]},
+          {:random, boolean() | nil},
+          {:timeout, String.t() | nil}
+        ]) :: :ok | {:error, term()}
   def up(%__MODULE__{} = service, optional_args \\ []) do
     query_builder =
       service.query_builder
       |> QB.select("up")
       |> QB.maybe_put_arg("ports", optional_args[:ports])
       |> QB.maybe_put_arg("random", optional_args[:random])
+      |> QB.maybe_put_arg("timeout", optional_args[:timeout])
 
     case Client.execute(service.client, query_builder) do
       {:ok, _} -> :ok
@@ -234,6 +234,12 @@ func LoadCloudFromID(id dagger.CloudID) *dagger.Cloud {
 	return client.LoadCloudFromID(id)
 }
 
+// Load a ContainerAttestation from its ID.
+func LoadContainerAttestationFromID(id dagger.ContainerAttestationID) *dagger.ContainerAttestation {
+	client := initClient()
+	return client.LoadContainerAttestationFromID(id)
+}
+
 // Load a Container from its ID.
 func LoadContainerFromID(id dagger.ContainerID) *dagger.Container {
 	client := initClient()
@@ -372,6 +378,18 @@ func LoadGeneratorGroupFromID(id dagger.GeneratorGroupID) *dagger.GeneratorGroup
 	return client.LoadGeneratorGroupFromID(id)
 }
 
+// Load a GitBlameLine from its ID.
+func LoadGitBlameLineFromID(id dagger.GitBlameLineID) *dagger.GitBlameLine {
+	client := initClient()
+	return client.LoadGitBlameLineFromID(id)
+}
+
+// Load a GitCommit from its ID.
+func LoadGitCommitFromID(id dagger.GitCommitID) *dagger.GitCommit {
+	client := initClient()
+	return client.LoadGitCommitFromID(id)
+}
+
 // Load a GitRef from its ID.
 func LoadGitRefFromID(id dagger.GitRefID) *dagger.GitRef {
 	client := initClient()
@@ -414,6 +432,12 @@ func LoadLLMFromID(id dagger.LLMID) *dagger.LLM {
 	return client.LoadLLMFromID(id)
 }
 
+// Load a LLMStreamEvent from its ID.
+func LoadLLMStreamEventFromID(id dagger.LLMStreamEventID) *dagger.LLMStreamEvent {
+	client := initClient()
+	return client.LoadLLMStreamEventFromID(id)
+}
+
 // Load a LLMTokenUsage from its ID.
 func LoadLLMTokenUsageFromID(id dagger.LLMTokenUsageID) *dagger.LLMTokenUsage {
 	client := initClient()
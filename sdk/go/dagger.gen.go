@@ -97,6 +97,31 @@ func getCustomError(err error) error {
 		if stderr, ok := ext["stderr"].(string); ok {
 			e.Stderr = stderr
 		}
+		if timedOut, ok := ext["timedOut"].(bool); ok {
+			e.TimedOut = timedOut
+		}
+		if oomKilled, ok := ext["oomKilled"].(bool); ok {
+			e.OOMKilled = oomKilled
+		}
+		return e
+	}
+
+	if typ == "LLM_BUDGET_EXCEEDED" {
+		e := &LLMBudgetExceededError{
+			original: lessNoisyErr,
+		}
+		if scope, ok := ext["scope"].(string); ok {
+			e.Scope = scope
+		}
+		if limit, ok := ext["limit"].(string); ok {
+			e.Limit = limit
+		}
+		if used, ok := ext["used"].(float64); ok {
+			e.Used = used
+		}
+		if maximum, ok := ext["max"].(float64); ok {
+			e.Max = maximum
+		}
 		return e
 	}
 
@@ -110,6 +135,10 @@ type ExecError struct {
 	ExitCode int
 	Stdout   string
 	Stderr   string
+	// TimedOut is set if the command was terminated for exceeding its timeout
+	TimedOut bool
+	// OOMKilled is set if the command was killed for exceeding its memory limit
+	OOMKilled bool
 }
 
 var _ extendedError = (*ExecError)(nil)
@@ -130,6 +159,36 @@ func (e *ExecError) Unwrap() error {
 	return e.original
 }
 
+// LLMBudgetExceededError is an API error from an LLM exceeding its budget.
+type LLMBudgetExceededError struct {
+	original extendedError
+	// Scope is the scope of the exceeded budget: "llm", "session" or "module"
+	Scope string
+	// Limit is the exceeded limit: "tokens" or "cost"
+	Limit string
+	// Used and Max are the usage and maximum of the limit, in tokens or USD
+	Used float64
+	Max  float64
+}
+
+var _ extendedError = (*LLMBudgetExceededError)(nil)
+
+func (e *LLMBudgetExceededError) Error() string {
+	return e.Message()
+}
+
+func (e *LLMBudgetExceededError) Extensions() map[string]any {
+	return e.original.Extensions()
+}
+
+func (e *LLMBudgetExceededError) Message() string {
+	return e.original.Error()
+}
+
+func (e *LLMBudgetExceededError) Unwrap() error {
+	return e.original
+}
+
 // The `AddressID` scalar type represents an identifier for an object of type Address.
 type AddressID string
 
@@ -151,6 +210,9 @@ type CheckID string
 // The `CloudID` scalar type represents an identifier for an object of type Cloud.
 type CloudID string
 
+// The `ContainerAttestationID` scalar type represents an identifier for an object of type ContainerAttestation.
+type ContainerAttestationID string
+
 // The `ContainerID` scalar type represents an identifier for an object of type Container.
 type ContainerID string
 
@@ -220,6 +282,12 @@ type GeneratorGroupID string
 // The `GeneratorID` scalar type represents an identifier for an object of type Generator.
 type GeneratorID string
 
+// The `GitBlameLineID` scalar type represents an identifier for an object of type GitBlameLine.
+type GitBlameLineID string
+
+// The `GitCommitID` scalar type represents an identifier for an object of type GitCommit.
+type GitCommitID string
+
 // The `GitRefID` scalar type represents an identifier for an object of type GitRef.
 type GitRefID string
 
@@ -244,6 +312,9 @@ type JSONValueID string
 // The `LLMID` scalar type represents an identifier for an object of type LLM.
 type LLMID string
 
+// The `LLMStreamEventID` scalar type represents an identifier for an object of type LLMStreamEvent.
+type LLMStreamEventID string
+
 // The `LLMTokenUsageID` scalar type represents an identifier for an object of type LLMTokenUsage.
 type LLMTokenUsageID string
 
@@ -618,6 +689,15 @@ func (r *Binding) AsContainer() *Container {
 	}
 }
 
+// Retrieve the binding value, as type ContainerAttestation
+func (r *Binding) AsContainerAttestation() *ContainerAttestation {
+	q := r.query.Select("asContainerAttestation")
+
+	return &ContainerAttestation{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type Directory
 func (r *Binding) AsDirectory() *Directory {
 	q := r.query.Select("asDirectory")
@@ -672,6 +752,24 @@ func (r *Binding) AsGeneratorGroup() *GeneratorGroup {
 	}
 }
 
+// Retrieve the binding value, as type GitBlameLine
+func (r *Binding) AsGitBlameLine() *GitBlameLine {
+	q := r.query.Select("asGitBlameLine")
+
+	return &GitBlameLine{
+		query: q,
+	}
+}
+
+// Retrieve the binding value, as type GitCommit
+func (r *Binding) AsGitCommit() *GitCommit {
+	q := r.query.Select("asGitCommit")
+
+	return &GitCommit{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type GitRef
 func (r *Binding) AsGitRef() *GitRef {
 	q := r.query.Select("asGitRef")
@@ -699,6 +797,15 @@ func (r *Binding) AsJSONValue() *JSONValue {
 	}
 }
 
+// Retrieve the binding value, as type LLMStreamEvent
+func (r *Binding) AsLLMStreamEvent() *LLMStreamEvent {
+	q := r.query.Select("asLLMStreamEvent")
+
+	return &LLMStreamEvent{
+		query: q,
+	}
+}
+
 // Retrieve the binding value, as type Module
 func (r *Binding) AsModule() *Module {
 	q := r.query.Select("asModule")
@@ -1166,12 +1273,21 @@ func (r *Changeset) WithChangesets(changes []*Changeset, opts ...ChangesetWithCh
 type Check struct {
 	query *querybuilder.Selection
 
-	completed   *bool
-	description *string
-	id          *CheckID
-	name        *string
-	passed      *bool
-	resultEmoji *string
+	attempts        *int
+	completed       *bool
+	description     *string
+	durationMs      *int
+	errorMessage    *string
+	id              *CheckID
+	logExcerpt      *string
+	name            *string
+	passed          *bool
+	quarantined     *bool
+	resultEmoji     *string
+	selectionReason *string
+	skipped         *bool
+	spanID          *string
+	traceID         *string
 }
 type WithCheckFunc func(r *Check) *Check
 
@@ -1188,6 +1304,19 @@ func (r *Check) WithGraphQLQuery(q *querybuilder.Selection) *Check {
 	}
 }
 
+// The number of times the check was run, including retries
+func (r *Check) Attempts(ctx context.Context) (int, error) {
+	if r.attempts != nil {
+		return *r.attempts, nil
+	}
+	q := r.query.Select("attempts")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // Whether the check completed
 func (r *Check) Completed(ctx context.Context) (bool, error) {
 	if r.completed != nil {
@@ -1214,6 +1343,32 @@ func (r *Check) Description(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// How long the check took to run, in milliseconds
+func (r *Check) DurationMs(ctx context.Context) (int, error) {
+	if r.durationMs != nil {
+		return *r.durationMs, nil
+	}
+	q := r.query.Select("durationMs")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The error the check failed with, if any
+func (r *Check) ErrorMessage(ctx context.Context) (string, error) {
+	if r.errorMessage != nil {
+		return *r.errorMessage, nil
+	}
+	q := r.query.Select("errorMessage")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // A unique identifier for this Check.
 func (r *Check) ID(ctx context.Context) (CheckID, error) {
 	if r.id != nil {
@@ -1254,6 +1409,19 @@ func (r *Check) MarshalJSON() ([]byte, error) {
 	return json.Marshal(id)
 }
 
+// The last lines of output of the failed command, if the check failed running one
+func (r *Check) LogExcerpt(ctx context.Context) (string, error) {
+	if r.logExcerpt != nil {
+		return *r.logExcerpt, nil
+	}
+	q := r.query.Select("logExcerpt")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // Return the fully qualified name of the check
 func (r *Check) Name(ctx context.Context) (string, error) {
 	if r.name != nil {
@@ -1299,6 +1467,19 @@ func (r *Check) Path(ctx context.Context) ([]string, error) {
 	return response, q.Execute(ctx)
 }
 
+// Whether the check is quarantined: its failures are reported, but don't fail the group
+func (r *Check) Quarantined(ctx context.Context) (bool, error) {
+	if r.quarantined != nil {
+		return *r.quarantined, nil
+	}
+	q := r.query.Select("quarantined")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // An emoji representing the result of the check
 func (r *Check) ResultEmoji(ctx context.Context) (string, error) {
 	if r.resultEmoji != nil {
@@ -1312,15 +1493,79 @@ func (r *Check) ResultEmoji(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// CheckRunOpts contains options for Check.Run
+type CheckRunOpts struct {
+	// Number of times the check is retried if it fails
+	Retries int
+}
+
 // Execute the check
-func (r *Check) Run() *Check {
+func (r *Check) Run(opts ...CheckRunOpts) *Check {
 	q := r.query.Select("run")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `retries` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Retries) {
+			q = q.Arg("retries", opts[i].Retries)
+		}
+	}
 
 	return &Check{
 		query: q,
 	}
 }
 
+// Why the check was selected or skipped by affectedBy
+func (r *Check) SelectionReason(ctx context.Context) (string, error) {
+	if r.selectionReason != nil {
+		return *r.selectionReason, nil
+	}
+	q := r.query.Select("selectionReason")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy
+func (r *Check) Skipped(ctx context.Context) (bool, error) {
+	if r.skipped != nil {
+		return *r.skipped, nil
+	}
+	q := r.query.Select("skipped")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ID of the span of the check
+func (r *Check) SpanID(ctx context.Context) (string, error) {
+	if r.spanID != nil {
+		return *r.spanID, nil
+	}
+	q := r.query.Select("spanID")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ID of the trace the check ran in
+func (r *Check) TraceID(ctx context.Context) (string, error) {
+	if r.traceID != nil {
+		return *r.traceID, nil
+	}
+	q := r.query.Select("traceID")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 type CheckGroup struct {
 	query *querybuilder.Selection
 
@@ -1341,6 +1586,31 @@ func (r *CheckGroup) WithGraphQLQuery(q *querybuilder.Selection) *CheckGroup {
 	}
 }
 
+// Skip the checks whose inputs are not affected by the given changes
+//
+// A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.
+func (r *CheckGroup) AffectedBy(changes *Changeset) *CheckGroup {
+	assertNotNil("changes", changes)
+	q := r.query.Select("affectedBy")
+	q = q.Arg("changes", changes)
+
+	return &CheckGroup{
+		query: q,
+	}
+}
+
+// Skip the checks whose inputs are not affected by the given changed paths
+//
+// Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+func (r *CheckGroup) AffectedByPaths(paths []string) *CheckGroup {
+	q := r.query.Select("affectedByPaths")
+	q = q.Arg("paths", paths)
+
+	return &CheckGroup{
+		query: q,
+	}
+}
+
 // A unique identifier for this CheckGroup.
 func (r *CheckGroup) ID(ctx context.Context) (CheckGroupID, error) {
 	if r.id != nil {
@@ -1414,18 +1684,56 @@ func (r *CheckGroup) List(ctx context.Context) ([]Check, error) {
 	return convert(response), nil
 }
 
-// Generate a markdown report
-func (r *CheckGroup) Report() *File {
+// CheckGroupReportOpts contains options for CheckGroup.Report
+type CheckGroupReportOpts struct {
+	// The format of the report
+	//
+	// Default: MARKDOWN
+	Format CheckReportFormat
+}
+
+// Generate a report of the check results
+func (r *CheckGroup) Report(opts ...CheckGroupReportOpts) *File {
 	q := r.query.Select("report")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `format` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Format) {
+			q = q.Arg("format", opts[i].Format)
+		}
+	}
 
 	return &File{
 		query: q,
 	}
 }
 
+// CheckGroupRunOpts contains options for CheckGroup.Run
+type CheckGroupRunOpts struct {
+	// Cancel the remaining checks as soon as one fails. Quarantined checks don't trigger cancellation.
+	FailFast bool
+	// Number of times a failed check is retried before it is reported as failed
+	Retries int
+	// Record failed checks in the results instead of returning an error, e.g. to generate a report of the failures
+	NoFail bool
+}
+
 // Execute all selected checks
-func (r *CheckGroup) Run() *CheckGroup {
+func (r *CheckGroup) Run(opts ...CheckGroupRunOpts) *CheckGroup {
 	q := r.query.Select("run")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `failFast` optional argument
+		if !querybuilder.IsZeroValue(opts[i].FailFast) {
+			q = q.Arg("failFast", opts[i].FailFast)
+		}
+		// `retries` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Retries) {
+			q = q.Arg("retries", opts[i].Retries)
+		}
+		// `noFail` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NoFail) {
+			q = q.Arg("noFail", opts[i].NoFail)
+		}
+	}
 
 	return &CheckGroup{
 		query: q,
@@ -1634,6 +1942,57 @@ func (r *Container) AsTarball(opts ...ContainerAsTarballOpts) *File {
 	}
 }
 
+// ContainerAttestationsOpts contains options for Container.Attestations
+type ContainerAttestationsOpts struct {
+	// Include a software bill of materials of the packages installed in the container, in the given format.
+	Sbom SBOMFormat
+	// Include SLSA provenance of the calls that produced the container.
+	Provenance bool
+}
+
+// The in-toto attestations that would be attached to the container image when published or exported.
+func (r *Container) Attestations(ctx context.Context, opts ...ContainerAttestationsOpts) ([]ContainerAttestation, error) {
+	q := r.query.Select("attestations")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `sbom` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sbom) {
+			q = q.Arg("sbom", opts[i].Sbom)
+		}
+		// `provenance` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Provenance) {
+			q = q.Arg("provenance", opts[i].Provenance)
+		}
+	}
+
+	q = q.Select("id")
+
+	type attestations struct {
+		Id ContainerAttestationID
+	}
+
+	convert := func(fields []attestations) []ContainerAttestation {
+		out := []ContainerAttestation{}
+
+		for i := range fields {
+			val := ContainerAttestation{id: &fields[i].Id}
+			val.query = q.Root().Select("loadContainerAttestationFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []attestations
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
 // The combined buffered standard output and standard error stream of the last executed command
 //
 // Returns an error if no command was executed
@@ -1832,6 +2191,10 @@ type ContainerExportOpts struct {
 	MediaTypes ImageMediaTypes
 	// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
 	Expand bool
+	// Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+	Sbom SBOMFormat
+	// Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+	Provenance bool
 }
 
 // Writes the container as an OCI tarball to the destination file path on the host.
@@ -1859,6 +2222,14 @@ func (r *Container) Export(ctx context.Context, path string, opts ...ContainerEx
 		if !querybuilder.IsZeroValue(opts[i].Expand) {
 			q = q.Arg("expand", opts[i].Expand)
 		}
+		// `sbom` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sbom) {
+			q = q.Arg("sbom", opts[i].Sbom)
+		}
+		// `provenance` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Provenance) {
+			q = q.Arg("provenance", opts[i].Provenance)
+		}
 	}
 	q = q.Arg("path", path)
 
@@ -1970,9 +2341,23 @@ func (r *Container) File(path string, opts ...ContainerFileOpts) *File {
 	}
 }
 
+// ContainerFromOpts contains options for Container.From
+type ContainerFromOpts struct {
+	// PEM encoded public key to verify the image signature with.
+	//
+	// If set, the image is rejected unless it has a cosign compatible signature verified by this key, attached as an OCI referrer or with the cosign tag scheme.
+	Verify string
+}
+
 // Download a container image, and apply it to the container state. All previous state will be lost.
-func (r *Container) From(address string) *Container {
+func (r *Container) From(address string, opts ...ContainerFromOpts) *Container {
 	q := r.query.Select("from")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `verify` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Verify) {
+			q = q.Arg("verify", opts[i].Verify)
+		}
+	}
 	q = q.Arg("address", address)
 
 	return &Container{
@@ -2142,6 +2527,16 @@ type ContainerPublishOpts struct {
 	//
 	// Default: OCIMediaTypes
 	MediaTypes ImageMediaTypes
+	// PEM encoded private key to sign the published image with.
+	//
+	// The signature is cosign compatible, and attached to the image both as an OCI referrer and with the cosign tag scheme ("sha256-<hex>.sig"), for cosign to find it with or without --experimental-oci11. Encrypted cosign keys are decrypted with signPassword.
+	Sign *Secret
+	// Password of the private key to sign the published image with.
+	SignPassword *Secret
+	// Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+	Sbom SBOMFormat
+	// Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+	Provenance bool
 }
 
 // Package the container state as an OCI image, and publish it to a registry
@@ -2165,6 +2560,22 @@ func (r *Container) Publish(ctx context.Context, address string, opts ...Contain
 		if !querybuilder.IsZeroValue(opts[i].MediaTypes) {
 			q = q.Arg("mediaTypes", opts[i].MediaTypes)
 		}
+		// `sign` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sign) {
+			q = q.Arg("sign", opts[i].Sign)
+		}
+		// `signPassword` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SignPassword) {
+			q = q.Arg("signPassword", opts[i].SignPassword)
+		}
+		// `sbom` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sbom) {
+			q = q.Arg("sbom", opts[i].Sbom)
+		}
+		// `provenance` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Provenance) {
+			q = q.Arg("provenance", opts[i].Provenance)
+		}
 	}
 	q = q.Arg("address", address)
 
@@ -2291,6 +2702,10 @@ type ContainerUpOpts struct {
 	//
 	// Frontend is the port accepting traffic on the host, backend is the service port.
 	Ports []PortForward
+	// Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+	//
+	// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+	Timeout string
 	// Command to run instead of the container's default command (e.g., ["go", "run", "main.go"]).
 	//
 	// If empty, the container's default command is used.
@@ -2326,6 +2741,10 @@ func (r *Container) Up(ctx context.Context, opts ...ContainerUpOpts) error {
 		if !querybuilder.IsZeroValue(opts[i].Ports) {
 			q = q.Arg("ports", opts[i].Ports)
 		}
+		// `timeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Timeout) {
+			q = q.Arg("timeout", opts[i].Timeout)
+		}
 		// `args` optional argument
 		if !querybuilder.IsZeroValue(opts[i].Args) {
 			q = q.Arg("args", opts[i].Args)
@@ -2379,6 +2798,16 @@ func (r *Container) WithAnnotation(name string, value string) *Container {
 	}
 }
 
+// Limits the CPU time available to commands run in this container.
+func (r *Container) WithCPULimit(cpus float64) *Container {
+	q := r.query.Select("withCPULimit")
+	q = q.Arg("cpus", cpus)
+
+	return &Container{
+		query: q,
+	}
+}
+
 // Configures default arguments for future commands. Like CMD in Dockerfile.
 func (r *Container) WithDefaultArgs(args []string) *Container {
 	q := r.query.Select("withDefaultArgs")
@@ -2563,6 +2992,24 @@ type ContainerWithExecOpts struct {
 	//
 	// Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
 	NoInit bool
+	// Maximum duration the command may run, as a Go duration string (e.g. "30s", "5m").
+	//
+	// When it elapses, the command is sent SIGTERM, followed by SIGKILL after a grace period, and the exec fails with a timed out ExecError, which is never cached.
+	//
+	// A timed out exec fails regardless of "expect", even if the command handles SIGTERM and exits with an expected code. To expect a timeout, check whether the ExecError timed out.
+	Timeout string
+	// Network access granted to the command.
+	//
+	// OFFLINE only allows the loopback interface. SERVICES_ONLY allows bound services and DNS. ALLOW_LIST additionally allows the destinations in "networkAllow". Other connections are rejected.
+	//
+	// DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST the command can still send data out through the names it looks up. Use OFFLINE if this must be prevented.
+	//
+	// Default: UNRESTRICTED
+	NetworkMode NetworkMode
+	// Hostnames, IPv4 addresses and CIDRs the command may connect to with the ALLOW_LIST network mode. Example: ["proxy.golang.org", "10.0.0.0/8"]
+	//
+	// Hostnames are resolved once, when the command starts: connections to the addresses they resolve to afterwards (e.g. when a DNS record changes or rotates) are rejected.
+	NetworkAllow []string
 }
 
 // Execute a command in the container, and return a new snapshot of the container state after execution.
@@ -2609,6 +3056,18 @@ func (r *Container) WithExec(args []string, opts ...ContainerWithExecOpts) *Cont
 		if !querybuilder.IsZeroValue(opts[i].NoInit) {
 			q = q.Arg("noInit", opts[i].NoInit)
 		}
+		// `timeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Timeout) {
+			q = q.Arg("timeout", opts[i].Timeout)
+		}
+		// `networkMode` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NetworkMode) {
+			q = q.Arg("networkMode", opts[i].NetworkMode)
+		}
+		// `networkAllow` optional argument
+		if !querybuilder.IsZeroValue(opts[i].NetworkAllow) {
+			q = q.Arg("networkAllow", opts[i].NetworkAllow)
+		}
 	}
 	q = q.Arg("args", args)
 
@@ -2738,45 +3197,165 @@ func (r *Container) WithFiles(path string, sources []*File, opts ...ContainerWit
 	}
 }
 
-// Retrieves this container plus the given label.
-func (r *Container) WithLabel(name string, value string) *Container {
-	q := r.query.Select("withLabel")
-	q = q.Arg("name", name)
-	q = q.Arg("value", value)
-
-	return &Container{
-		query: q,
-	}
-}
-
-// ContainerWithMountedCacheOpts contains options for Container.WithMountedCache
-type ContainerWithMountedCacheOpts struct {
-	// Identifier of the directory to use as the cache volume's root.
-	Source *Directory
-	// Sharing mode of the cache volume.
+// ContainerWithHealthcheckOpts contains options for Container.WithHealthcheck
+type ContainerWithHealthcheckOpts struct {
+	// Command to run in the service container, ready once it exits zero. Example: ["pg_isready", "-U", "postgres"]
+	Args []string
+	// Port to send an HTTP GET request to, ready once it responds with httpStatus. Example: 8080
+	HTTPPort int
+	// Path of the HTTP GET request. Example: "/healthz"
 	//
-	// Default: SHARED
-	Sharing CacheSharingMode
-	// A user:group to set for the mounted cache directory.
+	// Default: "/"
+	HTTPPath string
+	// Expected status of the HTTP response.
 	//
-	// Note that this changes the ownership of the specified mount along with the initial filesystem provided by source (if any). It does not have any effect if/when the cache has already been created.
+	// Default: 200
+	HTTPStatus int
+	// Regular expression matched against the lines of the service stdout, ready once a line matches. Example: "listening on .*:8080"
+	LogPattern string
+	// Time between checks, as a Go duration string. Example: "5s"
 	//
-	// The user and group can either be an ID (1000:1000) or a name (foo:bar).
+	// Default: "1s"
+	Interval string
+	// Time after which a single check fails, as a Go duration string.
 	//
-	// If the group is omitted, it defaults to the same as the user.
-	Owner string
-	// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
-	Expand bool
+	// Default: "10s"
+	Timeout string
+	// Initialization time during which failed checks are not counted, as a Go duration string.
+	//
+	// Default: "0s"
+	StartPeriod string
+	// Number of consecutive failed checks after which the service fails to start.
+	//
+	// Default: 30
+	Retries int
 }
 
-// Retrieves this container plus a cache volume mounted at the given path.
-func (r *Container) WithMountedCache(path string, cache *CacheVolume, opts ...ContainerWithMountedCacheOpts) *Container {
-	assertNotNil("cache", cache)
-	q := r.query.Select("withMountedCache")
+// Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+//
+// The service is not considered started until the check passes, after the health checks of its exposed ports.
+//
+// Exactly one of args, httpPort and logPattern must be set.
+func (r *Container) WithHealthcheck(opts ...ContainerWithHealthcheckOpts) *Container {
+	q := r.query.Select("withHealthcheck")
 	for i := len(opts) - 1; i >= 0; i-- {
-		// `source` optional argument
-		if !querybuilder.IsZeroValue(opts[i].Source) {
-			q = q.Arg("source", opts[i].Source)
+		// `args` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Args) {
+			q = q.Arg("args", opts[i].Args)
+		}
+		// `httpPort` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPPort) {
+			q = q.Arg("httpPort", opts[i].HTTPPort)
+		}
+		// `httpPath` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPPath) {
+			q = q.Arg("httpPath", opts[i].HTTPPath)
+		}
+		// `httpStatus` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPStatus) {
+			q = q.Arg("httpStatus", opts[i].HTTPStatus)
+		}
+		// `logPattern` optional argument
+		if !querybuilder.IsZeroValue(opts[i].LogPattern) {
+			q = q.Arg("logPattern", opts[i].LogPattern)
+		}
+		// `interval` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Interval) {
+			q = q.Arg("interval", opts[i].Interval)
+		}
+		// `timeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Timeout) {
+			q = q.Arg("timeout", opts[i].Timeout)
+		}
+		// `startPeriod` optional argument
+		if !querybuilder.IsZeroValue(opts[i].StartPeriod) {
+			q = q.Arg("startPeriod", opts[i].StartPeriod)
+		}
+		// `retries` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Retries) {
+			q = q.Arg("retries", opts[i].Retries)
+		}
+	}
+
+	return &Container{
+		query: q,
+	}
+}
+
+// Sets the relative block IO weight of commands run in this container.
+func (r *Container) WithIOWeight(weight int) *Container {
+	q := r.query.Select("withIOWeight")
+	q = q.Arg("weight", weight)
+
+	return &Container{
+		query: q,
+	}
+}
+
+// Use the HEALTHCHECK of the container image as its readiness check when started as a service.
+//
+// Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.
+//
+// Fails if the image has no healthcheck.
+func (r *Container) WithImageHealthcheck() *Container {
+	q := r.query.Select("withImageHealthcheck")
+
+	return &Container{
+		query: q,
+	}
+}
+
+// Retrieves this container plus the given label.
+func (r *Container) WithLabel(name string, value string) *Container {
+	q := r.query.Select("withLabel")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+
+	return &Container{
+		query: q,
+	}
+}
+
+// Limits the memory available to commands run in this container.
+//
+// A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.
+func (r *Container) WithMemoryLimit(limit string) *Container {
+	q := r.query.Select("withMemoryLimit")
+	q = q.Arg("limit", limit)
+
+	return &Container{
+		query: q,
+	}
+}
+
+// ContainerWithMountedCacheOpts contains options for Container.WithMountedCache
+type ContainerWithMountedCacheOpts struct {
+	// Identifier of the directory to use as the cache volume's root.
+	Source *Directory
+	// Sharing mode of the cache volume.
+	//
+	// Default: SHARED
+	Sharing CacheSharingMode
+	// A user:group to set for the mounted cache directory.
+	//
+	// Note that this changes the ownership of the specified mount along with the initial filesystem provided by source (if any). It does not have any effect if/when the cache has already been created.
+	//
+	// The user and group can either be an ID (1000:1000) or a name (foo:bar).
+	//
+	// If the group is omitted, it defaults to the same as the user.
+	Owner string
+	// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
+	Expand bool
+}
+
+// Retrieves this container plus a cache volume mounted at the given path.
+func (r *Container) WithMountedCache(path string, cache *CacheVolume, opts ...ContainerWithMountedCacheOpts) *Container {
+	assertNotNil("cache", cache)
+	q := r.query.Select("withMountedCache")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `source` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Source) {
+			q = q.Arg("source", opts[i].Source)
 		}
 		// `sharing` optional argument
 		if !querybuilder.IsZeroValue(opts[i].Sharing) {
@@ -2980,6 +3559,16 @@ func (r *Container) WithNewFile(path string, contents string, opts ...ContainerW
 	}
 }
 
+// Limits the number of processes that commands run in this container may create.
+func (r *Container) WithPidsLimit(limit int) *Container {
+	q := r.query.Select("withPidsLimit")
+	q = q.Arg("limit", limit)
+
+	return &Container{
+		query: q,
+	}
+}
+
 // Attach credentials for future publishing to a registry. Use in combination with publish
 func (r *Container) WithRegistryAuth(address string, username string, secret *Secret) *Container {
 	assertNotNil("secret", secret)
@@ -3263,6 +3852,15 @@ func (r *Container) WithoutFiles(paths []string, opts ...ContainerWithoutFilesOp
 	}
 }
 
+// Retrieves this container without its readiness check.
+func (r *Container) WithoutHealthcheck() *Container {
+	q := r.query.Select("withoutHealthcheck")
+
+	return &Container{
+		query: q,
+	}
+}
+
 // Retrieves this container minus the given environment label.
 func (r *Container) WithoutLabel(name string) *Container {
 	q := r.query.Select("withoutLabel")
@@ -3372,6 +3970,87 @@ func (r *Container) Workdir(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// An in-toto attestation of a container image, such as an SBOM or SLSA provenance.
+type ContainerAttestation struct {
+	query *querybuilder.Selection
+
+	id            *ContainerAttestationID
+	predicate     *JSON
+	predicateType *string
+}
+
+func (r *ContainerAttestation) WithGraphQLQuery(q *querybuilder.Selection) *ContainerAttestation {
+	return &ContainerAttestation{
+		query: q,
+	}
+}
+
+// A unique identifier for this ContainerAttestation.
+func (r *ContainerAttestation) ID(ctx context.Context) (ContainerAttestationID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response ContainerAttestationID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *ContainerAttestation) XXX_GraphQLType() string {
+	return "ContainerAttestation"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *ContainerAttestation) XXX_GraphQLIDType() string {
+	return "ContainerAttestationID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *ContainerAttestation) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *ContainerAttestation) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The predicate of the attestation, as a JSON document.
+func (r *ContainerAttestation) Predicate(ctx context.Context) (JSON, error) {
+	if r.predicate != nil {
+		return *r.predicate, nil
+	}
+	q := r.query.Select("predicate")
+
+	var response JSON
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The in-toto predicate type of the attestation.
+func (r *ContainerAttestation) PredicateType(ctx context.Context) (string, error) {
+	if r.predicateType != nil {
+		return *r.predicateType, nil
+	}
+	q := r.query.Select("predicateType")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // Reflective module API provided to functions at runtime.
 type CurrentModule struct {
 	query *querybuilder.Selection
@@ -5507,6 +6186,30 @@ func (r *Env) WithCloudOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type ContainerAttestation in the environment
+func (r *Env) WithContainerAttestationInput(name string, value *ContainerAttestation, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withContainerAttestationInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired ContainerAttestation output to be assigned in the environment
+func (r *Env) WithContainerAttestationOutput(name string, description string) *Env {
+	q := r.query.Select("withContainerAttestationOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type Container in the environment
 func (r *Env) WithContainerInput(name string, value *Container, description string) *Env {
 	assertNotNil("value", value)
@@ -5686,6 +6389,54 @@ func (r *Env) WithGeneratorOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type GitBlameLine in the environment
+func (r *Env) WithGitBlameLineInput(name string, value *GitBlameLine, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withGitBlameLineInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired GitBlameLine output to be assigned in the environment
+func (r *Env) WithGitBlameLineOutput(name string, description string) *Env {
+	q := r.query.Select("withGitBlameLineOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Create or update a binding of type GitCommit in the environment
+func (r *Env) WithGitCommitInput(name string, value *GitCommit, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withGitCommitInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired GitCommit output to be assigned in the environment
+func (r *Env) WithGitCommitOutput(name string, description string) *Env {
+	q := r.query.Select("withGitCommitOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Create or update a binding of type GitRef in the environment
 func (r *Env) WithGitRefInput(name string, value *GitRef, description string) *Env {
 	assertNotNil("value", value)
@@ -5758,6 +6509,30 @@ func (r *Env) WithJSONValueOutput(name string, description string) *Env {
 	}
 }
 
+// Create or update a binding of type LLMStreamEvent in the environment
+func (r *Env) WithLLMStreamEventInput(name string, value *LLMStreamEvent, description string) *Env {
+	assertNotNil("value", value)
+	q := r.query.Select("withLLMStreamEventInput")
+	q = q.Arg("name", name)
+	q = q.Arg("value", value)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
+// Declare a desired LLMStreamEvent output to be assigned in the environment
+func (r *Env) WithLLMStreamEventOutput(name string, description string) *Env {
+	q := r.query.Select("withLLMStreamEventOutput")
+	q = q.Arg("name", name)
+	q = q.Arg("description", description)
+
+	return &Env{
+		query: q,
+	}
+}
+
 // Sets the main module for this environment (the project being worked on)
 //
 // Contextual path arguments will be populated using the environment's workspace.
@@ -6693,6 +7468,43 @@ func (r *File) AsJSON() *JSONValue {
 	}
 }
 
+// Return the lines of this file with the commit that last changed them.
+//
+// The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.
+//
+// Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.
+func (r *File) Blame(ctx context.Context) ([]GitBlameLine, error) {
+	q := r.query.Select("blame")
+
+	q = q.Select("id")
+
+	type blame struct {
+		Id GitBlameLineID
+	}
+
+	convert := func(fields []blame) []GitBlameLine {
+		out := []GitBlameLine{}
+
+		for i := range fields {
+			val := GitBlameLine{id: &fields[i].Id}
+			val.query = q.Root().Select("loadGitBlameLineFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []blame
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
 // Change the owner of the file recursively.
 func (r *File) Chown(owner string) *File {
 	q := r.query.Select("chown")
@@ -7844,11 +8656,13 @@ func (r *GeneratedCode) WithVCSIgnoredPaths(paths []string) *GeneratedCode {
 type Generator struct {
 	query *querybuilder.Selection
 
-	completed   *bool
-	description *string
-	id          *GeneratorID
-	isEmpty     *bool
-	name        *string
+	completed       *bool
+	description     *string
+	id              *GeneratorID
+	isEmpty         *bool
+	name            *string
+	selectionReason *string
+	skipped         *bool
 }
 type WithGeneratorFunc func(r *Generator) *Generator
 
@@ -7994,15 +8808,41 @@ func (r *Generator) Run() *Generator {
 	}
 }
 
-type GeneratorGroup struct {
-	query *querybuilder.Selection
+// Why the generator was selected or skipped by affectedBy
+func (r *Generator) SelectionReason(ctx context.Context) (string, error) {
+	if r.selectionReason != nil {
+		return *r.selectionReason, nil
+	}
+	q := r.query.Select("selectionReason")
 
-	id      *GeneratorGroupID
-	isEmpty *bool
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
-type WithGeneratorGroupFunc func(r *GeneratorGroup) *GeneratorGroup
 
-// With calls the provided function with current GeneratorGroup.
+// Whether the generator is skipped because none of its inputs are affected by the changes passed to affectedBy
+func (r *Generator) Skipped(ctx context.Context) (bool, error) {
+	if r.skipped != nil {
+		return *r.skipped, nil
+	}
+	q := r.query.Select("skipped")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+type GeneratorGroup struct {
+	query *querybuilder.Selection
+
+	id      *GeneratorGroupID
+	isEmpty *bool
+}
+type WithGeneratorGroupFunc func(r *GeneratorGroup) *GeneratorGroup
+
+// With calls the provided function with current GeneratorGroup.
 //
 // This is useful for reusability and readability by not breaking the calling chain.
 func (r *GeneratorGroup) With(f WithGeneratorGroupFunc) *GeneratorGroup {
@@ -8015,6 +8855,31 @@ func (r *GeneratorGroup) WithGraphQLQuery(q *querybuilder.Selection) *GeneratorG
 	}
 }
 
+// Skip the generators whose inputs are not affected by the given changes
+//
+// A generator's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Generators without any declared input are always selected.
+func (r *GeneratorGroup) AffectedBy(changes *Changeset) *GeneratorGroup {
+	assertNotNil("changes", changes)
+	q := r.query.Select("affectedBy")
+	q = q.Arg("changes", changes)
+
+	return &GeneratorGroup{
+		query: q,
+	}
+}
+
+// Skip the generators whose inputs are not affected by the given changed paths
+//
+// Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+func (r *GeneratorGroup) AffectedByPaths(paths []string) *GeneratorGroup {
+	q := r.query.Select("affectedByPaths")
+	q = q.Arg("paths", paths)
+
+	return &GeneratorGroup{
+		query: q,
+	}
+}
+
 // GeneratorGroupChangesOpts contains options for GeneratorGroup.Changes
 type GeneratorGroupChangesOpts struct {
 	// Strategy to apply on conflicts between generators
@@ -8137,13 +9002,295 @@ func (r *GeneratorGroup) Run() *GeneratorGroup {
 	}
 }
 
+// A line of a file, with the commit that last changed it.
+type GitBlameLine struct {
+	query *querybuilder.Selection
+
+	authorEmail *string
+	authorName  *string
+	commit      *string
+	content     *string
+	date        *string
+	id          *GitBlameLineID
+	line        *int
+}
+
+func (r *GitBlameLine) WithGraphQLQuery(q *querybuilder.Selection) *GitBlameLine {
+	return &GitBlameLine{
+		query: q,
+	}
+}
+
+// The email of the author of the commit.
+func (r *GitBlameLine) AuthorEmail(ctx context.Context) (string, error) {
+	if r.authorEmail != nil {
+		return *r.authorEmail, nil
+	}
+	q := r.query.Select("authorEmail")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The name of the author of the commit.
+func (r *GitBlameLine) AuthorName(ctx context.Context) (string, error) {
+	if r.authorName != nil {
+		return *r.authorName, nil
+	}
+	q := r.query.Select("authorName")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The id of the commit that last changed the line, or all zeroes if it isn't committed yet.
+func (r *GitBlameLine) Commit(ctx context.Context) (string, error) {
+	if r.commit != nil {
+		return *r.commit, nil
+	}
+	q := r.query.Select("commit")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The content of the line.
+func (r *GitBlameLine) Content(ctx context.Context) (string, error) {
+	if r.content != nil {
+		return *r.content, nil
+	}
+	q := r.query.Select("content")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The date the commit was authored, in RFC 3339 format.
+func (r *GitBlameLine) Date(ctx context.Context) (string, error) {
+	if r.date != nil {
+		return *r.date, nil
+	}
+	q := r.query.Select("date")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this GitBlameLine.
+func (r *GitBlameLine) ID(ctx context.Context) (GitBlameLineID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response GitBlameLineID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *GitBlameLine) XXX_GraphQLType() string {
+	return "GitBlameLine"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *GitBlameLine) XXX_GraphQLIDType() string {
+	return "GitBlameLineID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *GitBlameLine) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *GitBlameLine) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The line number, starting at 1.
+func (r *GitBlameLine) Line(ctx context.Context) (int, error) {
+	if r.line != nil {
+		return *r.line, nil
+	}
+	q := r.query.Select("line")
+
+	var response int
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A commit in the history of a git ref.
+type GitCommit struct {
+	query *querybuilder.Selection
+
+	authorEmail *string
+	authorName  *string
+	commit      *string
+	date        *string
+	id          *GitCommitID
+	message     *string
+}
+
+func (r *GitCommit) WithGraphQLQuery(q *querybuilder.Selection) *GitCommit {
+	return &GitCommit{
+		query: q,
+	}
+}
+
+// The email of the author of the commit.
+func (r *GitCommit) AuthorEmail(ctx context.Context) (string, error) {
+	if r.authorEmail != nil {
+		return *r.authorEmail, nil
+	}
+	q := r.query.Select("authorEmail")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The name of the author of the commit.
+func (r *GitCommit) AuthorName(ctx context.Context) (string, error) {
+	if r.authorName != nil {
+		return *r.authorName, nil
+	}
+	q := r.query.Select("authorName")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The commit id.
+func (r *GitCommit) Commit(ctx context.Context) (string, error) {
+	if r.commit != nil {
+		return *r.commit, nil
+	}
+	q := r.query.Select("commit")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The date the commit was authored, in RFC 3339 format.
+func (r *GitCommit) Date(ctx context.Context) (string, error) {
+	if r.date != nil {
+		return *r.date, nil
+	}
+	q := r.query.Select("date")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The paths of the files changed by the commit, compared to its first parent.
+func (r *GitCommit) Files(ctx context.Context) ([]string, error) {
+	q := r.query.Select("files")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// A unique identifier for this GitCommit.
+func (r *GitCommit) ID(ctx context.Context) (GitCommitID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response GitCommitID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *GitCommit) XXX_GraphQLType() string {
+	return "GitCommit"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *GitCommit) XXX_GraphQLIDType() string {
+	return "GitCommitID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *GitCommit) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *GitCommit) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The full message of the commit.
+func (r *GitCommit) Message(ctx context.Context) (string, error) {
+	if r.message != nil {
+		return *r.message, nil
+	}
+	q := r.query.Select("message")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ids of the parents of the commit.
+func (r *GitCommit) Parents(ctx context.Context) ([]string, error) {
+	q := r.query.Select("parents")
+
+	var response []string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // A git ref (tag, branch, or commit).
 type GitRef struct {
 	query *querybuilder.Selection
 
 	commit *string
 	id     *GitRefID
+	push   *string
 	ref    *string
+	signer *string
 }
 type WithGitRefFunc func(r *GitRef) *GitRef
 
@@ -8184,46 +9331,180 @@ func (r *GitRef) CommonAncestor(other *GitRef) *GitRef {
 	}
 }
 
+// Return the changes from this ref to another ref, like "git diff".
+//
+// The .git directory isn't part of the changes.
+func (r *GitRef) Diff(other *GitRef) *Changeset {
+	assertNotNil("other", other)
+	q := r.query.Select("diff")
+	q = q.Arg("other", other)
+
+	return &Changeset{
+		query: q,
+	}
+}
+
 // A unique identifier for this GitRef.
 func (r *GitRef) ID(ctx context.Context) (GitRefID, error) {
 	if r.id != nil {
 		return *r.id, nil
 	}
-	q := r.query.Select("id")
+	q := r.query.Select("id")
+
+	var response GitRefID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *GitRef) XXX_GraphQLType() string {
+	return "GitRef"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *GitRef) XXX_GraphQLIDType() string {
+	return "GitRefID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *GitRef) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *GitRef) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// GitRefLogOpts contains options for GitRef.Log
+type GitRefLogOpts struct {
+	// Only include the commits changing these paths (e.g., ["docs", "README.md"]).
+	Paths []string
+	// The maximum number of commits to return. All the commits are returned if 0.
+	Limit int
+	// Only include the commits more recent than a date, in any format git understands (e.g., "2024-01-31" or "2 weeks ago").
+	Since string
+}
+
+// The history of this ref, most recent commits first.
+func (r *GitRef) Log(ctx context.Context, opts ...GitRefLogOpts) ([]GitCommit, error) {
+	q := r.query.Select("log")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `paths` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Paths) {
+			q = q.Arg("paths", opts[i].Paths)
+		}
+		// `limit` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Limit) {
+			q = q.Arg("limit", opts[i].Limit)
+		}
+		// `since` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Since) {
+			q = q.Arg("since", opts[i].Since)
+		}
+	}
+
+	q = q.Select("id")
+
+	type log struct {
+		Id GitCommitID
+	}
+
+	convert := func(fields []log) []GitCommit {
+		out := []GitCommit{}
+
+		for i := range fields {
+			val := GitCommit{id: &fields[i].Id}
+			val.query = q.Root().Select("loadGitCommitFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []log
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
+// GitRefPushOpts contains options for GitRef.Push
+type GitRefPushOpts struct {
+	// The remote ref to update (e.g., "refs/heads/fix" or "fix" for a branch). Defaults to the name of this ref.
+	Ref string
+	// Update the remote ref even if it isn't an ancestor of the pushed commit.
+	Force bool
+	// Set SSH known hosts
+	SSHKnownHosts string
+	// Set SSH auth socket
+	SSHAuthSocket *Socket
+	// Username used to populate the password during basic HTTP Authorization
+	HTTPAuthUsername string
+	// Secret used to populate the password during basic HTTP Authorization
+	HTTPAuthToken *Secret
+	// Secret used to populate the Authorization HTTP header
+	HTTPAuthHeader *Secret
+}
+
+// Push the commit of this ref to a remote repository.
+//
+// Returns the updated remote ref.
+func (r *GitRef) Push(ctx context.Context, remote string, opts ...GitRefPushOpts) (string, error) {
+	if r.push != nil {
+		return *r.push, nil
+	}
+	q := r.query.Select("push")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `ref` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Ref) {
+			q = q.Arg("ref", opts[i].Ref)
+		}
+		// `force` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Force) {
+			q = q.Arg("force", opts[i].Force)
+		}
+		// `sshKnownHosts` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SSHKnownHosts) {
+			q = q.Arg("sshKnownHosts", opts[i].SSHKnownHosts)
+		}
+		// `sshAuthSocket` optional argument
+		if !querybuilder.IsZeroValue(opts[i].SSHAuthSocket) {
+			q = q.Arg("sshAuthSocket", opts[i].SSHAuthSocket)
+		}
+		// `httpAuthUsername` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPAuthUsername) {
+			q = q.Arg("httpAuthUsername", opts[i].HTTPAuthUsername)
+		}
+		// `httpAuthToken` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPAuthToken) {
+			q = q.Arg("httpAuthToken", opts[i].HTTPAuthToken)
+		}
+		// `httpAuthHeader` optional argument
+		if !querybuilder.IsZeroValue(opts[i].HTTPAuthHeader) {
+			q = q.Arg("httpAuthHeader", opts[i].HTTPAuthHeader)
+		}
+	}
+	q = q.Arg("remote", remote)
 
-	var response GitRefID
+	var response string
 
 	q = q.Bind(&response)
 	return response, q.Execute(ctx)
 }
 
-// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
-func (r *GitRef) XXX_GraphQLType() string {
-	return "GitRef"
-}
-
-// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
-func (r *GitRef) XXX_GraphQLIDType() string {
-	return "GitRefID"
-}
-
-// XXX_GraphQLID is an internal function. It returns the underlying type ID
-func (r *GitRef) XXX_GraphQLID(ctx context.Context) (string, error) {
-	id, err := r.ID(ctx)
-	if err != nil {
-		return "", err
-	}
-	return string(id), nil
-}
-
-func (r *GitRef) MarshalJSON() ([]byte, error) {
-	id, err := r.ID(marshalCtx)
-	if err != nil {
-		return nil, err
-	}
-	return json.Marshal(id)
-}
-
 // The resolved ref name at this ref.
 func (r *GitRef) Ref(ctx context.Context) (string, error) {
 	if r.ref != nil {
@@ -8237,6 +9518,19 @@ func (r *GitRef) Ref(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.
+func (r *GitRef) Signer(ctx context.Context) (string, error) {
+	if r.signer != nil {
+		return *r.signer, nil
+	}
+	q := r.query.Select("signer")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // GitRefTreeOpts contains options for GitRef.Tree
 type GitRefTreeOpts struct {
 	// Set to true to discard .git directory.
@@ -8245,6 +9539,16 @@ type GitRefTreeOpts struct {
 	//
 	// Default: 1
 	Depth int
+	// Directories to check out, in git's sparse checkout cone mode (e.g., ["services/api", "libs"]).
+	//
+	// Files at the root of the repository and in the parents of these directories are checked out too.
+	//
+	// Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"]) are checked out in non-cone mode instead, where only the files matching them, like in .gitignore files, are checked out.
+	//
+	// Without .git directory, the tree only changes when the checked out files change.
+	Sparse []string
+	// Set to true to download the Git LFS objects of the checked out files.
+	Lfs bool
 }
 
 // The filesystem tree at this ref.
@@ -8259,6 +9563,14 @@ func (r *GitRef) Tree(opts ...GitRefTreeOpts) *Directory {
 		if !querybuilder.IsZeroValue(opts[i].Depth) {
 			q = q.Arg("depth", opts[i].Depth)
 		}
+		// `sparse` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Sparse) {
+			q = q.Arg("sparse", opts[i].Sparse)
+		}
+		// `lfs` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Lfs) {
+			q = q.Arg("lfs", opts[i].Lfs)
+		}
 	}
 
 	return &Directory{
@@ -8266,6 +9578,37 @@ func (r *GitRef) Tree(opts ...GitRefTreeOpts) *Directory {
 	}
 }
 
+// GitRefVerifyOpts contains options for GitRef.Verify
+type GitRefVerifyOpts struct {
+	// An SSH allowed signers file, trusting SSH keys for their principals (see ssh-keygen(1)).
+	AllowedSigners *File
+	// Trusted public keys: armored GPG public keys, or SSH public keys trusted for the principal in their comment.
+	Keys []*Secret
+}
+
+// Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+//
+// Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+//
+// Returns the verified ref, with its signer.
+func (r *GitRef) Verify(opts ...GitRefVerifyOpts) *GitRef {
+	q := r.query.Select("verify")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `allowedSigners` optional argument
+		if !querybuilder.IsZeroValue(opts[i].AllowedSigners) {
+			q = q.Arg("allowedSigners", opts[i].AllowedSigners)
+		}
+		// `keys` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Keys) {
+			q = q.Arg("keys", opts[i].Keys)
+		}
+	}
+
+	return &GitRef{
+		query: q,
+	}
+}
+
 // A git repository.
 type GitRepository struct {
 	query *querybuilder.Selection
@@ -8273,6 +9616,14 @@ type GitRepository struct {
 	id  *GitRepositoryID
 	url *string
 }
+type WithGitRepositoryFunc func(r *GitRepository) *GitRepository
+
+// With calls the provided function with current GitRepository.
+//
+// This is useful for reusability and readability by not breaking the calling chain.
+func (r *GitRepository) With(f WithGitRepositoryFunc) *GitRepository {
+	return f(r)
+}
 
 func (r *GitRepository) WithGraphQLQuery(q *querybuilder.Selection) *GitRepository {
 	return &GitRepository{
@@ -8444,6 +9795,21 @@ func (r *GitRepository) URL(ctx context.Context) (string, error) {
 	return response, q.Execute(ctx)
 }
 
+// Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.
+//
+// The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.
+func (r *GitRepository) WithCommit(changes *Changeset, message string, author string) *GitRepository {
+	assertNotNil("changes", changes)
+	q := r.query.Select("withCommit")
+	q = q.Arg("changes", changes)
+	q = q.Arg("message", message)
+	q = q.Arg("author", author)
+
+	return &GitRepository{
+		query: q,
+	}
+}
+
 // Information about the host environment.
 type Host struct {
 	query *querybuilder.Selection
@@ -9195,6 +10561,17 @@ func (r *LLM) BindResult(name string) *Binding {
 	}
 }
 
+// Return the cassette recorded by the LLM, to replay with withCassette
+//
+// Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is.
+func (r *LLM) Cassette() *File {
+	q := r.query.Select("cassette")
+
+	return &File{
+		query: q,
+	}
+}
+
 // return the LLM's current environment
 func (r *LLM) Env() *Env {
 	q := r.query.Select("env")
@@ -9341,6 +10718,53 @@ func (r *LLM) Step(ctx context.Context) (*LLM, error) {
 	}, nil
 }
 
+// LLMStreamOpts contains options for LLM.Stream
+type LLMStreamOpts struct {
+	// The number of events to skip, i.e. the number of events received from previous calls
+	After int
+}
+
+// Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+//
+// To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+func (r *LLM) Stream(ctx context.Context, opts ...LLMStreamOpts) ([]LLMStreamEvent, error) {
+	q := r.query.Select("stream")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `after` optional argument
+		if !querybuilder.IsZeroValue(opts[i].After) {
+			q = q.Arg("after", opts[i].After)
+		}
+	}
+
+	q = q.Select("id")
+
+	type stream struct {
+		Id LLMStreamEventID
+	}
+
+	convert := func(fields []stream) []LLMStreamEvent {
+		out := []LLMStreamEvent{}
+
+		for i := range fields {
+			val := LLMStreamEvent{id: &fields[i].Id}
+			val.query = q.Root().Select("loadLLMStreamEventFromID").Arg("id", fields[i].Id)
+			out = append(out, val)
+		}
+
+		return out
+	}
+	var response []stream
+
+	q = q.Bind(&response)
+
+	err := q.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return convert(response), nil
+}
+
 // synchronize LLM state
 func (r *LLM) Sync(ctx context.Context) (*LLM, error) {
 	q := r.query.Select("sync")
@@ -9387,6 +10811,68 @@ func (r *LLM) WithBlockedFunction(typeName string, function string) *LLM {
 	}
 }
 
+// LLMWithBudgetOpts contains options for LLM.WithBudget
+type LLMWithBudgetOpts struct {
+	// The maximum number of tokens, input and output, summed over all API calls. 0 means unlimited.
+	MaxTokens int
+	// The maximum estimated cost in USD, based on the price of the model. 0 means unlimited.
+	//
+	// Evaluating the LLM fails if the price of the model is unknown.
+	MaxCost float64
+}
+
+// Limit the tokens and estimated cost of the LLM.
+//
+// When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+func (r *LLM) WithBudget(opts ...LLMWithBudgetOpts) *LLM {
+	q := r.query.Select("withBudget")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `maxTokens` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MaxTokens) {
+			q = q.Arg("maxTokens", opts[i].MaxTokens)
+		}
+		// `maxCost` optional argument
+		if !querybuilder.IsZeroValue(opts[i].MaxCost) {
+			q = q.Arg("maxCost", opts[i].MaxCost)
+		}
+	}
+
+	return &LLM{
+		query: q,
+	}
+}
+
+// LLMWithCassetteOpts contains options for LLM.WithCassette
+type LLMWithCassetteOpts struct {
+	// The cassette to replay. Required in REPLAY mode.
+	File *File
+	// Whether to record or replay the cassette
+	//
+	// Default: REPLAY
+	Mode LLMCassetteMode
+}
+
+// Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+//
+// Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+func (r *LLM) WithCassette(opts ...LLMWithCassetteOpts) *LLM {
+	q := r.query.Select("withCassette")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `file` optional argument
+		if !querybuilder.IsZeroValue(opts[i].File) {
+			q = q.Arg("file", opts[i].File)
+		}
+		// `mode` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Mode) {
+			q = q.Arg("mode", opts[i].Mode)
+		}
+	}
+
+	return &LLM{
+		query: q,
+	}
+}
+
 // allow the LLM to interact with an environment via MCP
 func (r *LLM) WithEnv(env *Env) *LLM {
 	assertNotNil("env", env)
@@ -9469,22 +10955,182 @@ func (r *LLM) WithoutDefaultSystemPrompt() *LLM {
 	}
 }
 
-// Clear the message history, leaving only the system prompts
-func (r *LLM) WithoutMessageHistory() *LLM {
-	q := r.query.Select("withoutMessageHistory")
-
-	return &LLM{
-		query: q,
+// Clear the message history, leaving only the system prompts
+func (r *LLM) WithoutMessageHistory() *LLM {
+	q := r.query.Select("withoutMessageHistory")
+
+	return &LLM{
+		query: q,
+	}
+}
+
+// Clear the system prompts, leaving only the default system prompt
+func (r *LLM) WithoutSystemPrompts() *LLM {
+	q := r.query.Select("withoutSystemPrompts")
+
+	return &LLM{
+		query: q,
+	}
+}
+
+// An event emitted while an LLM is evaluated
+type LLMStreamEvent struct {
+	query *querybuilder.Selection
+
+	id            *LLMStreamEventID
+	kind          *LLMStreamEventKind
+	text          *string
+	toolArguments *string
+	toolCallID    *string
+	toolErrored   *bool
+	toolName      *string
+	toolResult    *string
+}
+
+func (r *LLMStreamEvent) WithGraphQLQuery(q *querybuilder.Selection) *LLMStreamEvent {
+	return &LLMStreamEvent{
+		query: q,
+	}
+}
+
+// A unique identifier for this LLMStreamEvent.
+func (r *LLMStreamEvent) ID(ctx context.Context) (LLMStreamEventID, error) {
+	if r.id != nil {
+		return *r.id, nil
+	}
+	q := r.query.Select("id")
+
+	var response LLMStreamEventID
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// XXX_GraphQLType is an internal function. It returns the native GraphQL type name
+func (r *LLMStreamEvent) XXX_GraphQLType() string {
+	return "LLMStreamEvent"
+}
+
+// XXX_GraphQLIDType is an internal function. It returns the native GraphQL type name for the ID of this object
+func (r *LLMStreamEvent) XXX_GraphQLIDType() string {
+	return "LLMStreamEventID"
+}
+
+// XXX_GraphQLID is an internal function. It returns the underlying type ID
+func (r *LLMStreamEvent) XXX_GraphQLID(ctx context.Context) (string, error) {
+	id, err := r.ID(ctx)
+	if err != nil {
+		return "", err
+	}
+	return string(id), nil
+}
+
+func (r *LLMStreamEvent) MarshalJSON() ([]byte, error) {
+	id, err := r.ID(marshalCtx)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(id)
+}
+
+// The kind of event.
+func (r *LLMStreamEvent) Kind(ctx context.Context) (LLMStreamEventKind, error) {
+	if r.kind != nil {
+		return *r.kind, nil
+	}
+	q := r.query.Select("kind")
+
+	var response LLMStreamEventKind
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The text generated by the model, for TEXT_DELTA events.
+func (r *LLMStreamEvent) Text(ctx context.Context) (string, error) {
+	if r.text != nil {
+		return *r.text, nil
+	}
+	q := r.query.Select("text")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The token usage of the model reply, for TOKEN_USAGE events.
+func (r *LLMStreamEvent) TokenUsage() *LLMTokenUsage {
+	q := r.query.Select("tokenUsage")
+
+	return &LLMTokenUsage{
+		query: q,
+	}
+}
+
+// The JSON-encoded arguments of the tool call, for TOOL_CALL_START events.
+func (r *LLMStreamEvent) ToolArguments(ctx context.Context) (string, error) {
+	if r.toolArguments != nil {
+		return *r.toolArguments, nil
+	}
+	q := r.query.Select("toolArguments")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The ID of the tool call, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+func (r *LLMStreamEvent) ToolCallID(ctx context.Context) (string, error) {
+	if r.toolCallID != nil {
+		return *r.toolCallID, nil
+	}
+	q := r.query.Select("toolCallID")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// Whether the tool call failed, for TOOL_CALL_FINISH events.
+func (r *LLMStreamEvent) ToolErrored(ctx context.Context) (bool, error) {
+	if r.toolErrored != nil {
+		return *r.toolErrored, nil
+	}
+	q := r.query.Select("toolErrored")
+
+	var response bool
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The name of the tool, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+func (r *LLMStreamEvent) ToolName(ctx context.Context) (string, error) {
+	if r.toolName != nil {
+		return *r.toolName, nil
+	}
+	q := r.query.Select("toolName")
+
+	var response string
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
+// The result of the tool call, for TOOL_CALL_FINISH events.
+func (r *LLMStreamEvent) ToolResult(ctx context.Context) (string, error) {
+	if r.toolResult != nil {
+		return *r.toolResult, nil
 	}
-}
+	q := r.query.Select("toolResult")
 
-// Clear the system prompts, leaving only the default system prompt
-func (r *LLM) WithoutSystemPrompts() *LLM {
-	q := r.query.Select("withoutSystemPrompts")
+	var response string
 
-	return &LLM{
-		query: q,
-	}
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
 }
 
 type LLMTokenUsage struct {
@@ -9492,6 +11138,7 @@ type LLMTokenUsage struct {
 
 	cachedTokenReads  *int
 	cachedTokenWrites *int
+	cost              *float64
 	id                *LLMTokenUsageID
 	inputTokens       *int
 	outputTokens      *int
@@ -9528,6 +11175,19 @@ func (r *LLMTokenUsage) CachedTokenWrites(ctx context.Context) (int, error) {
 	return response, q.Execute(ctx)
 }
 
+// The estimated cost in USD, if the price of the model is known.
+func (r *LLMTokenUsage) Cost(ctx context.Context) (float64, error) {
+	if r.cost != nil {
+		return *r.cost, nil
+	}
+	q := r.query.Select("cost")
+
+	var response float64
+
+	q = q.Bind(&response)
+	return response, q.Execute(ctx)
+}
+
 // A unique identifier for this LLMTokenUsage.
 func (r *LLMTokenUsage) ID(ctx context.Context) (LLMTokenUsageID, error) {
 	if r.id != nil {
@@ -11537,6 +13197,10 @@ type GitOpts struct {
 	HTTPAuthHeader *Secret
 	// A service which must be started before the repo is fetched.
 	ExperimentalServiceHost *Service
+	// Partial clone filter to fetch the repository with, like "blob:none" or "blob:limit=1m".
+	//
+	// The file contents needed by a checkout are fetched when checking it out.
+	Filter string
 }
 
 // Queries a Git repository.
@@ -11571,6 +13235,10 @@ func (r *Client) Git(url string, opts ...GitOpts) *GitRepository {
 		if !querybuilder.IsZeroValue(opts[i].ExperimentalServiceHost) {
 			q = q.Arg("experimentalServiceHost", opts[i].ExperimentalServiceHost)
 		}
+		// `filter` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Filter) {
+			q = q.Arg("filter", opts[i].Filter)
+		}
 	}
 	q = q.Arg("url", url)
 
@@ -11736,6 +13404,16 @@ func (r *Client) LoadCloudFromID(id CloudID) *Cloud {
 	}
 }
 
+// Load a ContainerAttestation from its ID.
+func (r *Client) LoadContainerAttestationFromID(id ContainerAttestationID) *ContainerAttestation {
+	q := r.query.Select("loadContainerAttestationFromID")
+	q = q.Arg("id", id)
+
+	return &ContainerAttestation{
+		query: q,
+	}
+}
+
 // Load a Container from its ID.
 func (r *Client) LoadContainerFromID(id ContainerID) *Container {
 	q := r.query.Select("loadContainerFromID")
@@ -11966,6 +13644,26 @@ func (r *Client) LoadGeneratorGroupFromID(id GeneratorGroupID) *GeneratorGroup {
 	}
 }
 
+// Load a GitBlameLine from its ID.
+func (r *Client) LoadGitBlameLineFromID(id GitBlameLineID) *GitBlameLine {
+	q := r.query.Select("loadGitBlameLineFromID")
+	q = q.Arg("id", id)
+
+	return &GitBlameLine{
+		query: q,
+	}
+}
+
+// Load a GitCommit from its ID.
+func (r *Client) LoadGitCommitFromID(id GitCommitID) *GitCommit {
+	q := r.query.Select("loadGitCommitFromID")
+	q = q.Arg("id", id)
+
+	return &GitCommit{
+		query: q,
+	}
+}
+
 // Load a GitRef from its ID.
 func (r *Client) LoadGitRefFromID(id GitRefID) *GitRef {
 	q := r.query.Select("loadGitRefFromID")
@@ -12036,6 +13734,16 @@ func (r *Client) LoadLLMFromID(id LLMID) *LLM {
 	}
 }
 
+// Load a LLMStreamEvent from its ID.
+func (r *Client) LoadLLMStreamEventFromID(id LLMStreamEventID) *LLMStreamEvent {
+	q := r.query.Select("loadLLMStreamEventFromID")
+	q = q.Arg("id", id)
+
+	return &LLMStreamEvent{
+		query: q,
+	}
+}
+
 // Load a LLMTokenUsage from its ID.
 func (r *Client) LoadLLMTokenUsageFromID(id LLMTokenUsageID) *LLMTokenUsage {
 	q := r.query.Select("loadLLMTokenUsageFromID")
@@ -12999,11 +14707,27 @@ func (r *Service) Ports(ctx context.Context) ([]Port, error) {
 	return convert(response), nil
 }
 
+// ServiceStartOpts contains options for Service.Start
+type ServiceStartOpts struct {
+	// Maximum duration the service may run once started, as a Go duration string (e.g. "10m").
+	//
+	// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period.
+	//
+	// A service that was already running, e.g. for a container it is bound to, is not stopped.
+	Timeout string
+}
+
 // Start the service and wait for its health checks to succeed.
 //
 // Services bound to a Container do not need to be manually started.
-func (r *Service) Start(ctx context.Context) (*Service, error) {
+func (r *Service) Start(ctx context.Context, opts ...ServiceStartOpts) (*Service, error) {
 	q := r.query.Select("start")
+	for i := len(opts) - 1; i >= 0; i-- {
+		// `timeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Timeout) {
+			q = q.Arg("timeout", opts[i].Timeout)
+		}
+	}
 
 	var id ServiceID
 	if err := q.Bind(&id).Execute(ctx); err != nil {
@@ -13079,6 +14803,10 @@ type ServiceUpOpts struct {
 	Ports []PortForward
 	// Bind each tunnel port to a random port on the host.
 	Random bool
+	// Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+	//
+	// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+	Timeout string
 }
 
 // Creates a tunnel that forwards traffic from the caller's network to this service.
@@ -13096,6 +14824,10 @@ func (r *Service) Up(ctx context.Context, opts ...ServiceUpOpts) error {
 		if !querybuilder.IsZeroValue(opts[i].Random) {
 			q = q.Arg("random", opts[i].Random)
 		}
+		// `timeout` optional argument
+		if !querybuilder.IsZeroValue(opts[i].Timeout) {
+			q = q.Arg("timeout", opts[i].Timeout)
+		}
 	}
 
 	return q.Execute(ctx)
@@ -14085,6 +15817,77 @@ const (
 	ChangesetsMergeConflictFail ChangesetsMergeConflict = "FAIL"
 )
 
+// The format of a check report
+type CheckReportFormat string
+
+func (CheckReportFormat) IsEnum() {}
+
+func (v CheckReportFormat) Name() string {
+	switch v {
+	case CheckReportFormatMarkdown:
+		return "MARKDOWN"
+	case CheckReportFormatJunit:
+		return "JUNIT"
+	case CheckReportFormatJson:
+		return "JSON"
+	case CheckReportFormatSarif:
+		return "SARIF"
+	default:
+		return ""
+	}
+}
+
+func (v CheckReportFormat) Value() string {
+	return string(v)
+}
+
+func (v *CheckReportFormat) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *CheckReportFormat) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "JSON":
+		*v = CheckReportFormatJson
+	case "JUNIT":
+		*v = CheckReportFormatJunit
+	case "MARKDOWN":
+		*v = CheckReportFormatMarkdown
+	case "SARIF":
+		*v = CheckReportFormatSarif
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// A markdown table, for humans
+	CheckReportFormatMarkdown CheckReportFormat = "MARKDOWN"
+
+	// JUnit XML, as consumed by most CI systems
+	CheckReportFormatJunit CheckReportFormat = "JUNIT"
+
+	// The check results as JSON
+	CheckReportFormatJson CheckReportFormat = "JSON"
+
+	// SARIF 2.1.0, as consumed by code scanning tools
+	CheckReportFormatSarif CheckReportFormat = "SARIF"
+)
+
 // File type.
 type ExistsType string
 
@@ -14424,6 +16227,141 @@ const (
 	ImageMediaTypesDocker           ImageMediaTypes = ImageMediaTypesDockerMediaTypes
 )
 
+// How an LLM uses its cassette
+type LLMCassetteMode string
+
+func (LLMCassetteMode) IsEnum() {}
+
+func (v LLMCassetteMode) Name() string {
+	switch v {
+	case LLMCassetteModeRecord:
+		return "RECORD"
+	case LLMCassetteModeReplay:
+		return "REPLAY"
+	default:
+		return ""
+	}
+}
+
+func (v LLMCassetteMode) Value() string {
+	return string(v)
+}
+
+func (v *LLMCassetteMode) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *LLMCassetteMode) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "RECORD":
+		*v = LLMCassetteModeRecord
+	case "REPLAY":
+		*v = LLMCassetteModeReplay
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// Send requests to the model, and record them with their responses
+	LLMCassetteModeRecord LLMCassetteMode = "RECORD"
+
+	// Serve requests from the recorded responses, without calling the model, and fail on requests that weren't recorded
+	LLMCassetteModeReplay LLMCassetteMode = "REPLAY"
+)
+
+// The kind of an LLM stream event
+type LLMStreamEventKind string
+
+func (LLMStreamEventKind) IsEnum() {}
+
+func (v LLMStreamEventKind) Name() string {
+	switch v {
+	case LLMStreamEventKindTextDelta:
+		return "TEXT_DELTA"
+	case LLMStreamEventKindToolCallStart:
+		return "TOOL_CALL_START"
+	case LLMStreamEventKindToolCallFinish:
+		return "TOOL_CALL_FINISH"
+	case LLMStreamEventKindTokenUsage:
+		return "TOKEN_USAGE"
+	case LLMStreamEventKindRetry:
+		return "RETRY"
+	default:
+		return ""
+	}
+}
+
+func (v LLMStreamEventKind) Value() string {
+	return string(v)
+}
+
+func (v *LLMStreamEventKind) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *LLMStreamEventKind) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "RETRY":
+		*v = LLMStreamEventKindRetry
+	case "TEXT_DELTA":
+		*v = LLMStreamEventKindTextDelta
+	case "TOKEN_USAGE":
+		*v = LLMStreamEventKindTokenUsage
+	case "TOOL_CALL_FINISH":
+		*v = LLMStreamEventKindToolCallFinish
+	case "TOOL_CALL_START":
+		*v = LLMStreamEventKindToolCallStart
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// A chunk of text generated by the model
+	LLMStreamEventKindTextDelta LLMStreamEventKind = "TEXT_DELTA"
+
+	// A tool call requested by the model started
+	LLMStreamEventKindToolCallStart LLMStreamEventKind = "TOOL_CALL_START"
+
+	// A tool call requested by the model finished
+	LLMStreamEventKindToolCallFinish LLMStreamEventKind = "TOOL_CALL_FINISH"
+
+	// The model replied, using tokens
+	LLMStreamEventKindTokenUsage LLMStreamEventKind = "TOKEN_USAGE"
+
+	// The model query failed and is retried, discarding the text streamed since the last TOKEN_USAGE event
+	LLMStreamEventKindRetry LLMStreamEventKind = "RETRY"
+)
+
 // Experimental features of a module
 type ModuleSourceExperimentalFeature string
 
@@ -14544,6 +16482,77 @@ const (
 	ModuleSourceKindDir       ModuleSourceKind = ModuleSourceKindDirSource
 )
 
+// Network access granted to an execution
+type NetworkMode string
+
+func (NetworkMode) IsEnum() {}
+
+func (v NetworkMode) Name() string {
+	switch v {
+	case NetworkModeUnrestricted:
+		return "UNRESTRICTED"
+	case NetworkModeOffline:
+		return "OFFLINE"
+	case NetworkModeServicesOnly:
+		return "SERVICES_ONLY"
+	case NetworkModeAllowList:
+		return "ALLOW_LIST"
+	default:
+		return ""
+	}
+}
+
+func (v NetworkMode) Value() string {
+	return string(v)
+}
+
+func (v *NetworkMode) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *NetworkMode) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "ALLOW_LIST":
+		*v = NetworkModeAllowList
+	case "OFFLINE":
+		*v = NetworkModeOffline
+	case "SERVICES_ONLY":
+		*v = NetworkModeServicesOnly
+	case "UNRESTRICTED":
+		*v = NetworkModeUnrestricted
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// Full network access
+	NetworkModeUnrestricted NetworkMode = "UNRESTRICTED"
+
+	// No network access, except for the loopback interface
+	NetworkModeOffline NetworkMode = "OFFLINE"
+
+	// Access to bound services and DNS only
+	NetworkModeServicesOnly NetworkMode = "SERVICES_ONLY"
+
+	// Access to bound services, DNS, and an allow-list of hostnames, IPs and CIDRs
+	NetworkModeAllowList NetworkMode = "ALLOW_LIST"
+)
+
 // Transport layer network protocol associated to a port.
 type NetworkProtocol string
 
@@ -14663,6 +16672,63 @@ const (
 	ReturnTypeAny ReturnType = "ANY"
 )
 
+// Format of a software bill of materials.
+type SBOMFormat string
+
+func (SBOMFormat) IsEnum() {}
+
+func (v SBOMFormat) Name() string {
+	switch v {
+	case SBOMFormatSpdx:
+		return "SPDX"
+	case SBOMFormatCyclonedx:
+		return "CYCLONEDX"
+	default:
+		return ""
+	}
+}
+
+func (v SBOMFormat) Value() string {
+	return string(v)
+}
+
+func (v *SBOMFormat) MarshalJSON() ([]byte, error) {
+	if *v == "" {
+		return []byte(`""`), nil
+	}
+	name := v.Name()
+	if name == "" {
+		return nil, fmt.Errorf("invalid enum value %q", *v)
+	}
+	return json.Marshal(name)
+}
+
+func (v *SBOMFormat) UnmarshalJSON(dt []byte) error {
+	var s string
+	if err := json.Unmarshal(dt, &s); err != nil {
+		return err
+	}
+	switch s {
+	case "":
+		*v = ""
+	case "CYCLONEDX":
+		*v = SBOMFormatCyclonedx
+	case "SPDX":
+		*v = SBOMFormatSpdx
+	default:
+		return fmt.Errorf("invalid enum value %q", s)
+	}
+	return nil
+}
+
+const (
+	// SPDX 2.3 JSON document
+	SBOMFormatSpdx SBOMFormat = "SPDX"
+
+	// CycloneDX 1.5 JSON document
+	SBOMFormatCyclonedx SBOMFormat = "CYCLONEDX"
+)
+
 // Distinguishes the different kinds of TypeDefs.
 type TypeDefKind string
 
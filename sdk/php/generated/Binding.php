@@ -73,6 +73,15 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type ContainerAttestation
+     */
+    public function asContainerAttestation(): ContainerAttestation
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asContainerAttestation');
+        return new \Dagger\ContainerAttestation($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type Directory
      */
@@ -127,6 +136,24 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\GeneratorGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type GitBlameLine
+     */
+    public function asGitBlameLine(): GitBlameLine
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asGitBlameLine');
+        return new \Dagger\GitBlameLine($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Retrieve the binding value, as type GitCommit
+     */
+    public function asGitCommit(): GitCommit
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asGitCommit');
+        return new \Dagger\GitCommit($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type GitRef
      */
@@ -154,6 +181,15 @@ class Binding extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\JsonValue($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieve the binding value, as type LLMStreamEvent
+     */
+    public function asLLMStreamEvent(): LLMStreamEvent
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('asLLMStreamEvent');
+        return new \Dagger\LLMStreamEvent($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieve the binding value, as type Module
      */
@@ -10,6 +10,15 @@ namespace Dagger;
 
 class Check extends Client\AbstractObject implements Client\IdAble
 {
+    /**
+     * The number of times the check was run, including retries
+     */
+    public function attempts(): int
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('attempts');
+        return (int)$this->queryLeaf($leafQueryBuilder, 'attempts');
+    }
+
     /**
      * Whether the check completed
      */
@@ -28,6 +37,24 @@ class Check extends Client\AbstractObject implements Client\IdAble
         return (string)$this->queryLeaf($leafQueryBuilder, 'description');
     }
 
+    /**
+     * How long the check took to run, in milliseconds
+     */
+    public function durationMs(): int
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('durationMs');
+        return (int)$this->queryLeaf($leafQueryBuilder, 'durationMs');
+    }
+
+    /**
+     * The error the check failed with, if any
+     */
+    public function errorMessage(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('errorMessage');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'errorMessage');
+    }
+
     /**
      * A unique identifier for this Check.
      */
@@ -37,6 +64,15 @@ class Check extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\CheckId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
     }
 
+    /**
+     * The last lines of output of the failed command, if the check failed running one
+     */
+    public function logExcerpt(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('logExcerpt');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'logExcerpt');
+    }
+
     /**
      * Return the fully qualified name of the check
      */
@@ -73,6 +109,15 @@ class Check extends Client\AbstractObject implements Client\IdAble
         return (array)$this->queryLeaf($leafQueryBuilder, 'path');
     }
 
+    /**
+     * Whether the check is quarantined: its failures are reported, but don't fail the group
+     */
+    public function quarantined(): bool
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('quarantined');
+        return (bool)$this->queryLeaf($leafQueryBuilder, 'quarantined');
+    }
+
     /**
      * An emoji representing the result of the check
      */
@@ -85,9 +130,48 @@ class Check extends Client\AbstractObject implements Client\IdAble
     /**
      * Execute the check
      */
-    public function run(): Check
+    public function run(?int $retries = 0): Check
     {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('run');
+        if (null !== $retries) {
+        $innerQueryBuilder->setArgument('retries', $retries);
+        }
         return new \Dagger\Check($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * Why the check was selected or skipped by affectedBy
+     */
+    public function selectionReason(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('selectionReason');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'selectionReason');
+    }
+
+    /**
+     * Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy
+     */
+    public function skipped(): bool
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('skipped');
+        return (bool)$this->queryLeaf($leafQueryBuilder, 'skipped');
+    }
+
+    /**
+     * The ID of the span of the check
+     */
+    public function spanID(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('spanID');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'spanID');
+    }
+
+    /**
+     * The ID of the trace the check ran in
+     */
+    public function traceID(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('traceID');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'traceID');
+    }
 }
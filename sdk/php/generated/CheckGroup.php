@@ -10,6 +10,30 @@ namespace Dagger;
 
 class CheckGroup extends Client\AbstractObject implements Client\IdAble
 {
+    /**
+     * Skip the checks whose inputs are not affected by the given changes
+     *
+     * A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.
+     */
+    public function affectedBy(ChangesetId|Changeset $changes): CheckGroup
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('affectedBy');
+        $innerQueryBuilder->setArgument('changes', $changes);
+        return new \Dagger\CheckGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Skip the checks whose inputs are not affected by the given changed paths
+     *
+     * Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+     */
+    public function affectedByPaths(array $paths): CheckGroup
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('affectedByPaths');
+        $innerQueryBuilder->setArgument('paths', $paths);
+        return new \Dagger\CheckGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * A unique identifier for this CheckGroup.
      */
@@ -29,20 +53,32 @@ class CheckGroup extends Client\AbstractObject implements Client\IdAble
     }
 
     /**
-     * Generate a markdown report
+     * Generate a report of the check results
      */
-    public function report(): File
+    public function report(?CheckReportFormat $format = null): File
     {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('report');
+        if (null !== $format) {
+        $innerQueryBuilder->setArgument('format', $format);
+        }
         return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
     /**
      * Execute all selected checks
      */
-    public function run(): CheckGroup
+    public function run(?bool $failFast = false, ?int $retries = 0, ?bool $noFail = false): CheckGroup
     {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('run');
+        if (null !== $failFast) {
+        $innerQueryBuilder->setArgument('failFast', $failFast);
+        }
+        if (null !== $retries) {
+        $innerQueryBuilder->setArgument('retries', $retries);
+        }
+        if (null !== $noFail) {
+        $innerQueryBuilder->setArgument('noFail', $noFail);
+        }
         return new \Dagger\CheckGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 }
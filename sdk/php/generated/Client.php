@@ -209,6 +209,7 @@ class Client extends Client\AbstractClient
         SecretId|Secret|null $httpAuthToken = null,
         SecretId|Secret|null $httpAuthHeader = null,
         ServiceId|Service|null $experimentalServiceHost = null,
+        ?string $filter = '',
     ): GitRepository {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('git');
         $innerQueryBuilder->setArgument('url', $url);
@@ -233,6 +234,9 @@ class Client extends Client\AbstractClient
         if (null !== $experimentalServiceHost) {
         $innerQueryBuilder->setArgument('experimentalServiceHost', $experimentalServiceHost);
         }
+        if (null !== $filter) {
+        $innerQueryBuilder->setArgument('filter', $filter);
+        }
         return new \Dagger\GitRepository($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -366,6 +370,17 @@ class Client extends Client\AbstractClient
         return new \Dagger\Cloud($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a ContainerAttestation from its ID.
+     */
+    public function loadContainerAttestationFromID(
+        ContainerAttestationId|ContainerAttestation $id,
+    ): ContainerAttestation {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadContainerAttestationFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\ContainerAttestation($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a Container from its ID.
      */
@@ -597,6 +612,26 @@ class Client extends Client\AbstractClient
         return new \Dagger\GeneratorGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a GitBlameLine from its ID.
+     */
+    public function loadGitBlameLineFromID(GitBlameLineId|GitBlameLine $id): GitBlameLine
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadGitBlameLineFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\GitBlameLine($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Load a GitCommit from its ID.
+     */
+    public function loadGitCommitFromID(GitCommitId|GitCommit $id): GitCommit
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadGitCommitFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\GitCommit($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a GitRef from its ID.
      */
@@ -667,6 +702,16 @@ class Client extends Client\AbstractClient
         return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Load a LLMStreamEvent from its ID.
+     */
+    public function loadLLMStreamEventFromID(LLMStreamEventId|LLMStreamEvent $id): LLMStreamEvent
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('loadLLMStreamEventFromID');
+        $innerQueryBuilder->setArgument('id', $id);
+        return new \Dagger\LLMStreamEvent($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Load a LLMTokenUsage from its ID.
      */
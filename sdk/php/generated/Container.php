@@ -69,6 +69,21 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * The in-toto attestations that would be attached to the container image when published or exported.
+     */
+    public function attestations(?SBOMFormat $sbom = null, ?bool $provenance = false): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('attestations');
+        if (null !== $sbom) {
+        $leafQueryBuilder->setArgument('sbom', $sbom);
+        }
+        if (null !== $provenance) {
+        $leafQueryBuilder->setArgument('provenance', $provenance);
+        }
+        return (array)$this->queryLeaf($leafQueryBuilder, 'attestations');
+    }
+
     /**
      * The combined buffered standard output and standard error stream of the last executed command
      *
@@ -197,6 +212,8 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?ImageLayerCompression $forcedCompression = null,
         ?ImageMediaTypes $mediaTypes = null,
         ?bool $expand = false,
+        ?SBOMFormat $sbom = null,
+        ?bool $provenance = false,
     ): string {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('export');
         $leafQueryBuilder->setArgument('path', $path);
@@ -212,6 +229,12 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $expand) {
         $leafQueryBuilder->setArgument('expand', $expand);
         }
+        if (null !== $sbom) {
+        $leafQueryBuilder->setArgument('sbom', $sbom);
+        }
+        if (null !== $provenance) {
+        $leafQueryBuilder->setArgument('provenance', $provenance);
+        }
         return (string)$this->queryLeaf($leafQueryBuilder, 'export');
     }
 
@@ -267,10 +290,13 @@ class Container extends Client\AbstractObject implements Client\IdAble
     /**
      * Download a container image, and apply it to the container state. All previous state will be lost.
      */
-    public function from(string $address): Container
+    public function from(string $address, ?string $verify = ''): Container
     {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('from');
         $innerQueryBuilder->setArgument('address', $address);
+        if (null !== $verify) {
+        $innerQueryBuilder->setArgument('verify', $verify);
+        }
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -352,6 +378,10 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?array $platformVariants = null,
         ?ImageLayerCompression $forcedCompression = null,
         ?ImageMediaTypes $mediaTypes = null,
+        SecretId|Secret|null $sign = null,
+        SecretId|Secret|null $signPassword = null,
+        ?SBOMFormat $sbom = null,
+        ?bool $provenance = false,
     ): string {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('publish');
         $leafQueryBuilder->setArgument('address', $address);
@@ -364,6 +394,18 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $mediaTypes) {
         $leafQueryBuilder->setArgument('mediaTypes', $mediaTypes);
         }
+        if (null !== $sign) {
+        $leafQueryBuilder->setArgument('sign', $sign);
+        }
+        if (null !== $signPassword) {
+        $leafQueryBuilder->setArgument('signPassword', $signPassword);
+        }
+        if (null !== $sbom) {
+        $leafQueryBuilder->setArgument('sbom', $sbom);
+        }
+        if (null !== $provenance) {
+        $leafQueryBuilder->setArgument('provenance', $provenance);
+        }
         return (string)$this->queryLeaf($leafQueryBuilder, 'publish');
     }
 
@@ -451,6 +493,7 @@ class Container extends Client\AbstractObject implements Client\IdAble
     public function up(
         ?bool $random = false,
         ?array $ports = null,
+        ?string $timeout = '',
         ?array $args = null,
         ?bool $useEntrypoint = false,
         ?bool $experimentalPrivilegedNesting = false,
@@ -465,6 +508,9 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $ports) {
         $leafQueryBuilder->setArgument('ports', $ports);
         }
+        if (null !== $timeout) {
+        $leafQueryBuilder->setArgument('timeout', $timeout);
+        }
         if (null !== $args) {
         $leafQueryBuilder->setArgument('args', $args);
         }
@@ -506,6 +552,16 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Limits the CPU time available to commands run in this container.
+     */
+    public function withCPULimit(float $cpus): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withCPULimit');
+        $innerQueryBuilder->setArgument('cpus', $cpus);
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Configures default arguments for future commands. Like CMD in Dockerfile.
      */
@@ -630,6 +686,9 @@ class Container extends Client\AbstractObject implements Client\IdAble
         ?bool $insecureRootCapabilities = false,
         ?bool $expand = false,
         ?bool $noInit = false,
+        ?string $timeout = '',
+        ?NetworkMode $networkMode = null,
+        ?array $networkAllow = null,
     ): Container {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withExec');
         $innerQueryBuilder->setArgument('args', $args);
@@ -663,6 +722,15 @@ class Container extends Client\AbstractObject implements Client\IdAble
         if (null !== $noInit) {
         $innerQueryBuilder->setArgument('noInit', $noInit);
         }
+        if (null !== $timeout) {
+        $innerQueryBuilder->setArgument('timeout', $timeout);
+        }
+        if (null !== $networkMode) {
+        $innerQueryBuilder->setArgument('networkMode', $networkMode);
+        }
+        if (null !== $networkAllow) {
+        $innerQueryBuilder->setArgument('networkAllow', $networkAllow);
+        }
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
@@ -745,6 +813,78 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+     *
+     * The service is not considered started until the check passes, after the health checks of its exposed ports.
+     *
+     * Exactly one of args, httpPort and logPattern must be set.
+     */
+    public function withHealthcheck(
+        ?array $args = null,
+        ?int $httpPort = 0,
+        ?string $httpPath = '/',
+        ?int $httpStatus = 200,
+        ?string $logPattern = '',
+        ?string $interval = '1s',
+        ?string $timeout = '10s',
+        ?string $startPeriod = '0s',
+        ?int $retries = 30,
+    ): Container {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withHealthcheck');
+        if (null !== $args) {
+        $innerQueryBuilder->setArgument('args', $args);
+        }
+        if (null !== $httpPort) {
+        $innerQueryBuilder->setArgument('httpPort', $httpPort);
+        }
+        if (null !== $httpPath) {
+        $innerQueryBuilder->setArgument('httpPath', $httpPath);
+        }
+        if (null !== $httpStatus) {
+        $innerQueryBuilder->setArgument('httpStatus', $httpStatus);
+        }
+        if (null !== $logPattern) {
+        $innerQueryBuilder->setArgument('logPattern', $logPattern);
+        }
+        if (null !== $interval) {
+        $innerQueryBuilder->setArgument('interval', $interval);
+        }
+        if (null !== $timeout) {
+        $innerQueryBuilder->setArgument('timeout', $timeout);
+        }
+        if (null !== $startPeriod) {
+        $innerQueryBuilder->setArgument('startPeriod', $startPeriod);
+        }
+        if (null !== $retries) {
+        $innerQueryBuilder->setArgument('retries', $retries);
+        }
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Sets the relative block IO weight of commands run in this container.
+     */
+    public function withIOWeight(int $weight): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withIOWeight');
+        $innerQueryBuilder->setArgument('weight', $weight);
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Use the HEALTHCHECK of the container image as its readiness check when started as a service.
+     *
+     * Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.
+     *
+     * Fails if the image has no healthcheck.
+     */
+    public function withImageHealthcheck(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withImageHealthcheck');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieves this container plus the given label.
      */
@@ -756,6 +896,18 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Limits the memory available to commands run in this container.
+     *
+     * A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.
+     */
+    public function withMemoryLimit(string $limit): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withMemoryLimit');
+        $innerQueryBuilder->setArgument('limit', $limit);
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieves this container plus a cache volume mounted at the given path.
      */
@@ -893,6 +1045,16 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Limits the number of processes that commands run in this container may create.
+     */
+    public function withPidsLimit(int $limit): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withPidsLimit');
+        $innerQueryBuilder->setArgument('limit', $limit);
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Attach credentials for future publishing to a registry. Use in combination with publish
      */
@@ -1094,6 +1256,15 @@ class Container extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Retrieves this container without its readiness check.
+     */
+    public function withoutHealthcheck(): Container
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withoutHealthcheck');
+        return new \Dagger\Container($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * Retrieves this container minus the given environment label.
      */
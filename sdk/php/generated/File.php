@@ -34,6 +34,19 @@ class File extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\JsonValue($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return the lines of this file with the commit that last changed them.
+     *
+     * The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.
+     *
+     * Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.
+     */
+    public function blame(): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('blame');
+        return (array)$this->queryLeaf($leafQueryBuilder, 'blame');
+    }
+
     /**
      * Change the owner of the file recursively.
      */
@@ -90,4 +90,22 @@ class Generator extends Client\AbstractObject implements Client\IdAble
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('run');
         return new \Dagger\Generator($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * Why the generator was selected or skipped by affectedBy
+     */
+    public function selectionReason(): string
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('selectionReason');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'selectionReason');
+    }
+
+    /**
+     * Whether the generator is skipped because none of its inputs are affected by the changes passed to affectedBy
+     */
+    public function skipped(): bool
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('skipped');
+        return (bool)$this->queryLeaf($leafQueryBuilder, 'skipped');
+    }
 }
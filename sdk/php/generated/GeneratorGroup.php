@@ -10,6 +10,30 @@ namespace Dagger;
 
 class GeneratorGroup extends Client\AbstractObject implements Client\IdAble
 {
+    /**
+     * Skip the generators whose inputs are not affected by the given changes
+     *
+     * A generator's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Generators without any declared input are always selected.
+     */
+    public function affectedBy(ChangesetId|Changeset $changes): GeneratorGroup
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('affectedBy');
+        $innerQueryBuilder->setArgument('changes', $changes);
+        return new \Dagger\GeneratorGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Skip the generators whose inputs are not affected by the given changed paths
+     *
+     * Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+     */
+    public function affectedByPaths(array $paths): GeneratorGroup
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('affectedByPaths');
+        $innerQueryBuilder->setArgument('paths', $paths);
+        return new \Dagger\GeneratorGroup($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * The combined changes from the generators execution
      *
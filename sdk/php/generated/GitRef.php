@@ -32,6 +32,18 @@ class GitRef extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\GitRef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return the changes from this ref to another ref, like "git diff".
+     *
+     * The .git directory isn't part of the changes.
+     */
+    public function diff(GitRefId|GitRef $other): Changeset
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('diff');
+        $innerQueryBuilder->setArgument('other', $other);
+        return new \Dagger\Changeset($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * A unique identifier for this GitRef.
      */
@@ -41,6 +53,65 @@ class GitRef extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\GitRefId((string)$this->queryLeaf($leafQueryBuilder, 'id'));
     }
 
+    /**
+     * The history of this ref, most recent commits first.
+     */
+    public function log(?array $paths = null, ?int $limit = 0, ?string $since = ''): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('log');
+        if (null !== $paths) {
+        $leafQueryBuilder->setArgument('paths', $paths);
+        }
+        if (null !== $limit) {
+        $leafQueryBuilder->setArgument('limit', $limit);
+        }
+        if (null !== $since) {
+        $leafQueryBuilder->setArgument('since', $since);
+        }
+        return (array)$this->queryLeaf($leafQueryBuilder, 'log');
+    }
+
+    /**
+     * Push the commit of this ref to a remote repository.
+     *
+     * Returns the updated remote ref.
+     */
+    public function push(
+        string $remote,
+        ?string $ref = '',
+        ?bool $force = false,
+        ?string $sshKnownHosts = '',
+        SocketId|Socket|null $sshAuthSocket = null,
+        ?string $httpAuthUsername = '',
+        SecretId|Secret|null $httpAuthToken = null,
+        SecretId|Secret|null $httpAuthHeader = null,
+    ): string {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('push');
+        $leafQueryBuilder->setArgument('remote', $remote);
+        if (null !== $ref) {
+        $leafQueryBuilder->setArgument('ref', $ref);
+        }
+        if (null !== $force) {
+        $leafQueryBuilder->setArgument('force', $force);
+        }
+        if (null !== $sshKnownHosts) {
+        $leafQueryBuilder->setArgument('sshKnownHosts', $sshKnownHosts);
+        }
+        if (null !== $sshAuthSocket) {
+        $leafQueryBuilder->setArgument('sshAuthSocket', $sshAuthSocket);
+        }
+        if (null !== $httpAuthUsername) {
+        $leafQueryBuilder->setArgument('httpAuthUsername', $httpAuthUsername);
+        }
+        if (null !== $httpAuthToken) {
+        $leafQueryBuilder->setArgument('httpAuthToken', $httpAuthToken);
+        }
+        if (null !== $httpAuthHeader) {
+        $leafQueryBuilder->setArgument('httpAuthHeader', $httpAuthHeader);
+        }
+        return (string)$this->queryLeaf($leafQueryBuilder, 'push');
+    }
+
     /**
      * The resolved ref name at this ref.
      */
@@ -51,10 +122,23 @@ class GitRef extends Client\AbstractObject implements Client\IdAble
     }
 
     /**
-     * The filesystem tree at this ref.
+     * The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.
      */
-    public function tree(?bool $discardGitDir = false, ?int $depth = 1): Directory
+    public function signer(): string
     {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('signer');
+        return (string)$this->queryLeaf($leafQueryBuilder, 'signer');
+    }
+
+    /**
+     * The filesystem tree at this ref.
+     */
+    public function tree(
+        ?bool $discardGitDir = false,
+        ?int $depth = 1,
+        ?array $sparse = null,
+        ?bool $lfs = false,
+    ): Directory {
         $innerQueryBuilder = new \Dagger\Client\QueryBuilder('tree');
         if (null !== $discardGitDir) {
         $innerQueryBuilder->setArgument('discardGitDir', $discardGitDir);
@@ -62,6 +146,31 @@ class GitRef extends Client\AbstractObject implements Client\IdAble
         if (null !== $depth) {
         $innerQueryBuilder->setArgument('depth', $depth);
         }
+        if (null !== $sparse) {
+        $innerQueryBuilder->setArgument('sparse', $sparse);
+        }
+        if (null !== $lfs) {
+        $innerQueryBuilder->setArgument('lfs', $lfs);
+        }
         return new \Dagger\Directory($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
+
+    /**
+     * Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+     *
+     * Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+     *
+     * Returns the verified ref, with its signer.
+     */
+    public function verify(FileId|File|null $allowedSigners = null, ?array $keys = null): GitRef
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('verify');
+        if (null !== $allowedSigners) {
+        $innerQueryBuilder->setArgument('allowedSigners', $allowedSigners);
+        }
+        if (null !== $keys) {
+        $innerQueryBuilder->setArgument('keys', $keys);
+        }
+        return new \Dagger\GitRef($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
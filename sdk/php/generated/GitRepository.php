@@ -121,4 +121,18 @@ class GitRepository extends Client\AbstractObject implements Client\IdAble
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('url');
         return (string)$this->queryLeaf($leafQueryBuilder, 'url');
     }
+
+    /**
+     * Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.
+     *
+     * The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.
+     */
+    public function withCommit(ChangesetId|Changeset $changes, string $message, string $author): GitRepository
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withCommit');
+        $innerQueryBuilder->setArgument('changes', $changes);
+        $innerQueryBuilder->setArgument('message', $message);
+        $innerQueryBuilder->setArgument('author', $author);
+        return new \Dagger\GitRepository($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
 }
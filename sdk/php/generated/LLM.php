@@ -30,6 +30,17 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\Binding($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Return the cassette recorded by the LLM, to replay with withCassette
+     *
+     * Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is.
+     */
+    public function cassette(): File
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('cassette');
+        return new \Dagger\File($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * return the LLM's current environment
      */
@@ -120,6 +131,20 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\LLMId((string)$this->queryLeaf($leafQueryBuilder, 'step'));
     }
 
+    /**
+     * Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+     *
+     * To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+     */
+    public function stream(?int $after = 0): array
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('stream');
+        if (null !== $after) {
+        $leafQueryBuilder->setArgument('after', $after);
+        }
+        return (array)$this->queryLeaf($leafQueryBuilder, 'stream');
+    }
+
     /**
      * synchronize LLM state
      */
@@ -158,6 +183,40 @@ class LLM extends Client\AbstractObject implements Client\IdAble
         return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
     }
 
+    /**
+     * Limit the tokens and estimated cost of the LLM.
+     *
+     * When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+     */
+    public function withBudget(?int $maxTokens = 0, ?float $maxCost = 0.0): LLM
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withBudget');
+        if (null !== $maxTokens) {
+        $innerQueryBuilder->setArgument('maxTokens', $maxTokens);
+        }
+        if (null !== $maxCost) {
+        $innerQueryBuilder->setArgument('maxCost', $maxCost);
+        }
+        return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
+    /**
+     * Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+     *
+     * Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+     */
+    public function withCassette(FileId|File|null $file = null, ?LLMCassetteMode $mode = null): LLM
+    {
+        $innerQueryBuilder = new \Dagger\Client\QueryBuilder('withCassette');
+        if (null !== $file) {
+        $innerQueryBuilder->setArgument('file', $file);
+        }
+        if (null !== $mode) {
+        $innerQueryBuilder->setArgument('mode', $mode);
+        }
+        return new \Dagger\LLM($this->client, $this->queryBuilderChain->chain($innerQueryBuilder));
+    }
+
     /**
      * allow the LLM to interact with an environment via MCP
      */
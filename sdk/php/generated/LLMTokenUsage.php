@@ -22,6 +22,15 @@ class LLMTokenUsage extends Client\AbstractObject implements Client\IdAble
         return (int)$this->queryLeaf($leafQueryBuilder, 'cachedTokenWrites');
     }
 
+    /**
+     * The estimated cost in USD, if the price of the model is known.
+     */
+    public function cost(): float
+    {
+        $leafQueryBuilder = new \Dagger\Client\QueryBuilder('cost');
+        return (float)$this->queryLeaf($leafQueryBuilder, 'cost');
+    }
+
     /**
      * A unique identifier for this LLMTokenUsage.
      */
@@ -64,9 +64,12 @@ class Service extends Client\AbstractObject implements Client\IdAble
      *
      * Services bound to a Container do not need to be manually started.
      */
-    public function start(): ServiceId
+    public function start(?string $timeout = ''): ServiceId
     {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('start');
+        if (null !== $timeout) {
+        $leafQueryBuilder->setArgument('timeout', $timeout);
+        }
         return new \Dagger\ServiceId((string)$this->queryLeaf($leafQueryBuilder, 'start'));
     }
 
@@ -103,7 +106,7 @@ class Service extends Client\AbstractObject implements Client\IdAble
     /**
      * Creates a tunnel that forwards traffic from the caller's network to this service.
      */
-    public function up(?array $ports = null, ?bool $random = false): void
+    public function up(?array $ports = null, ?bool $random = false, ?string $timeout = ''): void
     {
         $leafQueryBuilder = new \Dagger\Client\QueryBuilder('up');
         if (null !== $ports) {
@@ -112,6 +115,9 @@ class Service extends Client\AbstractObject implements Client\IdAble
         if (null !== $random) {
         $leafQueryBuilder->setArgument('random', $random);
         }
+        if (null !== $timeout) {
+        $leafQueryBuilder->setArgument('timeout', $timeout);
+        }
         $this->queryLeaf($leafQueryBuilder, 'up');
     }
 
@@ -46,6 +46,11 @@ class CloudID(Scalar):
     type Cloud."""
 
 
+class ContainerAttestationID(Scalar):
+    """The `ContainerAttestationID` scalar type represents an identifier
+    for an object of type ContainerAttestation."""
+
+
 class ContainerID(Scalar):
     """The `ContainerID` scalar type represents an identifier for an
     object of type Container."""
@@ -161,6 +166,16 @@ class GeneratorID(Scalar):
     object of type Generator."""
 
 
+class GitBlameLineID(Scalar):
+    """The `GitBlameLineID` scalar type represents an identifier for an
+    object of type GitBlameLine."""
+
+
+class GitCommitID(Scalar):
+    """The `GitCommitID` scalar type represents an identifier for an
+    object of type GitCommit."""
+
+
 class GitRefID(Scalar):
     """The `GitRefID` scalar type represents an identifier for an object
     of type GitRef."""
@@ -200,6 +215,11 @@ class LLMID(Scalar):
     type LLM."""
 
 
+class LLMStreamEventID(Scalar):
+    """The `LLMStreamEventID` scalar type represents an identifier for an
+    object of type LLMStreamEvent."""
+
+
 class LLMTokenUsageID(Scalar):
     """The `LLMTokenUsageID` scalar type represents an identifier for an
     object of type LLMTokenUsage."""
@@ -350,6 +370,22 @@ class ChangesetsMergeConflict(Enum):
     """Fail before attempting merge if file-level conflicts are detected between any changesets"""
 
 
+class CheckReportFormat(Enum):
+    """The format of a check report"""
+
+    JSON = "JSON"
+    """The check results as JSON"""
+
+    JUNIT = "JUNIT"
+    """JUnit XML, as consumed by most CI systems"""
+
+    MARKDOWN = "MARKDOWN"
+    """A markdown table, for humans"""
+
+    SARIF = "SARIF"
+    """SARIF 2.1.0, as consumed by code scanning tools"""
+
+
 class ExistsType(Enum):
     """File type."""
 
@@ -421,6 +457,35 @@ class ImageMediaTypes(Enum):
     OCI = "OCIMediaTypes"
 
 
+class LLMCassetteMode(Enum):
+    """How an LLM uses its cassette"""
+
+    RECORD = "RECORD"
+    """Send requests to the model, and record them with their responses"""
+
+    REPLAY = "REPLAY"
+    """Serve requests from the recorded responses, without calling the model, and fail on requests that weren't recorded"""
+
+
+class LLMStreamEventKind(Enum):
+    """The kind of an LLM stream event"""
+
+    RETRY = "RETRY"
+    """The model query failed and is retried, discarding the text streamed since the last TOKEN_USAGE event"""
+
+    TEXT_DELTA = "TEXT_DELTA"
+    """A chunk of text generated by the model"""
+
+    TOKEN_USAGE = "TOKEN_USAGE"
+    """The model replied, using tokens"""
+
+    TOOL_CALL_FINISH = "TOOL_CALL_FINISH"
+    """A tool call requested by the model finished"""
+
+    TOOL_CALL_START = "TOOL_CALL_START"
+    """A tool call requested by the model started"""
+
+
 class ModuleSourceExperimentalFeature(Enum):
     """Experimental features of a module"""
 
@@ -441,6 +506,22 @@ class ModuleSourceKind(Enum):
     LOCAL = "LOCAL_SOURCE"
 
 
+class NetworkMode(Enum):
+    """Network access granted to an execution"""
+
+    ALLOW_LIST = "ALLOW_LIST"
+    """Access to bound services, DNS, and an allow-list of hostnames, IPs and CIDRs"""
+
+    OFFLINE = "OFFLINE"
+    """No network access, except for the loopback interface"""
+
+    SERVICES_ONLY = "SERVICES_ONLY"
+    """Access to bound services and DNS only"""
+
+    UNRESTRICTED = "UNRESTRICTED"
+    """Full network access"""
+
+
 class NetworkProtocol(Enum):
     """Transport layer network protocol associated to a port."""
 
@@ -462,6 +543,16 @@ class ReturnType(Enum):
     """A successful execution (exit code 0)"""
 
 
+class SBOMFormat(Enum):
+    """Format of a software bill of materials."""
+
+    CYCLONEDX = "CYCLONEDX"
+    """CycloneDX 1.5 JSON document"""
+
+    SPDX = "SPDX"
+    """SPDX 2.3 JSON document"""
+
+
 class TypeDefKind(Enum):
     """Distinguishes the different kinds of TypeDefs."""
 
@@ -758,6 +849,12 @@ class Binding(Type):
         _ctx = self._select("asContainer", _args)
         return Container(_ctx)
 
+    def as_container_attestation(self) -> "ContainerAttestation":
+        """Retrieve the binding value, as type ContainerAttestation"""
+        _args: list[Arg] = []
+        _ctx = self._select("asContainerAttestation", _args)
+        return ContainerAttestation(_ctx)
+
     def as_directory(self) -> "Directory":
         """Retrieve the binding value, as type Directory"""
         _args: list[Arg] = []
@@ -794,6 +891,18 @@ class Binding(Type):
         _ctx = self._select("asGeneratorGroup", _args)
         return GeneratorGroup(_ctx)
 
+    def as_git_blame_line(self) -> "GitBlameLine":
+        """Retrieve the binding value, as type GitBlameLine"""
+        _args: list[Arg] = []
+        _ctx = self._select("asGitBlameLine", _args)
+        return GitBlameLine(_ctx)
+
+    def as_git_commit(self) -> "GitCommit":
+        """Retrieve the binding value, as type GitCommit"""
+        _args: list[Arg] = []
+        _ctx = self._select("asGitCommit", _args)
+        return GitCommit(_ctx)
+
     def as_git_ref(self) -> "GitRef":
         """Retrieve the binding value, as type GitRef"""
         _args: list[Arg] = []
@@ -812,6 +921,12 @@ class Binding(Type):
         _ctx = self._select("asJSONValue", _args)
         return JSONValue(_ctx)
 
+    def as_llm_stream_event(self) -> "LLMStreamEvent":
+        """Retrieve the binding value, as type LLMStreamEvent"""
+        _args: list[Arg] = []
+        _ctx = self._select("asLLMStreamEvent", _args)
+        return LLMStreamEvent(_ctx)
+
     def as_module(self) -> "Module":
         """Retrieve the binding value, as type Module"""
         _args: list[Arg] = []
@@ -1268,6 +1383,27 @@ class Changeset(Type):
 
 @typecheck
 class Check(Type):
+    async def attempts(self) -> int:
+        """The number of times the check was run, including retries
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("attempts", _args)
+        return await _ctx.execute(int)
+
     async def completed(self) -> bool:
         """Whether the check completed
 
@@ -1308,6 +1444,48 @@ class Check(Type):
         _ctx = self._select("description", _args)
         return await _ctx.execute(str)
 
+    async def duration_ms(self) -> int:
+        """How long the check took to run, in milliseconds
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("durationMs", _args)
+        return await _ctx.execute(int)
+
+    async def error_message(self) -> str:
+        """The error the check failed with, if any
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("errorMessage", _args)
+        return await _ctx.execute(str)
+
     async def id(self) -> CheckID:
         """A unique identifier for this Check.
 
@@ -1332,6 +1510,28 @@ class Check(Type):
         _ctx = self._select("id", _args)
         return await _ctx.execute(CheckID)
 
+    async def log_excerpt(self) -> str:
+        """The last lines of output of the failed command, if the check failed
+        running one
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("logExcerpt", _args)
+        return await _ctx.execute(str)
+
     async def name(self) -> str:
         """Return the fully qualified name of the check
 
@@ -1399,6 +1599,26 @@ class Check(Type):
         _ctx = self._select("path", _args)
         return await _ctx.execute(list[str])
 
+    async def quarantined(self) -> bool:
+        """Whether the check is quarantined: its failures are reported, but don't
+        fail the group
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("quarantined", _args)
+        return await _ctx.execute(bool)
+
     async def result_emoji(self) -> str:
         """An emoji representing the result of the check
 
@@ -1420,12 +1640,103 @@ class Check(Type):
         _ctx = self._select("resultEmoji", _args)
         return await _ctx.execute(str)
 
-    def run(self) -> Self:
-        """Execute the check"""
-        _args: list[Arg] = []
+    def run(self, *, retries: int | None = 0) -> Self:
+        """Execute the check
+
+        Parameters
+        ----------
+        retries:
+            Number of times the check is retried if it fails
+        """
+        _args = [
+            Arg("retries", retries, 0),
+        ]
         _ctx = self._select("run", _args)
         return Check(_ctx)
 
+    async def selection_reason(self) -> str:
+        """Why the check was selected or skipped by affectedBy
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("selectionReason", _args)
+        return await _ctx.execute(str)
+
+    async def skipped(self) -> bool:
+        """Whether the check is skipped because none of its inputs are affected
+        by the changes passed to affectedBy
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("skipped", _args)
+        return await _ctx.execute(bool)
+
+    async def span_id(self) -> str:
+        """The ID of the span of the check
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("spanID", _args)
+        return await _ctx.execute(str)
+
+    async def trace_id(self) -> str:
+        """The ID of the trace the check ran in
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("traceID", _args)
+        return await _ctx.execute(str)
+
     def with_(self, cb: Callable[["Check"], "Check"]) -> "Check":
         """Call the provided callable with current Check.
 
@@ -1436,6 +1747,46 @@ class Check(Type):
 
 @typecheck
 class CheckGroup(Type):
+    def affected_by(self, changes: Changeset) -> Self:
+        """Skip the checks whose inputs are not affected by the given changes
+
+        A check's inputs are the default paths of the contextual arguments of
+        the functions called to reach it, with their ignore patterns applied,
+        and the config and source code of the modules defining them and of
+        their local dependencies. Checks without any declared input are always
+        selected.
+
+        Parameters
+        ----------
+        changes:
+            The changes to select checks for, relative to the context
+            directory
+        """
+        _args = [
+            Arg("changes", changes),
+        ]
+        _ctx = self._select("affectedBy", _args)
+        return CheckGroup(_ctx)
+
+    def affected_by_paths(self, paths: list[str]) -> Self:
+        """Skip the checks whose inputs are not affected by the given changed
+        paths
+
+        Like affectedBy, for changes computed by the caller, e.g. from a git
+        diff on the host.
+
+        Parameters
+        ----------
+        paths:
+            The added, modified or removed paths, relative to the context
+            directory
+        """
+        _args = [
+            Arg("paths", paths),
+        ]
+        _ctx = self._select("affectedByPaths", _args)
+        return CheckGroup(_ctx)
+
     async def id(self) -> CheckGroupID:
         """A unique identifier for this CheckGroup.
 
@@ -1466,15 +1817,50 @@ class CheckGroup(Type):
         _ctx = self._select("list", _args)
         return await _ctx.execute_object_list(Check)
 
-    def report(self) -> "File":
-        """Generate a markdown report"""
-        _args: list[Arg] = []
+    def report(
+        self,
+        *,
+        format: CheckReportFormat | None = CheckReportFormat.MARKDOWN,
+    ) -> "File":
+        """Generate a report of the check results
+
+        Parameters
+        ----------
+        format:
+            The format of the report
+        """
+        _args = [
+            Arg("format", format, CheckReportFormat.MARKDOWN),
+        ]
         _ctx = self._select("report", _args)
         return File(_ctx)
 
-    def run(self) -> Self:
-        """Execute all selected checks"""
-        _args: list[Arg] = []
+    def run(
+        self,
+        *,
+        fail_fast: bool | None = False,
+        retries: int | None = 0,
+        no_fail: bool | None = False,
+    ) -> Self:
+        """Execute all selected checks
+
+        Parameters
+        ----------
+        fail_fast:
+            Cancel the remaining checks as soon as one fails. Quarantined
+            checks don't trigger cancellation.
+        retries:
+            Number of times a failed check is retried before it is reported as
+            failed
+        no_fail:
+            Record failed checks in the results instead of returning an error,
+            e.g. to generate a report of the failures
+        """
+        _args = [
+            Arg("failFast", fail_fast, False),
+            Arg("retries", retries, 0),
+            Arg("noFail", no_fail, False),
+        ]
         _ctx = self._select("run", _args)
         return CheckGroup(_ctx)
 
@@ -1634,10 +2020,34 @@ class Container(Type):
         _ctx = self._select("asTarball", _args)
         return File(_ctx)
 
-    async def combined_output(self) -> str:
-        """The combined buffered standard output and standard error stream of the
-        last executed command
-
+    async def attestations(
+        self,
+        *,
+        sbom: SBOMFormat | None = None,
+        provenance: bool | None = False,
+    ) -> list["ContainerAttestation"]:
+        """The in-toto attestations that would be attached to the container image
+        when published or exported.
+
+        Parameters
+        ----------
+        sbom:
+            Include a software bill of materials of the packages installed in
+            the container, in the given format.
+        provenance:
+            Include SLSA provenance of the calls that produced the container.
+        """
+        _args = [
+            Arg("sbom", sbom, None),
+            Arg("provenance", provenance, False),
+        ]
+        _ctx = self._select("attestations", _args)
+        return await _ctx.execute_object_list(ContainerAttestation)
+
+    async def combined_output(self) -> str:
+        """The combined buffered standard output and standard error stream of the
+        last executed command
+
         Returns an error if no command was executed
 
         Returns
@@ -1861,6 +2271,8 @@ class Container(Type):
         forced_compression: ImageLayerCompression | None = None,
         media_types: ImageMediaTypes | None = ImageMediaTypes.OCIMediaTypes,
         expand: bool | None = False,
+        sbom: SBOMFormat | None = None,
+        provenance: bool | None = False,
     ) -> str:
         """Writes the container as an OCI tarball to the destination file path on
         the host.
@@ -1892,6 +2304,12 @@ class Container(Type):
             Replace "${VAR}" or "$VAR" in the value of path according to the
             current environment variables defined in the container (e.g.
             "/$VAR/foo").
+        sbom:
+            Attach a software bill of materials of the packages installed in
+            the container, in the given format, as an in-toto attestation.
+        provenance:
+            Attach SLSA provenance of the calls that produced the container as
+            an in-toto attestation.
 
         Returns
         -------
@@ -1917,6 +2335,8 @@ class Container(Type):
             Arg("forcedCompression", forced_compression, None),
             Arg("mediaTypes", media_types, ImageMediaTypes.OCIMediaTypes),
             Arg("expand", expand, False),
+            Arg("sbom", sbom, None),
+            Arg("provenance", provenance, False),
         ]
         _ctx = self._select("export", _args)
         return await _ctx.execute(str)
@@ -2014,7 +2434,12 @@ class Container(Type):
         _ctx = self._select("file", _args)
         return File(_ctx)
 
-    def from_(self, address: str) -> Self:
+    def from_(
+        self,
+        address: str,
+        *,
+        verify: str | None = "",
+    ) -> Self:
         """Download a container image, and apply it to the container state. All
         previous state will be lost.
 
@@ -2023,9 +2448,15 @@ class Container(Type):
         address:
             Address of the container image to download, in standard OCI ref
             format. Example:"registry.dagger.io/engine:latest"
+        verify:
+            PEM encoded public key to verify the image signature with.
+            If set, the image is rejected unless it has a cosign compatible
+            signature verified by this key, attached as an OCI referrer or
+            with the cosign tag scheme.
         """
         _args = [
             Arg("address", address),
+            Arg("verify", verify, ""),
         ]
         _ctx = self._select("from", _args)
         return Container(_ctx)
@@ -2183,6 +2614,10 @@ class Container(Type):
         platform_variants: "list[Container] | None" = None,
         forced_compression: ImageLayerCompression | None = None,
         media_types: ImageMediaTypes | None = ImageMediaTypes.OCIMediaTypes,
+        sign: "Secret | None" = None,
+        sign_password: "Secret | None" = None,
+        sbom: SBOMFormat | None = None,
+        provenance: bool | None = False,
     ) -> str:
         """Package the container state as an OCI image, and publish it to a
         registry
@@ -2212,6 +2647,21 @@ class Container(Type):
             Defaults to "OCI", which is compatible with most recent
             registries, but "Docker" may be needed for older registries
             without OCI support.
+        sign:
+            PEM encoded private key to sign the published image with.
+            The signature is cosign compatible, and attached to the image both
+            as an OCI referrer and with the cosign tag scheme
+            ("sha256-<hex>.sig"), for cosign to find it with or without
+            --experimental-oci11. Encrypted cosign keys are decrypted with
+            signPassword.
+        sign_password:
+            Password of the private key to sign the published image with.
+        sbom:
+            Attach a software bill of materials of the packages installed in
+            the container, in the given format, as an in-toto attestation.
+        provenance:
+            Attach SLSA provenance of the calls that produced the container as
+            an in-toto attestation.
 
         Returns
         -------
@@ -2236,6 +2686,10 @@ class Container(Type):
             ),
             Arg("forcedCompression", forced_compression, None),
             Arg("mediaTypes", media_types, ImageMediaTypes.OCIMediaTypes),
+            Arg("sign", sign, None),
+            Arg("signPassword", sign_password, None),
+            Arg("sbom", sbom, None),
+            Arg("provenance", provenance, False),
         ]
         _ctx = self._select("publish", _args)
         return await _ctx.execute(str)
@@ -2375,6 +2829,7 @@ class Container(Type):
         *,
         random: bool | None = False,
         ports: list[PortForward] | None = None,
+        timeout: str | None = "",
         args: list[str] | None = None,
         use_entrypoint: bool | None = False,
         experimental_privileged_nesting: bool | None = False,
@@ -2395,6 +2850,11 @@ class Container(Type):
             List of frontend/backend port mappings to forward.
             Frontend is the port accepting traffic on the host, backend is the
             service port.
+        timeout:
+            Maximum duration to keep the tunnel and service up, as a Go
+            duration string (e.g. "10m").
+            When it elapses, the service is sent SIGTERM, followed by SIGKILL
+            after a grace period, and an error is returned.
         args:
             Command to run instead of the container's default command (e.g.,
             ["go", "run", "main.go"]).
@@ -2435,6 +2895,7 @@ class Container(Type):
         _args = [
             Arg("random", random, False),
             Arg("ports", [] if ports is None else ports, []),
+            Arg("timeout", timeout, ""),
             Arg("args", [] if args is None else args, []),
             Arg("useEntrypoint", use_entrypoint, False),
             Arg(
@@ -2485,6 +2946,21 @@ class Container(Type):
         _ctx = self._select("withAnnotation", _args)
         return Container(_ctx)
 
+    def with_cpu_limit(self, cpus: float) -> Self:
+        """Limits the CPU time available to commands run in this container.
+
+        Parameters
+        ----------
+        cpus:
+            Number of CPUs the commands may use, e.g. 1.5. Set to 0 to remove
+            the limit.
+        """
+        _args = [
+            Arg("cpus", cpus),
+        ]
+        _ctx = self._select("withCPULimit", _args)
+        return Container(_ctx)
+
     def with_default_args(self, args: list[str]) -> Self:
         """Configures default arguments for future commands. Like CMD in
         Dockerfile.
@@ -2679,6 +3155,9 @@ class Container(Type):
         insecure_root_capabilities: bool | None = False,
         expand: bool | None = False,
         no_init: bool | None = False,
+        timeout: str | None = "",
+        network_mode: NetworkMode | None = NetworkMode.UNRESTRICTED,
+        network_allow: list[str] | None = None,
     ) -> Self:
         """Execute a command in the container, and return a new snapshot of the
         container state after execution.
@@ -2726,6 +3205,30 @@ class Container(Type):
             Only use this if you specifically need the command to be pid 1 in
             the container. Otherwise it may result in unexpected behavior. If
             you're not sure, you don't need this.
+        timeout:
+            Maximum duration the command may run, as a Go duration string
+            (e.g. "30s", "5m").
+            When it elapses, the command is sent SIGTERM, followed by SIGKILL
+            after a grace period, and the exec fails with a timed out
+            ExecError, which is never cached.
+            A timed out exec fails regardless of "expect", even if the command
+            handles SIGTERM and exits with an expected code. To expect a
+            timeout, check whether the ExecError timed out.
+        network_mode:
+            Network access granted to the command.
+            OFFLINE only allows the loopback interface. SERVICES_ONLY allows
+            bound services and DNS. ALLOW_LIST additionally allows the
+            destinations in "networkAllow". Other connections are rejected.
+            DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST
+            the command can still send data out through the names it looks up.
+            Use OFFLINE if this must be prevented.
+        network_allow:
+            Hostnames, IPv4 addresses and CIDRs the command may connect to
+            with the ALLOW_LIST network mode. Example: ["proxy.golang.org",
+            "10.0.0.0/8"]
+            Hostnames are resolved once, when the command starts: connections
+            to the addresses they resolve to afterwards (e.g. when a DNS
+            record changes or rotates) are rejected.
         """
         _args = [
             Arg("args", args),
@@ -2741,6 +3244,9 @@ class Container(Type):
             Arg("insecureRootCapabilities", insecure_root_capabilities, False),
             Arg("expand", expand, False),
             Arg("noInit", no_init, False),
+            Arg("timeout", timeout, ""),
+            Arg("networkMode", network_mode, NetworkMode.UNRESTRICTED),
+            Arg("networkAllow", [] if network_allow is None else network_allow, []),
         ]
         _ctx = self._select("withExec", _args)
         return Container(_ctx)
@@ -2861,6 +3367,95 @@ class Container(Type):
         _ctx = self._select("withFiles", _args)
         return Container(_ctx)
 
+    def with_healthcheck(
+        self,
+        *,
+        args: list[str] | None = None,
+        http_port: int | None = 0,
+        http_path: str | None = "/",
+        http_status: int | None = 200,
+        log_pattern: str | None = "",
+        interval: str | None = "1s",
+        timeout: str | None = "10s",
+        start_period: str | None = "0s",
+        retries: int | None = 30,
+    ) -> Self:
+        """Set a readiness check run when the container is started as a service.
+        Like HEALTHCHECK in Dockerfile.
+
+        The service is not considered started until the check passes, after
+        the health checks of its exposed ports.
+
+        Exactly one of args, httpPort and logPattern must be set.
+
+        Parameters
+        ----------
+        args:
+            Command to run in the service container, ready once it exits zero.
+            Example: ["pg_isready", "-U", "postgres"]
+        http_port:
+            Port to send an HTTP GET request to, ready once it responds with
+            httpStatus. Example: 8080
+        http_path:
+            Path of the HTTP GET request. Example: "/healthz"
+        http_status:
+            Expected status of the HTTP response.
+        log_pattern:
+            Regular expression matched against the lines of the service
+            stdout, ready once a line matches. Example: "listening on .*:8080"
+        interval:
+            Time between checks, as a Go duration string. Example: "5s"
+        timeout:
+            Time after which a single check fails, as a Go duration string.
+        start_period:
+            Initialization time during which failed checks are not counted, as
+            a Go duration string.
+        retries:
+            Number of consecutive failed checks after which the service fails
+            to start.
+        """
+        _args = [
+            Arg("args", [] if args is None else args, []),
+            Arg("httpPort", http_port, 0),
+            Arg("httpPath", http_path, "/"),
+            Arg("httpStatus", http_status, 200),
+            Arg("logPattern", log_pattern, ""),
+            Arg("interval", interval, "1s"),
+            Arg("timeout", timeout, "10s"),
+            Arg("startPeriod", start_period, "0s"),
+            Arg("retries", retries, 30),
+        ]
+        _ctx = self._select("withHealthcheck", _args)
+        return Container(_ctx)
+
+    def with_io_weight(self, weight: int) -> Self:
+        """Sets the relative block IO weight of commands run in this container.
+
+        Parameters
+        ----------
+        weight:
+            Weight between 10 and 1000, relative to other containers. Set to 0
+            to use the default.
+        """
+        _args = [
+            Arg("weight", weight),
+        ]
+        _ctx = self._select("withIOWeight", _args)
+        return Container(_ctx)
+
+    def with_image_healthcheck(self) -> Self:
+        """Use the HEALTHCHECK of the container image as its readiness check when
+        started as a service.
+
+        Image healthchecks are not run unless opted into, since their
+        intervals are meant for monitoring long-running containers.
+
+        Fails if the image has no healthcheck.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("withImageHealthcheck", _args)
+        return Container(_ctx)
+
     def with_label(self, name: str, value: str) -> Self:
         """Retrieves this container plus the given label.
 
@@ -2879,6 +3474,25 @@ class Container(Type):
         _ctx = self._select("withLabel", _args)
         return Container(_ctx)
 
+    def with_memory_limit(self, limit: str) -> Self:
+        """Limits the memory available to commands run in this container.
+
+        A command exceeding the limit is killed, and fails with an ExecError
+        that reports the OOM kill.
+
+        Parameters
+        ----------
+        limit:
+            Maximum memory usage, including swap, as a number of bytes or with
+            a unit suffix (e.g. "512MiB", "4GiB"). Units are powers of 1024.
+            Set to "0" to remove the limit.
+        """
+        _args = [
+            Arg("limit", limit),
+        ]
+        _ctx = self._select("withMemoryLimit", _args)
+        return Container(_ctx)
+
     def with_mounted_cache(
         self,
         path: str,
@@ -3108,6 +3722,21 @@ class Container(Type):
         _ctx = self._select("withNewFile", _args)
         return Container(_ctx)
 
+    def with_pids_limit(self, limit: int) -> Self:
+        """Limits the number of processes that commands run in this container may
+        create.
+
+        Parameters
+        ----------
+        limit:
+            Maximum number of processes. Set to 0 to remove the limit.
+        """
+        _args = [
+            Arg("limit", limit),
+        ]
+        _ctx = self._select("withPidsLimit", _args)
+        return Container(_ctx)
+
     def with_registry_auth(
         self,
         address: str,
@@ -3447,6 +4076,12 @@ class Container(Type):
         _ctx = self._select("withoutFiles", _args)
         return Container(_ctx)
 
+    def without_healthcheck(self) -> Self:
+        """Retrieves this container without its readiness check."""
+        _args: list[Arg] = []
+        _ctx = self._select("withoutHealthcheck", _args)
+        return Container(_ctx)
+
     def without_label(self, name: str) -> Self:
         """Retrieves this container minus the given environment label.
 
@@ -3590,6 +4225,76 @@ class Container(Type):
         return cb(self)
 
 
+@typecheck
+class ContainerAttestation(Type):
+    """An in-toto attestation of a container image, such as an SBOM or
+    SLSA provenance."""
+
+    async def id(self) -> ContainerAttestationID:
+        """A unique identifier for this ContainerAttestation.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        ContainerAttestationID
+            The `ContainerAttestationID` scalar type represents an identifier
+            for an object of type ContainerAttestation.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(ContainerAttestationID)
+
+    async def predicate(self) -> JSON:
+        """The predicate of the attestation, as a JSON document.
+
+        Returns
+        -------
+        JSON
+            An arbitrary JSON-encoded value.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("predicate", _args)
+        return await _ctx.execute(JSON)
+
+    async def predicate_type(self) -> str:
+        """The in-toto predicate type of the attestation.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("predicateType", _args)
+        return await _ctx.execute(str)
+
+
 @typecheck
 class CurrentModule(Type):
     """Reflective module API provided to functions at runtime."""
@@ -5676,6 +6381,50 @@ class Env(Type):
         _ctx = self._select("withCloudOutput", _args)
         return Env(_ctx)
 
+    def with_container_attestation_input(
+        self,
+        name: str,
+        value: ContainerAttestation,
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type ContainerAttestation in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The ContainerAttestation value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withContainerAttestationInput", _args)
+        return Env(_ctx)
+
+    def with_container_attestation_output(self, name: str, description: str) -> Self:
+        """Declare a desired ContainerAttestation output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withContainerAttestationOutput", _args)
+        return Env(_ctx)
+
     def with_container_input(
         self,
         name: str,
@@ -5982,20 +6731,20 @@ class Env(Type):
         _ctx = self._select("withGeneratorOutput", _args)
         return Env(_ctx)
 
-    def with_git_ref_input(
+    def with_git_blame_line_input(
         self,
         name: str,
-        value: "GitRef",
+        value: "GitBlameLine",
         description: str,
     ) -> Self:
-        """Create or update a binding of type GitRef in the environment
+        """Create or update a binding of type GitBlameLine in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The GitRef value to assign to the binding
+            The GitBlameLine value to assign to the binding
         description:
             The purpose of the input
         """
@@ -6004,11 +6753,12 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withGitRefInput", _args)
+        _ctx = self._select("withGitBlameLineInput", _args)
         return Env(_ctx)
 
-    def with_git_ref_output(self, name: str, description: str) -> Self:
-        """Declare a desired GitRef output to be assigned in the environment
+    def with_git_blame_line_output(self, name: str, description: str) -> Self:
+        """Declare a desired GitBlameLine output to be assigned in the
+        environment
 
         Parameters
         ----------
@@ -6021,23 +6771,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withGitRefOutput", _args)
+        _ctx = self._select("withGitBlameLineOutput", _args)
         return Env(_ctx)
 
-    def with_git_repository_input(
+    def with_git_commit_input(
         self,
         name: str,
-        value: "GitRepository",
+        value: "GitCommit",
         description: str,
     ) -> Self:
-        """Create or update a binding of type GitRepository in the environment
+        """Create or update a binding of type GitCommit in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The GitRepository value to assign to the binding
+            The GitCommit value to assign to the binding
         description:
             The purpose of the input
         """
@@ -6046,12 +6796,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withGitRepositoryInput", _args)
+        _ctx = self._select("withGitCommitInput", _args)
         return Env(_ctx)
 
-    def with_git_repository_output(self, name: str, description: str) -> Self:
-        """Declare a desired GitRepository output to be assigned in the
-        environment
+    def with_git_commit_output(self, name: str, description: str) -> Self:
+        """Declare a desired GitCommit output to be assigned in the environment
 
         Parameters
         ----------
@@ -6064,23 +6813,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withGitRepositoryOutput", _args)
+        _ctx = self._select("withGitCommitOutput", _args)
         return Env(_ctx)
 
-    def with_json_value_input(
+    def with_git_ref_input(
         self,
         name: str,
-        value: "JSONValue",
+        value: "GitRef",
         description: str,
     ) -> Self:
-        """Create or update a binding of type JSONValue in the environment
+        """Create or update a binding of type GitRef in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The JSONValue value to assign to the binding
+            The GitRef value to assign to the binding
         description:
             The purpose of the input
         """
@@ -6089,11 +6838,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withJSONValueInput", _args)
+        _ctx = self._select("withGitRefInput", _args)
         return Env(_ctx)
 
-    def with_json_value_output(self, name: str, description: str) -> Self:
-        """Declare a desired JSONValue output to be assigned in the environment
+    def with_git_ref_output(self, name: str, description: str) -> Self:
+        """Declare a desired GitRef output to be assigned in the environment
 
         Parameters
         ----------
@@ -6106,58 +6855,23 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withJSONValueOutput", _args)
-        return Env(_ctx)
-
-    def with_main_module(self, module: "Module") -> Self:
-        """Sets the main module for this environment (the project being worked
-        on)
-
-        Contextual path arguments will be populated using the environment's
-        workspace.
-        """
-        _args = [
-            Arg("module", module),
-        ]
-        _ctx = self._select("withMainModule", _args)
-        return Env(_ctx)
-
-    def with_module(self, module: "Module") -> Self:
-        """Installs a module into the environment, exposing its functions to the
-        model
-
-        Contextual path arguments will be populated using the environment's
-        workspace.
-
-        .. deprecated::
-            Use withMainModule instead
-        """
-        warnings.warn(
-            'Method "with_module" is deprecated: Use withMainModule instead',
-            DeprecationWarning,
-            stacklevel=4,
-        )
-        _args = [
-            Arg("module", module),
-        ]
-        _ctx = self._select("withModule", _args)
+        _ctx = self._select("withGitRefOutput", _args)
         return Env(_ctx)
 
-    def with_module_config_client_input(
+    def with_git_repository_input(
         self,
         name: str,
-        value: "ModuleConfigClient",
+        value: "GitRepository",
         description: str,
     ) -> Self:
-        """Create or update a binding of type ModuleConfigClient in the
-        environment
+        """Create or update a binding of type GitRepository in the environment
 
         Parameters
         ----------
         name:
             The name of the binding
         value:
-            The ModuleConfigClient value to assign to the binding
+            The GitRepository value to assign to the binding
         description:
             The purpose of the input
         """
@@ -6166,11 +6880,11 @@ class Env(Type):
             Arg("value", value),
             Arg("description", description),
         ]
-        _ctx = self._select("withModuleConfigClientInput", _args)
+        _ctx = self._select("withGitRepositoryInput", _args)
         return Env(_ctx)
 
-    def with_module_config_client_output(self, name: str, description: str) -> Self:
-        """Declare a desired ModuleConfigClient output to be assigned in the
+    def with_git_repository_output(self, name: str, description: str) -> Self:
+        """Declare a desired GitRepository output to be assigned in the
         environment
 
         Parameters
@@ -6184,13 +6898,176 @@ class Env(Type):
             Arg("name", name),
             Arg("description", description),
         ]
-        _ctx = self._select("withModuleConfigClientOutput", _args)
+        _ctx = self._select("withGitRepositoryOutput", _args)
         return Env(_ctx)
 
-    def with_module_input(
+    def with_json_value_input(
         self,
         name: str,
-        value: "Module",
+        value: "JSONValue",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type JSONValue in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The JSONValue value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withJSONValueInput", _args)
+        return Env(_ctx)
+
+    def with_json_value_output(self, name: str, description: str) -> Self:
+        """Declare a desired JSONValue output to be assigned in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withJSONValueOutput", _args)
+        return Env(_ctx)
+
+    def with_llm_stream_event_input(
+        self,
+        name: str,
+        value: "LLMStreamEvent",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type LLMStreamEvent in the environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The LLMStreamEvent value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withLLMStreamEventInput", _args)
+        return Env(_ctx)
+
+    def with_llm_stream_event_output(self, name: str, description: str) -> Self:
+        """Declare a desired LLMStreamEvent output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withLLMStreamEventOutput", _args)
+        return Env(_ctx)
+
+    def with_main_module(self, module: "Module") -> Self:
+        """Sets the main module for this environment (the project being worked
+        on)
+
+        Contextual path arguments will be populated using the environment's
+        workspace.
+        """
+        _args = [
+            Arg("module", module),
+        ]
+        _ctx = self._select("withMainModule", _args)
+        return Env(_ctx)
+
+    def with_module(self, module: "Module") -> Self:
+        """Installs a module into the environment, exposing its functions to the
+        model
+
+        Contextual path arguments will be populated using the environment's
+        workspace.
+
+        .. deprecated::
+            Use withMainModule instead
+        """
+        warnings.warn(
+            'Method "with_module" is deprecated: Use withMainModule instead',
+            DeprecationWarning,
+            stacklevel=4,
+        )
+        _args = [
+            Arg("module", module),
+        ]
+        _ctx = self._select("withModule", _args)
+        return Env(_ctx)
+
+    def with_module_config_client_input(
+        self,
+        name: str,
+        value: "ModuleConfigClient",
+        description: str,
+    ) -> Self:
+        """Create or update a binding of type ModuleConfigClient in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        value:
+            The ModuleConfigClient value to assign to the binding
+        description:
+            The purpose of the input
+        """
+        _args = [
+            Arg("name", name),
+            Arg("value", value),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withModuleConfigClientInput", _args)
+        return Env(_ctx)
+
+    def with_module_config_client_output(self, name: str, description: str) -> Self:
+        """Declare a desired ModuleConfigClient output to be assigned in the
+        environment
+
+        Parameters
+        ----------
+        name:
+            The name of the binding
+        description:
+            A description of the desired value of the binding
+        """
+        _args = [
+            Arg("name", name),
+            Arg("description", description),
+        ]
+        _ctx = self._select("withModuleConfigClientOutput", _args)
+        return Env(_ctx)
+
+    def with_module_input(
+        self,
+        name: str,
+        value: "Module",
         description: str,
     ) -> Self:
         """Create or update a binding of type Module in the environment
@@ -7119,6 +7996,20 @@ class File(Type):
         _ctx = self._select("asJSON", _args)
         return JSONValue(_ctx)
 
+    async def blame(self) -> list["GitBlameLine"]:
+        """Return the lines of this file with the commit that last changed them.
+
+        The file must be in a git checkout with its .git directory, like the
+        tree of a git ref. Lines of shallow checkouts are attributed to their
+        oldest commit at most.
+
+        Files with a filter attribute, partial clones, and repositories whose
+        git directory is outside of the directory are not supported.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("blame", _args)
+        return await _ctx.execute_object_list(GitBlameLine)
+
     def chown(self, owner: str) -> Self:
         """Change the owner of the file recursively.
 
@@ -8380,6 +9271,47 @@ class Generator(Type):
         _ctx = self._select("run", _args)
         return Generator(_ctx)
 
+    async def selection_reason(self) -> str:
+        """Why the generator was selected or skipped by affectedBy
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("selectionReason", _args)
+        return await _ctx.execute(str)
+
+    async def skipped(self) -> bool:
+        """Whether the generator is skipped because none of its inputs are
+        affected by the changes passed to affectedBy
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("skipped", _args)
+        return await _ctx.execute(bool)
+
     def with_(self, cb: Callable[["Generator"], "Generator"]) -> "Generator":
         """Call the provided callable with current Generator.
 
@@ -8390,6 +9322,46 @@ class Generator(Type):
 
 @typecheck
 class GeneratorGroup(Type):
+    def affected_by(self, changes: Changeset) -> Self:
+        """Skip the generators whose inputs are not affected by the given changes
+
+        A generator's inputs are the default paths of the contextual arguments
+        of the functions called to reach it, with their ignore patterns
+        applied, and the config and source code of the modules defining them
+        and of their local dependencies. Generators without any declared input
+        are always selected.
+
+        Parameters
+        ----------
+        changes:
+            The changes to select generators for, relative to the context
+            directory
+        """
+        _args = [
+            Arg("changes", changes),
+        ]
+        _ctx = self._select("affectedBy", _args)
+        return GeneratorGroup(_ctx)
+
+    def affected_by_paths(self, paths: list[str]) -> Self:
+        """Skip the generators whose inputs are not affected by the given changed
+        paths
+
+        Like affectedBy, for changes computed by the caller, e.g. from a git
+        diff on the host.
+
+        Parameters
+        ----------
+        paths:
+            The added, modified or removed paths, relative to the context
+            directory
+        """
+        _args = [
+            Arg("paths", paths),
+        ]
+        _ctx = self._select("affectedByPaths", _args)
+        return GeneratorGroup(_ctx)
+
     def changes(
         self,
         *,
@@ -8482,11 +9454,11 @@ class GeneratorGroup(Type):
 
 
 @typecheck
-class GitRef(Type):
-    """A git ref (tag, branch, or commit)."""
+class GitBlameLine(Type):
+    """A line of a file, with the commit that last changed it."""
 
-    async def commit(self) -> str:
-        """The resolved commit id at this ref.
+    async def author_email(self) -> str:
+        """The email of the author of the commit.
 
         Returns
         -------
@@ -8503,35 +9475,18 @@ class GitRef(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("commit", _args)
+        _ctx = self._select("authorEmail", _args)
         return await _ctx.execute(str)
 
-    def common_ancestor(self, other: Self) -> Self:
-        """Find the best common ancestor between this ref and another ref.
-
-        Parameters
-        ----------
-        other:
-            The other ref to compare against.
-        """
-        _args = [
-            Arg("other", other),
-        ]
-        _ctx = self._select("commonAncestor", _args)
-        return GitRef(_ctx)
-
-    async def id(self) -> GitRefID:
-        """A unique identifier for this GitRef.
-
-        Note
-        ----
-        This is lazily evaluated, no operation is actually run.
+    async def author_name(self) -> str:
+        """The name of the author of the commit.
 
         Returns
         -------
-        GitRefID
-            The `GitRefID` scalar type represents an identifier for an object
-            of type GitRef.
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
 
         Raises
         ------
@@ -8541,11 +9496,12 @@ class GitRef(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("id", _args)
-        return await _ctx.execute(GitRefID)
+        _ctx = self._select("authorName", _args)
+        return await _ctx.execute(str)
 
-    async def ref(self) -> str:
-        """The resolved ref name at this ref.
+    async def commit(self) -> str:
+        """The id of the commit that last changed the line, or all zeroes if it
+        isn't committed yet.
 
         Returns
         -------
@@ -8562,7 +9518,489 @@ class GitRef(Type):
             If the API returns an error.
         """
         _args: list[Arg] = []
-        _ctx = self._select("ref", _args)
+        _ctx = self._select("commit", _args)
+        return await _ctx.execute(str)
+
+    async def content(self) -> str:
+        """The content of the line.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("content", _args)
+        return await _ctx.execute(str)
+
+    async def date(self) -> str:
+        """The date the commit was authored, in RFC 3339 format.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("date", _args)
+        return await _ctx.execute(str)
+
+    async def id(self) -> GitBlameLineID:
+        """A unique identifier for this GitBlameLine.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        GitBlameLineID
+            The `GitBlameLineID` scalar type represents an identifier for an
+            object of type GitBlameLine.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(GitBlameLineID)
+
+    async def line(self) -> int:
+        """The line number, starting at 1.
+
+        Returns
+        -------
+        int
+            The `Int` scalar type represents non-fractional signed whole
+            numeric values. Int can represent values between -(2^31) and 2^31
+            - 1.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("line", _args)
+        return await _ctx.execute(int)
+
+
+@typecheck
+class GitCommit(Type):
+    """A commit in the history of a git ref."""
+
+    async def author_email(self) -> str:
+        """The email of the author of the commit.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("authorEmail", _args)
+        return await _ctx.execute(str)
+
+    async def author_name(self) -> str:
+        """The name of the author of the commit.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("authorName", _args)
+        return await _ctx.execute(str)
+
+    async def commit(self) -> str:
+        """The commit id.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("commit", _args)
+        return await _ctx.execute(str)
+
+    async def date(self) -> str:
+        """The date the commit was authored, in RFC 3339 format.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("date", _args)
+        return await _ctx.execute(str)
+
+    async def files(self) -> list[str]:
+        """The paths of the files changed by the commit, compared to its first
+        parent.
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("files", _args)
+        return await _ctx.execute(list[str])
+
+    async def id(self) -> GitCommitID:
+        """A unique identifier for this GitCommit.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        GitCommitID
+            The `GitCommitID` scalar type represents an identifier for an
+            object of type GitCommit.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(GitCommitID)
+
+    async def message(self) -> str:
+        """The full message of the commit.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("message", _args)
+        return await _ctx.execute(str)
+
+    async def parents(self) -> list[str]:
+        """The ids of the parents of the commit.
+
+        Returns
+        -------
+        list[str]
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("parents", _args)
+        return await _ctx.execute(list[str])
+
+
+@typecheck
+class GitRef(Type):
+    """A git ref (tag, branch, or commit)."""
+
+    async def commit(self) -> str:
+        """The resolved commit id at this ref.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("commit", _args)
+        return await _ctx.execute(str)
+
+    def common_ancestor(self, other: Self) -> Self:
+        """Find the best common ancestor between this ref and another ref.
+
+        Parameters
+        ----------
+        other:
+            The other ref to compare against.
+        """
+        _args = [
+            Arg("other", other),
+        ]
+        _ctx = self._select("commonAncestor", _args)
+        return GitRef(_ctx)
+
+    def diff(self, other: Self) -> Changeset:
+        """Return the changes from this ref to another ref, like "git diff".
+
+        The .git directory isn't part of the changes.
+
+        Parameters
+        ----------
+        other:
+            The ref to compare this ref against.
+        """
+        _args = [
+            Arg("other", other),
+        ]
+        _ctx = self._select("diff", _args)
+        return Changeset(_ctx)
+
+    async def id(self) -> GitRefID:
+        """A unique identifier for this GitRef.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        GitRefID
+            The `GitRefID` scalar type represents an identifier for an object
+            of type GitRef.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(GitRefID)
+
+    async def log(
+        self,
+        *,
+        paths: list[str] | None = None,
+        limit: int | None = 0,
+        since: str | None = "",
+    ) -> list[GitCommit]:
+        """The history of this ref, most recent commits first.
+
+        Parameters
+        ----------
+        paths:
+            Only include the commits changing these paths (e.g., ["docs",
+            "README.md"]).
+        limit:
+            The maximum number of commits to return. All the commits are
+            returned if 0.
+        since:
+            Only include the commits more recent than a date, in any format
+            git understands (e.g., "2024-01-31" or "2 weeks ago").
+        """
+        _args = [
+            Arg("paths", [] if paths is None else paths, []),
+            Arg("limit", limit, 0),
+            Arg("since", since, ""),
+        ]
+        _ctx = self._select("log", _args)
+        return await _ctx.execute_object_list(GitCommit)
+
+    async def push(
+        self,
+        remote: str,
+        *,
+        ref: str | None = "",
+        force: bool | None = False,
+        ssh_known_hosts: str | None = "",
+        ssh_auth_socket: "Socket | None" = None,
+        http_auth_username: str | None = "",
+        http_auth_token: "Secret | None" = None,
+        http_auth_header: "Secret | None" = None,
+    ) -> str:
+        """Push the commit of this ref to a remote repository.
+
+        Returns the updated remote ref.
+
+        Parameters
+        ----------
+        remote:
+            URL of the remote repository.
+        ref:
+            The remote ref to update (e.g., "refs/heads/fix" or "fix" for a
+            branch). Defaults to the name of this ref.
+        force:
+            Update the remote ref even if it isn't an ancestor of the pushed
+            commit.
+        ssh_known_hosts:
+            Set SSH known hosts
+        ssh_auth_socket:
+            Set SSH auth socket
+        http_auth_username:
+            Username used to populate the password during basic HTTP
+            Authorization
+        http_auth_token:
+            Secret used to populate the password during basic HTTP
+            Authorization
+        http_auth_header:
+            Secret used to populate the Authorization HTTP header
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args = [
+            Arg("remote", remote),
+            Arg("ref", ref, ""),
+            Arg("force", force, False),
+            Arg("sshKnownHosts", ssh_known_hosts, ""),
+            Arg("sshAuthSocket", ssh_auth_socket, None),
+            Arg("httpAuthUsername", http_auth_username, ""),
+            Arg("httpAuthToken", http_auth_token, None),
+            Arg("httpAuthHeader", http_auth_header, None),
+        ]
+        _ctx = self._select("push", _args)
+        return await _ctx.execute(str)
+
+    async def ref(self) -> str:
+        """The resolved ref name at this ref.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("ref", _args)
+        return await _ctx.execute(str)
+
+    async def signer(self) -> str:
+        """The identity of the signer of this ref, as verified by "verify": the
+        principal of an SSH key, or the user ID of a GPG key.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("signer", _args)
         return await _ctx.execute(str)
 
     def tree(
@@ -8570,6 +10008,8 @@ class GitRef(Type):
         *,
         discard_git_dir: bool | None = False,
         depth: int | None = 1,
+        sparse: list[str] | None = None,
+        lfs: bool | None = False,
     ) -> Directory:
         """The filesystem tree at this ref.
 
@@ -8579,14 +10019,60 @@ class GitRef(Type):
             Set to true to discard .git directory.
         depth:
             The depth of the tree to fetch.
+        sparse:
+            Directories to check out, in git's sparse checkout cone mode
+            (e.g., ["services/api", "libs"]).
+            Files at the root of the repository and in the parents of these
+            directories are checked out too.
+            Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"])
+            are checked out in non-cone mode instead, where only the files
+            matching them, like in .gitignore files, are checked out.
+            Without .git directory, the tree only changes when the checked out
+            files change.
+        lfs:
+            Set to true to download the Git LFS objects of the checked out
+            files.
         """
         _args = [
             Arg("discardGitDir", discard_git_dir, False),
             Arg("depth", depth, 1),
+            Arg("sparse", sparse, None),
+            Arg("lfs", lfs, False),
         ]
         _ctx = self._select("tree", _args)
         return Directory(_ctx)
 
+    def verify(
+        self,
+        *,
+        allowed_signers: File | None = None,
+        keys: "list[Secret] | None" = None,
+    ) -> Self:
+        """Verify the signature of this ref, failing unless it's signed by one of
+        the trusted signers.
+
+        Annotated tags are verified with the signature of the tag, other refs
+        with the signature of their commit. Both SSH and GPG signatures are
+        supported.
+
+        Returns the verified ref, with its signer.
+
+        Parameters
+        ----------
+        allowed_signers:
+            An SSH allowed signers file, trusting SSH keys for their
+            principals (see ssh-keygen(1)).
+        keys:
+            Trusted public keys: armored GPG public keys, or SSH public keys
+            trusted for the principal in their comment.
+        """
+        _args = [
+            Arg("allowedSigners", allowed_signers, None),
+            Arg("keys", [] if keys is None else keys, []),
+        ]
+        _ctx = self._select("verify", _args)
+        return GitRef(_ctx)
+
     def with_(self, cb: Callable[["GitRef"], "GitRef"]) -> "GitRef":
         """Call the provided callable with current GitRef.
 
@@ -8784,6 +10270,45 @@ class GitRepository(Type):
         _ctx = self._select("url", _args)
         return await _ctx.execute(str | None)
 
+    def with_commit(
+        self,
+        changes: Changeset,
+        message: str,
+        author: str,
+    ) -> Self:
+        """Commit changes on top of HEAD, returning a repository whose HEAD is
+        the new commit.
+
+        The returned repository only has the new commit and its parent:
+        pushing it requires the remote to have the parent.
+
+        Parameters
+        ----------
+        changes:
+            The changes to commit, applied to the tree of HEAD.
+        message:
+            The commit message.
+        author:
+            The author and committer of the commit (e.g., "Dagger Bot
+            <bot@example.com>").
+        """
+        _args = [
+            Arg("changes", changes),
+            Arg("message", message),
+            Arg("author", author),
+        ]
+        _ctx = self._select("withCommit", _args)
+        return GitRepository(_ctx)
+
+    def with_(
+        self, cb: Callable[["GitRepository"], "GitRepository"]
+    ) -> "GitRepository":
+        """Call the provided callable with current GitRepository.
+
+        This is useful for reusability and readability by not breaking the calling chain.
+        """
+        return cb(self)
+
 
 @typecheck
 class Host(Type):
@@ -9424,6 +10949,16 @@ class LLM(Type):
         _ctx = self._select("bindResult", _args)
         return Binding(_ctx)
 
+    def cassette(self) -> File:
+        """Return the cassette recorded by the LLM, to replay with withCassette
+
+        Dagger secrets are scrubbed from it, as they are from the output of
+        commands. Other sensitive data in the conversation is kept as is.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("cassette", _args)
+        return File(_ctx)
+
     def env(self) -> Env:
         """return the LLM's current environment"""
         _args: list[Arg] = []
@@ -9599,6 +11134,26 @@ class LLM(Type):
         _args: list[Arg] = []
         return await self._ctx.execute_sync(self, "step", _args)
 
+    async def stream(self, *, after: int | None = 0) -> list["LLMStreamEvent"]:
+        """Synchronize LLM state in the background, and return the events emitted
+        while doing so as soon as there are any: text generated by the model,
+        tool calls, and token usage
+
+        To render progress as it happens, call it repeatedly with the number
+        of events received so far, until it returns no events.
+
+        Parameters
+        ----------
+        after:
+            The number of events to skip, i.e. the number of events received
+            from previous calls
+        """
+        _args = [
+            Arg("after", after, 0),
+        ]
+        _ctx = self._select("stream", _args)
+        return await _ctx.execute_object_list(LLMStreamEvent)
+
     async def sync(self) -> Self:
         """synchronize LLM state
 
@@ -9661,6 +11216,60 @@ class LLM(Type):
         _ctx = self._select("withBlockedFunction", _args)
         return LLM(_ctx)
 
+    def with_budget(
+        self,
+        *,
+        max_tokens: int | None = 0,
+        max_cost: float | None = 0.0,
+    ) -> Self:
+        """Limit the tokens and estimated cost of the LLM.
+
+        When a limit is exceeded, evaluating the LLM fails with an error of
+        type LLM_BUDGET_EXCEEDED.
+
+        Parameters
+        ----------
+        max_tokens:
+            The maximum number of tokens, input and output, summed over all
+            API calls. 0 means unlimited.
+        max_cost:
+            The maximum estimated cost in USD, based on the price of the
+            model. 0 means unlimited.
+            Evaluating the LLM fails if the price of the model is unknown.
+        """
+        _args = [
+            Arg("maxTokens", max_tokens, 0),
+            Arg("maxCost", max_cost, 0.0),
+        ]
+        _ctx = self._select("withBudget", _args)
+        return LLM(_ctx)
+
+    def with_cassette(
+        self,
+        *,
+        file: File | None = None,
+        mode: LLMCassetteMode | None = LLMCassetteMode.REPLAY,
+    ) -> Self:
+        """Record the requests sent to the model with their responses in a
+        cassette, or replay them from a cassette.
+
+        Replaying a cassette doesn't call the model, which makes LLM tests
+        hermetic. Requests that weren't recorded fail the evaluation.
+
+        Parameters
+        ----------
+        file:
+            The cassette to replay. Required in REPLAY mode.
+        mode:
+            Whether to record or replay the cassette
+        """
+        _args = [
+            Arg("file", file, None),
+            Arg("mode", mode, LLMCassetteMode.REPLAY),
+        ]
+        _ctx = self._select("withCassette", _args)
+        return LLM(_ctx)
+
     def with_env(self, env: Env) -> Self:
         """allow the LLM to interact with an environment via MCP"""
         _args = [
@@ -9776,6 +11385,186 @@ class LLM(Type):
         return cb(self)
 
 
+@typecheck
+class LLMStreamEvent(Type):
+    """An event emitted while an LLM is evaluated"""
+
+    async def id(self) -> LLMStreamEventID:
+        """A unique identifier for this LLMStreamEvent.
+
+        Note
+        ----
+        This is lazily evaluated, no operation is actually run.
+
+        Returns
+        -------
+        LLMStreamEventID
+            The `LLMStreamEventID` scalar type represents an identifier for an
+            object of type LLMStreamEvent.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("id", _args)
+        return await _ctx.execute(LLMStreamEventID)
+
+    async def kind(self) -> LLMStreamEventKind:
+        """The kind of event.
+
+        Returns
+        -------
+        LLMStreamEventKind
+            The kind of an LLM stream event
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("kind", _args)
+        return await _ctx.execute(LLMStreamEventKind)
+
+    async def text(self) -> str:
+        """The text generated by the model, for TEXT_DELTA events.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("text", _args)
+        return await _ctx.execute(str)
+
+    def token_usage(self) -> "LLMTokenUsage":
+        """The token usage of the model reply, for TOKEN_USAGE events."""
+        _args: list[Arg] = []
+        _ctx = self._select("tokenUsage", _args)
+        return LLMTokenUsage(_ctx)
+
+    async def tool_arguments(self) -> str:
+        """The JSON-encoded arguments of the tool call, for TOOL_CALL_START
+        events.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("toolArguments", _args)
+        return await _ctx.execute(str)
+
+    async def tool_call_id(self) -> str:
+        """The ID of the tool call, for TOOL_CALL_START and TOOL_CALL_FINISH
+        events.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("toolCallID", _args)
+        return await _ctx.execute(str)
+
+    async def tool_errored(self) -> bool:
+        """Whether the tool call failed, for TOOL_CALL_FINISH events.
+
+        Returns
+        -------
+        bool
+            The `Boolean` scalar type represents `true` or `false`.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("toolErrored", _args)
+        return await _ctx.execute(bool)
+
+    async def tool_name(self) -> str:
+        """The name of the tool, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("toolName", _args)
+        return await _ctx.execute(str)
+
+    async def tool_result(self) -> str:
+        """The result of the tool call, for TOOL_CALL_FINISH events.
+
+        Returns
+        -------
+        str
+            The `String` scalar type represents textual data, represented as
+            UTF-8 character sequences. The String type is most often used by
+            GraphQL to represent free-form human-readable text.
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("toolResult", _args)
+        return await _ctx.execute(str)
+
+
 @typecheck
 class LLMTokenUsage(Type):
     async def cached_token_reads(self) -> int:
@@ -9816,6 +11605,27 @@ class LLMTokenUsage(Type):
         _ctx = self._select("cachedTokenWrites", _args)
         return await _ctx.execute(int)
 
+    async def cost(self) -> float:
+        """The estimated cost in USD, if the price of the model is known.
+
+        Returns
+        -------
+        float
+            The `Float` scalar type represents signed double-precision
+            fractional values as specified by [IEEE
+            754](http://en.wikipedia.org/wiki/IEEE_floating_point).
+
+        Raises
+        ------
+        ExecuteTimeoutError
+            If the time to execute the query exceeds the configured timeout.
+        QueryError
+            If the API returns an error.
+        """
+        _args: list[Arg] = []
+        _ctx = self._select("cost", _args)
+        return await _ctx.execute(float)
+
     async def id(self) -> LLMTokenUsageID:
         """A unique identifier for this LLMTokenUsage.
 
@@ -11688,6 +13498,7 @@ class Client(Root):
         http_auth_token: "Secret | None" = None,
         http_auth_header: "Secret | None" = None,
         experimental_service_host: "Service | None" = None,
+        filter: str | None = "",
     ) -> GitRepository:
         """Queries a Git repository.
 
@@ -11715,6 +13526,11 @@ class Client(Root):
             Secret used to populate the Authorization HTTP header
         experimental_service_host:
             A service which must be started before the repo is fetched.
+        filter:
+            Partial clone filter to fetch the repository with, like
+            "blob:none" or "blob:limit=1m".
+            The file contents needed by a checkout are fetched when checking
+            it out.
         """
         _args = [
             Arg("url", url),
@@ -11725,6 +13541,7 @@ class Client(Root):
             Arg("httpAuthToken", http_auth_token, None),
             Arg("httpAuthHeader", http_auth_header, None),
             Arg("experimentalServiceHost", experimental_service_host, None),
+            Arg("filter", filter, ""),
         ]
         _ctx = self._select("git", _args)
         return GitRepository(_ctx)
@@ -11857,6 +13674,16 @@ class Client(Root):
         _ctx = self._select("loadCloudFromID", _args)
         return Cloud(_ctx)
 
+    def load_container_attestation_from_id(
+        self, id: ContainerAttestationID
+    ) -> ContainerAttestation:
+        """Load a ContainerAttestation from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadContainerAttestationFromID", _args)
+        return ContainerAttestation(_ctx)
+
     def load_container_from_id(self, id: ContainerID) -> Container:
         """Load a Container from its ID."""
         _args = [
@@ -12049,6 +13876,22 @@ class Client(Root):
         _ctx = self._select("loadGeneratorGroupFromID", _args)
         return GeneratorGroup(_ctx)
 
+    def load_git_blame_line_from_id(self, id: GitBlameLineID) -> GitBlameLine:
+        """Load a GitBlameLine from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadGitBlameLineFromID", _args)
+        return GitBlameLine(_ctx)
+
+    def load_git_commit_from_id(self, id: GitCommitID) -> GitCommit:
+        """Load a GitCommit from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadGitCommitFromID", _args)
+        return GitCommit(_ctx)
+
     def load_git_ref_from_id(self, id: GitRefID) -> GitRef:
         """Load a GitRef from its ID."""
         _args = [
@@ -12107,6 +13950,14 @@ class Client(Root):
         _ctx = self._select("loadLLMFromID", _args)
         return LLM(_ctx)
 
+    def load_llm_stream_event_from_id(self, id: LLMStreamEventID) -> LLMStreamEvent:
+        """Load a LLMStreamEvent from its ID."""
+        _args = [
+            Arg("id", id),
+        ]
+        _ctx = self._select("loadLLMStreamEventFromID", _args)
+        return LLMStreamEvent(_ctx)
+
     def load_llm_token_usage_from_id(self, id: LLMTokenUsageID) -> LLMTokenUsage:
         """Load a LLMTokenUsage from its ID."""
         _args = [
@@ -12970,11 +14821,21 @@ class Service(Type):
         _ctx = self._select("ports", _args)
         return await _ctx.execute_object_list(Port)
 
-    async def start(self) -> Self:
+    async def start(self, *, timeout: str | None = "") -> Self:
         """Start the service and wait for its health checks to succeed.
 
         Services bound to a Container do not need to be manually started.
 
+        Parameters
+        ----------
+        timeout:
+            Maximum duration the service may run once started, as a Go
+            duration string (e.g. "10m").
+            When it elapses, the service is sent SIGTERM, followed by SIGKILL
+            after a grace period.
+            A service that was already running, e.g. for a container it is
+            bound to, is not stopped.
+
         Raises
         ------
         ExecuteTimeoutError
@@ -12982,7 +14843,9 @@ class Service(Type):
         QueryError
             If the API returns an error.
         """
-        _args: list[Arg] = []
+        _args = [
+            Arg("timeout", timeout, ""),
+        ]
         return await self._ctx.execute_sync(self, "start", _args)
 
     async def stop(self, *, kill: bool | None = False) -> Self:
@@ -13033,6 +14896,7 @@ class Service(Type):
         *,
         ports: list[PortForward] | None = None,
         random: bool | None = False,
+        timeout: str | None = "",
     ) -> Void | None:
         """Creates a tunnel that forwards traffic from the caller's network to
         this service.
@@ -13045,6 +14909,11 @@ class Service(Type):
             service port.
         random:
             Bind each tunnel port to a random port on the host.
+        timeout:
+            Maximum duration to keep the tunnel and service up, as a Go
+            duration string (e.g. "10m").
+            When it elapses, the service is sent SIGTERM, followed by SIGKILL
+            after a grace period, and an error is returned.
 
         Returns
         -------
@@ -13062,6 +14931,7 @@ class Service(Type):
         _args = [
             Arg("ports", [] if ports is None else ports, []),
             Arg("random", random, False),
+            Arg("timeout", timeout, ""),
         ]
         _ctx = self._select("up", _args)
         await _ctx.execute()
@@ -13794,10 +15664,13 @@ __all__ = [
     "CheckGroup",
     "CheckGroupID",
     "CheckID",
+    "CheckReportFormat",
     "Client",
     "Cloud",
     "CloudID",
     "Container",
+    "ContainerAttestation",
+    "ContainerAttestationID",
     "ContainerID",
     "CurrentModule",
     "CurrentModuleID",
@@ -13846,6 +15719,10 @@ __all__ = [
     "GeneratorGroup",
     "GeneratorGroupID",
     "GeneratorID",
+    "GitBlameLine",
+    "GitBlameLineID",
+    "GitCommit",
+    "GitCommitID",
     "GitRef",
     "GitRefID",
     "GitRepository",
@@ -13860,6 +15737,10 @@ __all__ = [
     "InterfaceTypeDefID",
     "JSONValue",
     "JSONValueID",
+    "LLMCassetteMode",
+    "LLMStreamEvent",
+    "LLMStreamEventID",
+    "LLMStreamEventKind",
     "LLMTokenUsage",
     "LLMTokenUsageID",
     "Label",
@@ -13874,6 +15755,7 @@ __all__ = [
     "ModuleSourceExperimentalFeature",
     "ModuleSourceID",
     "ModuleSourceKind",
+    "NetworkMode",
     "NetworkProtocol",
     "ObjectTypeDef",
     "ObjectTypeDefID",
@@ -13883,6 +15765,7 @@ __all__ = [
     "PortForward",
     "PortID",
     "ReturnType",
+    "SBOMFormat",
     "SDKConfig",
     "SDKConfigID",
     "ScalarTypeDef",
@@ -247,6 +247,41 @@ impl CloudId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct ContainerAttestationId(pub String);
+impl From<&str> for ContainerAttestationId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for ContainerAttestationId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<ContainerAttestationId> for ContainerAttestation {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerAttestationId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<ContainerAttestationId> for ContainerAttestationId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<ContainerAttestationId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<ContainerAttestationId, DaggerError>(self) })
+    }
+}
+impl ContainerAttestationId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct ContainerId(pub String);
 impl From<&str> for ContainerId {
     fn from(value: &str) -> Self {
@@ -1040,6 +1075,76 @@ impl GeneratorId {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct GitBlameLineId(pub String);
+impl From<&str> for GitBlameLineId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for GitBlameLineId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<GitBlameLineId> for GitBlameLine {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<GitBlameLineId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<GitBlameLineId> for GitBlameLineId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<GitBlameLineId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<GitBlameLineId, DaggerError>(self) })
+    }
+}
+impl GitBlameLineId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct GitCommitId(pub String);
+impl From<&str> for GitCommitId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for GitCommitId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<GitCommitId> for GitCommit {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<GitCommitId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<GitCommitId> for GitCommitId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<GitCommitId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<GitCommitId, DaggerError>(self) })
+    }
+}
+impl GitCommitId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct GitRefId(pub String);
 impl From<&str> for GitRefId {
     fn from(value: &str) -> Self {
@@ -1296,6 +1401,41 @@ impl Llmid {
     }
 }
 #[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
+pub struct LlmStreamEventId(pub String);
+impl From<&str> for LlmStreamEventId {
+    fn from(value: &str) -> Self {
+        Self(value.to_string())
+    }
+}
+impl From<String> for LlmStreamEventId {
+    fn from(value: String) -> Self {
+        Self(value)
+    }
+}
+impl IntoID<LlmStreamEventId> for LlmStreamEvent {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<LlmStreamEventId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { self.id().await })
+    }
+}
+impl IntoID<LlmStreamEventId> for LlmStreamEventId {
+    fn into_id(
+        self,
+    ) -> std::pin::Pin<
+        Box<dyn core::future::Future<Output = Result<LlmStreamEventId, DaggerError>> + Send>,
+    > {
+        Box::pin(async move { Ok::<LlmStreamEventId, DaggerError>(self) })
+    }
+}
+impl LlmStreamEventId {
+    fn quote(&self) -> String {
+        format!("\"{}\"", self.0.clone())
+    }
+}
+#[derive(Serialize, Deserialize, PartialEq, Debug, Clone)]
 pub struct LlmTokenUsageId(pub String);
 impl From<&str> for LlmTokenUsageId {
     fn from(value: &str) -> Self {
@@ -2232,6 +2372,15 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type ContainerAttestation
+    pub fn as_container_attestation(&self) -> ContainerAttestation {
+        let query = self.selection.select("asContainerAttestation");
+        ContainerAttestation {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type Directory
     pub fn as_directory(&self) -> Directory {
         let query = self.selection.select("asDirectory");
@@ -2286,6 +2435,24 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type GitBlameLine
+    pub fn as_git_blame_line(&self) -> GitBlameLine {
+        let query = self.selection.select("asGitBlameLine");
+        GitBlameLine {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Retrieve the binding value, as type GitCommit
+    pub fn as_git_commit(&self) -> GitCommit {
+        let query = self.selection.select("asGitCommit");
+        GitCommit {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type GitRef
     pub fn as_git_ref(&self) -> GitRef {
         let query = self.selection.select("asGitRef");
@@ -2313,6 +2480,15 @@ impl Binding {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieve the binding value, as type LLMStreamEvent
+    pub fn as_llm_stream_event(&self) -> LlmStreamEvent {
+        let query = self.selection.select("asLLMStreamEvent");
+        LlmStreamEvent {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieve the binding value, as type Module
     pub fn as_module(&self) -> Module {
         let query = self.selection.select("asModule");
@@ -2632,7 +2808,18 @@ pub struct Check {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct CheckRunOpts {
+    /// Number of times the check is retried if it fails
+    #[builder(setter(into, strip_option), default)]
+    pub retries: Option<isize>,
+}
 impl Check {
+    /// The number of times the check was run, including retries
+    pub async fn attempts(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("attempts");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Whether the check completed
     pub async fn completed(&self) -> Result<bool, DaggerError> {
         let query = self.selection.select("completed");
@@ -2643,11 +2830,26 @@ impl Check {
         let query = self.selection.select("description");
         query.execute(self.graphql_client.clone()).await
     }
+    /// How long the check took to run, in milliseconds
+    pub async fn duration_ms(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("durationMs");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The error the check failed with, if any
+    pub async fn error_message(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("errorMessage");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// A unique identifier for this Check.
     pub async fn id(&self) -> Result<CheckId, DaggerError> {
         let query = self.selection.select("id");
         query.execute(self.graphql_client.clone()).await
     }
+    /// The last lines of output of the failed command, if the check failed running one
+    pub async fn log_excerpt(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("logExcerpt");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Return the fully qualified name of the check
     pub async fn name(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("name");
@@ -2672,12 +2874,21 @@ impl Check {
         let query = self.selection.select("path");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Whether the check is quarantined: its failures are reported, but don't fail the group
+    pub async fn quarantined(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("quarantined");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// An emoji representing the result of the check
     pub async fn result_emoji(&self) -> Result<String, DaggerError> {
         let query = self.selection.select("resultEmoji");
         query.execute(self.graphql_client.clone()).await
     }
     /// Execute the check
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub fn run(&self) -> Check {
         let query = self.selection.select("run");
         Check {
@@ -2686,6 +2897,42 @@ impl Check {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Execute the check
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn run_opts(&self, opts: CheckRunOpts) -> Check {
+        let mut query = self.selection.select("run");
+        if let Some(retries) = opts.retries {
+            query = query.arg("retries", retries);
+        }
+        Check {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Why the check was selected or skipped by affectedBy
+    pub async fn selection_reason(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("selectionReason");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy
+    pub async fn skipped(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("skipped");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the span of the check
+    pub async fn span_id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("spanID");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the trace the check ran in
+    pub async fn trace_id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("traceID");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct CheckGroup {
@@ -2693,7 +2940,64 @@ pub struct CheckGroup {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct CheckGroupReportOpts {
+    /// The format of the report
+    #[builder(setter(into, strip_option), default)]
+    pub format: Option<CheckReportFormat>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct CheckGroupRunOpts {
+    /// Cancel the remaining checks as soon as one fails. Quarantined checks don't trigger cancellation.
+    #[builder(setter(into, strip_option), default)]
+    pub fail_fast: Option<bool>,
+    /// Record failed checks in the results instead of returning an error, e.g. to generate a report of the failures
+    #[builder(setter(into, strip_option), default)]
+    pub no_fail: Option<bool>,
+    /// Number of times a failed check is retried before it is reported as failed
+    #[builder(setter(into, strip_option), default)]
+    pub retries: Option<isize>,
+}
 impl CheckGroup {
+    /// Skip the checks whose inputs are not affected by the given changes
+    /// A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.
+    ///
+    /// # Arguments
+    ///
+    /// * `changes` - The changes to select checks for, relative to the context directory
+    pub fn affected_by(&self, changes: impl IntoID<ChangesetId>) -> CheckGroup {
+        let mut query = self.selection.select("affectedBy");
+        query = query.arg_lazy(
+            "changes",
+            Box::new(move || {
+                let changes = changes.clone();
+                Box::pin(async move { changes.into_id().await.unwrap().quote() })
+            }),
+        );
+        CheckGroup {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Skip the checks whose inputs are not affected by the given changed paths
+    /// Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+    ///
+    /// # Arguments
+    ///
+    /// * `paths` - The added, modified or removed paths, relative to the context directory
+    pub fn affected_by_paths(&self, paths: Vec<impl Into<String>>) -> CheckGroup {
+        let mut query = self.selection.select("affectedByPaths");
+        query = query.arg(
+            "paths",
+            paths.into_iter().map(|i| i.into()).collect::<Vec<String>>(),
+        );
+        CheckGroup {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// A unique identifier for this CheckGroup.
     pub async fn id(&self) -> Result<CheckGroupId, DaggerError> {
         let query = self.selection.select("id");
@@ -2708,7 +3012,11 @@ impl CheckGroup {
             graphql_client: self.graphql_client.clone(),
         }]
     }
-    /// Generate a markdown report
+    /// Generate a report of the check results
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub fn report(&self) -> File {
         let query = self.selection.select("report");
         File {
@@ -2717,7 +3025,27 @@ impl CheckGroup {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Generate a report of the check results
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn report_opts(&self, opts: CheckGroupReportOpts) -> File {
+        let mut query = self.selection.select("report");
+        if let Some(format) = opts.format {
+            query = query.arg("format", format);
+        }
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Execute all selected checks
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub fn run(&self) -> CheckGroup {
         let query = self.selection.select("run");
         CheckGroup {
@@ -2726,6 +3054,28 @@ impl CheckGroup {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Execute all selected checks
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn run_opts(&self, opts: CheckGroupRunOpts) -> CheckGroup {
+        let mut query = self.selection.select("run");
+        if let Some(fail_fast) = opts.fail_fast {
+            query = query.arg("failFast", fail_fast);
+        }
+        if let Some(retries) = opts.retries {
+            query = query.arg("retries", retries);
+        }
+        if let Some(no_fail) = opts.no_fail {
+            query = query.arg("noFail", no_fail);
+        }
+        CheckGroup {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
 }
 #[derive(Clone)]
 pub struct Cloud {
@@ -2790,6 +3140,15 @@ pub struct ContainerAsTarballOpts {
     pub platform_variants: Option<Vec<ContainerId>>,
 }
 #[derive(Builder, Debug, PartialEq)]
+pub struct ContainerAttestationsOpts {
+    /// Include SLSA provenance of the calls that produced the container.
+    #[builder(setter(into, strip_option), default)]
+    pub provenance: Option<bool>,
+    /// Include a software bill of materials of the packages installed in the container, in the given format.
+    #[builder(setter(into, strip_option), default)]
+    pub sbom: Option<SbomFormat>,
+}
+#[derive(Builder, Debug, PartialEq)]
 pub struct ContainerDirectoryOpts {
     /// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
     #[builder(setter(into, strip_option), default)]
@@ -2821,6 +3180,12 @@ pub struct ContainerExportOpts {
     /// Used for multi-platform image.
     #[builder(setter(into, strip_option), default)]
     pub platform_variants: Option<Vec<ContainerId>>,
+    /// Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+    #[builder(setter(into, strip_option), default)]
+    pub provenance: Option<bool>,
+    /// Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+    #[builder(setter(into, strip_option), default)]
+    pub sbom: Option<SbomFormat>,
 }
 #[derive(Builder, Debug, PartialEq)]
 pub struct ContainerExportImageOpts {
@@ -2844,6 +3209,13 @@ pub struct ContainerFileOpts {
     pub expand: Option<bool>,
 }
 #[derive(Builder, Debug, PartialEq)]
+pub struct ContainerFromOpts<'a> {
+    /// PEM encoded public key to verify the image signature with.
+    /// If set, the image is rejected unless it has a cosign compatible signature verified by this key, attached as an OCI referrer or with the cosign tag scheme.
+    #[builder(setter(into, strip_option), default)]
+    pub verify: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
 pub struct ContainerImportOpts<'a> {
     /// Identifies the tag to import from the archive, if the archive bundles multiple tags.
     #[builder(setter(into, strip_option), default)]
@@ -2863,6 +3235,19 @@ pub struct ContainerPublishOpts {
     /// Used for multi-platform image.
     #[builder(setter(into, strip_option), default)]
     pub platform_variants: Option<Vec<ContainerId>>,
+    /// Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+    #[builder(setter(into, strip_option), default)]
+    pub provenance: Option<bool>,
+    /// Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+    #[builder(setter(into, strip_option), default)]
+    pub sbom: Option<SbomFormat>,
+    /// PEM encoded private key to sign the published image with.
+    /// The signature is cosign compatible, and attached to the image both as an OCI referrer and with the cosign tag scheme ("sha256-<hex>.sig"), for cosign to find it with or without --experimental-oci11. Encrypted cosign keys are decrypted with signPassword.
+    #[builder(setter(into, strip_option), default)]
+    pub sign: Option<SecretId>,
+    /// Password of the private key to sign the published image with.
+    #[builder(setter(into, strip_option), default)]
+    pub sign_password: Option<SecretId>,
 }
 #[derive(Builder, Debug, PartialEq)]
 pub struct ContainerStatOpts {
@@ -2908,6 +3293,10 @@ pub struct ContainerUpOpts<'a> {
     /// Bind each tunnel port to a random port on the host.
     #[builder(setter(into, strip_option), default)]
     pub random: Option<bool>,
+    /// Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+    /// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+    #[builder(setter(into, strip_option), default)]
+    pub timeout: Option<&'a str>,
     /// If the container has an entrypoint, prepend it to the args.
     #[builder(setter(into, strip_option), default)]
     pub use_entrypoint: Option<bool>,
@@ -2968,6 +3357,15 @@ pub struct ContainerWithExecOpts<'a> {
     /// DANGER: this grants the command full access to the host system. Only use when 1) you trust the command being executed and 2) you specifically need this level of access.
     #[builder(setter(into, strip_option), default)]
     pub insecure_root_capabilities: Option<bool>,
+    /// Hostnames, IPv4 addresses and CIDRs the command may connect to with the ALLOW_LIST network mode. Example: ["proxy.golang.org", "10.0.0.0/8"]
+    /// Hostnames are resolved once, when the command starts: connections to the addresses they resolve to afterwards (e.g. when a DNS record changes or rotates) are rejected.
+    #[builder(setter(into, strip_option), default)]
+    pub network_allow: Option<Vec<&'a str>>,
+    /// Network access granted to the command.
+    /// OFFLINE only allows the loopback interface. SERVICES_ONLY allows bound services and DNS. ALLOW_LIST additionally allows the destinations in "networkAllow". Other connections are rejected.
+    /// DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST the command can still send data out through the names it looks up. Use OFFLINE if this must be prevented.
+    #[builder(setter(into, strip_option), default)]
+    pub network_mode: Option<NetworkMode>,
     /// Skip the automatic init process injected into containers by default.
     /// Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
     #[builder(setter(into, strip_option), default)]
@@ -2984,6 +3382,11 @@ pub struct ContainerWithExecOpts<'a> {
     /// Content to write to the command's standard input. Example: "Hello world")
     #[builder(setter(into, strip_option), default)]
     pub stdin: Option<&'a str>,
+    /// Maximum duration the command may run, as a Go duration string (e.g. "30s", "5m").
+    /// When it elapses, the command is sent SIGTERM, followed by SIGKILL after a grace period, and the exec fails with a timed out ExecError, which is never cached.
+    /// A timed out exec fails regardless of "expect", even if the command handles SIGTERM and exits with an expected code. To expect a timeout, check whether the ExecError timed out.
+    #[builder(setter(into, strip_option), default)]
+    pub timeout: Option<&'a str>,
     /// Apply the OCI entrypoint, if present, by prepending it to the args. Ignored by default.
     #[builder(setter(into, strip_option), default)]
     pub use_entrypoint: Option<bool>,
@@ -3029,14 +3432,44 @@ pub struct ContainerWithFilesOpts<'a> {
     pub permissions: Option<isize>,
 }
 #[derive(Builder, Debug, PartialEq)]
-pub struct ContainerWithMountedCacheOpts<'a> {
-    /// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
+pub struct ContainerWithHealthcheckOpts<'a> {
+    /// Command to run in the service container, ready once it exits zero. Example: ["pg_isready", "-U", "postgres"]
     #[builder(setter(into, strip_option), default)]
-    pub expand: Option<bool>,
-    /// A user:group to set for the mounted cache directory.
-    /// Note that this changes the ownership of the specified mount along with the initial filesystem provided by source (if any). It does not have any effect if/when the cache has already been created.
-    /// The user and group can either be an ID (1000:1000) or a name (foo:bar).
-    /// If the group is omitted, it defaults to the same as the user.
+    pub args: Option<Vec<&'a str>>,
+    /// Path of the HTTP GET request. Example: "/healthz"
+    #[builder(setter(into, strip_option), default)]
+    pub http_path: Option<&'a str>,
+    /// Port to send an HTTP GET request to, ready once it responds with httpStatus. Example: 8080
+    #[builder(setter(into, strip_option), default)]
+    pub http_port: Option<isize>,
+    /// Expected status of the HTTP response.
+    #[builder(setter(into, strip_option), default)]
+    pub http_status: Option<isize>,
+    /// Time between checks, as a Go duration string. Example: "5s"
+    #[builder(setter(into, strip_option), default)]
+    pub interval: Option<&'a str>,
+    /// Regular expression matched against the lines of the service stdout, ready once a line matches. Example: "listening on .*:8080"
+    #[builder(setter(into, strip_option), default)]
+    pub log_pattern: Option<&'a str>,
+    /// Number of consecutive failed checks after which the service fails to start.
+    #[builder(setter(into, strip_option), default)]
+    pub retries: Option<isize>,
+    /// Initialization time during which failed checks are not counted, as a Go duration string.
+    #[builder(setter(into, strip_option), default)]
+    pub start_period: Option<&'a str>,
+    /// Time after which a single check fails, as a Go duration string.
+    #[builder(setter(into, strip_option), default)]
+    pub timeout: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct ContainerWithMountedCacheOpts<'a> {
+    /// Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
+    #[builder(setter(into, strip_option), default)]
+    pub expand: Option<bool>,
+    /// A user:group to set for the mounted cache directory.
+    /// Note that this changes the ownership of the specified mount along with the initial filesystem provided by source (if any). It does not have any effect if/when the cache has already been created.
+    /// The user and group can either be an ID (1000:1000) or a name (foo:bar).
+    /// If the group is omitted, it defaults to the same as the user.
     #[builder(setter(into, strip_option), default)]
     pub owner: Option<&'a str>,
     /// Sharing mode of the cache volume.
@@ -3256,6 +3689,38 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// The in-toto attestations that would be attached to the container image when published or exported.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn attestations(&self) -> Vec<ContainerAttestation> {
+        let query = self.selection.select("attestations");
+        vec![ContainerAttestation {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// The in-toto attestations that would be attached to the container image when published or exported.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn attestations_opts(&self, opts: ContainerAttestationsOpts) -> Vec<ContainerAttestation> {
+        let mut query = self.selection.select("attestations");
+        if let Some(sbom) = opts.sbom {
+            query = query.arg("sbom", sbom);
+        }
+        if let Some(provenance) = opts.provenance {
+            query = query.arg("provenance", provenance);
+        }
+        vec![ContainerAttestation {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
     /// The combined buffered standard output and standard error stream of the last executed command
     /// Returns an error if no command was executed
     pub async fn combined_output(&self) -> Result<String, DaggerError> {
@@ -3443,6 +3908,12 @@ impl Container {
         if let Some(expand) = opts.expand {
             query = query.arg("expand", expand);
         }
+        if let Some(sbom) = opts.sbom {
+            query = query.arg("sbom", sbom);
+        }
+        if let Some(provenance) = opts.provenance {
+            query = query.arg("provenance", provenance);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Exports the container as an image to the host's container image store.
@@ -3530,6 +4001,7 @@ impl Container {
     /// # Arguments
     ///
     /// * `address` - Address of the container image to download, in standard OCI ref format. Example:"registry.dagger.io/engine:latest"
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub fn from(&self, address: impl Into<String>) -> Container {
         let mut query = self.selection.select("from");
         query = query.arg("address", address.into());
@@ -3539,6 +4011,28 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Download a container image, and apply it to the container state. All previous state will be lost.
+    ///
+    /// # Arguments
+    ///
+    /// * `address` - Address of the container image to download, in standard OCI ref format. Example:"registry.dagger.io/engine:latest"
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn from_opts<'a>(
+        &self,
+        address: impl Into<String>,
+        opts: ContainerFromOpts<'a>,
+    ) -> Container {
+        let mut query = self.selection.select("from");
+        query = query.arg("address", address.into());
+        if let Some(verify) = opts.verify {
+            query = query.arg("verify", verify);
+        }
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// A unique identifier for this Container.
     pub async fn id(&self) -> Result<ContainerId, DaggerError> {
         let query = self.selection.select("id");
@@ -3666,6 +4160,18 @@ impl Container {
         if let Some(media_types) = opts.media_types {
             query = query.arg("mediaTypes", media_types);
         }
+        if let Some(sign) = opts.sign {
+            query = query.arg("sign", sign);
+        }
+        if let Some(sign_password) = opts.sign_password {
+            query = query.arg("signPassword", sign_password);
+        }
+        if let Some(sbom) = opts.sbom {
+            query = query.arg("sbom", sbom);
+        }
+        if let Some(provenance) = opts.provenance {
+            query = query.arg("provenance", provenance);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Return a snapshot of the container's root filesystem. The snapshot can be modified then written back using withRootfs. Use that method for filesystem modifications.
@@ -3790,6 +4296,9 @@ impl Container {
         if let Some(ports) = opts.ports {
             query = query.arg("ports", ports);
         }
+        if let Some(timeout) = opts.timeout {
+            query = query.arg("timeout", timeout);
+        }
         if let Some(args) = opts.args {
             query = query.arg("args", args);
         }
@@ -3834,6 +4343,20 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Limits the CPU time available to commands run in this container.
+    ///
+    /// # Arguments
+    ///
+    /// * `cpus` - Number of CPUs the commands may use, e.g. 1.5. Set to 0 to remove the limit.
+    pub fn with_cpu_limit(&self, cpus: f64) -> Container {
+        let mut query = self.selection.select("withCPULimit");
+        query = query.arg("cpus", cpus);
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Configures default arguments for future commands. Like CMD in Dockerfile.
     ///
     /// # Arguments
@@ -4168,6 +4691,15 @@ impl Container {
         if let Some(no_init) = opts.no_init {
             query = query.arg("noInit", no_init);
         }
+        if let Some(timeout) = opts.timeout {
+            query = query.arg("timeout", timeout);
+        }
+        if let Some(network_mode) = opts.network_mode {
+            query = query.arg("networkMode", network_mode);
+        }
+        if let Some(network_allow) = opts.network_allow {
+            query = query.arg("networkAllow", network_allow);
+        }
         Container {
             proc: self.proc.clone(),
             selection: query,
@@ -4331,6 +4863,88 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+    /// The service is not considered started until the check passes, after the health checks of its exposed ports.
+    /// Exactly one of args, httpPort and logPattern must be set.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_healthcheck(&self) -> Container {
+        let query = self.selection.select("withHealthcheck");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+    /// The service is not considered started until the check passes, after the health checks of its exposed ports.
+    /// Exactly one of args, httpPort and logPattern must be set.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_healthcheck_opts<'a>(&self, opts: ContainerWithHealthcheckOpts<'a>) -> Container {
+        let mut query = self.selection.select("withHealthcheck");
+        if let Some(args) = opts.args {
+            query = query.arg("args", args);
+        }
+        if let Some(http_port) = opts.http_port {
+            query = query.arg("httpPort", http_port);
+        }
+        if let Some(http_path) = opts.http_path {
+            query = query.arg("httpPath", http_path);
+        }
+        if let Some(http_status) = opts.http_status {
+            query = query.arg("httpStatus", http_status);
+        }
+        if let Some(log_pattern) = opts.log_pattern {
+            query = query.arg("logPattern", log_pattern);
+        }
+        if let Some(interval) = opts.interval {
+            query = query.arg("interval", interval);
+        }
+        if let Some(timeout) = opts.timeout {
+            query = query.arg("timeout", timeout);
+        }
+        if let Some(start_period) = opts.start_period {
+            query = query.arg("startPeriod", start_period);
+        }
+        if let Some(retries) = opts.retries {
+            query = query.arg("retries", retries);
+        }
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Sets the relative block IO weight of commands run in this container.
+    ///
+    /// # Arguments
+    ///
+    /// * `weight` - Weight between 10 and 1000, relative to other containers. Set to 0 to use the default.
+    pub fn with_io_weight(&self, weight: isize) -> Container {
+        let mut query = self.selection.select("withIOWeight");
+        query = query.arg("weight", weight);
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Use the HEALTHCHECK of the container image as its readiness check when started as a service.
+    /// Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.
+    /// Fails if the image has no healthcheck.
+    pub fn with_image_healthcheck(&self) -> Container {
+        let query = self.selection.select("withImageHealthcheck");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieves this container plus the given label.
     ///
     /// # Arguments
@@ -4347,6 +4961,21 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Limits the memory available to commands run in this container.
+    /// A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.
+    ///
+    /// # Arguments
+    ///
+    /// * `limit` - Maximum memory usage, including swap, as a number of bytes or with a unit suffix (e.g. "512MiB", "4GiB"). Units are powers of 1024. Set to "0" to remove the limit.
+    pub fn with_memory_limit(&self, limit: impl Into<String>) -> Container {
+        let mut query = self.selection.select("withMemoryLimit");
+        query = query.arg("limit", limit.into());
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieves this container plus a cache volume mounted at the given path.
     ///
     /// # Arguments
@@ -4688,6 +5317,20 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Limits the number of processes that commands run in this container may create.
+    ///
+    /// # Arguments
+    ///
+    /// * `limit` - Maximum number of processes. Set to 0 to remove the limit.
+    pub fn with_pids_limit(&self, limit: isize) -> Container {
+        let mut query = self.selection.select("withPidsLimit");
+        query = query.arg("limit", limit);
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Attach credentials for future publishing to a registry. Use in combination with publish
     ///
     /// # Arguments
@@ -5170,6 +5813,15 @@ impl Container {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Retrieves this container without its readiness check.
+    pub fn without_healthcheck(&self) -> Container {
+        let query = self.selection.select("withoutHealthcheck");
+        Container {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Retrieves this container minus the given environment label.
     ///
     /// # Arguments
@@ -5315,6 +5967,29 @@ impl Container {
     }
 }
 #[derive(Clone)]
+pub struct ContainerAttestation {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl ContainerAttestation {
+    /// A unique identifier for this ContainerAttestation.
+    pub async fn id(&self) -> Result<ContainerAttestationId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The predicate of the attestation, as a JSON document.
+    pub async fn predicate(&self) -> Result<Json, DaggerError> {
+        let query = self.selection.select("predicate");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The in-toto predicate type of the attestation.
+    pub async fn predicate_type(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("predicateType");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
 pub struct CurrentModule {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
@@ -7261,6 +7936,55 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Create or update a binding of type ContainerAttestation in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The ContainerAttestation value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_container_attestation_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<ContainerAttestationId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerAttestationInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired ContainerAttestation output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_container_attestation_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withContainerAttestationOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Create or update a binding of type Container in the environment
     ///
     /// # Arguments
@@ -7606,20 +8330,20 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Create or update a binding of type GitRef in the environment
+    /// Create or update a binding of type GitBlameLine in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
-    /// * `value` - The GitRef value to assign to the binding
+    /// * `value` - The GitBlameLine value to assign to the binding
     /// * `description` - The purpose of the input
-    pub fn with_git_ref_input(
+    pub fn with_git_blame_line_input(
         &self,
         name: impl Into<String>,
-        value: impl IntoID<GitRefId>,
+        value: impl IntoID<GitBlameLineId>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withGitRefInput");
+        let mut query = self.selection.select("withGitBlameLineInput");
         query = query.arg("name", name.into());
         query = query.arg_lazy(
             "value",
@@ -7635,18 +8359,18 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Declare a desired GitRef output to be assigned in the environment
+    /// Declare a desired GitBlameLine output to be assigned in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
     /// * `description` - A description of the desired value of the binding
-    pub fn with_git_ref_output(
+    pub fn with_git_blame_line_output(
         &self,
         name: impl Into<String>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withGitRefOutput");
+        let mut query = self.selection.select("withGitBlameLineOutput");
         query = query.arg("name", name.into());
         query = query.arg("description", description.into());
         Env {
@@ -7655,20 +8379,20 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Create or update a binding of type GitRepository in the environment
+    /// Create or update a binding of type GitCommit in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
-    /// * `value` - The GitRepository value to assign to the binding
+    /// * `value` - The GitCommit value to assign to the binding
     /// * `description` - The purpose of the input
-    pub fn with_git_repository_input(
+    pub fn with_git_commit_input(
         &self,
         name: impl Into<String>,
-        value: impl IntoID<GitRepositoryId>,
+        value: impl IntoID<GitCommitId>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withGitRepositoryInput");
+        let mut query = self.selection.select("withGitCommitInput");
         query = query.arg("name", name.into());
         query = query.arg_lazy(
             "value",
@@ -7684,18 +8408,18 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Declare a desired GitRepository output to be assigned in the environment
+    /// Declare a desired GitCommit output to be assigned in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
     /// * `description` - A description of the desired value of the binding
-    pub fn with_git_repository_output(
+    pub fn with_git_commit_output(
         &self,
         name: impl Into<String>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withGitRepositoryOutput");
+        let mut query = self.selection.select("withGitCommitOutput");
         query = query.arg("name", name.into());
         query = query.arg("description", description.into());
         Env {
@@ -7704,20 +8428,20 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Create or update a binding of type JSONValue in the environment
+    /// Create or update a binding of type GitRef in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
-    /// * `value` - The JSONValue value to assign to the binding
+    /// * `value` - The GitRef value to assign to the binding
     /// * `description` - The purpose of the input
-    pub fn with_json_value_input(
+    pub fn with_git_ref_input(
         &self,
         name: impl Into<String>,
-        value: impl IntoID<JsonValueId>,
+        value: impl IntoID<GitRefId>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withJSONValueInput");
+        let mut query = self.selection.select("withGitRefInput");
         query = query.arg("name", name.into());
         query = query.arg_lazy(
             "value",
@@ -7733,18 +8457,18 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Declare a desired JSONValue output to be assigned in the environment
+    /// Declare a desired GitRef output to be assigned in the environment
     ///
     /// # Arguments
     ///
     /// * `name` - The name of the binding
     /// * `description` - A description of the desired value of the binding
-    pub fn with_json_value_output(
+    pub fn with_git_ref_output(
         &self,
         name: impl Into<String>,
         description: impl Into<String>,
     ) -> Env {
-        let mut query = self.selection.select("withJSONValueOutput");
+        let mut query = self.selection.select("withGitRefOutput");
         query = query.arg("name", name.into());
         query = query.arg("description", description.into());
         Env {
@@ -7753,16 +8477,163 @@ impl Env {
             graphql_client: self.graphql_client.clone(),
         }
     }
-    /// Sets the main module for this environment (the project being worked on)
-    /// Contextual path arguments will be populated using the environment's workspace.
-    pub fn with_main_module(&self, module: impl IntoID<ModuleId>) -> Env {
-        let mut query = self.selection.select("withMainModule");
+    /// Create or update a binding of type GitRepository in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The GitRepository value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_git_repository_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<GitRepositoryId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withGitRepositoryInput");
+        query = query.arg("name", name.into());
         query = query.arg_lazy(
-            "module",
+            "value",
             Box::new(move || {
-                let module = module.clone();
-                Box::pin(async move { module.into_id().await.unwrap().quote() })
-            }),
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired GitRepository output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_git_repository_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withGitRepositoryOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Create or update a binding of type JSONValue in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The JSONValue value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_json_value_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<JsonValueId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withJSONValueInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired JSONValue output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_json_value_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withJSONValueOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Create or update a binding of type LLMStreamEvent in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `value` - The LLMStreamEvent value to assign to the binding
+    /// * `description` - The purpose of the input
+    pub fn with_llm_stream_event_input(
+        &self,
+        name: impl Into<String>,
+        value: impl IntoID<LlmStreamEventId>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withLLMStreamEventInput");
+        query = query.arg("name", name.into());
+        query = query.arg_lazy(
+            "value",
+            Box::new(move || {
+                let value = value.clone();
+                Box::pin(async move { value.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Declare a desired LLMStreamEvent output to be assigned in the environment
+    ///
+    /// # Arguments
+    ///
+    /// * `name` - The name of the binding
+    /// * `description` - A description of the desired value of the binding
+    pub fn with_llm_stream_event_output(
+        &self,
+        name: impl Into<String>,
+        description: impl Into<String>,
+    ) -> Env {
+        let mut query = self.selection.select("withLLMStreamEventOutput");
+        query = query.arg("name", name.into());
+        query = query.arg("description", description.into());
+        Env {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Sets the main module for this environment (the project being worked on)
+    /// Contextual path arguments will be populated using the environment's workspace.
+    pub fn with_main_module(&self, module: impl IntoID<ModuleId>) -> Env {
+        let mut query = self.selection.select("withMainModule");
+        query = query.arg_lazy(
+            "module",
+            Box::new(move || {
+                let module = module.clone();
+                Box::pin(async move { module.into_id().await.unwrap().quote() })
+            }),
         );
         Env {
             proc: self.proc.clone(),
@@ -8698,6 +9569,17 @@ impl File {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Return the lines of this file with the commit that last changed them.
+    /// The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.
+    /// Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.
+    pub fn blame(&self) -> Vec<GitBlameLine> {
+        let query = self.selection.select("blame");
+        vec![GitBlameLine {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
     /// Change the owner of the file recursively.
     ///
     /// # Arguments
@@ -9523,6 +10405,16 @@ impl Generator {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Why the generator was selected or skipped by affectedBy
+    pub async fn selection_reason(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("selectionReason");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the generator is skipped because none of its inputs are affected by the changes passed to affectedBy
+    pub async fn skipped(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("skipped");
+        query.execute(self.graphql_client.clone()).await
+    }
 }
 #[derive(Clone)]
 pub struct GeneratorGroup {
@@ -9537,6 +10429,45 @@ pub struct GeneratorGroupChangesOpts {
     pub on_conflict: Option<ChangesetsMergeConflict>,
 }
 impl GeneratorGroup {
+    /// Skip the generators whose inputs are not affected by the given changes
+    /// A generator's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Generators without any declared input are always selected.
+    ///
+    /// # Arguments
+    ///
+    /// * `changes` - The changes to select generators for, relative to the context directory
+    pub fn affected_by(&self, changes: impl IntoID<ChangesetId>) -> GeneratorGroup {
+        let mut query = self.selection.select("affectedBy");
+        query = query.arg_lazy(
+            "changes",
+            Box::new(move || {
+                let changes = changes.clone();
+                Box::pin(async move { changes.into_id().await.unwrap().quote() })
+            }),
+        );
+        GeneratorGroup {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Skip the generators whose inputs are not affected by the given changed paths
+    /// Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+    ///
+    /// # Arguments
+    ///
+    /// * `paths` - The added, modified or removed paths, relative to the context directory
+    pub fn affected_by_paths(&self, paths: Vec<impl Into<String>>) -> GeneratorGroup {
+        let mut query = self.selection.select("affectedByPaths");
+        query = query.arg(
+            "paths",
+            paths.into_iter().map(|i| i.into()).collect::<Vec<String>>(),
+        );
+        GeneratorGroup {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// The combined changes from the generators execution
     /// If any conflict occurs, for instance if the same file is modified by multiple generators, or if a file is both modified and deleted, an error is raised and the merge of the changesets will failed.
     /// Set 'continueOnConflicts' flag to force to merge the changes in a 'last write wins' strategy.
@@ -9600,19 +10531,164 @@ impl GeneratorGroup {
     }
 }
 #[derive(Clone)]
+pub struct GitBlameLine {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl GitBlameLine {
+    /// The email of the author of the commit.
+    pub async fn author_email(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("authorEmail");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The name of the author of the commit.
+    pub async fn author_name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("authorName");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The id of the commit that last changed the line, or all zeroes if it isn't committed yet.
+    pub async fn commit(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("commit");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The content of the line.
+    pub async fn content(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("content");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The date the commit was authored, in RFC 3339 format.
+    pub async fn date(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("date");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this GitBlameLine.
+    pub async fn id(&self) -> Result<GitBlameLineId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The line number, starting at 1.
+    pub async fn line(&self) -> Result<isize, DaggerError> {
+        let query = self.selection.select("line");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
+pub struct GitCommit {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl GitCommit {
+    /// The email of the author of the commit.
+    pub async fn author_email(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("authorEmail");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The name of the author of the commit.
+    pub async fn author_name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("authorName");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The commit id.
+    pub async fn commit(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("commit");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The date the commit was authored, in RFC 3339 format.
+    pub async fn date(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("date");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The paths of the files changed by the commit, compared to its first parent.
+    pub async fn files(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("files");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// A unique identifier for this GitCommit.
+    pub async fn id(&self) -> Result<GitCommitId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The full message of the commit.
+    pub async fn message(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("message");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ids of the parents of the commit.
+    pub async fn parents(&self) -> Result<Vec<String>, DaggerError> {
+        let query = self.selection.select("parents");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
 pub struct GitRef {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
 #[derive(Builder, Debug, PartialEq)]
-pub struct GitRefTreeOpts {
+pub struct GitRefLogOpts<'a> {
+    /// The maximum number of commits to return. All the commits are returned if 0.
+    #[builder(setter(into, strip_option), default)]
+    pub limit: Option<isize>,
+    /// Only include the commits changing these paths (e.g., ["docs", "README.md"]).
+    #[builder(setter(into, strip_option), default)]
+    pub paths: Option<Vec<&'a str>>,
+    /// Only include the commits more recent than a date, in any format git understands (e.g., "2024-01-31" or "2 weeks ago").
+    #[builder(setter(into, strip_option), default)]
+    pub since: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct GitRefPushOpts<'a> {
+    /// Update the remote ref even if it isn't an ancestor of the pushed commit.
+    #[builder(setter(into, strip_option), default)]
+    pub force: Option<bool>,
+    /// Secret used to populate the Authorization HTTP header
+    #[builder(setter(into, strip_option), default)]
+    pub http_auth_header: Option<SecretId>,
+    /// Secret used to populate the password during basic HTTP Authorization
+    #[builder(setter(into, strip_option), default)]
+    pub http_auth_token: Option<SecretId>,
+    /// Username used to populate the password during basic HTTP Authorization
+    #[builder(setter(into, strip_option), default)]
+    pub http_auth_username: Option<&'a str>,
+    /// The remote ref to update (e.g., "refs/heads/fix" or "fix" for a branch). Defaults to the name of this ref.
+    #[builder(setter(into, strip_option), default)]
+    pub r#ref: Option<&'a str>,
+    /// Set SSH auth socket
+    #[builder(setter(into, strip_option), default)]
+    pub ssh_auth_socket: Option<SocketId>,
+    /// Set SSH known hosts
+    #[builder(setter(into, strip_option), default)]
+    pub ssh_known_hosts: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct GitRefTreeOpts<'a> {
     /// The depth of the tree to fetch.
     #[builder(setter(into, strip_option), default)]
     pub depth: Option<isize>,
     /// Set to true to discard .git directory.
     #[builder(setter(into, strip_option), default)]
     pub discard_git_dir: Option<bool>,
+    /// Set to true to download the Git LFS objects of the checked out files.
+    #[builder(setter(into, strip_option), default)]
+    pub lfs: Option<bool>,
+    /// Directories to check out, in git's sparse checkout cone mode (e.g., ["services/api", "libs"]).
+    /// Files at the root of the repository and in the parents of these directories are checked out too.
+    /// Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"]) are checked out in non-cone mode instead, where only the files matching them, like in .gitignore files, are checked out.
+    /// Without .git directory, the tree only changes when the checked out files change.
+    #[builder(setter(into, strip_option), default)]
+    pub sparse: Option<Vec<&'a str>>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct GitRefVerifyOpts {
+    /// An SSH allowed signers file, trusting SSH keys for their principals (see ssh-keygen(1)).
+    #[builder(setter(into, strip_option), default)]
+    pub allowed_signers: Option<FileId>,
+    /// Trusted public keys: armored GPG public keys, or SSH public keys trusted for the principal in their comment.
+    #[builder(setter(into, strip_option), default)]
+    pub keys: Option<Vec<SecretId>>,
 }
 impl GitRef {
     /// The resolved commit id at this ref.
@@ -9639,10 +10715,115 @@ impl GitRef {
             selection: query,
             graphql_client: self.graphql_client.clone(),
         }
-    }
-    /// A unique identifier for this GitRef.
-    pub async fn id(&self) -> Result<GitRefId, DaggerError> {
-        let query = self.selection.select("id");
+    }
+    /// Return the changes from this ref to another ref, like "git diff".
+    /// The .git directory isn't part of the changes.
+    ///
+    /// # Arguments
+    ///
+    /// * `other` - The ref to compare this ref against.
+    pub fn diff(&self, other: impl IntoID<GitRefId>) -> Changeset {
+        let mut query = self.selection.select("diff");
+        query = query.arg_lazy(
+            "other",
+            Box::new(move || {
+                let other = other.clone();
+                Box::pin(async move { other.into_id().await.unwrap().quote() })
+            }),
+        );
+        Changeset {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// A unique identifier for this GitRef.
+    pub async fn id(&self) -> Result<GitRefId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The history of this ref, most recent commits first.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn log(&self) -> Vec<GitCommit> {
+        let query = self.selection.select("log");
+        vec![GitCommit {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// The history of this ref, most recent commits first.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn log_opts<'a>(&self, opts: GitRefLogOpts<'a>) -> Vec<GitCommit> {
+        let mut query = self.selection.select("log");
+        if let Some(paths) = opts.paths {
+            query = query.arg("paths", paths);
+        }
+        if let Some(limit) = opts.limit {
+            query = query.arg("limit", limit);
+        }
+        if let Some(since) = opts.since {
+            query = query.arg("since", since);
+        }
+        vec![GitCommit {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// Push the commit of this ref to a remote repository.
+    /// Returns the updated remote ref.
+    ///
+    /// # Arguments
+    ///
+    /// * `remote` - URL of the remote repository.
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub async fn push(&self, remote: impl Into<String>) -> Result<String, DaggerError> {
+        let mut query = self.selection.select("push");
+        query = query.arg("remote", remote.into());
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Push the commit of this ref to a remote repository.
+    /// Returns the updated remote ref.
+    ///
+    /// # Arguments
+    ///
+    /// * `remote` - URL of the remote repository.
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub async fn push_opts<'a>(
+        &self,
+        remote: impl Into<String>,
+        opts: GitRefPushOpts<'a>,
+    ) -> Result<String, DaggerError> {
+        let mut query = self.selection.select("push");
+        query = query.arg("remote", remote.into());
+        if let Some(r#ref) = opts.r#ref {
+            query = query.arg("ref", r#ref);
+        }
+        if let Some(force) = opts.force {
+            query = query.arg("force", force);
+        }
+        if let Some(ssh_known_hosts) = opts.ssh_known_hosts {
+            query = query.arg("sshKnownHosts", ssh_known_hosts);
+        }
+        if let Some(ssh_auth_socket) = opts.ssh_auth_socket {
+            query = query.arg("sshAuthSocket", ssh_auth_socket);
+        }
+        if let Some(http_auth_username) = opts.http_auth_username {
+            query = query.arg("httpAuthUsername", http_auth_username);
+        }
+        if let Some(http_auth_token) = opts.http_auth_token {
+            query = query.arg("httpAuthToken", http_auth_token);
+        }
+        if let Some(http_auth_header) = opts.http_auth_header {
+            query = query.arg("httpAuthHeader", http_auth_header);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// The resolved ref name at this ref.
@@ -9650,6 +10831,11 @@ impl GitRef {
         let query = self.selection.select("ref");
         query.execute(self.graphql_client.clone()).await
     }
+    /// The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.
+    pub async fn signer(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("signer");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// The filesystem tree at this ref.
     ///
     /// # Arguments
@@ -9668,7 +10854,7 @@ impl GitRef {
     /// # Arguments
     ///
     /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
-    pub fn tree_opts(&self, opts: GitRefTreeOpts) -> Directory {
+    pub fn tree_opts<'a>(&self, opts: GitRefTreeOpts<'a>) -> Directory {
         let mut query = self.selection.select("tree");
         if let Some(discard_git_dir) = opts.discard_git_dir {
             query = query.arg("discardGitDir", discard_git_dir);
@@ -9676,12 +10862,54 @@ impl GitRef {
         if let Some(depth) = opts.depth {
             query = query.arg("depth", depth);
         }
+        if let Some(sparse) = opts.sparse {
+            query = query.arg("sparse", sparse);
+        }
+        if let Some(lfs) = opts.lfs {
+            query = query.arg("lfs", lfs);
+        }
         Directory {
             proc: self.proc.clone(),
             selection: query,
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+    /// Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+    /// Returns the verified ref, with its signer.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn verify(&self) -> GitRef {
+        let query = self.selection.select("verify");
+        GitRef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+    /// Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+    /// Returns the verified ref, with its signer.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn verify_opts(&self, opts: GitRefVerifyOpts) -> GitRef {
+        let mut query = self.selection.select("verify");
+        if let Some(allowed_signers) = opts.allowed_signers {
+            query = query.arg("allowedSigners", allowed_signers);
+        }
+        if let Some(keys) = opts.keys {
+            query = query.arg("keys", keys);
+        }
+        GitRef {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
 }
 #[derive(Clone)]
 pub struct GitRepository {
@@ -9843,6 +11071,36 @@ impl GitRepository {
         let query = self.selection.select("url");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.
+    /// The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.
+    ///
+    /// # Arguments
+    ///
+    /// * `changes` - The changes to commit, applied to the tree of HEAD.
+    /// * `message` - The commit message.
+    /// * `author` - The author and committer of the commit (e.g., "Dagger Bot <bot@example.com>").
+    pub fn with_commit(
+        &self,
+        changes: impl IntoID<ChangesetId>,
+        message: impl Into<String>,
+        author: impl Into<String>,
+    ) -> GitRepository {
+        let mut query = self.selection.select("withCommit");
+        query = query.arg_lazy(
+            "changes",
+            Box::new(move || {
+                let changes = changes.clone();
+                Box::pin(async move { changes.into_id().await.unwrap().quote() })
+            }),
+        );
+        query = query.arg("message", message.into());
+        query = query.arg("author", author.into());
+        GitRepository {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
 }
 #[derive(Clone)]
 pub struct Host {
@@ -10386,6 +11644,31 @@ pub struct Llm {
     pub selection: Selection,
     pub graphql_client: DynGraphQLClient,
 }
+#[derive(Builder, Debug, PartialEq)]
+pub struct LlmStreamOpts {
+    /// The number of events to skip, i.e. the number of events received from previous calls
+    #[builder(setter(into, strip_option), default)]
+    pub after: Option<isize>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct LlmWithBudgetOpts {
+    /// The maximum estimated cost in USD, based on the price of the model. 0 means unlimited.
+    /// Evaluating the LLM fails if the price of the model is unknown.
+    #[builder(setter(into, strip_option), default)]
+    pub max_cost: Option<f64>,
+    /// The maximum number of tokens, input and output, summed over all API calls. 0 means unlimited.
+    #[builder(setter(into, strip_option), default)]
+    pub max_tokens: Option<isize>,
+}
+#[derive(Builder, Debug, PartialEq)]
+pub struct LlmWithCassetteOpts {
+    /// The cassette to replay. Required in REPLAY mode.
+    #[builder(setter(into, strip_option), default)]
+    pub file: Option<FileId>,
+    /// Whether to record or replay the cassette
+    #[builder(setter(into, strip_option), default)]
+    pub mode: Option<LlmCassetteMode>,
+}
 impl Llm {
     /// create a branch in the LLM's history
     pub fn attempt(&self, number: isize) -> Llm {
@@ -10407,6 +11690,16 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Return the cassette recorded by the LLM, to replay with withCassette
+    /// Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is.
+    pub fn cassette(&self) -> File {
+        let query = self.selection.select("cassette");
+        File {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// return the LLM's current environment
     pub fn env(&self) -> Env {
         let query = self.selection.select("env");
@@ -10465,6 +11758,37 @@ impl Llm {
         let query = self.selection.select("step");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+    /// To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn stream(&self) -> Vec<LlmStreamEvent> {
+        let query = self.selection.select("stream");
+        vec![LlmStreamEvent {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
+    /// Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+    /// To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn stream_opts(&self, opts: LlmStreamOpts) -> Vec<LlmStreamEvent> {
+        let mut query = self.selection.select("stream");
+        if let Some(after) = opts.after {
+            query = query.arg("after", after);
+        }
+        vec![LlmStreamEvent {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }]
+    }
     /// synchronize LLM state
     pub async fn sync(&self) -> Result<Llmid, DaggerError> {
         let query = self.selection.select("sync");
@@ -10506,6 +11830,74 @@ impl Llm {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Limit the tokens and estimated cost of the LLM.
+    /// When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_budget(&self) -> Llm {
+        let query = self.selection.select("withBudget");
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Limit the tokens and estimated cost of the LLM.
+    /// When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_budget_opts(&self, opts: LlmWithBudgetOpts) -> Llm {
+        let mut query = self.selection.select("withBudget");
+        if let Some(max_tokens) = opts.max_tokens {
+            query = query.arg("maxTokens", max_tokens);
+        }
+        if let Some(max_cost) = opts.max_cost {
+            query = query.arg("maxCost", max_cost);
+        }
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+    /// Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_cassette(&self) -> Llm {
+        let query = self.selection.select("withCassette");
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+    /// Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub fn with_cassette_opts(&self, opts: LlmWithCassetteOpts) -> Llm {
+        let mut query = self.selection.select("withCassette");
+        if let Some(file) = opts.file {
+            query = query.arg("file", file);
+        }
+        if let Some(mode) = opts.mode {
+            query = query.arg("mode", mode);
+        }
+        Llm {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// allow the LLM to interact with an environment via MCP
     pub fn with_env(&self, env: impl IntoID<EnvId>) -> Llm {
         let mut query = self.selection.select("withEnv");
@@ -10644,6 +12036,63 @@ impl Llm {
     }
 }
 #[derive(Clone)]
+pub struct LlmStreamEvent {
+    pub proc: Option<Arc<DaggerSessionProc>>,
+    pub selection: Selection,
+    pub graphql_client: DynGraphQLClient,
+}
+impl LlmStreamEvent {
+    /// A unique identifier for this LLMStreamEvent.
+    pub async fn id(&self) -> Result<LlmStreamEventId, DaggerError> {
+        let query = self.selection.select("id");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The kind of event.
+    pub async fn kind(&self) -> Result<LlmStreamEventKind, DaggerError> {
+        let query = self.selection.select("kind");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The text generated by the model, for TEXT_DELTA events.
+    pub async fn text(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("text");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The token usage of the model reply, for TOKEN_USAGE events.
+    pub fn token_usage(&self) -> LlmTokenUsage {
+        let query = self.selection.select("tokenUsage");
+        LlmTokenUsage {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// The JSON-encoded arguments of the tool call, for TOOL_CALL_START events.
+    pub async fn tool_arguments(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("toolArguments");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The ID of the tool call, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+    pub async fn tool_call_id(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("toolCallID");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// Whether the tool call failed, for TOOL_CALL_FINISH events.
+    pub async fn tool_errored(&self) -> Result<bool, DaggerError> {
+        let query = self.selection.select("toolErrored");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The name of the tool, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+    pub async fn tool_name(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("toolName");
+        query.execute(self.graphql_client.clone()).await
+    }
+    /// The result of the tool call, for TOOL_CALL_FINISH events.
+    pub async fn tool_result(&self) -> Result<String, DaggerError> {
+        let query = self.selection.select("toolResult");
+        query.execute(self.graphql_client.clone()).await
+    }
+}
+#[derive(Clone)]
 pub struct LlmTokenUsage {
     pub proc: Option<Arc<DaggerSessionProc>>,
     pub selection: Selection,
@@ -10658,6 +12107,11 @@ impl LlmTokenUsage {
         let query = self.selection.select("cachedTokenWrites");
         query.execute(self.graphql_client.clone()).await
     }
+    /// The estimated cost in USD, if the price of the model is known.
+    pub async fn cost(&self) -> Result<f64, DaggerError> {
+        let query = self.selection.select("cost");
+        query.execute(self.graphql_client.clone()).await
+    }
     /// A unique identifier for this LLMTokenUsage.
     pub async fn id(&self) -> Result<LlmTokenUsageId, DaggerError> {
         let query = self.selection.select("id");
@@ -11717,6 +13171,10 @@ pub struct QueryGitOpts<'a> {
     /// A service which must be started before the repo is fetched.
     #[builder(setter(into, strip_option), default)]
     pub experimental_service_host: Option<ServiceId>,
+    /// Partial clone filter to fetch the repository with, like "blob:none" or "blob:limit=1m".
+    /// The file contents needed by a checkout are fetched when checking it out.
+    #[builder(setter(into, strip_option), default)]
+    pub filter: Option<&'a str>,
     /// Secret used to populate the Authorization HTTP header
     #[builder(setter(into, strip_option), default)]
     pub http_auth_header: Option<SecretId>,
@@ -12122,6 +13580,9 @@ impl Query {
         if let Some(experimental_service_host) = opts.experimental_service_host {
             query = query.arg("experimentalServiceHost", experimental_service_host);
         }
+        if let Some(filter) = opts.filter {
+            query = query.arg("filter", filter);
+        }
         GitRepository {
             proc: self.proc.clone(),
             selection: query,
@@ -12332,6 +13793,25 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a ContainerAttestation from its ID.
+    pub fn load_container_attestation_from_id(
+        &self,
+        id: impl IntoID<ContainerAttestationId>,
+    ) -> ContainerAttestation {
+        let mut query = self.selection.select("loadContainerAttestationFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        ContainerAttestation {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a Container from its ID.
     pub fn load_container_from_id(&self, id: impl IntoID<ContainerId>) -> Container {
         let mut query = self.selection.select("loadContainerFromID");
@@ -12715,6 +14195,38 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a GitBlameLine from its ID.
+    pub fn load_git_blame_line_from_id(&self, id: impl IntoID<GitBlameLineId>) -> GitBlameLine {
+        let mut query = self.selection.select("loadGitBlameLineFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        GitBlameLine {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
+    /// Load a GitCommit from its ID.
+    pub fn load_git_commit_from_id(&self, id: impl IntoID<GitCommitId>) -> GitCommit {
+        let mut query = self.selection.select("loadGitCommitFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        GitCommit {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a GitRef from its ID.
     pub fn load_git_ref_from_id(&self, id: impl IntoID<GitRefId>) -> GitRef {
         let mut query = self.selection.select("loadGitRefFromID");
@@ -12830,6 +14342,25 @@ impl Query {
             graphql_client: self.graphql_client.clone(),
         }
     }
+    /// Load a LLMStreamEvent from its ID.
+    pub fn load_llm_stream_event_from_id(
+        &self,
+        id: impl IntoID<LlmStreamEventId>,
+    ) -> LlmStreamEvent {
+        let mut query = self.selection.select("loadLLMStreamEventFromID");
+        query = query.arg_lazy(
+            "id",
+            Box::new(move || {
+                let id = id.clone();
+                Box::pin(async move { id.into_id().await.unwrap().quote() })
+            }),
+        );
+        LlmStreamEvent {
+            proc: self.proc.clone(),
+            selection: query,
+            graphql_client: self.graphql_client.clone(),
+        }
+    }
     /// Load a LLMTokenUsage from its ID.
     pub fn load_llm_token_usage_from_id(&self, id: impl IntoID<LlmTokenUsageId>) -> LlmTokenUsage {
         let mut query = self.selection.select("loadLLMTokenUsageFromID");
@@ -13443,6 +14974,14 @@ pub struct ServiceEndpointOpts<'a> {
     pub scheme: Option<&'a str>,
 }
 #[derive(Builder, Debug, PartialEq)]
+pub struct ServiceStartOpts<'a> {
+    /// Maximum duration the service may run once started, as a Go duration string (e.g. "10m").
+    /// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period.
+    /// A service that was already running, e.g. for a container it is bound to, is not stopped.
+    #[builder(setter(into, strip_option), default)]
+    pub timeout: Option<&'a str>,
+}
+#[derive(Builder, Debug, PartialEq)]
 pub struct ServiceStopOpts {
     /// Immediately kill the service without waiting for a graceful exit
     #[builder(setter(into, strip_option), default)]
@@ -13454,7 +14993,7 @@ pub struct ServiceTerminalOpts<'a> {
     pub cmd: Option<Vec<&'a str>>,
 }
 #[derive(Builder, Debug, PartialEq)]
-pub struct ServiceUpOpts {
+pub struct ServiceUpOpts<'a> {
     /// List of frontend/backend port mappings to forward.
     /// Frontend is the port accepting traffic on the host, backend is the service port.
     #[builder(setter(into, strip_option), default)]
@@ -13462,6 +15001,10 @@ pub struct ServiceUpOpts {
     /// Bind each tunnel port to a random port on the host.
     #[builder(setter(into, strip_option), default)]
     pub random: Option<bool>,
+    /// Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+    /// When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+    #[builder(setter(into, strip_option), default)]
+    pub timeout: Option<&'a str>,
 }
 impl Service {
     /// Retrieves an endpoint that clients can use to reach this container.
@@ -13516,10 +15059,30 @@ impl Service {
     }
     /// Start the service and wait for its health checks to succeed.
     /// Services bound to a Container do not need to be manually started.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
     pub async fn start(&self) -> Result<ServiceId, DaggerError> {
         let query = self.selection.select("start");
         query.execute(self.graphql_client.clone()).await
     }
+    /// Start the service and wait for its health checks to succeed.
+    /// Services bound to a Container do not need to be manually started.
+    ///
+    /// # Arguments
+    ///
+    /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
+    pub async fn start_opts<'a>(
+        &self,
+        opts: ServiceStartOpts<'a>,
+    ) -> Result<ServiceId, DaggerError> {
+        let mut query = self.selection.select("start");
+        if let Some(timeout) = opts.timeout {
+            query = query.arg("timeout", timeout);
+        }
+        query.execute(self.graphql_client.clone()).await
+    }
     /// Stop the service.
     ///
     /// # Arguments
@@ -13587,7 +15150,7 @@ impl Service {
     /// # Arguments
     ///
     /// * `opt` - optional argument, see inner type for documentation, use <func>_opts to use
-    pub async fn up_opts(&self, opts: ServiceUpOpts) -> Result<Void, DaggerError> {
+    pub async fn up_opts<'a>(&self, opts: ServiceUpOpts<'a>) -> Result<Void, DaggerError> {
         let mut query = self.selection.select("up");
         if let Some(ports) = opts.ports {
             query = query.arg("ports", ports);
@@ -13595,6 +15158,9 @@ impl Service {
         if let Some(random) = opts.random {
             query = query.arg("random", random);
         }
+        if let Some(timeout) = opts.timeout {
+            query = query.arg("timeout", timeout);
+        }
         query.execute(self.graphql_client.clone()).await
     }
     /// Configures a hostname which can be used by clients within the session to reach this container.
@@ -14266,6 +15832,17 @@ pub enum ChangesetsMergeConflict {
     FailEarly,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum CheckReportFormat {
+    #[serde(rename = "JSON")]
+    Json,
+    #[serde(rename = "JUNIT")]
+    Junit,
+    #[serde(rename = "MARKDOWN")]
+    Markdown,
+    #[serde(rename = "SARIF")]
+    Sarif,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum ExistsType {
     #[serde(rename = "DIRECTORY_TYPE")]
     DirectoryType,
@@ -14325,6 +15902,26 @@ pub enum ImageMediaTypes {
     OciMediaTypes,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum LLMCassetteMode {
+    #[serde(rename = "RECORD")]
+    Record,
+    #[serde(rename = "REPLAY")]
+    Replay,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum LLMStreamEventKind {
+    #[serde(rename = "RETRY")]
+    Retry,
+    #[serde(rename = "TEXT_DELTA")]
+    TextDelta,
+    #[serde(rename = "TOKEN_USAGE")]
+    TokenUsage,
+    #[serde(rename = "TOOL_CALL_FINISH")]
+    ToolCallFinish,
+    #[serde(rename = "TOOL_CALL_START")]
+    ToolCallStart,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum ModuleSourceExperimentalFeature {
     #[serde(rename = "SELF_CALLS")]
     SelfCalls,
@@ -14345,6 +15942,17 @@ pub enum ModuleSourceKind {
     LocalSource,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum NetworkMode {
+    #[serde(rename = "ALLOW_LIST")]
+    AllowList,
+    #[serde(rename = "OFFLINE")]
+    Offline,
+    #[serde(rename = "SERVICES_ONLY")]
+    ServicesOnly,
+    #[serde(rename = "UNRESTRICTED")]
+    Unrestricted,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum NetworkProtocol {
     #[serde(rename = "TCP")]
     Tcp,
@@ -14361,6 +15969,13 @@ pub enum ReturnType {
     Success,
 }
 #[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
+pub enum SBOMFormat {
+    #[serde(rename = "CYCLONEDX")]
+    Cyclonedx,
+    #[serde(rename = "SPDX")]
+    Spdx,
+}
+#[derive(Serialize, Deserialize, Clone, PartialEq, Debug)]
 pub enum TypeDefKind {
     #[serde(rename = "BOOLEAN")]
     Boolean,
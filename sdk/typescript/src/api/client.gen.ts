@@ -253,6 +253,37 @@ function ChangesetsMergeConflictNameToValue(
       return name as ChangesetsMergeConflict
   }
 }
+export type CheckRunOpts = {
+  /**
+   * Number of times the check is retried if it fails
+   */
+  retries?: number
+}
+
+export type CheckGroupReportOpts = {
+  /**
+   * The format of the report
+   */
+  format?: CheckReportFormat
+}
+
+export type CheckGroupRunOpts = {
+  /**
+   * Cancel the remaining checks as soon as one fails. Quarantined checks don't trigger cancellation.
+   */
+  failFast?: boolean
+
+  /**
+   * Number of times a failed check is retried before it is reported as failed
+   */
+  retries?: number
+
+  /**
+   * Record failed checks in the results instead of returning an error, e.g. to generate a report of the failures
+   */
+  noFail?: boolean
+}
+
 /**
  * The `CheckGroupID` scalar type represents an identifier for an object of type CheckGroup.
  */
@@ -263,6 +294,68 @@ export type CheckGroupID = string & { __CheckGroupID: never }
  */
 export type CheckID = string & { __CheckID: never }
 
+/**
+ * The format of a check report
+ */
+export enum CheckReportFormat {
+  /**
+   * The check results as JSON
+   */
+  Json = "JSON",
+
+  /**
+   * JUnit XML, as consumed by most CI systems
+   */
+  Junit = "JUNIT",
+
+  /**
+   * A markdown table, for humans
+   */
+  Markdown = "MARKDOWN",
+
+  /**
+   * SARIF 2.1.0, as consumed by code scanning tools
+   */
+  Sarif = "SARIF",
+}
+
+/**
+ * Utility function to convert a CheckReportFormat value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function CheckReportFormatValueToName(value: CheckReportFormat): string {
+  switch (value) {
+    case CheckReportFormat.Json:
+      return "JSON"
+    case CheckReportFormat.Junit:
+      return "JUNIT"
+    case CheckReportFormat.Markdown:
+      return "MARKDOWN"
+    case CheckReportFormat.Sarif:
+      return "SARIF"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a CheckReportFormat name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function CheckReportFormatNameToValue(name: string): CheckReportFormat {
+  switch (name) {
+    case "JSON":
+      return CheckReportFormat.Json
+    case "JUNIT":
+      return CheckReportFormat.Junit
+    case "MARKDOWN":
+      return CheckReportFormat.Markdown
+    case "SARIF":
+      return CheckReportFormat.Sarif
+    default:
+      return name as CheckReportFormat
+  }
+}
 /**
  * The `CloudID` scalar type represents an identifier for an object of type Cloud.
  */
@@ -327,6 +420,18 @@ export type ContainerAsTarballOpts = {
   mediaTypes?: ImageMediaTypes
 }
 
+export type ContainerAttestationsOpts = {
+  /**
+   * Include a software bill of materials of the packages installed in the container, in the given format.
+   */
+  sbom?: SBOMFormat
+
+  /**
+   * Include SLSA provenance of the calls that produced the container.
+   */
+  provenance?: boolean
+}
+
 export type ContainerDirectoryOpts = {
   /**
    * Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
@@ -372,6 +477,16 @@ export type ContainerExportOpts = {
    * Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
    */
   expand?: boolean
+
+  /**
+   * Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+   */
+  sbom?: SBOMFormat
+
+  /**
+   * Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+   */
+  provenance?: boolean
 }
 
 export type ContainerExportImageOpts = {
@@ -404,6 +519,15 @@ export type ContainerFileOpts = {
   expand?: boolean
 }
 
+export type ContainerFromOpts = {
+  /**
+   * PEM encoded public key to verify the image signature with.
+   *
+   * If set, the image is rejected unless it has a cosign compatible signature verified by this key, attached as an OCI referrer or with the cosign tag scheme.
+   */
+  verify?: string
+}
+
 export type ContainerImportOpts = {
   /**
    * Identifies the tag to import from the archive, if the archive bundles multiple tags.
@@ -432,6 +556,28 @@ export type ContainerPublishOpts = {
    * Defaults to "OCI", which is compatible with most recent registries, but "Docker" may be needed for older registries without OCI support.
    */
   mediaTypes?: ImageMediaTypes
+
+  /**
+   * PEM encoded private key to sign the published image with.
+   *
+   * The signature is cosign compatible, and attached to the image both as an OCI referrer and with the cosign tag scheme ("sha256-<hex>.sig"), for cosign to find it with or without --experimental-oci11. Encrypted cosign keys are decrypted with signPassword.
+   */
+  sign?: Secret
+
+  /**
+   * Password of the private key to sign the published image with.
+   */
+  signPassword?: Secret
+
+  /**
+   * Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+   */
+  sbom?: SBOMFormat
+
+  /**
+   * Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
+   */
+  provenance?: boolean
 }
 
 export type ContainerStatOpts = {
@@ -471,6 +617,13 @@ export type ContainerUpOpts = {
    */
   ports?: PortForward[]
 
+  /**
+   * Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+   */
+  timeout?: string
+
   /**
    * Command to run instead of the container's default command (e.g., ["go", "run", "main.go"]).
    *
@@ -617,6 +770,31 @@ export type ContainerWithExecOpts = {
    * Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
    */
   noInit?: boolean
+
+  /**
+   * Maximum duration the command may run, as a Go duration string (e.g. "30s", "5m").
+   *
+   * When it elapses, the command is sent SIGTERM, followed by SIGKILL after a grace period, and the exec fails with a timed out ExecError, which is never cached.
+   *
+   * A timed out exec fails regardless of "expect", even if the command handles SIGTERM and exits with an expected code. To expect a timeout, check whether the ExecError timed out.
+   */
+  timeout?: string
+
+  /**
+   * Network access granted to the command.
+   *
+   * OFFLINE only allows the loopback interface. SERVICES_ONLY allows bound services and DNS. ALLOW_LIST additionally allows the destinations in "networkAllow". Other connections are rejected.
+   *
+   * DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST the command can still send data out through the names it looks up. Use OFFLINE if this must be prevented.
+   */
+  networkMode?: NetworkMode
+
+  /**
+   * Hostnames, IPv4 addresses and CIDRs the command may connect to with the ALLOW_LIST network mode. Example: ["proxy.golang.org", "10.0.0.0/8"]
+   *
+   * Hostnames are resolved once, when the command starts: connections to the addresses they resolve to afterwards (e.g. when a DNS record changes or rotates) are rejected.
+   */
+  networkAllow?: string[]
 }
 
 export type ContainerWithExposedPortOpts = {
@@ -678,6 +856,53 @@ export type ContainerWithFilesOpts = {
   expand?: boolean
 }
 
+export type ContainerWithHealthcheckOpts = {
+  /**
+   * Command to run in the service container, ready once it exits zero. Example: ["pg_isready", "-U", "postgres"]
+   */
+  args?: string[]
+
+  /**
+   * Port to send an HTTP GET request to, ready once it responds with httpStatus. Example: 8080
+   */
+  httpPort?: number
+
+  /**
+   * Path of the HTTP GET request. Example: "/healthz"
+   */
+  httpPath?: string
+
+  /**
+   * Expected status of the HTTP response.
+   */
+  httpStatus?: number
+
+  /**
+   * Regular expression matched against the lines of the service stdout, ready once a line matches. Example: "listening on .*:8080"
+   */
+  logPattern?: string
+
+  /**
+   * Time between checks, as a Go duration string. Example: "5s"
+   */
+  interval?: string
+
+  /**
+   * Time after which a single check fails, as a Go duration string.
+   */
+  timeout?: string
+
+  /**
+   * Initialization time during which failed checks are not counted, as a Go duration string.
+   */
+  startPeriod?: string
+
+  /**
+   * Number of consecutive failed checks after which the service fails to start.
+   */
+  retries?: number
+}
+
 export type ContainerWithMountedCacheOpts = {
   /**
    * Identifier of the directory to use as the cache volume's root.
@@ -873,6 +1098,13 @@ export type ContainerWithoutUnixSocketOpts = {
   expand?: boolean
 }
 
+/**
+ * The `ContainerAttestationID` scalar type represents an identifier for an object of type ContainerAttestation.
+ */
+export type ContainerAttestationID = string & {
+  __ContainerAttestationID: never
+}
+
 /**
  * The `ContainerID` scalar type represents an identifier for an object of type Container.
  */
@@ -1638,6 +1870,70 @@ export type GeneratorGroupID = string & { __GeneratorGroupID: never }
  */
 export type GeneratorID = string & { __GeneratorID: never }
 
+/**
+ * The `GitBlameLineID` scalar type represents an identifier for an object of type GitBlameLine.
+ */
+export type GitBlameLineID = string & { __GitBlameLineID: never }
+
+/**
+ * The `GitCommitID` scalar type represents an identifier for an object of type GitCommit.
+ */
+export type GitCommitID = string & { __GitCommitID: never }
+
+export type GitRefLogOpts = {
+  /**
+   * Only include the commits changing these paths (e.g., ["docs", "README.md"]).
+   */
+  paths?: string[]
+
+  /**
+   * The maximum number of commits to return. All the commits are returned if 0.
+   */
+  limit?: number
+
+  /**
+   * Only include the commits more recent than a date, in any format git understands (e.g., "2024-01-31" or "2 weeks ago").
+   */
+  since?: string
+}
+
+export type GitRefPushOpts = {
+  /**
+   * The remote ref to update (e.g., "refs/heads/fix" or "fix" for a branch). Defaults to the name of this ref.
+   */
+  ref?: string
+
+  /**
+   * Update the remote ref even if it isn't an ancestor of the pushed commit.
+   */
+  force?: boolean
+
+  /**
+   * Set SSH known hosts
+   */
+  sshKnownHosts?: string
+
+  /**
+   * Set SSH auth socket
+   */
+  sshAuthSocket?: Socket
+
+  /**
+   * Username used to populate the password during basic HTTP Authorization
+   */
+  httpAuthUsername?: string
+
+  /**
+   * Secret used to populate the password during basic HTTP Authorization
+   */
+  httpAuthToken?: Secret
+
+  /**
+   * Secret used to populate the Authorization HTTP header
+   */
+  httpAuthHeader?: Secret
+}
+
 export type GitRefTreeOpts = {
   /**
    * Set to true to discard .git directory.
@@ -1648,6 +1944,34 @@ export type GitRefTreeOpts = {
    * The depth of the tree to fetch.
    */
   depth?: number
+
+  /**
+   * Directories to check out, in git's sparse checkout cone mode (e.g., ["services/api", "libs"]).
+   *
+   * Files at the root of the repository and in the parents of these directories are checked out too.
+   *
+   * Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"]) are checked out in non-cone mode instead, where only the files matching them, like in .gitignore files, are checked out.
+   *
+   * Without .git directory, the tree only changes when the checked out files change.
+   */
+  sparse?: string[]
+
+  /**
+   * Set to true to download the Git LFS objects of the checked out files.
+   */
+  lfs?: boolean
+}
+
+export type GitRefVerifyOpts = {
+  /**
+   * An SSH allowed signers file, trusting SSH keys for their principals (see ssh-keygen(1)).
+   */
+  allowedSigners?: File
+
+  /**
+   * Trusted public keys: armored GPG public keys, or SSH public keys trusted for the principal in their comment.
+   */
+  keys?: Secret[]
 }
 
 /**
@@ -1860,11 +2184,164 @@ export type JSONValueContentsOpts = {
  */
 export type JSONValueID = string & { __JSONValueID: never }
 
+export type LLMStreamOpts = {
+  /**
+   * The number of events to skip, i.e. the number of events received from previous calls
+   */
+  after?: number
+}
+
+export type LLMWithBudgetOpts = {
+  /**
+   * The maximum number of tokens, input and output, summed over all API calls. 0 means unlimited.
+   */
+  maxTokens?: number
+
+  /**
+   * The maximum estimated cost in USD, based on the price of the model. 0 means unlimited.
+   *
+   * Evaluating the LLM fails if the price of the model is unknown.
+   */
+  maxCost?: float
+}
+
+export type LLMWithCassetteOpts = {
+  /**
+   * The cassette to replay. Required in REPLAY mode.
+   */
+  file?: File
+
+  /**
+   * Whether to record or replay the cassette
+   */
+  mode?: LLMCassetteMode
+}
+
+/**
+ * How an LLM uses its cassette
+ */
+export enum LLMCassetteMode {
+  /**
+   * Send requests to the model, and record them with their responses
+   */
+  Record = "RECORD",
+
+  /**
+   * Serve requests from the recorded responses, without calling the model, and fail on requests that weren't recorded
+   */
+  Replay = "REPLAY",
+}
+
+/**
+ * Utility function to convert a LLMCassetteMode value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function LlmcassetteModeValueToName(value: LLMCassetteMode): string {
+  switch (value) {
+    case LLMCassetteMode.Record:
+      return "RECORD"
+    case LLMCassetteMode.Replay:
+      return "REPLAY"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a LLMCassetteMode name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function LlmcassetteModeNameToValue(name: string): LLMCassetteMode {
+  switch (name) {
+    case "RECORD":
+      return LLMCassetteMode.Record
+    case "REPLAY":
+      return LLMCassetteMode.Replay
+    default:
+      return name as LLMCassetteMode
+  }
+}
 /**
  * The `LLMID` scalar type represents an identifier for an object of type LLM.
  */
 export type LLMID = string & { __LLMID: never }
 
+/**
+ * The `LLMStreamEventID` scalar type represents an identifier for an object of type LLMStreamEvent.
+ */
+export type LLMStreamEventID = string & { __LLMStreamEventID: never }
+
+/**
+ * The kind of an LLM stream event
+ */
+export enum LLMStreamEventKind {
+  /**
+   * The model query failed and is retried, discarding the text streamed since the last TOKEN_USAGE event
+   */
+  Retry = "RETRY",
+
+  /**
+   * A chunk of text generated by the model
+   */
+  TextDelta = "TEXT_DELTA",
+
+  /**
+   * The model replied, using tokens
+   */
+  TokenUsage = "TOKEN_USAGE",
+
+  /**
+   * A tool call requested by the model finished
+   */
+  ToolCallFinish = "TOOL_CALL_FINISH",
+
+  /**
+   * A tool call requested by the model started
+   */
+  ToolCallStart = "TOOL_CALL_START",
+}
+
+/**
+ * Utility function to convert a LLMStreamEventKind value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function LlmstreamEventKindValueToName(value: LLMStreamEventKind): string {
+  switch (value) {
+    case LLMStreamEventKind.Retry:
+      return "RETRY"
+    case LLMStreamEventKind.TextDelta:
+      return "TEXT_DELTA"
+    case LLMStreamEventKind.TokenUsage:
+      return "TOKEN_USAGE"
+    case LLMStreamEventKind.ToolCallFinish:
+      return "TOOL_CALL_FINISH"
+    case LLMStreamEventKind.ToolCallStart:
+      return "TOOL_CALL_START"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a LLMStreamEventKind name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function LlmstreamEventKindNameToValue(name: string): LLMStreamEventKind {
+  switch (name) {
+    case "RETRY":
+      return LLMStreamEventKind.Retry
+    case "TEXT_DELTA":
+      return LLMStreamEventKind.TextDelta
+    case "TOKEN_USAGE":
+      return LLMStreamEventKind.TokenUsage
+    case "TOOL_CALL_FINISH":
+      return LLMStreamEventKind.ToolCallFinish
+    case "TOOL_CALL_START":
+      return LLMStreamEventKind.ToolCallStart
+    default:
+      return name as LLMStreamEventKind
+  }
+}
 /**
  * The `LLMTokenUsageID` scalar type represents an identifier for an object of type LLMTokenUsage.
  */
@@ -2000,6 +2477,68 @@ function ModuleSourceKindNameToValue(name: string): ModuleSourceKind {
       return name as ModuleSourceKind
   }
 }
+/**
+ * Network access granted to an execution
+ */
+export enum NetworkMode {
+  /**
+   * Access to bound services, DNS, and an allow-list of hostnames, IPs and CIDRs
+   */
+  AllowList = "ALLOW_LIST",
+
+  /**
+   * No network access, except for the loopback interface
+   */
+  Offline = "OFFLINE",
+
+  /**
+   * Access to bound services and DNS only
+   */
+  ServicesOnly = "SERVICES_ONLY",
+
+  /**
+   * Full network access
+   */
+  Unrestricted = "UNRESTRICTED",
+}
+
+/**
+ * Utility function to convert a NetworkMode value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function NetworkModeValueToName(value: NetworkMode): string {
+  switch (value) {
+    case NetworkMode.AllowList:
+      return "ALLOW_LIST"
+    case NetworkMode.Offline:
+      return "OFFLINE"
+    case NetworkMode.ServicesOnly:
+      return "SERVICES_ONLY"
+    case NetworkMode.Unrestricted:
+      return "UNRESTRICTED"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a NetworkMode name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function NetworkModeNameToValue(name: string): NetworkMode {
+  switch (name) {
+    case "ALLOW_LIST":
+      return NetworkMode.AllowList
+    case "OFFLINE":
+      return NetworkMode.Offline
+    case "SERVICES_ONLY":
+      return NetworkMode.ServicesOnly
+    case "UNRESTRICTED":
+      return NetworkMode.Unrestricted
+    default:
+      return name as NetworkMode
+  }
+}
 /**
  * Transport layer network protocol associated to a port.
  */
@@ -2155,6 +2694,13 @@ export type ClientGitOpts = {
    * A service which must be started before the repo is fetched.
    */
   experimentalServiceHost?: Service
+
+  /**
+   * Partial clone filter to fetch the repository with, like "blob:none" or "blob:limit=1m".
+   *
+   * The file contents needed by a checkout are fetched when checking it out.
+   */
+  filter?: string
 }
 
 export type ClientHttpOpts = {
@@ -2277,6 +2823,50 @@ function ReturnTypeNameToValue(name: string): ReturnType {
       return name as ReturnType
   }
 }
+/**
+ * Format of a software bill of materials.
+ */
+export enum SBOMFormat {
+  /**
+   * CycloneDX 1.5 JSON document
+   */
+  Cyclonedx = "CYCLONEDX",
+
+  /**
+   * SPDX 2.3 JSON document
+   */
+  Spdx = "SPDX",
+}
+
+/**
+ * Utility function to convert a SBOMFormat value to its name so
+ * it can be uses as argument to call a exposed function.
+ */
+function SbomformatValueToName(value: SBOMFormat): string {
+  switch (value) {
+    case SBOMFormat.Cyclonedx:
+      return "CYCLONEDX"
+    case SBOMFormat.Spdx:
+      return "SPDX"
+    default:
+      return value
+  }
+}
+
+/**
+ * Utility function to convert a SBOMFormat name to its value so
+ * it can be properly used inside the module runtime.
+ */
+function SbomformatNameToValue(name: string): SBOMFormat {
+  switch (name) {
+    case "CYCLONEDX":
+      return SBOMFormat.Cyclonedx
+    case "SPDX":
+      return SBOMFormat.Spdx
+    default:
+      return name as SBOMFormat
+  }
+}
 /**
  * The `SDKConfigID` scalar type represents an identifier for an object of type SDKConfig.
  */
@@ -2314,6 +2904,17 @@ export type ServiceEndpointOpts = {
   scheme?: string
 }
 
+export type ServiceStartOpts = {
+  /**
+   * Maximum duration the service may run once started, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period.
+   *
+   * A service that was already running, e.g. for a container it is bound to, is not stopped.
+   */
+  timeout?: string
+}
+
 export type ServiceStopOpts = {
   /**
    * Immediately kill the service without waiting for a graceful exit
@@ -2337,6 +2938,13 @@ export type ServiceUpOpts = {
    * Bind each tunnel port to a random port on the host.
    */
   random?: boolean
+
+  /**
+   * Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
+   */
+  timeout?: string
 }
 
 /**
@@ -2892,6 +3500,14 @@ export class Binding extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type ContainerAttestation
+   */
+  asContainerAttestation = (): ContainerAttestation => {
+    const ctx = this._ctx.select("asContainerAttestation")
+    return new ContainerAttestation(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type Directory
    */
@@ -2940,6 +3556,22 @@ export class Binding extends BaseClient {
     return new GeneratorGroup(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type GitBlameLine
+   */
+  asGitBlameLine = (): GitBlameLine => {
+    const ctx = this._ctx.select("asGitBlameLine")
+    return new GitBlameLine(ctx)
+  }
+
+  /**
+   * Retrieve the binding value, as type GitCommit
+   */
+  asGitCommit = (): GitCommit => {
+    const ctx = this._ctx.select("asGitCommit")
+    return new GitCommit(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type GitRef
    */
@@ -2964,6 +3596,14 @@ export class Binding extends BaseClient {
     return new JSONValue(ctx)
   }
 
+  /**
+   * Retrieve the binding value, as type LLMStreamEvent
+   */
+  asLLMStreamEvent = (): LLMStreamEvent => {
+    const ctx = this._ctx.select("asLLMStreamEvent")
+    return new LLMStreamEvent(ctx)
+  }
+
   /**
    * Retrieve the binding value, as type Module
    */
@@ -3358,11 +3998,20 @@ export class Changeset extends BaseClient {
 
 export class Check extends BaseClient {
   private readonly _id?: CheckID = undefined
+  private readonly _attempts?: number = undefined
   private readonly _completed?: boolean = undefined
   private readonly _description?: string = undefined
+  private readonly _durationMs?: number = undefined
+  private readonly _errorMessage?: string = undefined
+  private readonly _logExcerpt?: string = undefined
   private readonly _name?: string = undefined
   private readonly _passed?: boolean = undefined
+  private readonly _quarantined?: boolean = undefined
   private readonly _resultEmoji?: string = undefined
+  private readonly _selectionReason?: string = undefined
+  private readonly _skipped?: boolean = undefined
+  private readonly _spanID?: string = undefined
+  private readonly _traceID?: string = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
@@ -3370,20 +4019,38 @@ export class Check extends BaseClient {
   constructor(
     ctx?: Context,
     _id?: CheckID,
+    _attempts?: number,
     _completed?: boolean,
     _description?: string,
+    _durationMs?: number,
+    _errorMessage?: string,
+    _logExcerpt?: string,
     _name?: string,
     _passed?: boolean,
+    _quarantined?: boolean,
     _resultEmoji?: string,
+    _selectionReason?: string,
+    _skipped?: boolean,
+    _spanID?: string,
+    _traceID?: string,
   ) {
     super(ctx)
 
     this._id = _id
+    this._attempts = _attempts
     this._completed = _completed
     this._description = _description
+    this._durationMs = _durationMs
+    this._errorMessage = _errorMessage
+    this._logExcerpt = _logExcerpt
     this._name = _name
     this._passed = _passed
+    this._quarantined = _quarantined
     this._resultEmoji = _resultEmoji
+    this._selectionReason = _selectionReason
+    this._skipped = _skipped
+    this._spanID = _spanID
+    this._traceID = _traceID
   }
 
   /**
@@ -3401,6 +4068,21 @@ export class Check extends BaseClient {
     return response
   }
 
+  /**
+   * The number of times the check was run, including retries
+   */
+  attempts = async (): Promise<number> => {
+    if (this._attempts) {
+      return this._attempts
+    }
+
+    const ctx = this._ctx.select("attempts")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * Whether the check completed
    */
@@ -3431,6 +4113,51 @@ export class Check extends BaseClient {
     return response
   }
 
+  /**
+   * How long the check took to run, in milliseconds
+   */
+  durationMs = async (): Promise<number> => {
+    if (this._durationMs) {
+      return this._durationMs
+    }
+
+    const ctx = this._ctx.select("durationMs")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The error the check failed with, if any
+   */
+  errorMessage = async (): Promise<string> => {
+    if (this._errorMessage) {
+      return this._errorMessage
+    }
+
+    const ctx = this._ctx.select("errorMessage")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The last lines of output of the failed command, if the check failed running one
+   */
+  logExcerpt = async (): Promise<string> => {
+    if (this._logExcerpt) {
+      return this._logExcerpt
+    }
+
+    const ctx = this._ctx.select("logExcerpt")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * Return the fully qualified name of the check
    */
@@ -3447,48 +4174,117 @@ export class Check extends BaseClient {
   }
 
   /**
-   * The original module in which the check has been defined
+   * The original module in which the check has been defined
+   */
+  originalModule = (): Module_ => {
+    const ctx = this._ctx.select("originalModule")
+    return new Module_(ctx)
+  }
+
+  /**
+   * Whether the check passed
+   */
+  passed = async (): Promise<boolean> => {
+    if (this._passed) {
+      return this._passed
+    }
+
+    const ctx = this._ctx.select("passed")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The path of the check within its module
+   */
+  path = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("path")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the check is quarantined: its failures are reported, but don't fail the group
+   */
+  quarantined = async (): Promise<boolean> => {
+    if (this._quarantined) {
+      return this._quarantined
+    }
+
+    const ctx = this._ctx.select("quarantined")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * An emoji representing the result of the check
+   */
+  resultEmoji = async (): Promise<string> => {
+    if (this._resultEmoji) {
+      return this._resultEmoji
+    }
+
+    const ctx = this._ctx.select("resultEmoji")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Execute the check
+   * @param opts.retries Number of times the check is retried if it fails
    */
-  originalModule = (): Module_ => {
-    const ctx = this._ctx.select("originalModule")
-    return new Module_(ctx)
+  run = (opts?: CheckRunOpts): Check => {
+    const ctx = this._ctx.select("run", { ...opts })
+    return new Check(ctx)
   }
 
   /**
-   * Whether the check passed
+   * Why the check was selected or skipped by affectedBy
    */
-  passed = async (): Promise<boolean> => {
-    if (this._passed) {
-      return this._passed
+  selectionReason = async (): Promise<string> => {
+    if (this._selectionReason) {
+      return this._selectionReason
     }
 
-    const ctx = this._ctx.select("passed")
+    const ctx = this._ctx.select("selectionReason")
 
-    const response: Awaited<boolean> = await ctx.execute()
+    const response: Awaited<string> = await ctx.execute()
 
     return response
   }
 
   /**
-   * The path of the check within its module
+   * Whether the check is skipped because none of its inputs are affected by the changes passed to affectedBy
    */
-  path = async (): Promise<string[]> => {
-    const ctx = this._ctx.select("path")
+  skipped = async (): Promise<boolean> => {
+    if (this._skipped) {
+      return this._skipped
+    }
 
-    const response: Awaited<string[]> = await ctx.execute()
+    const ctx = this._ctx.select("skipped")
+
+    const response: Awaited<boolean> = await ctx.execute()
 
     return response
   }
 
   /**
-   * An emoji representing the result of the check
+   * The ID of the span of the check
    */
-  resultEmoji = async (): Promise<string> => {
-    if (this._resultEmoji) {
-      return this._resultEmoji
+  spanID = async (): Promise<string> => {
+    if (this._spanID) {
+      return this._spanID
     }
 
-    const ctx = this._ctx.select("resultEmoji")
+    const ctx = this._ctx.select("spanID")
 
     const response: Awaited<string> = await ctx.execute()
 
@@ -3496,11 +4292,18 @@ export class Check extends BaseClient {
   }
 
   /**
-   * Execute the check
+   * The ID of the trace the check ran in
    */
-  run = (): Check => {
-    const ctx = this._ctx.select("run")
-    return new Check(ctx)
+  traceID = async (): Promise<string> => {
+    if (this._traceID) {
+      return this._traceID
+    }
+
+    const ctx = this._ctx.select("traceID")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
   }
 
   /**
@@ -3540,6 +4343,28 @@ export class CheckGroup extends BaseClient {
     return response
   }
 
+  /**
+   * Skip the checks whose inputs are not affected by the given changes
+   *
+   * A check's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Checks without any declared input are always selected.
+   * @param changes The changes to select checks for, relative to the context directory
+   */
+  affectedBy = (changes: Changeset): CheckGroup => {
+    const ctx = this._ctx.select("affectedBy", { changes })
+    return new CheckGroup(ctx)
+  }
+
+  /**
+   * Skip the checks whose inputs are not affected by the given changed paths
+   *
+   * Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+   * @param paths The added, modified or removed paths, relative to the context directory
+   */
+  affectedByPaths = (paths: string[]): CheckGroup => {
+    const ctx = this._ctx.select("affectedByPaths", { paths })
+    return new CheckGroup(ctx)
+  }
+
   /**
    * Return a list of individual checks and their details
    */
@@ -3556,18 +4381,26 @@ export class CheckGroup extends BaseClient {
   }
 
   /**
-   * Generate a markdown report
+   * Generate a report of the check results
+   * @param opts.format The format of the report
    */
-  report = (): File => {
-    const ctx = this._ctx.select("report")
+  report = (opts?: CheckGroupReportOpts): File => {
+    const metadata = {
+      format: { is_enum: true, value_to_name: CheckReportFormatValueToName },
+    }
+
+    const ctx = this._ctx.select("report", { ...opts, __metadata: metadata })
     return new File(ctx)
   }
 
   /**
    * Execute all selected checks
+   * @param opts.failFast Cancel the remaining checks as soon as one fails. Quarantined checks don't trigger cancellation.
+   * @param opts.retries Number of times a failed check is retried before it is reported as failed
+   * @param opts.noFail Record failed checks in the results instead of returning an error, e.g. to generate a report of the failures
    */
-  run = (): CheckGroup => {
-    const ctx = this._ctx.select("run")
+  run = (opts?: CheckGroupRunOpts): CheckGroup => {
+    const ctx = this._ctx.select("run", { ...opts })
     return new CheckGroup(ctx)
   }
 
@@ -3755,6 +4588,34 @@ export class Container extends BaseClient {
     return new File(ctx)
   }
 
+  /**
+   * The in-toto attestations that would be attached to the container image when published or exported.
+   * @param opts.sbom Include a software bill of materials of the packages installed in the container, in the given format.
+   * @param opts.provenance Include SLSA provenance of the calls that produced the container.
+   */
+  attestations = async (
+    opts?: ContainerAttestationsOpts,
+  ): Promise<ContainerAttestation[]> => {
+    type attestations = {
+      id: ContainerAttestationID
+    }
+
+    const metadata = {
+      sbom: { is_enum: true, value_to_name: SBOMFormatValueToName },
+    }
+
+    const ctx = this._ctx.select("attestations", {
+      ...opts,
+      __metadata: metadata,
+    }).select("id")
+
+    const response: Awaited<attestations[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadContainerAttestationFromID(r.id),
+    )
+  }
+
   /**
    * The combined buffered standard output and standard error stream of the last executed command
    *
@@ -3927,6 +4788,8 @@ export class Container extends BaseClient {
    *
    * Defaults to OCI, which is largely compatible with most recent container runtimes, but Docker may be needed for older runtimes without OCI support.
    * @param opts.expand Replace "${VAR}" or "$VAR" in the value of path according to the current environment variables defined in the container (e.g. "/$VAR/foo").
+   * @param opts.sbom Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+   * @param opts.provenance Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
    */
   export = async (
     path: string,
@@ -3942,6 +4805,7 @@ export class Container extends BaseClient {
         value_to_name: ImageLayerCompressionValueToName,
       },
       mediaTypes: { is_enum: true, value_to_name: ImageMediaTypesValueToName },
+      sbom: { is_enum: true, value_to_name: SBOMFormatValueToName },
     }
 
     const ctx = this._ctx.select("export", {
@@ -4025,9 +4889,12 @@ export class Container extends BaseClient {
   /**
    * Download a container image, and apply it to the container state. All previous state will be lost.
    * @param address Address of the container image to download, in standard OCI ref format. Example:"registry.dagger.io/engine:latest"
+   * @param opts.verify PEM encoded public key to verify the image signature with.
+   *
+   * If set, the image is rejected unless it has a cosign compatible signature verified by this key, attached as an OCI referrer or with the cosign tag scheme.
    */
-  from = (address: string): Container => {
-    const ctx = this._ctx.select("from", { address })
+  from = (address: string, opts?: ContainerFromOpts): Container => {
+    const ctx = this._ctx.select("from", { address, ...opts })
     return new Container(ctx)
   }
 
@@ -4129,6 +4996,12 @@ export class Container extends BaseClient {
    * @param opts.mediaTypes Use the specified media types for the published image's layers.
    *
    * Defaults to "OCI", which is compatible with most recent registries, but "Docker" may be needed for older registries without OCI support.
+   * @param opts.sign PEM encoded private key to sign the published image with.
+   *
+   * The signature is cosign compatible, and attached to the image both as an OCI referrer and with the cosign tag scheme ("sha256-<hex>.sig"), for cosign to find it with or without --experimental-oci11. Encrypted cosign keys are decrypted with signPassword.
+   * @param opts.signPassword Password of the private key to sign the published image with.
+   * @param opts.sbom Attach a software bill of materials of the packages installed in the container, in the given format, as an in-toto attestation.
+   * @param opts.provenance Attach SLSA provenance of the calls that produced the container as an in-toto attestation.
    */
   publish = async (
     address: string,
@@ -4144,6 +5017,7 @@ export class Container extends BaseClient {
         value_to_name: ImageLayerCompressionValueToName,
       },
       mediaTypes: { is_enum: true, value_to_name: ImageMediaTypesValueToName },
+      sbom: { is_enum: true, value_to_name: SBOMFormatValueToName },
     }
 
     const ctx = this._ctx.select("publish", {
@@ -4241,6 +5115,9 @@ export class Container extends BaseClient {
    * @param opts.ports List of frontend/backend port mappings to forward.
    *
    * Frontend is the port accepting traffic on the host, backend is the service port.
+   * @param opts.timeout Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
    * @param opts.args Command to run instead of the container's default command (e.g., ["go", "run", "main.go"]).
    *
    * If empty, the container's default command is used.
@@ -4287,6 +5164,15 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Limits the CPU time available to commands run in this container.
+   * @param cpus Number of CPUs the commands may use, e.g. 1.5. Set to 0 to remove the limit.
+   */
+  withCPULimit = (cpus: float): Container => {
+    const ctx = this._ctx.select("withCPULimit", { cpus })
+    return new Container(ctx)
+  }
+
   /**
    * Configures default arguments for future commands. Like CMD in Dockerfile.
    * @param args Arguments to prepend to future executions (e.g., ["-v", "--no-cache"]).
@@ -4400,10 +5286,24 @@ export class Container extends BaseClient {
    * @param opts.noInit Skip the automatic init process injected into containers by default.
    *
    * Only use this if you specifically need the command to be pid 1 in the container. Otherwise it may result in unexpected behavior. If you're not sure, you don't need this.
+   * @param opts.timeout Maximum duration the command may run, as a Go duration string (e.g. "30s", "5m").
+   *
+   * When it elapses, the command is sent SIGTERM, followed by SIGKILL after a grace period, and the exec fails with a timed out ExecError, which is never cached.
+   *
+   * A timed out exec fails regardless of "expect", even if the command handles SIGTERM and exits with an expected code. To expect a timeout, check whether the ExecError timed out.
+   * @param opts.networkMode Network access granted to the command.
+   *
+   * OFFLINE only allows the loopback interface. SERVICES_ONLY allows bound services and DNS. ALLOW_LIST additionally allows the destinations in "networkAllow". Other connections are rejected.
+   *
+   * DNS queries are not filtered, so with SERVICES_ONLY and ALLOW_LIST the command can still send data out through the names it looks up. Use OFFLINE if this must be prevented.
+   * @param opts.networkAllow Hostnames, IPv4 addresses and CIDRs the command may connect to with the ALLOW_LIST network mode. Example: ["proxy.golang.org", "10.0.0.0/8"]
+   *
+   * Hostnames are resolved once, when the command starts: connections to the addresses they resolve to afterwards (e.g. when a DNS record changes or rotates) are rejected.
    */
   withExec = (args: string[], opts?: ContainerWithExecOpts): Container => {
     const metadata = {
       expect: { is_enum: true, value_to_name: ReturnTypeValueToName },
+      networkMode: { is_enum: true, value_to_name: NetworkModeValueToName },
     }
 
     const ctx = this._ctx.select("withExec", {
@@ -4485,6 +5385,48 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Set a readiness check run when the container is started as a service. Like HEALTHCHECK in Dockerfile.
+   *
+   * The service is not considered started until the check passes, after the health checks of its exposed ports.
+   *
+   * Exactly one of args, httpPort and logPattern must be set.
+   * @param opts.args Command to run in the service container, ready once it exits zero. Example: ["pg_isready", "-U", "postgres"]
+   * @param opts.httpPort Port to send an HTTP GET request to, ready once it responds with httpStatus. Example: 8080
+   * @param opts.httpPath Path of the HTTP GET request. Example: "/healthz"
+   * @param opts.httpStatus Expected status of the HTTP response.
+   * @param opts.logPattern Regular expression matched against the lines of the service stdout, ready once a line matches. Example: "listening on .*:8080"
+   * @param opts.interval Time between checks, as a Go duration string. Example: "5s"
+   * @param opts.timeout Time after which a single check fails, as a Go duration string.
+   * @param opts.startPeriod Initialization time during which failed checks are not counted, as a Go duration string.
+   * @param opts.retries Number of consecutive failed checks after which the service fails to start.
+   */
+  withHealthcheck = (opts?: ContainerWithHealthcheckOpts): Container => {
+    const ctx = this._ctx.select("withHealthcheck", { ...opts })
+    return new Container(ctx)
+  }
+
+  /**
+   * Sets the relative block IO weight of commands run in this container.
+   * @param weight Weight between 10 and 1000, relative to other containers. Set to 0 to use the default.
+   */
+  withIOWeight = (weight: number): Container => {
+    const ctx = this._ctx.select("withIOWeight", { weight })
+    return new Container(ctx)
+  }
+
+  /**
+   * Use the HEALTHCHECK of the container image as its readiness check when started as a service.
+   *
+   * Image healthchecks are not run unless opted into, since their intervals are meant for monitoring long-running containers.
+   *
+   * Fails if the image has no healthcheck.
+   */
+  withImageHealthcheck = (): Container => {
+    const ctx = this._ctx.select("withImageHealthcheck")
+    return new Container(ctx)
+  }
+
   /**
    * Retrieves this container plus the given label.
    * @param name The name of the label (e.g., "org.opencontainers.artifact.created").
@@ -4495,6 +5437,17 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Limits the memory available to commands run in this container.
+   *
+   * A command exceeding the limit is killed, and fails with an ExecError that reports the OOM kill.
+   * @param limit Maximum memory usage, including swap, as a number of bytes or with a unit suffix (e.g. "512MiB", "4GiB"). Units are powers of 1024. Set to "0" to remove the limit.
+   */
+  withMemoryLimit = (limit: string): Container => {
+    const ctx = this._ctx.select("withMemoryLimit", { limit })
+    return new Container(ctx)
+  }
+
   /**
    * Retrieves this container plus a cache volume mounted at the given path.
    * @param path Location of the cache directory (e.g., "/root/.npm").
@@ -4630,6 +5583,15 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Limits the number of processes that commands run in this container may create.
+   * @param limit Maximum number of processes. Set to 0 to remove the limit.
+   */
+  withPidsLimit = (limit: number): Container => {
+    const ctx = this._ctx.select("withPidsLimit", { limit })
+    return new Container(ctx)
+  }
+
   /**
    * Attach credentials for future publishing to a registry. Use in combination with publish
    * @param address The image address that needs authentication. Same format as "docker push". Example: "registry.dagger.io/dagger:latest"
@@ -4830,6 +5792,14 @@ export class Container extends BaseClient {
     return new Container(ctx)
   }
 
+  /**
+   * Retrieves this container without its readiness check.
+   */
+  withoutHealthcheck = (): Container => {
+    const ctx = this._ctx.select("withoutHealthcheck")
+    return new Container(ctx)
+  }
+
   /**
    * Retrieves this container minus the given environment label.
    * @param name The name of the label to remove (e.g., "org.opencontainers.artifact.created").
@@ -4930,6 +5900,76 @@ export class Container extends BaseClient {
   }
 }
 
+/**
+ * An in-toto attestation of a container image, such as an SBOM or SLSA provenance.
+ */
+export class ContainerAttestation extends BaseClient {
+  private readonly _id?: ContainerAttestationID = undefined
+  private readonly _predicate?: JSON = undefined
+  private readonly _predicateType?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: ContainerAttestationID,
+    _predicate?: JSON,
+    _predicateType?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._predicate = _predicate
+    this._predicateType = _predicateType
+  }
+
+  /**
+   * A unique identifier for this ContainerAttestation.
+   */
+  id = async (): Promise<ContainerAttestationID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<ContainerAttestationID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The predicate of the attestation, as a JSON document.
+   */
+  predicate = async (): Promise<JSON> => {
+    if (this._predicate) {
+      return this._predicate
+    }
+
+    const ctx = this._ctx.select("predicate")
+
+    const response: Awaited<JSON> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The in-toto predicate type of the attestation.
+   */
+  predicateType = async (): Promise<string> => {
+    if (this._predicateType) {
+      return this._predicateType
+    }
+
+    const ctx = this._ctx.select("predicateType")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+}
+
 /**
  * Reflective module API provided to functions at runtime.
  */
@@ -6479,6 +7519,38 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type ContainerAttestation in the environment
+   * @param name The name of the binding
+   * @param value The ContainerAttestation value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withContainerAttestationInput = (
+    name: string,
+    value: ContainerAttestation,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withContainerAttestationInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired ContainerAttestation output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withContainerAttestationOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withContainerAttestationOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type Container in the environment
    * @param name The name of the binding
@@ -6679,6 +7751,67 @@ export class Env extends BaseClient {
     return new Env(ctx)
   }
 
+  /**
+   * Create or update a binding of type GitBlameLine in the environment
+   * @param name The name of the binding
+   * @param value The GitBlameLine value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withGitBlameLineInput = (
+    name: string,
+    value: GitBlameLine,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withGitBlameLineInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired GitBlameLine output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withGitBlameLineOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withGitBlameLineOutput", {
+      name,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Create or update a binding of type GitCommit in the environment
+   * @param name The name of the binding
+   * @param value The GitCommit value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withGitCommitInput = (
+    name: string,
+    value: GitCommit,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withGitCommitInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired GitCommit output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withGitCommitOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withGitCommitOutput", { name, description })
+    return new Env(ctx)
+  }
+
   /**
    * Create or update a binding of type GitRef in the environment
    * @param name The name of the binding
@@ -6742,12 +7875,41 @@ export class Env extends BaseClient {
    * @param value The JSONValue value to assign to the binding
    * @param description The purpose of the input
    */
-  withJSONValueInput = (
+  withJSONValueInput = (
+    name: string,
+    value: JSONValue,
+    description: string,
+  ): Env => {
+    const ctx = this._ctx.select("withJSONValueInput", {
+      name,
+      value,
+      description,
+    })
+    return new Env(ctx)
+  }
+
+  /**
+   * Declare a desired JSONValue output to be assigned in the environment
+   * @param name The name of the binding
+   * @param description A description of the desired value of the binding
+   */
+  withJSONValueOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withJSONValueOutput", { name, description })
+    return new Env(ctx)
+  }
+
+  /**
+   * Create or update a binding of type LLMStreamEvent in the environment
+   * @param name The name of the binding
+   * @param value The LLMStreamEvent value to assign to the binding
+   * @param description The purpose of the input
+   */
+  withLLMStreamEventInput = (
     name: string,
-    value: JSONValue,
+    value: LLMStreamEvent,
     description: string,
   ): Env => {
-    const ctx = this._ctx.select("withJSONValueInput", {
+    const ctx = this._ctx.select("withLLMStreamEventInput", {
       name,
       value,
       description,
@@ -6756,12 +7918,15 @@ export class Env extends BaseClient {
   }
 
   /**
-   * Declare a desired JSONValue output to be assigned in the environment
+   * Declare a desired LLMStreamEvent output to be assigned in the environment
    * @param name The name of the binding
    * @param description A description of the desired value of the binding
    */
-  withJSONValueOutput = (name: string, description: string): Env => {
-    const ctx = this._ctx.select("withJSONValueOutput", { name, description })
+  withLLMStreamEventOutput = (name: string, description: string): Env => {
+    const ctx = this._ctx.select("withLLMStreamEventOutput", {
+      name,
+      description,
+    })
     return new Env(ctx)
   }
 
@@ -7631,6 +8796,27 @@ export class File extends BaseClient {
     return new JSONValue(ctx)
   }
 
+  /**
+   * Return the lines of this file with the commit that last changed them.
+   *
+   * The file must be in a git checkout with its .git directory, like the tree of a git ref. Lines of shallow checkouts are attributed to their oldest commit at most.
+   *
+   * Files with a filter attribute, partial clones, and repositories whose git directory is outside of the directory are not supported.
+   */
+  blame = async (): Promise<GitBlameLine[]> => {
+    type blame = {
+      id: GitBlameLineID
+    }
+
+    const ctx = this._ctx.select("blame").select("id")
+
+    const response: Awaited<blame[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadGitBlameLineFromID(r.id),
+    )
+  }
+
   /**
    * Change the owner of the file recursively.
    * @param owner A user:group to set for the file.
@@ -8520,6 +9706,8 @@ export class Generator extends BaseClient {
   private readonly _description?: string = undefined
   private readonly _isEmpty?: boolean = undefined
   private readonly _name?: string = undefined
+  private readonly _selectionReason?: string = undefined
+  private readonly _skipped?: boolean = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
@@ -8531,6 +9719,8 @@ export class Generator extends BaseClient {
     _description?: string,
     _isEmpty?: boolean,
     _name?: string,
+    _selectionReason?: string,
+    _skipped?: boolean,
   ) {
     super(ctx)
 
@@ -8539,6 +9729,8 @@ export class Generator extends BaseClient {
     this._description = _description
     this._isEmpty = _isEmpty
     this._name = _name
+    this._selectionReason = _selectionReason
+    this._skipped = _skipped
   }
 
   /**
@@ -8651,6 +9843,36 @@ export class Generator extends BaseClient {
     return new Generator(ctx)
   }
 
+  /**
+   * Why the generator was selected or skipped by affectedBy
+   */
+  selectionReason = async (): Promise<string> => {
+    if (this._selectionReason) {
+      return this._selectionReason
+    }
+
+    const ctx = this._ctx.select("selectionReason")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the generator is skipped because none of its inputs are affected by the changes passed to affectedBy
+   */
+  skipped = async (): Promise<boolean> => {
+    if (this._skipped) {
+      return this._skipped
+    }
+
+    const ctx = this._ctx.select("skipped")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * Call the provided function with current Generator.
    *
@@ -8690,6 +9912,28 @@ export class GeneratorGroup extends BaseClient {
     return response
   }
 
+  /**
+   * Skip the generators whose inputs are not affected by the given changes
+   *
+   * A generator's inputs are the default paths of the contextual arguments of the functions called to reach it, with their ignore patterns applied, and the config and source code of the modules defining them and of their local dependencies. Generators without any declared input are always selected.
+   * @param changes The changes to select generators for, relative to the context directory
+   */
+  affectedBy = (changes: Changeset): GeneratorGroup => {
+    const ctx = this._ctx.select("affectedBy", { changes })
+    return new GeneratorGroup(ctx)
+  }
+
+  /**
+   * Skip the generators whose inputs are not affected by the given changed paths
+   *
+   * Like affectedBy, for changes computed by the caller, e.g. from a git diff on the host.
+   * @param paths The added, modified or removed paths, relative to the context directory
+   */
+  affectedByPaths = (paths: string[]): GeneratorGroup => {
+    const ctx = this._ctx.select("affectedByPaths", { paths })
+    return new GeneratorGroup(ctx)
+  }
+
   /**
    * The combined changes from the generators execution
    *
@@ -8753,8 +9997,296 @@ export class GeneratorGroup extends BaseClient {
    *
    * This is useful for reusability and readability by not breaking the calling chain.
    */
-  with = (arg: (param: GeneratorGroup) => GeneratorGroup) => {
-    return arg(this)
+  with = (arg: (param: GeneratorGroup) => GeneratorGroup) => {
+    return arg(this)
+  }
+}
+
+/**
+ * A line of a file, with the commit that last changed it.
+ */
+export class GitBlameLine extends BaseClient {
+  private readonly _id?: GitBlameLineID = undefined
+  private readonly _authorEmail?: string = undefined
+  private readonly _authorName?: string = undefined
+  private readonly _commit?: string = undefined
+  private readonly _content?: string = undefined
+  private readonly _date?: string = undefined
+  private readonly _line?: number = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: GitBlameLineID,
+    _authorEmail?: string,
+    _authorName?: string,
+    _commit?: string,
+    _content?: string,
+    _date?: string,
+    _line?: number,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._authorEmail = _authorEmail
+    this._authorName = _authorName
+    this._commit = _commit
+    this._content = _content
+    this._date = _date
+    this._line = _line
+  }
+
+  /**
+   * A unique identifier for this GitBlameLine.
+   */
+  id = async (): Promise<GitBlameLineID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<GitBlameLineID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The email of the author of the commit.
+   */
+  authorEmail = async (): Promise<string> => {
+    if (this._authorEmail) {
+      return this._authorEmail
+    }
+
+    const ctx = this._ctx.select("authorEmail")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The name of the author of the commit.
+   */
+  authorName = async (): Promise<string> => {
+    if (this._authorName) {
+      return this._authorName
+    }
+
+    const ctx = this._ctx.select("authorName")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The id of the commit that last changed the line, or all zeroes if it isn't committed yet.
+   */
+  commit = async (): Promise<string> => {
+    if (this._commit) {
+      return this._commit
+    }
+
+    const ctx = this._ctx.select("commit")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The content of the line.
+   */
+  content = async (): Promise<string> => {
+    if (this._content) {
+      return this._content
+    }
+
+    const ctx = this._ctx.select("content")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The date the commit was authored, in RFC 3339 format.
+   */
+  date = async (): Promise<string> => {
+    if (this._date) {
+      return this._date
+    }
+
+    const ctx = this._ctx.select("date")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The line number, starting at 1.
+   */
+  line = async (): Promise<number> => {
+    if (this._line) {
+      return this._line
+    }
+
+    const ctx = this._ctx.select("line")
+
+    const response: Awaited<number> = await ctx.execute()
+
+    return response
+  }
+}
+
+/**
+ * A commit in the history of a git ref.
+ */
+export class GitCommit extends BaseClient {
+  private readonly _id?: GitCommitID = undefined
+  private readonly _authorEmail?: string = undefined
+  private readonly _authorName?: string = undefined
+  private readonly _commit?: string = undefined
+  private readonly _date?: string = undefined
+  private readonly _message?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: GitCommitID,
+    _authorEmail?: string,
+    _authorName?: string,
+    _commit?: string,
+    _date?: string,
+    _message?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._authorEmail = _authorEmail
+    this._authorName = _authorName
+    this._commit = _commit
+    this._date = _date
+    this._message = _message
+  }
+
+  /**
+   * A unique identifier for this GitCommit.
+   */
+  id = async (): Promise<GitCommitID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<GitCommitID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The email of the author of the commit.
+   */
+  authorEmail = async (): Promise<string> => {
+    if (this._authorEmail) {
+      return this._authorEmail
+    }
+
+    const ctx = this._ctx.select("authorEmail")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The name of the author of the commit.
+   */
+  authorName = async (): Promise<string> => {
+    if (this._authorName) {
+      return this._authorName
+    }
+
+    const ctx = this._ctx.select("authorName")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The commit id.
+   */
+  commit = async (): Promise<string> => {
+    if (this._commit) {
+      return this._commit
+    }
+
+    const ctx = this._ctx.select("commit")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The date the commit was authored, in RFC 3339 format.
+   */
+  date = async (): Promise<string> => {
+    if (this._date) {
+      return this._date
+    }
+
+    const ctx = this._ctx.select("date")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The paths of the files changed by the commit, compared to its first parent.
+   */
+  files = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("files")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The full message of the commit.
+   */
+  message = async (): Promise<string> => {
+    if (this._message) {
+      return this._message
+    }
+
+    const ctx = this._ctx.select("message")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The ids of the parents of the commit.
+   */
+  parents = async (): Promise<string[]> => {
+    const ctx = this._ctx.select("parents")
+
+    const response: Awaited<string[]> = await ctx.execute()
+
+    return response
   }
 }
 
@@ -8764,17 +10296,28 @@ export class GeneratorGroup extends BaseClient {
 export class GitRef extends BaseClient {
   private readonly _id?: GitRefID = undefined
   private readonly _commit?: string = undefined
+  private readonly _push?: string = undefined
   private readonly _ref?: string = undefined
+  private readonly _signer?: string = undefined
 
   /**
    * Constructor is used for internal usage only, do not create object from it.
    */
-  constructor(ctx?: Context, _id?: GitRefID, _commit?: string, _ref?: string) {
+  constructor(
+    ctx?: Context,
+    _id?: GitRefID,
+    _commit?: string,
+    _push?: string,
+    _ref?: string,
+    _signer?: string,
+  ) {
     super(ctx)
 
     this._id = _id
     this._commit = _commit
+    this._push = _push
     this._ref = _ref
+    this._signer = _signer
   }
 
   /**
@@ -8816,6 +10359,60 @@ export class GitRef extends BaseClient {
     return new GitRef(ctx)
   }
 
+  /**
+   * Return the changes from this ref to another ref, like "git diff".
+   *
+   * The .git directory isn't part of the changes.
+   * @param other The ref to compare this ref against.
+   */
+  diff = (other: GitRef): Changeset => {
+    const ctx = this._ctx.select("diff", { other })
+    return new Changeset(ctx)
+  }
+
+  /**
+   * The history of this ref, most recent commits first.
+   * @param opts.paths Only include the commits changing these paths (e.g., ["docs", "README.md"]).
+   * @param opts.limit The maximum number of commits to return. All the commits are returned if 0.
+   * @param opts.since Only include the commits more recent than a date, in any format git understands (e.g., "2024-01-31" or "2 weeks ago").
+   */
+  log = async (opts?: GitRefLogOpts): Promise<GitCommit[]> => {
+    type log = {
+      id: GitCommitID
+    }
+
+    const ctx = this._ctx.select("log", { ...opts }).select("id")
+
+    const response: Awaited<log[]> = await ctx.execute()
+
+    return response.map((r) => new Client(ctx.copy()).loadGitCommitFromID(r.id))
+  }
+
+  /**
+   * Push the commit of this ref to a remote repository.
+   *
+   * Returns the updated remote ref.
+   * @param remote URL of the remote repository.
+   * @param opts.ref The remote ref to update (e.g., "refs/heads/fix" or "fix" for a branch). Defaults to the name of this ref.
+   * @param opts.force Update the remote ref even if it isn't an ancestor of the pushed commit.
+   * @param opts.sshKnownHosts Set SSH known hosts
+   * @param opts.sshAuthSocket Set SSH auth socket
+   * @param opts.httpAuthUsername Username used to populate the password during basic HTTP Authorization
+   * @param opts.httpAuthToken Secret used to populate the password during basic HTTP Authorization
+   * @param opts.httpAuthHeader Secret used to populate the Authorization HTTP header
+   */
+  push = async (remote: string, opts?: GitRefPushOpts): Promise<string> => {
+    if (this._push) {
+      return this._push
+    }
+
+    const ctx = this._ctx.select("push", { remote, ...opts })
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * The resolved ref name at this ref.
    */
@@ -8831,16 +10428,53 @@ export class GitRef extends BaseClient {
     return response
   }
 
+  /**
+   * The identity of the signer of this ref, as verified by "verify": the principal of an SSH key, or the user ID of a GPG key.
+   */
+  signer = async (): Promise<string> => {
+    if (this._signer) {
+      return this._signer
+    }
+
+    const ctx = this._ctx.select("signer")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
   /**
    * The filesystem tree at this ref.
    * @param opts.discardGitDir Set to true to discard .git directory.
    * @param opts.depth The depth of the tree to fetch.
+   * @param opts.sparse Directories to check out, in git's sparse checkout cone mode (e.g., ["services/api", "libs"]).
+   *
+   * Files at the root of the repository and in the parents of these directories are checked out too.
+   *
+   * Patterns with wildcards or negations (e.g., ["*.go", "!vendor/"]) are checked out in non-cone mode instead, where only the files matching them, like in .gitignore files, are checked out.
+   *
+   * Without .git directory, the tree only changes when the checked out files change.
+   * @param opts.lfs Set to true to download the Git LFS objects of the checked out files.
    */
   tree = (opts?: GitRefTreeOpts): Directory => {
     const ctx = this._ctx.select("tree", { ...opts })
     return new Directory(ctx)
   }
 
+  /**
+   * Verify the signature of this ref, failing unless it's signed by one of the trusted signers.
+   *
+   * Annotated tags are verified with the signature of the tag, other refs with the signature of their commit. Both SSH and GPG signatures are supported.
+   *
+   * Returns the verified ref, with its signer.
+   * @param opts.allowedSigners An SSH allowed signers file, trusting SSH keys for their principals (see ssh-keygen(1)).
+   * @param opts.keys Trusted public keys: armored GPG public keys, or SSH public keys trusted for the principal in their comment.
+   */
+  verify = (opts?: GitRefVerifyOpts): GitRef => {
+    const ctx = this._ctx.select("verify", { ...opts })
+    return new GitRef(ctx)
+  }
+
   /**
    * Call the provided function with current GitRef.
    *
@@ -8981,6 +10615,32 @@ export class GitRepository extends BaseClient {
 
     return response
   }
+
+  /**
+   * Commit changes on top of HEAD, returning a repository whose HEAD is the new commit.
+   *
+   * The returned repository only has the new commit and its parent: pushing it requires the remote to have the parent.
+   * @param changes The changes to commit, applied to the tree of HEAD.
+   * @param message The commit message.
+   * @param author The author and committer of the commit (e.g., "Dagger Bot <bot@example.com>").
+   */
+  withCommit = (
+    changes: Changeset,
+    message: string,
+    author: string,
+  ): GitRepository => {
+    const ctx = this._ctx.select("withCommit", { changes, message, author })
+    return new GitRepository(ctx)
+  }
+
+  /**
+   * Call the provided function with current GitRepository.
+   *
+   * This is useful for reusability and readability by not breaking the calling chain.
+   */
+  with = (arg: (param: GitRepository) => GitRepository) => {
+    return arg(this)
+  }
 }
 
 /**
@@ -9552,6 +11212,16 @@ export class LLM extends BaseClient {
     return new Binding(ctx)
   }
 
+  /**
+   * Return the cassette recorded by the LLM, to replay with withCassette
+   *
+   * Dagger secrets are scrubbed from it, as they are from the output of commands. Other sensitive data in the conversation is kept as is.
+   */
+  cassette = (): File => {
+    const ctx = this._ctx.select("cassette")
+    return new File(ctx)
+  }
+
   /**
    * return the LLM's current environment
    */
@@ -9665,6 +11335,26 @@ export class LLM extends BaseClient {
     return new Client(ctx.copy()).loadLLMFromID(response)
   }
 
+  /**
+   * Synchronize LLM state in the background, and return the events emitted while doing so as soon as there are any: text generated by the model, tool calls, and token usage
+   *
+   * To render progress as it happens, call it repeatedly with the number of events received so far, until it returns no events.
+   * @param opts.after The number of events to skip, i.e. the number of events received from previous calls
+   */
+  stream = async (opts?: LLMStreamOpts): Promise<LLMStreamEvent[]> => {
+    type stream = {
+      id: LLMStreamEventID
+    }
+
+    const ctx = this._ctx.select("stream", { ...opts }).select("id")
+
+    const response: Awaited<stream[]> = await ctx.execute()
+
+    return response.map((r) =>
+      new Client(ctx.copy()).loadLLMStreamEventFromID(r.id),
+    )
+  }
+
   /**
    * synchronize LLM state
    */
@@ -9714,6 +11404,39 @@ export class LLM extends BaseClient {
     return new LLM(ctx)
   }
 
+  /**
+   * Limit the tokens and estimated cost of the LLM.
+   *
+   * When a limit is exceeded, evaluating the LLM fails with an error of type LLM_BUDGET_EXCEEDED.
+   * @param opts.maxTokens The maximum number of tokens, input and output, summed over all API calls. 0 means unlimited.
+   * @param opts.maxCost The maximum estimated cost in USD, based on the price of the model. 0 means unlimited.
+   *
+   * Evaluating the LLM fails if the price of the model is unknown.
+   */
+  withBudget = (opts?: LLMWithBudgetOpts): LLM => {
+    const ctx = this._ctx.select("withBudget", { ...opts })
+    return new LLM(ctx)
+  }
+
+  /**
+   * Record the requests sent to the model with their responses in a cassette, or replay them from a cassette.
+   *
+   * Replaying a cassette doesn't call the model, which makes LLM tests hermetic. Requests that weren't recorded fail the evaluation.
+   * @param opts.file The cassette to replay. Required in REPLAY mode.
+   * @param opts.mode Whether to record or replay the cassette
+   */
+  withCassette = (opts?: LLMWithCassetteOpts): LLM => {
+    const metadata = {
+      mode: { is_enum: true, value_to_name: LLMCassetteModeValueToName },
+    }
+
+    const ctx = this._ctx.select("withCassette", {
+      ...opts,
+      __metadata: metadata,
+    })
+    return new LLM(ctx)
+  }
+
   /**
    * allow the LLM to interact with an environment via MCP
    */
@@ -9810,10 +11533,179 @@ export class LLM extends BaseClient {
   }
 }
 
+/**
+ * An event emitted while an LLM is evaluated
+ */
+export class LLMStreamEvent extends BaseClient {
+  private readonly _id?: LLMStreamEventID = undefined
+  private readonly _kind?: LLMStreamEventKind = undefined
+  private readonly _text?: string = undefined
+  private readonly _toolArguments?: string = undefined
+  private readonly _toolCallID?: string = undefined
+  private readonly _toolErrored?: boolean = undefined
+  private readonly _toolName?: string = undefined
+  private readonly _toolResult?: string = undefined
+
+  /**
+   * Constructor is used for internal usage only, do not create object from it.
+   */
+  constructor(
+    ctx?: Context,
+    _id?: LLMStreamEventID,
+    _kind?: LLMStreamEventKind,
+    _text?: string,
+    _toolArguments?: string,
+    _toolCallID?: string,
+    _toolErrored?: boolean,
+    _toolName?: string,
+    _toolResult?: string,
+  ) {
+    super(ctx)
+
+    this._id = _id
+    this._kind = _kind
+    this._text = _text
+    this._toolArguments = _toolArguments
+    this._toolCallID = _toolCallID
+    this._toolErrored = _toolErrored
+    this._toolName = _toolName
+    this._toolResult = _toolResult
+  }
+
+  /**
+   * A unique identifier for this LLMStreamEvent.
+   */
+  id = async (): Promise<LLMStreamEventID> => {
+    if (this._id) {
+      return this._id
+    }
+
+    const ctx = this._ctx.select("id")
+
+    const response: Awaited<LLMStreamEventID> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The kind of event.
+   */
+  kind = async (): Promise<LLMStreamEventKind> => {
+    if (this._kind) {
+      return this._kind
+    }
+
+    const ctx = this._ctx.select("kind")
+
+    const response: Awaited<LLMStreamEventKind> = await ctx.execute()
+
+    return LLMStreamEventKindNameToValue(response)
+  }
+
+  /**
+   * The text generated by the model, for TEXT_DELTA events.
+   */
+  text = async (): Promise<string> => {
+    if (this._text) {
+      return this._text
+    }
+
+    const ctx = this._ctx.select("text")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The token usage of the model reply, for TOKEN_USAGE events.
+   */
+  tokenUsage = (): LLMTokenUsage => {
+    const ctx = this._ctx.select("tokenUsage")
+    return new LLMTokenUsage(ctx)
+  }
+
+  /**
+   * The JSON-encoded arguments of the tool call, for TOOL_CALL_START events.
+   */
+  toolArguments = async (): Promise<string> => {
+    if (this._toolArguments) {
+      return this._toolArguments
+    }
+
+    const ctx = this._ctx.select("toolArguments")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The ID of the tool call, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+   */
+  toolCallID = async (): Promise<string> => {
+    if (this._toolCallID) {
+      return this._toolCallID
+    }
+
+    const ctx = this._ctx.select("toolCallID")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * Whether the tool call failed, for TOOL_CALL_FINISH events.
+   */
+  toolErrored = async (): Promise<boolean> => {
+    if (this._toolErrored) {
+      return this._toolErrored
+    }
+
+    const ctx = this._ctx.select("toolErrored")
+
+    const response: Awaited<boolean> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The name of the tool, for TOOL_CALL_START and TOOL_CALL_FINISH events.
+   */
+  toolName = async (): Promise<string> => {
+    if (this._toolName) {
+      return this._toolName
+    }
+
+    const ctx = this._ctx.select("toolName")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+
+  /**
+   * The result of the tool call, for TOOL_CALL_FINISH events.
+   */
+  toolResult = async (): Promise<string> => {
+    if (this._toolResult) {
+      return this._toolResult
+    }
+
+    const ctx = this._ctx.select("toolResult")
+
+    const response: Awaited<string> = await ctx.execute()
+
+    return response
+  }
+}
+
 export class LLMTokenUsage extends BaseClient {
   private readonly _id?: LLMTokenUsageID = undefined
   private readonly _cachedTokenReads?: number = undefined
   private readonly _cachedTokenWrites?: number = undefined
+  private readonly _cost?: float = undefined
   private readonly _inputTokens?: number = undefined
   private readonly _outputTokens?: number = undefined
   private readonly _totalTokens?: number = undefined
@@ -9826,6 +11718,7 @@ export class LLMTokenUsage extends BaseClient {
     _id?: LLMTokenUsageID,
     _cachedTokenReads?: number,
     _cachedTokenWrites?: number,
+    _cost?: float,
     _inputTokens?: number,
     _outputTokens?: number,
     _totalTokens?: number,
@@ -9835,6 +11728,7 @@ export class LLMTokenUsage extends BaseClient {
     this._id = _id
     this._cachedTokenReads = _cachedTokenReads
     this._cachedTokenWrites = _cachedTokenWrites
+    this._cost = _cost
     this._inputTokens = _inputTokens
     this._outputTokens = _outputTokens
     this._totalTokens = _totalTokens
@@ -9876,6 +11770,21 @@ export class LLMTokenUsage extends BaseClient {
 
     return response
   }
+
+  /**
+   * The estimated cost in USD, if the price of the model is known.
+   */
+  cost = async (): Promise<float> => {
+    if (this._cost) {
+      return this._cost
+    }
+
+    const ctx = this._ctx.select("cost")
+
+    const response: Awaited<float> = await ctx.execute()
+
+    return response
+  }
   inputTokens = async (): Promise<number> => {
     if (this._inputTokens) {
       return this._inputTokens
@@ -11524,6 +13433,9 @@ export class Client extends BaseClient {
    * @param opts.httpAuthToken Secret used to populate the password during basic HTTP Authorization
    * @param opts.httpAuthHeader Secret used to populate the Authorization HTTP header
    * @param opts.experimentalServiceHost A service which must be started before the repo is fetched.
+   * @param opts.filter Partial clone filter to fetch the repository with, like "blob:none" or "blob:limit=1m".
+   *
+   * The file contents needed by a checkout are fetched when checking it out.
    */
   git = (url: string, opts?: ClientGitOpts): GitRepository => {
     const ctx = this._ctx.select("git", { url, ...opts })
@@ -11626,6 +13538,16 @@ export class Client extends BaseClient {
     return new Cloud(ctx)
   }
 
+  /**
+   * Load a ContainerAttestation from its ID.
+   */
+  loadContainerAttestationFromID = (
+    id: ContainerAttestationID,
+  ): ContainerAttestation => {
+    const ctx = this._ctx.select("loadContainerAttestationFromID", { id })
+    return new ContainerAttestation(ctx)
+  }
+
   /**
    * Load a Container from its ID.
    */
@@ -11814,6 +13736,22 @@ export class Client extends BaseClient {
     return new GeneratorGroup(ctx)
   }
 
+  /**
+   * Load a GitBlameLine from its ID.
+   */
+  loadGitBlameLineFromID = (id: GitBlameLineID): GitBlameLine => {
+    const ctx = this._ctx.select("loadGitBlameLineFromID", { id })
+    return new GitBlameLine(ctx)
+  }
+
+  /**
+   * Load a GitCommit from its ID.
+   */
+  loadGitCommitFromID = (id: GitCommitID): GitCommit => {
+    const ctx = this._ctx.select("loadGitCommitFromID", { id })
+    return new GitCommit(ctx)
+  }
+
   /**
    * Load a GitRef from its ID.
    */
@@ -11870,6 +13808,14 @@ export class Client extends BaseClient {
     return new LLM(ctx)
   }
 
+  /**
+   * Load a LLMStreamEvent from its ID.
+   */
+  loadLLMStreamEventFromID = (id: LLMStreamEventID): LLMStreamEvent => {
+    const ctx = this._ctx.select("loadLLMStreamEventFromID", { id })
+    return new LLMStreamEvent(ctx)
+  }
+
   /**
    * Load a LLMTokenUsage from its ID.
    */
@@ -12673,9 +14619,14 @@ export class Service extends BaseClient {
    * Start the service and wait for its health checks to succeed.
    *
    * Services bound to a Container do not need to be manually started.
+   * @param opts.timeout Maximum duration the service may run once started, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period.
+   *
+   * A service that was already running, e.g. for a container it is bound to, is not stopped.
    */
-  start = async (): Promise<Service> => {
-    const ctx = this._ctx.select("start")
+  start = async (opts?: ServiceStartOpts): Promise<Service> => {
+    const ctx = this._ctx.select("start", { ...opts })
 
     const response: Awaited<ServiceID> = await ctx.execute()
 
@@ -12715,6 +14666,9 @@ export class Service extends BaseClient {
    *
    * Frontend is the port accepting traffic on the host, backend is the service port.
    * @param opts.random Bind each tunnel port to a random port on the host.
+   * @param opts.timeout Maximum duration to keep the tunnel and service up, as a Go duration string (e.g. "10m").
+   *
+   * When it elapses, the service is sent SIGTERM, followed by SIGKILL after a grace period, and an error is returned.
    */
   up = async (opts?: ServiceUpOpts): Promise<void> => {
     if (this._up) {
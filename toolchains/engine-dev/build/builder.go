@@ -109,7 +109,9 @@ func (build *Builder) Engine(ctx context.Context) (*dagger.Container, error) {
 		"ca-certificates",
 		"mount", "umount", "posix-libc-utils", "coreutils",
 		// for git
-		"git", "openssh-client",
+		"git", "git-lfs", "openssh-client",
+		// for GitRef.verify
+		"openssh-keygen", "gnupg",
 		// for compression/decompression, containerd prefers igzip from the isa-l package as it's fastest
 		"isa-l", "pigz", "xz",
 		// for CNI (use nft variants for compatibility with kernels lacking legacy xtables)
@@ -33,10 +33,14 @@ type GitCLI struct {
 	sshAuthSock   string
 	sshKnownHosts string
 
+	gpgHome string
+
 	ignoreError bool
 	config      map[string]string
 
 	indexFile string
+
+	noLazyFetch bool
 }
 
 // Option provides a variadic option for configuring the git client.
@@ -107,6 +111,14 @@ func WithSSHKnownHosts(sshKnownHosts string) Option {
 	}
 }
 
+// WithGPGHome sets the GnuPG home directory, with the keys to verify
+// signatures with.
+func WithGPGHome(gpgHome string) Option {
+	return func(b *GitCLI) {
+		b.gpgHome = gpgHome
+	}
+}
+
 // WithIgnoreError ignores all errors from the command.
 func WithIgnoreError() Option {
 	return func(b *GitCLI) {
@@ -179,6 +191,14 @@ func WithIndexFile(indexFile string) Option {
 	}
 }
 
+// WithNoLazyFetch keeps git from fetching the objects missing from a partial
+// clone from its promisor remotes.
+func WithNoLazyFetch() Option {
+	return func(b *GitCLI) {
+		b.noLazyFetch = true
+	}
+}
+
 // New initializes a new git client
 func NewGitCLI(opts ...Option) *GitCLI {
 	c := &GitCLI{}
@@ -282,6 +302,9 @@ func (cli *GitCLI) Run(ctx context.Context, args ...string) (_ []byte, rerr erro
 	if cli.sshAuthSock != "" {
 		cmd.Env = append(cmd.Env, "SSH_AUTH_SOCK="+cli.sshAuthSock)
 	}
+	if cli.gpgHome != "" {
+		cmd.Env = append(cmd.Env, "GNUPGHOME="+cli.gpgHome)
+	}
 
 	if len(cli.config) > 0 {
 		cmd.Env = MergeGitConfigEnv(cmd.Env, cli.config)
@@ -289,6 +312,9 @@ func (cli *GitCLI) Run(ctx context.Context, args ...string) (_ []byte, rerr erro
 	if cli.indexFile != "" {
 		cmd.Env = append(cmd.Env, "GIT_INDEX_FILE="+cli.indexFile)
 	}
+	if cli.noLazyFetch {
+		cmd.Env = append(cmd.Env, "GIT_NO_LAZY_FETCH=1")
+	}
 
 	var err error
 	if cli.exec != nil {
@@ -0,0 +1,32 @@
+package sbom
+
+import (
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+const apkInstalledPath = "/lib/apk/db/installed"
+
+func scanApk(root string, osRelease osRelease) ([]Package, error) {
+	db, err := readFile(root, apkInstalledPath)
+	if err != nil || db == nil {
+		return nil, err
+	}
+	var pkgs []Package
+	for _, para := range paragraphs(db, ":") {
+		name := para["P"]
+		if name == "" {
+			continue
+		}
+		pkgs = append(pkgs, Package{
+			Type:      packageurl.TypeApk,
+			Namespace: osRelease.namespace("alpine"),
+			Name:      name,
+			Version:   para["V"],
+			Arch:      para["A"],
+			License:   para["L"],
+			Source:    para["o"],
+			Distro:    osRelease.distro(),
+		})
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,89 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const cycloneDXSpecVersion = "1.5"
+
+type cycloneDXBOM struct {
+	BOMFormat    string               `json:"bomFormat"`
+	SpecVersion  string               `json:"specVersion"`
+	SerialNumber string               `json:"serialNumber"`
+	Version      int                  `json:"version"`
+	Metadata     cycloneDXMetadata    `json:"metadata"`
+	Components   []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cycloneDXComponent `json:"components"`
+	} `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXComponent struct {
+	Type       string              `json:"type"`
+	BOMRef     string              `json:"bom-ref,omitempty"`
+	Name       string              `json:"name"`
+	Version    string              `json:"version,omitempty"`
+	PURL       string              `json:"purl,omitempty"`
+	Licenses   []cycloneDXLicense  `json:"licenses,omitempty"`
+	Properties []cycloneDXProperty `json:"properties,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+type cycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// CycloneDX encodes packages as a CycloneDX 1.5 JSON BOM of a container
+// named name.
+func CycloneDX(name string, pkgs []Package, created time.Time) ([]byte, error) {
+	digest := packagesDigest(pkgs)
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: cycloneDXSpecVersion,
+		// a deterministic name-based UUID, derived from the packages
+		SerialNumber: fmt.Sprintf("urn:uuid:%s-%s-5%s-8%s-%s", digest[0:8], digest[8:12], digest[13:16], digest[17:20], digest[20:32]),
+		Version:      1,
+		Components:   []cycloneDXComponent{},
+	}
+	bom.Metadata.Timestamp = created.UTC().Format(time.RFC3339)
+	bom.Metadata.Tools.Components = []cycloneDXComponent{
+		{Type: "application", Name: "dagger"},
+	}
+	bom.Metadata.Component = cycloneDXComponent{Type: "container", Name: name}
+	for _, pkg := range pkgs {
+		purl := pkg.PURL()
+		component := cycloneDXComponent{
+			Type:    "library",
+			BOMRef:  purl,
+			Name:    pkg.Name,
+			Version: pkg.Version,
+			PURL:    purl,
+		}
+		if pkg.License != "" {
+			var license cycloneDXLicense
+			license.License.Name = pkg.License
+			component.Licenses = []cycloneDXLicense{license}
+		}
+		if pkg.Source != "" && pkg.Source != pkg.Name {
+			component.Properties = append(component.Properties, cycloneDXProperty{
+				Name:  "dagger:package:source",
+				Value: pkg.Source,
+			})
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	return json.Marshal(bom)
+}
@@ -0,0 +1,68 @@
+package sbom
+
+import (
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/containerd/continuity/fs"
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+const (
+	dpkgStatusPath = "/var/lib/dpkg/status"
+	// Distroless images list their packages in one file per package
+	dpkgStatusDir = "/var/lib/dpkg/status.d"
+)
+
+func scanDpkg(root string, osRelease osRelease) ([]Package, error) {
+	var dbs [][]byte
+	status, err := readFile(root, dpkgStatusPath)
+	if err != nil {
+		return nil, err
+	}
+	if status != nil {
+		dbs = append(dbs, status)
+	}
+	dir, err := fs.RootPath(root, dpkgStatusDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".md5sums") {
+			continue
+		}
+		data, err := readFile(root, path.Join(dpkgStatusDir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		dbs = append(dbs, data)
+	}
+
+	var pkgs []Package
+	for _, db := range dbs {
+		for _, para := range paragraphs(db, ":") {
+			name := para["Package"]
+			if name == "" {
+				continue
+			}
+			// only count installed packages, status is "want flag status"
+			if status, ok := para["Status"]; ok && !slices.Contains(strings.Fields(status), "installed") {
+				continue
+			}
+			source, _, _ := strings.Cut(para["Source"], " ")
+			pkgs = append(pkgs, Package{
+				Type:      packageurl.TypeDebian,
+				Namespace: osRelease.namespace("debian"),
+				Name:      name,
+				Version:   para["Version"],
+				Arch:      para["Architecture"],
+				Source:    source,
+				Distro:    osRelease.distro(),
+			})
+		}
+	}
+	return pkgs, nil
+}
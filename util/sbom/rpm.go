@@ -0,0 +1,164 @@
+package sbom
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/containerd/continuity/fs"
+	packageurl "github.com/package-url/packageurl-go"
+	_ "modernc.org/sqlite"
+)
+
+// The SQLite RPM databases, in order of preference. The older Berkeley DB and
+// NDB formats are not supported.
+var rpmDBPaths = []string{
+	"/usr/lib/sysimage/rpm/rpmdb.sqlite",
+	"/var/lib/rpm/rpmdb.sqlite",
+}
+
+// RPM header tags
+const (
+	rpmTagName      = 1000
+	rpmTagVersion   = 1001
+	rpmTagRelease   = 1002
+	rpmTagEpoch     = 1003
+	rpmTagLicense   = 1014
+	rpmTagArch      = 1022
+	rpmTagSourceRPM = 1044
+)
+
+// RPM header value types
+const (
+	rpmTypeInt32       = 4
+	rpmTypeString      = 6
+	rpmTypeI18NString  = 9
+	rpmHeaderEntrySize = 16
+)
+
+func scanRpm(root string, osRelease osRelease) ([]Package, error) {
+	for _, dbPath := range rpmDBPaths {
+		p, err := fs.RootPath(root, dbPath)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(p); err != nil {
+			if errors.Is(err, iofs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		return readRpmDB(p, osRelease)
+	}
+	return nil, nil
+}
+
+func readRpmDB(p string, osRelease osRelease) ([]Package, error) {
+	// the root filesystem is read-only
+	connURL := &url.URL{
+		Scheme:   "file",
+		Path:     p,
+		RawQuery: url.Values{"mode": {"ro"}, "immutable": {"1"}}.Encode(),
+	}
+	db, err := sql.Open("sqlite", connURL.String())
+	if err != nil {
+		return nil, fmt.Errorf("open rpm database: %w", err)
+	}
+	defer db.Close()
+
+	rows, err := db.Query("SELECT blob FROM Packages")
+	if err != nil {
+		return nil, fmt.Errorf("query rpm database: %w", err)
+	}
+	defer rows.Close()
+
+	var pkgs []Package
+	for rows.Next() {
+		var blob []byte
+		if err := rows.Scan(&blob); err != nil {
+			return nil, err
+		}
+		hdr, err := parseRpmHeader(blob)
+		if err != nil {
+			return nil, fmt.Errorf("parse rpm header: %w", err)
+		}
+		name := hdr.strings[rpmTagName]
+		// public keys are stored as pseudo-packages
+		if name == "" || name == "gpg-pubkey" {
+			continue
+		}
+		version := hdr.strings[rpmTagVersion]
+		if release := hdr.strings[rpmTagRelease]; release != "" {
+			version += "-" + release
+		}
+		if epoch, ok := hdr.ints[rpmTagEpoch]; ok && epoch != 0 {
+			version = strconv.Itoa(int(epoch)) + ":" + version
+		}
+		pkgs = append(pkgs, Package{
+			Type:      packageurl.TypeRPM,
+			Namespace: osRelease.namespace("redhat"),
+			Name:      name,
+			Version:   version,
+			Arch:      hdr.strings[rpmTagArch],
+			License:   hdr.strings[rpmTagLicense],
+			Source:    hdr.strings[rpmTagSourceRPM],
+			Distro:    osRelease.distro(),
+		})
+	}
+	return pkgs, rows.Err()
+}
+
+type rpmHeader struct {
+	strings map[int32]string
+	ints    map[int32]int32
+}
+
+// parseRpmHeader parses the string and integer values of an RPM header blob,
+// as stored in the database: the count of index entries and the size of the
+// data, followed by the index entries and the data.
+func parseRpmHeader(blob []byte) (*rpmHeader, error) {
+	if len(blob) < 8 {
+		return nil, errors.New("header too short")
+	}
+	count := int(binary.BigEndian.Uint32(blob[0:4]))
+	size := int(binary.BigEndian.Uint32(blob[4:8]))
+	dataStart := 8 + count*rpmHeaderEntrySize
+	if count < 0 || size < 0 || dataStart+size > len(blob) {
+		return nil, errors.New("header truncated")
+	}
+	data := blob[dataStart : dataStart+size]
+
+	hdr := &rpmHeader{
+		strings: map[int32]string{},
+		ints:    map[int32]int32{},
+	}
+	for i := range count {
+		entry := blob[8+i*rpmHeaderEntrySize : 8+(i+1)*rpmHeaderEntrySize]
+		tag := int32(binary.BigEndian.Uint32(entry[0:4]))
+		typ := binary.BigEndian.Uint32(entry[4:8])
+		offset := int(int32(binary.BigEndian.Uint32(entry[8:12])))
+		if offset < 0 || offset >= len(data) {
+			continue
+		}
+		switch typ {
+		case rpmTypeString, rpmTypeI18NString:
+			value := data[offset:]
+			if end := bytes.IndexByte(value, 0); end >= 0 {
+				value = value[:end]
+			}
+			hdr.strings[tag] = string(value)
+		case rpmTypeInt32:
+			if offset+4 > len(data) {
+				continue
+			}
+			hdr.ints[tag] = int32(binary.BigEndian.Uint32(data[offset : offset+4]))
+		}
+	}
+	return hdr, nil
+}
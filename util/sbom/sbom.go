@@ -0,0 +1,202 @@
+// Package sbom generates software bills of materials of root filesystems,
+// from the databases of the packages installed in them.
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/containerd/continuity/fs"
+	packageurl "github.com/package-url/packageurl-go"
+)
+
+// A Package installed in a root filesystem.
+type Package struct {
+	// The package type, as a purl type: "deb", "apk" or "rpm"
+	Type string
+	// The distribution of the package, as the ID of os-release
+	Namespace string
+
+	Name    string
+	Version string
+	Arch    string
+	// The license of the package, as declared by the package database
+	License string
+	// The name of the source package, if different from the package name
+	Source string
+	// The distribution the root filesystem is, as "<ID>-<VERSION_ID>"
+	Distro string
+}
+
+// PURL returns the package URL of the package.
+func (pkg Package) PURL() string {
+	qualifiers := map[string]string{}
+	if pkg.Arch != "" {
+		qualifiers["arch"] = pkg.Arch
+	}
+	if pkg.Distro != "" {
+		qualifiers["distro"] = pkg.Distro
+	}
+	version := pkg.Version
+	if pkg.Type == packageurl.TypeRPM {
+		// the epoch of RPMs is a qualifier
+		if epoch, rest, ok := strings.Cut(version, ":"); ok {
+			qualifiers["epoch"] = epoch
+			version = rest
+		}
+	}
+	if pkg.Source != "" && pkg.Source != pkg.Name {
+		qualifiers["upstream"] = pkg.Source
+	}
+	return packageurl.NewPackageURL(
+		pkg.Type,
+		pkg.Namespace,
+		pkg.Name,
+		version,
+		packageurl.QualifiersFromMap(qualifiers),
+		"",
+	).ToString()
+}
+
+// A scanner lists the packages of a package database.
+type scanner func(root string, osRelease osRelease) ([]Package, error)
+
+var scanners = []scanner{
+	scanDpkg,
+	scanApk,
+	scanRpm,
+}
+
+// Scan returns the packages installed in the root filesystem at root,
+// sorted by type, name and version.
+//
+// The dpkg, apk and RPM (SQLite) package databases are supported.
+func Scan(root string) ([]Package, error) {
+	osRelease, err := readOSRelease(root)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []Package
+	for _, scan := range scanners {
+		found, err := scan(root, osRelease)
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, found...)
+	}
+	slices.SortFunc(pkgs, func(a, b Package) int {
+		return strings.Compare(
+			a.Type+"\x00"+a.Name+"\x00"+a.Version+"\x00"+a.Arch,
+			b.Type+"\x00"+b.Name+"\x00"+b.Version+"\x00"+b.Arch,
+		)
+	})
+	return slices.CompactFunc(pkgs, func(a, b Package) bool {
+		return a.Type == b.Type && a.Name == b.Name && a.Version == b.Version && a.Arch == b.Arch
+	}), nil
+}
+
+// readFile reads a file of the root filesystem, resolving symlinks within
+// it. A missing file is returned as nil.
+func readFile(root, path string) ([]byte, error) {
+	p, err := fs.RootPath(root, path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		if errors.Is(err, iofs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// osRelease is the identification of the distribution of a root filesystem.
+type osRelease struct {
+	ID        string
+	VersionID string
+}
+
+func (rel osRelease) distro() string {
+	if rel.ID == "" || rel.VersionID == "" {
+		return rel.ID
+	}
+	return rel.ID + "-" + rel.VersionID
+}
+
+func (rel osRelease) namespace(fallback string) string {
+	if rel.ID != "" {
+		return rel.ID
+	}
+	return fallback
+}
+
+func readOSRelease(root string) (osRelease, error) {
+	var rel osRelease
+	for _, path := range []string{"/etc/os-release", "/usr/lib/os-release"} {
+		data, err := readFile(root, path)
+		if err != nil {
+			return rel, err
+		}
+		if data == nil {
+			continue
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			key, value, ok := strings.Cut(scanner.Text(), "=")
+			if !ok {
+				continue
+			}
+			value = strings.Trim(value, `"'`)
+			switch key {
+			case "ID":
+				rel.ID = value
+			case "VERSION_ID":
+				rel.VersionID = value
+			}
+		}
+		return rel, scanner.Err()
+	}
+	return rel, nil
+}
+
+// paragraphs splits a package database made of blank-line separated
+// paragraphs of "key: value" or "k:value" lines.
+func paragraphs(data []byte, sep string) []map[string]string {
+	var out []map[string]string
+	cur := map[string]string{}
+	var lastKey string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			if len(cur) > 0 {
+				out = append(out, cur)
+				cur = map[string]string{}
+			}
+			lastKey = ""
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && lastKey != "" {
+			// continuation line
+			cur[lastKey] += "\n" + strings.TrimSpace(line)
+			continue
+		}
+		key, value, ok := strings.Cut(line, sep)
+		if !ok {
+			continue
+		}
+		lastKey = key
+		cur[key] = strings.TrimSpace(value)
+	}
+	if len(cur) > 0 {
+		out = append(out, cur)
+	}
+	return out
+}
@@ -0,0 +1,215 @@
+package sbom
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	spdx_json "github.com/spdx/tools-golang/json"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	p := filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+}
+
+func TestScanDpkg(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "/usr/lib/os-release", "PRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\nID=debian\nVERSION_ID=\"12\"\n")
+	// os-release is usually a symlink, resolved within the root filesystem
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "etc"), 0o755))
+	require.NoError(t, os.Symlink("/usr/lib/os-release", filepath.Join(root, "etc", "os-release")))
+	writeFile(t, root, dpkgStatusPath, `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Source: glibc
+Version: 2.36-9+deb12u4
+Description: GNU C Library: Shared libraries
+ Contains the standard libraries that are used by nearly all programs on
+ the system.
+
+Package: removed
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.0
+
+Package: bash
+Status: install ok installed
+Architecture: amd64
+Version: 5.2.15-2+b2
+`)
+	writeFile(t, root, dpkgStatusDir+"/tzdata", "Package: tzdata\nVersion: 2024a-0+deb12u1\nArchitecture: all\n")
+	writeFile(t, root, dpkgStatusDir+"/tzdata.md5sums", "d41d8cd98f00b204e9800998ecf8427e  usr/share/zoneinfo/UTC\n")
+
+	pkgs, err := Scan(root)
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Type: "deb", Namespace: "debian", Name: "bash", Version: "5.2.15-2+b2", Arch: "amd64", Distro: "debian-12"},
+		{Type: "deb", Namespace: "debian", Name: "libc6", Version: "2.36-9+deb12u4", Arch: "amd64", Source: "glibc", Distro: "debian-12"},
+		{Type: "deb", Namespace: "debian", Name: "tzdata", Version: "2024a-0+deb12u1", Arch: "all", Distro: "debian-12"},
+	}, pkgs)
+	require.Equal(t, "pkg:deb/debian/libc6@2.36-9%2Bdeb12u4?arch=amd64&distro=debian-12&upstream=glibc", pkgs[1].PURL())
+}
+
+func TestScanApk(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "/etc/os-release", "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.20.3\n")
+	writeFile(t, root, apkInstalledPath, `C:Q1abc=
+P:musl
+V:1.2.5-r0
+A:x86_64
+L:MIT
+o:musl
+F:lib
+R:ld-musl-x86_64.so.1
+
+C:Q1def=
+P:libcrypto3
+V:3.3.2-r0
+A:x86_64
+L:Apache-2.0
+o:openssl
+`)
+
+	pkgs, err := Scan(root)
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Type: "apk", Namespace: "alpine", Name: "libcrypto3", Version: "3.3.2-r0", Arch: "x86_64", License: "Apache-2.0", Source: "openssl", Distro: "alpine-3.20.3"},
+		{Type: "apk", Namespace: "alpine", Name: "musl", Version: "1.2.5-r0", Arch: "x86_64", License: "MIT", Source: "musl", Distro: "alpine-3.20.3"},
+	}, pkgs)
+	require.Equal(t, "pkg:apk/alpine/musl@1.2.5-r0?arch=x86_64&distro=alpine-3.20.3", pkgs[1].PURL())
+}
+
+func TestScanRpm(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "/etc/os-release", "ID=fedora\nVERSION_ID=40\n")
+	dbPath := filepath.Join(root, rpmDBPaths[1])
+	require.NoError(t, os.MkdirAll(filepath.Dir(dbPath), 0o755))
+	db, err := sql.Open("sqlite", dbPath)
+	require.NoError(t, err)
+	_, err = db.Exec("CREATE TABLE Packages (hnum INTEGER PRIMARY KEY AUTOINCREMENT, blob BLOB NOT NULL)")
+	require.NoError(t, err)
+	for _, hdr := range [][]byte{
+		rpmHeaderBlob(map[int32]any{
+			rpmTagName:      "bash",
+			rpmTagVersion:   "5.2.26",
+			rpmTagRelease:   "3.fc40",
+			rpmTagArch:      "x86_64",
+			rpmTagLicense:   "GPL-3.0-or-later",
+			rpmTagSourceRPM: "bash-5.2.26-3.fc40.src.rpm",
+		}),
+		rpmHeaderBlob(map[int32]any{
+			rpmTagName:    "openssl-libs",
+			rpmTagVersion: "3.2.2",
+			rpmTagRelease: "1.fc40",
+			rpmTagEpoch:   int32(1),
+			rpmTagArch:    "x86_64",
+		}),
+		rpmHeaderBlob(map[int32]any{
+			rpmTagName:    "gpg-pubkey",
+			rpmTagVersion: "a15b79cc",
+		}),
+	} {
+		_, err = db.Exec("INSERT INTO Packages (blob) VALUES (?)", hdr)
+		require.NoError(t, err)
+	}
+	require.NoError(t, db.Close())
+
+	pkgs, err := Scan(root)
+	require.NoError(t, err)
+	require.Equal(t, []Package{
+		{Type: "rpm", Namespace: "fedora", Name: "bash", Version: "5.2.26-3.fc40", Arch: "x86_64", License: "GPL-3.0-or-later", Source: "bash-5.2.26-3.fc40.src.rpm", Distro: "fedora-40"},
+		{Type: "rpm", Namespace: "fedora", Name: "openssl-libs", Version: "1:3.2.2-1.fc40", Arch: "x86_64", Distro: "fedora-40"},
+	}, pkgs)
+	require.Equal(t, "pkg:rpm/fedora/openssl-libs@3.2.2-1.fc40?arch=x86_64&distro=fedora-40&epoch=1", pkgs[1].PURL())
+}
+
+func TestScanEmpty(t *testing.T) {
+	pkgs, err := Scan(t.TempDir())
+	require.NoError(t, err)
+	require.Empty(t, pkgs)
+}
+
+func TestFormats(t *testing.T) {
+	pkgs := []Package{
+		{Type: "apk", Namespace: "alpine", Name: "musl", Version: "1.2.5-r0", Arch: "x86_64", License: "MIT", Distro: "alpine-3.20.3"},
+		{Type: "apk", Namespace: "alpine", Name: "libcrypto3", Version: "3.3.2-r0", Arch: "x86_64", Source: "openssl", Distro: "alpine-3.20.3"},
+	}
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("spdx", func(t *testing.T) {
+		data, err := SPDX("registry.example.com/app:v1", pkgs, created)
+		require.NoError(t, err)
+		doc, err := spdx_json.Read(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+		require.Equal(t, "registry.example.com/app:v1", doc.DocumentName)
+		require.Equal(t, "2024-01-02T03:04:05Z", doc.CreationInfo.Created)
+		require.Len(t, doc.Packages, 2)
+		require.Equal(t, "musl", doc.Packages[0].PackageName)
+		require.Equal(t, pkgs[0].PURL(), doc.Packages[0].PackageExternalReferences[0].Locator)
+		require.Len(t, doc.Relationships, 2)
+
+		// documents of the same packages are identified the same
+		again, err := SPDX("registry.example.com/app:v1", pkgs, created)
+		require.NoError(t, err)
+		require.Equal(t, data, again)
+	})
+
+	t.Run("cyclonedx", func(t *testing.T) {
+		data, err := CycloneDX("registry.example.com/app:v1", pkgs, created)
+		require.NoError(t, err)
+		var bom cycloneDXBOM
+		require.NoError(t, json.Unmarshal(data, &bom))
+		require.Equal(t, "CycloneDX", bom.BOMFormat)
+		require.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-8[0-9a-f]{3}-[0-9a-f]{12}$`, bom.SerialNumber)
+		require.Equal(t, "container", bom.Metadata.Component.Type)
+		require.Len(t, bom.Components, 2)
+		require.Equal(t, pkgs[0].PURL(), bom.Components[0].PURL)
+		require.Equal(t, "MIT", bom.Components[0].Licenses[0].License.Name)
+		require.Equal(t, "openssl", bom.Components[1].Properties[0].Value)
+	})
+}
+
+// rpmHeaderBlob encodes an RPM header the way it is stored in the database.
+func rpmHeaderBlob(tags map[int32]any) []byte {
+	var index, data bytes.Buffer
+	for tag, value := range tags {
+		var typ uint32
+		switch value := value.(type) {
+		case string:
+			typ = rpmTypeString
+			writeRpmEntry(&index, tag, typ, data.Len())
+			data.WriteString(value)
+			data.WriteByte(0)
+		case int32:
+			typ = rpmTypeInt32
+			for data.Len()%4 != 0 {
+				data.WriteByte(0)
+			}
+			writeRpmEntry(&index, tag, typ, data.Len())
+			binary.Write(&data, binary.BigEndian, value)
+		}
+	}
+	var blob bytes.Buffer
+	binary.Write(&blob, binary.BigEndian, uint32(len(tags)))
+	binary.Write(&blob, binary.BigEndian, uint32(data.Len()))
+	blob.Write(index.Bytes())
+	blob.Write(data.Bytes())
+	return blob.Bytes()
+}
+
+func writeRpmEntry(index *bytes.Buffer, tag int32, typ uint32, offset int) {
+	binary.Write(index, binary.BigEndian, tag)
+	binary.Write(index, binary.BigEndian, typ)
+	binary.Write(index, binary.BigEndian, int32(offset))
+	binary.Write(index, binary.BigEndian, uint32(1))
+}
@@ -0,0 +1,87 @@
+package sbom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	spdx_json "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+)
+
+const spdxNoAssertion = "NOASSERTION"
+
+var spdxInvalidIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// SPDX encodes packages as an SPDX 2.3 JSON document describing them.
+func SPDX(name string, pkgs []Package, created time.Time) ([]byte, error) {
+	doc := &spdx.Document{
+		SPDXVersion:       spdx.Version,
+		DataLicense:       spdx.DataLicense,
+		SPDXIdentifier:    "DOCUMENT",
+		DocumentName:      name,
+		DocumentNamespace: "https://dagger.io/spdxdocs/" + spdxInvalidIDChars.ReplaceAllString(name, "-") + "-" + packagesDigest(pkgs),
+		CreationInfo: &spdx.CreationInfo{
+			Creators: []common.Creator{
+				{Creator: "dagger", CreatorType: "Tool"},
+			},
+			Created: created.UTC().Format(time.RFC3339),
+		},
+	}
+	for _, pkg := range pkgs {
+		purl := pkg.PURL()
+		id := common.ElementID("Package-" + spdxInvalidIDChars.ReplaceAllString(pkg.Type+"-"+pkg.Name, "-") + "-" + shortDigest(purl))
+		spdxPkg := &spdx.Package{
+			PackageName:             pkg.Name,
+			PackageSPDXIdentifier:   id,
+			PackageVersion:          pkg.Version,
+			PackageDownloadLocation: spdxNoAssertion,
+			FilesAnalyzed:           false,
+			// declared licenses are not guaranteed to be SPDX expressions
+			PackageLicenseConcluded: spdxNoAssertion,
+			PackageLicenseDeclared:  spdxNoAssertion,
+			PackageLicenseComments:  pkg.License,
+			PackageCopyrightText:    spdxNoAssertion,
+			PackageExternalReferences: []*spdx.PackageExternalReference{
+				{
+					Category: common.CategoryPackageManager,
+					RefType:  common.TypePackageManagerPURL,
+					Locator:  purl,
+				},
+			},
+		}
+		if pkg.Source != "" && pkg.Source != pkg.Name {
+			spdxPkg.PackageSourceInfo = "built from " + pkg.Source
+		}
+		doc.Packages = append(doc.Packages, spdxPkg)
+		doc.Relationships = append(doc.Relationships, &spdx.Relationship{
+			RefA:         common.DocElementID{ElementRefID: "DOCUMENT"},
+			RefB:         common.DocElementID{ElementRefID: id},
+			Relationship: common.TypeRelationshipDescribe,
+		})
+	}
+	var buf bytes.Buffer
+	if err := spdx_json.Write(doc, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// packagesDigest returns a digest of the package list, to identify documents
+// deterministically.
+func packagesDigest(pkgs []Package) string {
+	h := sha256.New()
+	for _, pkg := range pkgs {
+		h.Write([]byte(pkg.PURL()))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func shortDigest(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])[:16]
+}